@@ -6,6 +6,14 @@
     "upto-v3.1.0-add_writer_column.sql": importstr "rid/upto-v3.1.0-add_writer_column.sql",
     "upto-v3.1.1-add_index_by_time_subscriptions.sql": importstr "rid/upto-v3.1.1-add_index_by_time_subscriptions.sql",
     "upto-v4.0.0-rename_defaultdb_to_rid.sql": importstr "rid/upto-v4.0.0-rename_defaultdb_to_rid.sql",
+    "upto-v4.1.0-create_audit_log.sql": importstr "rid/upto-v4.1.0-create_audit_log.sql",
+    "upto-v4.2.0-create_subscription_events.sql": importstr "rid/upto-v4.2.0-create_subscription_events.sql",
+    "upto-v4.3.0-set_table_localities.sql": importstr "rid/upto-v4.3.0-set_table_localities.sql",
+    "upto-v4.4.0-create_leases.sql": importstr "rid/upto-v4.4.0-create_leases.sql",
+    "downfrom-v4.4.0-remove_leases.sql": importstr "rid/downfrom-v4.4.0-remove_leases.sql",
+    "downfrom-v4.3.0-unset_table_localities.sql": importstr "rid/downfrom-v4.3.0-unset_table_localities.sql",
+    "downfrom-v4.2.0-remove_subscription_events.sql": importstr "rid/downfrom-v4.2.0-remove_subscription_events.sql",
+    "downfrom-v4.1.0-remove_audit_log.sql": importstr "rid/downfrom-v4.1.0-remove_audit_log.sql",
     "downfrom-v4.0.0-move_rid_to_defaultdb.sql": importstr "rid/downfrom-v4.0.0-move_rid_to_defaultdb.sql",
     "downfrom-v3.1.1-remove_index_by_time_subscriptions.sql": importstr "rid/downfrom-v3.1.1-remove_index_by_time_subscriptions.sql",
     "downfrom-v3.1.0-remove_writer_column.sql": importstr "rid/downfrom-v3.1.0-remove_writer_column.sql",
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS audit_log;
+
+UPDATE schema_versions set schema_version = 'v4.0.0' WHERE onerow_enforcer = TRUE;
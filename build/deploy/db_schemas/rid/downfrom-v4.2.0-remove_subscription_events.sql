@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS subscription_events;
+
+UPDATE schema_versions set schema_version = 'v4.1.0' WHERE onerow_enforcer = TRUE;
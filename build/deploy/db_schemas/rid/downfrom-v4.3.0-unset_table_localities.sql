@@ -0,0 +1,13 @@
+-- multi-region: begin
+ALTER TABLE identification_service_areas SET LOCALITY REGIONAL BY TABLE IN PRIMARY REGION;
+ALTER TABLE subscriptions SET LOCALITY REGIONAL BY TABLE IN PRIMARY REGION;
+ALTER TABLE audit_log SET LOCALITY REGIONAL BY TABLE IN PRIMARY REGION;
+ALTER TABLE subscription_events SET LOCALITY REGIONAL BY TABLE IN PRIMARY REGION;
+ALTER TABLE schema_versions SET LOCALITY REGIONAL BY TABLE IN PRIMARY REGION;
+ALTER TABLE identification_service_areas DROP COLUMN IF EXISTS crdb_region;
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS crdb_region;
+ALTER TABLE audit_log DROP COLUMN IF EXISTS crdb_region;
+ALTER TABLE subscription_events DROP COLUMN IF EXISTS crdb_region;
+-- multi-region: end
+
+UPDATE schema_versions set schema_version = 'v4.2.0' WHERE onerow_enforcer = TRUE;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS leases;
+
+UPDATE schema_versions set schema_version = 'v4.3.0' WHERE onerow_enforcer = TRUE;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS subscription_events (
+  subscription_id UUID NOT NULL,
+  notification_index INT4 NOT NULL,
+  entity_type STRING NOT NULL,
+  entity_id STRING NOT NULL,
+  version STRING NOT NULL DEFAULT '',
+  deleted BOOL NOT NULL DEFAULT FALSE,
+  recorded_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (subscription_id, notification_index),
+  INDEX recorded_at_idx (recorded_at)
+);
+
+UPDATE schema_versions set schema_version = 'v4.2.0' WHERE onerow_enforcer = TRUE;
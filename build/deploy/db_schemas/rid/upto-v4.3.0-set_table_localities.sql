@@ -0,0 +1,14 @@
+/* In multi-region databases, the rows of the tables written by DSS instances
+   are homed in the region of the node through which they are written, which
+   is the region of the writing DSS instance, and the schema version is
+   readable from every region. The statements between the multi-region
+   markers are only run by db-manager against multi-region databases. */
+-- multi-region: begin
+ALTER TABLE identification_service_areas SET LOCALITY REGIONAL BY ROW;
+ALTER TABLE subscriptions SET LOCALITY REGIONAL BY ROW;
+ALTER TABLE audit_log SET LOCALITY REGIONAL BY ROW;
+ALTER TABLE subscription_events SET LOCALITY REGIONAL BY ROW;
+ALTER TABLE schema_versions SET LOCALITY GLOBAL;
+-- multi-region: end
+
+UPDATE schema_versions set schema_version = 'v4.3.0' WHERE onerow_enforcer = TRUE;
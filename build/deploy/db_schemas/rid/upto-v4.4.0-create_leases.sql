@@ -0,0 +1,14 @@
+/* Leases electing, in each locality, the single DSS instance running each
+   scheduled job. A lease is held by holder until expires_at unless renewed. */
+CREATE TABLE IF NOT EXISTS leases (
+  name STRING NOT NULL,
+  locality STRING NOT NULL,
+  holder STRING NOT NULL,
+  expires_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (name, locality)
+);
+-- multi-region: begin
+ALTER TABLE leases SET LOCALITY REGIONAL BY ROW;
+-- multi-region: end
+
+UPDATE schema_versions set schema_version = 'v4.4.0' WHERE onerow_enforcer = TRUE;
@@ -4,6 +4,28 @@
     "upto-v2.0.0-support_api_1_0_0.sql": importstr "rid/upto-v2.0.0-support_api_1_0_0.sql",
     "upto-v3.0.0-add_inverted_indices.sql": importstr "rid/upto-v3.0.0-add_inverted_indices.sql",
     "upto-v3.1.0-create_uss_availability.sql": importstr "rid/upto-v3.1.0-create_uss_availability.sql",
+    "upto-v3.2.0-create_audit_log.sql": importstr "scd/upto-v3.2.0-create_audit_log.sql",
+    "upto-v3.3.0-create_history_tables.sql": importstr "scd/upto-v3.3.0-create_history_tables.sql",
+    "upto-v3.4.0-create_subscription_events.sql": importstr "scd/upto-v3.4.0-create_subscription_events.sql",
+    "upto-v3.5.0-create_state_transitions.sql": importstr "scd/upto-v3.5.0-create_state_transitions.sql",
+    "downfrom-v3.5.0-remove_state_transitions.sql": importstr "scd/downfrom-v3.5.0-remove_state_transitions.sql",
+    "upto-v3.6.0-create_operational_intent_priorities.sql": importstr "scd/upto-v3.6.0-create_operational_intent_priorities.sql",
+    "downfrom-v3.6.0-remove_operational_intent_priorities.sql": importstr "scd/downfrom-v3.6.0-remove_operational_intent_priorities.sql",
+    "upto-v3.7.0-create_off_nominal_volumes.sql": importstr "scd/upto-v3.7.0-create_off_nominal_volumes.sql",
+    "downfrom-v3.7.0-remove_off_nominal_volumes.sql": importstr "scd/downfrom-v3.7.0-remove_off_nominal_volumes.sql",
+    "upto-v3.8.0-create_constraint_categories.sql": importstr "scd/upto-v3.8.0-create_constraint_categories.sql",
+    "downfrom-v3.8.0-remove_constraint_categories.sql": importstr "scd/downfrom-v3.8.0-remove_constraint_categories.sql",
+    "upto-v3.9.0-add_ovn_columns.sql": importstr "scd/upto-v3.9.0-add_ovn_columns.sql",
+    "downfrom-v3.9.0-remove_ovn_columns.sql": importstr "scd/downfrom-v3.9.0-remove_ovn_columns.sql",
+    "upto-v3.10.0-set_table_localities.sql": importstr "scd/upto-v3.10.0-set_table_localities.sql",
+    "downfrom-v3.10.0-unset_table_localities.sql": importstr "scd/downfrom-v3.10.0-unset_table_localities.sql",
+    "upto-v3.11.0-create_leases.sql": importstr "scd/upto-v3.11.0-create_leases.sql",
+    "downfrom-v3.11.0-remove_leases.sql": importstr "scd/downfrom-v3.11.0-remove_leases.sql",
+    "upto-v3.12.0-add_history_attributes.sql": importstr "scd/upto-v3.12.0-add_history_attributes.sql",
+    "downfrom-v3.12.0-remove_history_attributes.sql": importstr "scd/downfrom-v3.12.0-remove_history_attributes.sql",
+    "downfrom-v3.4.0-remove_subscription_events.sql": importstr "scd/downfrom-v3.4.0-remove_subscription_events.sql",
+    "downfrom-v3.3.0-remove_history_tables.sql": importstr "scd/downfrom-v3.3.0-remove_history_tables.sql",
+    "downfrom-v3.2.0-remove_audit_log.sql": importstr "scd/downfrom-v3.2.0-remove_audit_log.sql",
     "downfrom-v3.1.0-remove_uss_availability.sql": importstr "rid/downfrom-v3.1.0-remove_uss_availability.sql",
     "downfrom-v3.0.0-remove_inverted_indices.sql": importstr "rid/downfrom-v3.0.0-remove_inverted_indices.sql",
     "downfrom-v2.0.0-remove_api_1_0_0_support.sql": importstr "rid/downfrom-v2.0.0-remove_api_1_0_0_support.sql",
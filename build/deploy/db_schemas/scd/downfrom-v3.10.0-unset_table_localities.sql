@@ -0,0 +1,31 @@
+-- multi-region: begin
+ALTER TABLE scd_operations SET LOCALITY REGIONAL BY TABLE IN PRIMARY REGION;
+ALTER TABLE scd_subscriptions SET LOCALITY REGIONAL BY TABLE IN PRIMARY REGION;
+ALTER TABLE scd_constraints SET LOCALITY REGIONAL BY TABLE IN PRIMARY REGION;
+ALTER TABLE scd_uss_availability SET LOCALITY REGIONAL BY TABLE IN PRIMARY REGION;
+ALTER TABLE scd_audit_log SET LOCALITY REGIONAL BY TABLE IN PRIMARY REGION;
+ALTER TABLE scd_operations_history SET LOCALITY REGIONAL BY TABLE IN PRIMARY REGION;
+ALTER TABLE scd_constraints_history SET LOCALITY REGIONAL BY TABLE IN PRIMARY REGION;
+ALTER TABLE scd_subscription_events SET LOCALITY REGIONAL BY TABLE IN PRIMARY REGION;
+ALTER TABLE scd_operational_intent_state_transitions SET LOCALITY REGIONAL BY TABLE IN PRIMARY REGION;
+ALTER TABLE scd_operational_intent_priorities SET LOCALITY REGIONAL BY TABLE IN PRIMARY REGION;
+ALTER TABLE scd_operational_intent_off_nominal_volumes SET LOCALITY REGIONAL BY TABLE IN PRIMARY REGION;
+ALTER TABLE scd_constraint_attributes SET LOCALITY REGIONAL BY TABLE IN PRIMARY REGION;
+ALTER TABLE scd_subscription_constraint_categories SET LOCALITY REGIONAL BY TABLE IN PRIMARY REGION;
+ALTER TABLE schema_versions SET LOCALITY REGIONAL BY TABLE IN PRIMARY REGION;
+ALTER TABLE scd_operations DROP COLUMN IF EXISTS crdb_region;
+ALTER TABLE scd_subscriptions DROP COLUMN IF EXISTS crdb_region;
+ALTER TABLE scd_constraints DROP COLUMN IF EXISTS crdb_region;
+ALTER TABLE scd_uss_availability DROP COLUMN IF EXISTS crdb_region;
+ALTER TABLE scd_audit_log DROP COLUMN IF EXISTS crdb_region;
+ALTER TABLE scd_operations_history DROP COLUMN IF EXISTS crdb_region;
+ALTER TABLE scd_constraints_history DROP COLUMN IF EXISTS crdb_region;
+ALTER TABLE scd_subscription_events DROP COLUMN IF EXISTS crdb_region;
+ALTER TABLE scd_operational_intent_state_transitions DROP COLUMN IF EXISTS crdb_region;
+ALTER TABLE scd_operational_intent_priorities DROP COLUMN IF EXISTS crdb_region;
+ALTER TABLE scd_operational_intent_off_nominal_volumes DROP COLUMN IF EXISTS crdb_region;
+ALTER TABLE scd_constraint_attributes DROP COLUMN IF EXISTS crdb_region;
+ALTER TABLE scd_subscription_constraint_categories DROP COLUMN IF EXISTS crdb_region;
+-- multi-region: end
+
+UPDATE schema_versions set schema_version = 'v3.9.0' WHERE onerow_enforcer = TRUE;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS scd_leases;
+
+UPDATE schema_versions set schema_version = 'v3.10.0' WHERE onerow_enforcer = TRUE;
@@ -0,0 +1,13 @@
+ALTER TABLE scd_operations_history DROP IF EXISTS ovn;
+ALTER TABLE scd_operations_history DROP IF EXISTS priority;
+ALTER TABLE scd_operations_history DROP IF EXISTS off_nominal_altitude_lower;
+ALTER TABLE scd_operations_history DROP IF EXISTS off_nominal_altitude_upper;
+ALTER TABLE scd_operations_history DROP IF EXISTS off_nominal_starts_at;
+ALTER TABLE scd_operations_history DROP IF EXISTS off_nominal_ends_at;
+ALTER TABLE scd_operations_history DROP IF EXISTS off_nominal_cells;
+ALTER TABLE scd_constraints_history DROP IF EXISTS ovn;
+ALTER TABLE scd_constraints_history DROP IF EXISTS category;
+ALTER TABLE scd_constraints_history DROP IF EXISTS authority;
+ALTER TABLE scd_constraints_history DROP IF EXISTS reason;
+
+UPDATE schema_versions set schema_version = 'v3.11.0' WHERE onerow_enforcer = TRUE;
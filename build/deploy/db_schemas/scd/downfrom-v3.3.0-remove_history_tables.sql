@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS scd_constraints_history;
+DROP TABLE IF EXISTS scd_operations_history;
+UPDATE schema_versions set schema_version = 'v3.2.0' WHERE onerow_enforcer = TRUE;
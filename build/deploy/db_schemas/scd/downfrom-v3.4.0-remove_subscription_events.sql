@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS scd_subscription_events;
+
+UPDATE schema_versions set schema_version = 'v3.3.0' WHERE onerow_enforcer = TRUE;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS scd_operational_intent_state_transitions;
+
+UPDATE schema_versions set schema_version = 'v3.4.0' WHERE onerow_enforcer = TRUE;
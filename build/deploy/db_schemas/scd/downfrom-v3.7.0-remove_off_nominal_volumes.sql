@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS scd_operational_intent_off_nominal_volumes;
+
+UPDATE schema_versions set schema_version = 'v3.6.0' WHERE onerow_enforcer = TRUE;
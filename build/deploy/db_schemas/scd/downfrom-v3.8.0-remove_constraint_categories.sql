@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS scd_subscription_constraint_categories;
+DROP TABLE IF EXISTS scd_constraint_attributes;
+
+UPDATE schema_versions set schema_version = 'v3.7.0' WHERE onerow_enforcer = TRUE;
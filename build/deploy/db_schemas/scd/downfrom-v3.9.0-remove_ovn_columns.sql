@@ -0,0 +1,5 @@
+ALTER TABLE scd_operations DROP IF EXISTS ovn;
+ALTER TABLE scd_constraints DROP IF EXISTS ovn;
+ALTER TABLE scd_subscriptions DROP IF EXISTS ovn;
+
+UPDATE schema_versions set schema_version = 'v3.8.0' WHERE onerow_enforcer = TRUE;
@@ -0,0 +1,23 @@
+/* In multi-region databases, the rows of the tables written by DSS instances
+   are homed in the region of the node through which they are written, which
+   is the region of the writing DSS instance, and the schema version is
+   readable from every region. The statements between the multi-region
+   markers are only run by db-manager against multi-region databases. */
+-- multi-region: begin
+ALTER TABLE scd_operations SET LOCALITY REGIONAL BY ROW;
+ALTER TABLE scd_subscriptions SET LOCALITY REGIONAL BY ROW;
+ALTER TABLE scd_constraints SET LOCALITY REGIONAL BY ROW;
+ALTER TABLE scd_uss_availability SET LOCALITY REGIONAL BY ROW;
+ALTER TABLE scd_audit_log SET LOCALITY REGIONAL BY ROW;
+ALTER TABLE scd_operations_history SET LOCALITY REGIONAL BY ROW;
+ALTER TABLE scd_constraints_history SET LOCALITY REGIONAL BY ROW;
+ALTER TABLE scd_subscription_events SET LOCALITY REGIONAL BY ROW;
+ALTER TABLE scd_operational_intent_state_transitions SET LOCALITY REGIONAL BY ROW;
+ALTER TABLE scd_operational_intent_priorities SET LOCALITY REGIONAL BY ROW;
+ALTER TABLE scd_operational_intent_off_nominal_volumes SET LOCALITY REGIONAL BY ROW;
+ALTER TABLE scd_constraint_attributes SET LOCALITY REGIONAL BY ROW;
+ALTER TABLE scd_subscription_constraint_categories SET LOCALITY REGIONAL BY ROW;
+ALTER TABLE schema_versions SET LOCALITY GLOBAL;
+-- multi-region: end
+
+UPDATE schema_versions set schema_version = 'v3.10.0' WHERE onerow_enforcer = TRUE;
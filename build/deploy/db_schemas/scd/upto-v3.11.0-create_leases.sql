@@ -0,0 +1,14 @@
+/* Leases electing, in each locality, the single DSS instance running each
+   scheduled job. A lease is held by holder until expires_at unless renewed. */
+CREATE TABLE IF NOT EXISTS scd_leases (
+  name STRING NOT NULL,
+  locality STRING NOT NULL,
+  holder STRING NOT NULL,
+  expires_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (name, locality)
+);
+-- multi-region: begin
+ALTER TABLE scd_leases SET LOCALITY REGIONAL BY ROW;
+-- multi-region: end
+
+UPDATE schema_versions set schema_version = 'v3.11.0' WHERE onerow_enforcer = TRUE;
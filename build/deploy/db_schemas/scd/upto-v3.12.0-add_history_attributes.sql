@@ -0,0 +1,16 @@
+/* Attributes of operational intents and constraints stored outside of their
+   rows, retained along with their prior versions. Versions retained before
+   do not have them. */
+ALTER TABLE scd_operations_history ADD COLUMN IF NOT EXISTS ovn STRING;
+ALTER TABLE scd_operations_history ADD COLUMN IF NOT EXISTS priority INT4;
+ALTER TABLE scd_operations_history ADD COLUMN IF NOT EXISTS off_nominal_altitude_lower REAL;
+ALTER TABLE scd_operations_history ADD COLUMN IF NOT EXISTS off_nominal_altitude_upper REAL;
+ALTER TABLE scd_operations_history ADD COLUMN IF NOT EXISTS off_nominal_starts_at TIMESTAMPTZ;
+ALTER TABLE scd_operations_history ADD COLUMN IF NOT EXISTS off_nominal_ends_at TIMESTAMPTZ;
+ALTER TABLE scd_operations_history ADD COLUMN IF NOT EXISTS off_nominal_cells INT64[];
+ALTER TABLE scd_constraints_history ADD COLUMN IF NOT EXISTS ovn STRING;
+ALTER TABLE scd_constraints_history ADD COLUMN IF NOT EXISTS category STRING;
+ALTER TABLE scd_constraints_history ADD COLUMN IF NOT EXISTS authority STRING;
+ALTER TABLE scd_constraints_history ADD COLUMN IF NOT EXISTS reason STRING;
+
+UPDATE schema_versions set schema_version = 'v3.12.0' WHERE onerow_enforcer = TRUE;
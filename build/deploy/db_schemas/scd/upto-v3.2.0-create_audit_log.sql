@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS scd_audit_log (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  entity_type STRING NOT NULL,
+  entity_id STRING NOT NULL,
+  operation STRING NOT NULL,
+  owner STRING NOT NULL,
+  old_version STRING NOT NULL DEFAULT '',
+  new_version STRING NOT NULL DEFAULT '',
+  starts_at TIMESTAMPTZ,
+  ends_at TIMESTAMPTZ,
+  altitude_lower REAL,
+  altitude_upper REAL,
+  recorded_at TIMESTAMPTZ NOT NULL,
+  INDEX entity_idx (entity_type, entity_id, recorded_at),
+  INDEX owner_idx (owner, recorded_at),
+  INDEX recorded_at_idx (recorded_at)
+);
+
+UPDATE schema_versions set schema_version = 'v3.2.0' WHERE onerow_enforcer = TRUE;
@@ -0,0 +1,43 @@
+/* Prior versions of operational intents, retained when they are updated or
+   deleted. superseded_at is the time at which the version stopped being
+   current. */
+CREATE TABLE IF NOT EXISTS scd_operations_history (
+  id UUID NOT NULL,
+  owner STRING NOT NULL,
+  version INT4 NOT NULL DEFAULT 0,
+  url STRING NOT NULL,
+  altitude_lower REAL,
+  altitude_upper REAL,
+  starts_at TIMESTAMPTZ,
+  ends_at TIMESTAMPTZ,
+  subscription_id UUID,
+  updated_at TIMESTAMPTZ NOT NULL,
+  state operational_intent_state NOT NULL DEFAULT 'Unknown',
+  cells INT64[],
+  superseded_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (id, updated_at),
+  INVERTED INDEX cells_idx (cells),
+  INDEX owner_idx (owner),
+  INDEX superseded_at_idx (superseded_at)
+);
+
+/* Prior versions of constraints, retained when they are updated or deleted. */
+CREATE TABLE IF NOT EXISTS scd_constraints_history (
+  id UUID NOT NULL,
+  owner STRING NOT NULL,
+  version INT4 NOT NULL DEFAULT 0,
+  url STRING NOT NULL,
+  altitude_lower REAL,
+  altitude_upper REAL,
+  starts_at TIMESTAMPTZ,
+  ends_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ NOT NULL,
+  cells INT64[] NOT NULL,
+  superseded_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (id, updated_at),
+  INVERTED INDEX cells_idx (cells),
+  INDEX owner_idx (owner),
+  INDEX superseded_at_idx (superseded_at)
+);
+
+UPDATE schema_versions set schema_version = 'v3.3.0' WHERE onerow_enforcer = TRUE;
@@ -0,0 +1,15 @@
+/* Changes of the state of operational intents. from_state is NULL on creation
+   and to_state is 'Ended' on deletion. sequence_number orders the changes
+   recorded in the same transaction, which share their recorded_at. */
+CREATE TABLE IF NOT EXISTS scd_operational_intent_state_transitions (
+  operational_intent_id UUID NOT NULL,
+  owner STRING NOT NULL,
+  version INT4 NOT NULL,
+  from_state STRING,
+  to_state STRING NOT NULL,
+  recorded_at TIMESTAMPTZ NOT NULL,
+  sequence_number INT8 NOT NULL DEFAULT unique_rowid(),
+  PRIMARY KEY (operational_intent_id, recorded_at, sequence_number)
+);
+
+UPDATE schema_versions set schema_version = 'v3.5.0' WHERE onerow_enforcer = TRUE;
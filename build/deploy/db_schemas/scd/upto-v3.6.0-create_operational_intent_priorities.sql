@@ -0,0 +1,8 @@
+/* Priorities of operational intents. Operational intents without a row have
+   the lowest priority, 0. */
+CREATE TABLE IF NOT EXISTS scd_operational_intent_priorities (
+  operational_intent_id UUID PRIMARY KEY REFERENCES scd_operations (id) ON DELETE CASCADE,
+  priority INT4 NOT NULL CHECK (priority >= 0)
+);
+
+UPDATE schema_versions set schema_version = 'v3.6.0' WHERE onerow_enforcer = TRUE;
@@ -0,0 +1,16 @@
+/* Union of the off-nominal volumes of Nonconforming and Contingent
+   operational intents, stored separately from their nominal extents. */
+CREATE TABLE IF NOT EXISTS scd_operational_intent_off_nominal_volumes (
+  operational_intent_id UUID PRIMARY KEY REFERENCES scd_operations (id) ON DELETE CASCADE,
+  altitude_lower REAL,
+  altitude_upper REAL,
+  starts_at TIMESTAMPTZ,
+  ends_at TIMESTAMPTZ,
+  cells INT64[] NOT NULL CHECK (array_length(cells, 1) IS NOT NULL),
+  INVERTED INDEX cells_idx (cells),
+  INDEX starts_at_idx (starts_at),
+  INDEX ends_at_idx (ends_at),
+  CHECK (starts_at IS NULL OR ends_at IS NULL OR starts_at < ends_at)
+);
+
+UPDATE schema_versions set schema_version = 'v3.7.0' WHERE onerow_enforcer = TRUE;
@@ -0,0 +1,18 @@
+/* Category, authority and reason of constraints. Constraints without a row
+   are Restricted. */
+CREATE TABLE IF NOT EXISTS scd_constraint_attributes (
+  constraint_id UUID PRIMARY KEY REFERENCES scd_constraints (id) ON DELETE CASCADE,
+  category STRING NOT NULL,
+  authority STRING,
+  reason STRING,
+  INDEX category_idx (category)
+);
+
+/* Categories of the constraints notifying subscriptions. Subscriptions
+   without a row are notified of constraints of any category. */
+CREATE TABLE IF NOT EXISTS scd_subscription_constraint_categories (
+  subscription_id UUID PRIMARY KEY REFERENCES scd_subscriptions (id) ON DELETE CASCADE,
+  categories STRING[] NOT NULL CHECK (array_length(categories, 1) IS NOT NULL)
+);
+
+UPDATE schema_versions set schema_version = 'v3.8.0' WHERE onerow_enforcer = TRUE;
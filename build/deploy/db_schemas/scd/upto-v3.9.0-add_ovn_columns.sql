@@ -0,0 +1,7 @@
+/* OVNs generated when operational intents, constraints and subscriptions are
+   written. The OVN of a row without one is derived from its updated_at. */
+ALTER TABLE scd_operations ADD COLUMN IF NOT EXISTS ovn STRING;
+ALTER TABLE scd_constraints ADD COLUMN IF NOT EXISTS ovn STRING;
+ALTER TABLE scd_subscriptions ADD COLUMN IF NOT EXISTS ovn STRING;
+
+UPDATE schema_versions set schema_version = 'v3.9.0' WHERE onerow_enforcer = TRUE;
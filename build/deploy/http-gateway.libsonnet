@@ -79,6 +79,12 @@ local ingress(metadata) = base.Ingress(metadata, 'https-ingress') {
                 'trace-requests': metadata.gateway.traceRequests,
               },
               readinessProbe: {
+                httpGet: {
+                  path: '/ready',
+                  port: metadata.gateway.port,
+                },
+              },
+              livenessProbe: {
                 httpGet: {
                   path: '/healthy',
                   port: metadata.gateway.port,
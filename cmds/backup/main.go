@@ -0,0 +1,122 @@
+// Command backup exports the remote ID and strategic conflict detection
+// entities of a DSS to files, or imports them into an empty DSS.
+
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/interuss/dss/pkg/backup"
+	"github.com/interuss/dss/pkg/cockroach"
+	"github.com/interuss/dss/pkg/cockroach/flags"
+	"github.com/interuss/dss/pkg/logging"
+	ridc "github.com/interuss/dss/pkg/rid/store/cockroach"
+	scdc "github.com/interuss/dss/pkg/scd/store/cockroach"
+	"github.com/interuss/stacktrace"
+	"go.uber.org/zap"
+)
+
+var (
+	exportDir = flag.String("export_dir", "", "Directory to export the entities of the DSS to")
+	importDir = flag.String("import_dir", "", "Directory of a backup to import into the empty DSS")
+	enableSCD = flag.Bool("enable_scd", false, "Exports or imports the strategic conflict detection entities as well as the remote ID entities")
+	pageSize  = flag.Int("page_size", backup.DefaultPageSize, "Number of entities read or restored at once")
+)
+
+// dialRIDStore connects to the remote ID database, falling back to the
+// defaultdb database of older versions.
+func dialRIDStore(ctx context.Context, logger *zap.Logger) (*ridc.Store, error) {
+	connectParameters := flags.ConnectParameters()
+	connectParameters.ApplicationName = "backup"
+	connectParameters.DBName = "rid"
+	crdb, err := cockroach.Dial(ctx, connectParameters)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to connect to remote ID database")
+	}
+	store, err := ridc.NewStore(ctx, crdb, connectParameters.DBName, logger)
+	if err == nil {
+		return store, nil
+	}
+	crdb.Pool.Close()
+
+	connectParameters.DBName = "defaultdb"
+	crdb, err = cockroach.Dial(ctx, connectParameters)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to connect to remote ID database for older version <defaultdb>")
+	}
+	store, err = ridc.NewStore(ctx, crdb, connectParameters.DBName, logger)
+	if err != nil {
+		crdb.Pool.Close()
+		return nil, stacktrace.Propagate(err, "Failed to create remote ID store")
+	}
+	return store, nil
+}
+
+// dialSCDStore connects to the strategic conflict detection database.
+func dialSCDStore(ctx context.Context, logger *zap.Logger) (*scdc.Store, error) {
+	connectParameters := flags.ConnectParameters()
+	connectParameters.ApplicationName = "backup"
+	connectParameters.DBName = scdc.DatabaseName
+	crdb, err := cockroach.Dial(ctx, connectParameters)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to connect to strategic conflict detection database")
+	}
+	store, err := scdc.NewStore(ctx, crdb, logger)
+	if err != nil {
+		crdb.Pool.Close()
+		return nil, stacktrace.Propagate(err, "Failed to create strategic conflict detection store")
+	}
+	return store, nil
+}
+
+func run(ctx context.Context, logger *zap.Logger) error {
+	if (*exportDir == "") == (*importDir == "") {
+		return stacktrace.NewError("Exactly one of export_dir and import_dir must be specified")
+	}
+	if *pageSize <= 0 {
+		return stacktrace.NewError("Invalid page_size %d", *pageSize)
+	}
+
+	stores := &backup.Stores{
+		PageSize: *pageSize,
+		Logf:     logger.Sugar().Infof,
+	}
+	ridStore, err := dialRIDStore(ctx, logger)
+	if err != nil {
+		return err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	defer ridStore.Close()
+	stores.RID = ridStore
+	if *enableSCD {
+		scdStore, err := dialSCDStore(ctx, logger)
+		if err != nil {
+			return err // No need to Propagate this error as this stack layer does not add useful information
+		}
+		defer scdStore.Close()
+		stores.SCD = scdStore
+	}
+
+	if *exportDir != "" {
+		manifest, err := stores.Export(ctx, *exportDir)
+		if err != nil {
+			return stacktrace.Propagate(err, "Failed to export to %s", *exportDir)
+		}
+		logger.Info("export complete", zap.String("dir", *exportDir), zap.Any("counts", manifest.Counts))
+		return nil
+	}
+	manifest, err := stores.Import(ctx, *importDir)
+	if err != nil {
+		return stacktrace.Propagate(err, "Failed to import from %s", *importDir)
+	}
+	logger.Info("import complete", zap.String("dir", *importDir), zap.Time("exported_at", manifest.ExportedAt), zap.Any("counts", manifest.Counts))
+	return nil
+}
+
+func main() {
+	flag.Parse()
+	logger := logging.Logger
+	if err := run(context.Background(), logger); err != nil {
+		logger.Fatal("Backup failed", zap.Error(err))
+	}
+}
@@ -0,0 +1,102 @@
+// Command constraint-import synchronizes strategic conflict detection
+// Constraints with an ED-269 UAS zones file.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"time"
+
+	"github.com/interuss/dss/pkg/cockroach"
+	"github.com/interuss/dss/pkg/cockroach/flags"
+	"github.com/interuss/dss/pkg/logging"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	"github.com/interuss/dss/pkg/scd"
+	"github.com/interuss/dss/pkg/scd/ed269"
+	scdc "github.com/interuss/dss/pkg/scd/store/cockroach"
+	"github.com/interuss/stacktrace"
+	"go.uber.org/zap"
+)
+
+var (
+	zonesFile          = flag.String("zones_file", "", "Path to the ED-269 JSON file of the UAS zones to import")
+	manager            = flag.String("manager", "", "Manager of the imported Constraints. Constraints of this manager which are not in zones_file are deleted, so it must be dedicated to the import.")
+	ussBaseURL         = flag.String("uss_base_url", "", "Base URL of the USS serving the details of the imported Constraints")
+	horizon            = flag.Duration("horizon", 30*24*time.Hour, "Duration from now after which zones without end time are considered to end. Re-run the import before half of it elapses to extend them.")
+	maxGroundElevation = flag.Float64("max_ground_elevation", 0, "Highest elevation of the ground in the zones, in meters above the WGS84 ellipsoid, added to the upper limits relative to the ground")
+	enableHTTP         = flag.Bool("enable_http", false, "Allows an http scheme for uss_base_url")
+)
+
+func run(ctx context.Context, logger *zap.Logger) error {
+	switch {
+	case *zonesFile == "":
+		return stacktrace.NewError("Missing zones_file")
+	case *manager == "":
+		return stacktrace.NewError("Missing manager")
+	case *ussBaseURL == "":
+		return stacktrace.NewError("Missing uss_base_url")
+	case *horizon <= 0:
+		return stacktrace.NewError("Invalid horizon %s", *horizon)
+	}
+
+	f, err := os.Open(*zonesFile)
+	if err != nil {
+		return stacktrace.Propagate(err, "Unable to open %s", *zonesFile)
+	}
+	defer f.Close()
+	zones, err := ed269.Parse(f)
+	if err != nil {
+		return stacktrace.Propagate(err, "Unable to parse %s", *zonesFile)
+	}
+
+	connectParameters := flags.ConnectParameters()
+	connectParameters.ApplicationName = "constraint-import"
+	connectParameters.DBName = scdc.DatabaseName
+	crdb, err := cockroach.Dial(ctx, connectParameters)
+	if err != nil {
+		return stacktrace.Propagate(err, "Failed to connect to strategic conflict detection database")
+	}
+	store, err := scdc.NewStore(ctx, crdb, logger)
+	if err != nil {
+		crdb.Pool.Close()
+		return stacktrace.Propagate(err, "Failed to create strategic conflict detection store")
+	}
+	defer store.Close()
+
+	importer := &ed269.Importer{
+		Server: &scd.Server{
+			Store:      store,
+			EnableHTTP: *enableHTTP,
+		},
+		Manager:    dssmodels.Manager(*manager),
+		USSBaseURL: *ussBaseURL,
+		Options: ed269.Options{
+			Now:                time.Now(),
+			Horizon:            *horizon,
+			MaxGroundElevation: *maxGroundElevation,
+		},
+		Logger: logger,
+	}
+	result, err := importer.Import(ctx, zones.Features)
+	if result != nil {
+		logger.Info("import summary",
+			zap.Int("created", result.Created),
+			zap.Int("updated", result.Updated),
+			zap.Int("unchanged", result.Unchanged),
+			zap.Int("deleted", result.Deleted))
+	}
+	if err != nil {
+		return stacktrace.Propagate(err, "Failed to import zones")
+	}
+	return nil
+}
+
+func main() {
+	flag.Parse()
+	logger := logging.Logger
+	if err := run(context.Background(), logger); err != nil {
+		logger.Fatal("Import failed", zap.Error(err))
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"flag"
+	"net/url"
+	"time"
+
+	"github.com/interuss/dss/pkg/cockroach"
+	"github.com/interuss/dss/pkg/cockroach/flags"
+	"github.com/interuss/dss/pkg/logging"
+	"github.com/interuss/dss/pkg/ratelimit"
+	"github.com/interuss/stacktrace"
+	"github.com/robfig/cron/v3"
+)
+
+// envPrefix prefixes the names of the environment variables overriding the
+// configuration, e.g. DSS_LOG_LEVEL or DSS_COCKROACH_HOST.
+const envPrefix = "DSS_"
+
+// RateLimits configures the rate limits of each class of calls, as
+// <tokens per second>/<burst>. A class is unlimited if its rate is empty.
+type RateLimits struct {
+	Read   string `yaml:"read"`
+	Write  string `yaml:"write"`
+	Search string `yaml:"search"`
+}
+
+// SCDLimits configures the number of SCD entities a manager may have or change
+// at once. A limit is disabled if 0.
+type SCDLimits struct {
+	MaxSubscriptionsPerArea     int `yaml:"max_subscriptions_per_area"`
+	MaxActiveOperationalIntents int `yaml:"max_active_operational_intents"`
+	MaxImplicitSubscriptions    int `yaml:"max_implicit_subscriptions"`
+	MaxBulkChanges              int `yaml:"max_bulk_changes"`
+}
+
+// Config is the configuration of core-service.
+type Config struct {
+	Addr                 string        `yaml:"addr"`
+	PublicKeyFiles       string        `yaml:"public_key_files"`
+	JWKSEndpoint         string        `yaml:"jwks_endpoint"`
+	JWKSKeyIDs           string        `yaml:"jwks_key_ids"`
+	KeyRefreshTimeout    time.Duration `yaml:"key_refresh_timeout"`
+	AcceptedJWTAudiences string        `yaml:"accepted_jwt_audiences"`
+	ServerTimeout        time.Duration `yaml:"server_timeout"`
+	ReflectAPI           bool          `yaml:"reflect_api"`
+	LogFormat            string        `yaml:"log_format"`
+	LogLevel             string        `yaml:"log_level"`
+	DumpRequests         bool          `yaml:"dump_requests"`
+	ProfServiceName      string        `yaml:"gcp_prof_service_name"`
+	EnableSCD            bool          `yaml:"enable_scd"`
+	EnableHTTP           bool          `yaml:"enable_http"`
+	Locality             string        `yaml:"locality"`
+	HealthCheckInterval  time.Duration `yaml:"health_check_interval"`
+	ShutdownTimeout      time.Duration `yaml:"shutdown_timeout"`
+	GarbageCollectorSpec string        `yaml:"garbage_collector_spec"`
+	EnableWatch          bool          `yaml:"enable_watch"`
+	WatchPollInterval    time.Duration `yaml:"watch_poll_interval"`
+	WatchEventRetention  time.Duration `yaml:"watch_event_retention"`
+	PriorityAwareKeys    bool          `yaml:"scd_priority_aware_keys"`
+	OVNKeyFile           string        `yaml:"scd_ovn_key_file"`
+	ReadStaleness        string        `yaml:"read_staleness"`
+	JobLeaseTTL          time.Duration `yaml:"job_lease_ttl"`
+	MetricsAddr          string        `yaml:"metrics_addr"`
+
+	RateLimits RateLimits                   `yaml:"rate_limit"`
+	SCDLimits  SCDLimits                    `yaml:"scd"`
+	Cockroach  *cockroach.ConnectParameters `yaml:"cockroach"`
+}
+
+var (
+	configFile = flag.String("config", "", "Path to a YAML configuration file. Values set on the command line or in DSS_* environment variables take precedence over the file.")
+
+	cfg = Config{Cockroach: flags.BoundConnectParameters()}
+)
+
+func init() {
+	flag.StringVar(&cfg.Addr, "addr", ":8081", "address")
+	flag.StringVar(&cfg.PublicKeyFiles, "public_key_files", "", "Path to public Keys to use for JWT decoding, separated by commas.")
+	flag.StringVar(&cfg.JWKSEndpoint, "jwks_endpoint", "", "URL pointing to an endpoint serving JWKS")
+	flag.StringVar(&cfg.JWKSKeyIDs, "jwks_key_ids", "", "IDs of a set of key in a JWKS, separated by commas")
+	flag.DurationVar(&cfg.KeyRefreshTimeout, "key_refresh_timeout", 1*time.Minute, "Timeout for refreshing keys for JWT verification")
+	flag.StringVar(&cfg.AcceptedJWTAudiences, "accepted_jwt_audiences", "", "comma-separated acceptable JWT `aud` claims")
+	flag.DurationVar(&cfg.ServerTimeout, "server_timeout", 10*time.Second, "Default timeout for server calls")
+	flag.DurationVar(&cfg.ServerTimeout, "server timeout", 10*time.Second, "Deprecated: use server_timeout")
+	flag.BoolVar(&cfg.ReflectAPI, "reflect_api", false, "Whether to reflect the API.")
+	flag.StringVar(&cfg.LogFormat, "log_format", logging.DefaultFormat, "The log format in {json, console}")
+	flag.StringVar(&cfg.LogLevel, "log_level", logging.DefaultLevel.String(), "The log level")
+	flag.BoolVar(&cfg.DumpRequests, "dump_requests", false, "Log request and response protos")
+	flag.StringVar(&cfg.ProfServiceName, "gcp_prof_service_name", "", "Service name for the Go profiler")
+	flag.BoolVar(&cfg.EnableSCD, "enable_scd", false, "Enables the Strategic Conflict Detection API")
+	flag.BoolVar(&cfg.EnableHTTP, "enable_http", false, "Enables http scheme for Strategic Conflict Detection API")
+	flag.StringVar(&cfg.Locality, "locality", "", "self-identification string used as CRDB table writer column")
+	flag.DurationVar(&cfg.HealthCheckInterval, "health_check_interval", 15*time.Second, "Interval between checks of the dependencies of the services reported through the gRPC health service")
+	flag.DurationVar(&cfg.ShutdownTimeout, "shutdown_timeout", 30*time.Second, "Maximum time to wait on shutdown for in-flight requests and running jobs to complete")
+	flag.StringVar(&cfg.GarbageCollectorSpec, "garbage_collector_spec", "@every 30m", "Garbage collector schedule. The value must follow robfig/cron format. See https://godoc.org/github.com/robfig/cron#hdr-Usage for more detail.")
+	flag.DurationVar(&cfg.JobLeaseTTL, "job_lease_ttl", 1*time.Minute, "Duration of the leases electing the single instance of each locality running each scheduled job, renewed while the job runs")
+	flag.StringVar(&cfg.MetricsAddr, "metrics_addr", "", "Address on which metrics, such as the status of the job leases, are served at /debug/vars. Disabled if empty.")
+	flag.BoolVar(&cfg.EnableWatch, "enable_watch", false, "Enables the streaming API to watch the changes relevant to subscriptions")
+	flag.DurationVar(&cfg.WatchPollInterval, "watch_poll_interval", 1*time.Second, "Interval at which watch streams poll the database for new subscription events")
+	flag.DurationVar(&cfg.WatchEventRetention, "watch_event_retention", 24*time.Hour, "Duration for which subscription events are retained, and may be resumed from by watch streams")
+	flag.BoolVar(&cfg.PriorityAwareKeys, "scd_priority_aware_keys", false, "Only requires the OVNs of the SCD OperationalIntents of equal or higher priority in the key of an OperationalIntent")
+	flag.StringVar(&cfg.OVNKeyFile, "scd_ovn_key_file", "", "Path to a file containing the secret key with which the OVNs of SCD entities are generated. Random OVNs are generated if empty.")
+	flag.StringVar(&cfg.ReadStaleness, "read_staleness", "", "Staleness of the reads of each read-only method, as a comma-separated list of <method>=<staleness>, where <method> is a full gRPC method name and <staleness> is either follower or a duration, e.g. /ridpbv1.DiscoveryAndSynchronizationService/SearchIdentificationServiceAreas=follower,/scdpb.UTMAPIUSSDSSAndUSSUSSService/GetOperationalIntentReference=5s. Reads are fresh if empty.")
+
+	flag.StringVar(&cfg.RateLimits.Read, "rate_limit_read", "", "Rate limit of read calls per owner, as <tokens per second>/<burst>. Unlimited if empty.")
+	flag.StringVar(&cfg.RateLimits.Write, "rate_limit_write", "", "Rate limit of write calls per owner, as <tokens per second>/<burst>. Unlimited if empty.")
+	flag.StringVar(&cfg.RateLimits.Search, "rate_limit_search", "", "Rate limit of search calls per owner, as <tokens per second>/<burst>. Unlimited if empty.")
+
+	flag.IntVar(&cfg.SCDLimits.MaxSubscriptionsPerArea, "scd_max_subscriptions_per_area", 0, "Maximum number of explicit SCD Subscriptions a manager may have in a single cell. Unlimited if 0.")
+	flag.IntVar(&cfg.SCDLimits.MaxActiveOperationalIntents, "scd_max_active_operational_intents", 0, "Maximum number of active SCD OperationalIntents a manager may have. Unlimited if 0.")
+	flag.IntVar(&cfg.SCDLimits.MaxImplicitSubscriptions, "scd_max_implicit_subscriptions", 0, "Maximum number of active implicit SCD Subscriptions a manager may have. Unlimited if 0.")
+	flag.IntVar(&cfg.SCDLimits.MaxBulkChanges, "scd_max_bulk_changes", 100, "Maximum number of changes a single SCD bulk request may apply. Unlimited if 0.")
+}
+
+// Validate implements config.Validator.
+func (c *Config) Validate() error {
+	if c.Addr == "" {
+		return stacktrace.NewError("Missing addr")
+	}
+	if c.PublicKeyFiles != "" && c.JWKSEndpoint != "" {
+		return stacktrace.NewError("Only one of public_key_files and jwks_endpoint may be set")
+	}
+	if c.JWKSEndpoint != "" {
+		if c.JWKSKeyIDs == "" {
+			return stacktrace.NewError("Missing jwks_key_ids for jwks_endpoint")
+		}
+		if _, err := url.Parse(c.JWKSEndpoint); err != nil {
+			return stacktrace.Propagate(err, "Invalid jwks_endpoint")
+		}
+	}
+	if c.KeyRefreshTimeout <= 0 {
+		return stacktrace.NewError("Invalid key_refresh_timeout %s", c.KeyRefreshTimeout)
+	}
+	if c.ServerTimeout <= 0 {
+		return stacktrace.NewError("Invalid server_timeout %s", c.ServerTimeout)
+	}
+	if c.LogFormat != logging.FormatJSON && c.LogFormat != logging.FormatConsole {
+		return stacktrace.NewError("Invalid log_format `%s`", c.LogFormat)
+	}
+	if c.HealthCheckInterval <= 0 {
+		return stacktrace.NewError("Invalid health_check_interval %s", c.HealthCheckInterval)
+	}
+	if c.ShutdownTimeout <= 0 {
+		return stacktrace.NewError("Invalid shutdown_timeout %s", c.ShutdownTimeout)
+	}
+	if _, err := cron.ParseStandard(c.GarbageCollectorSpec); err != nil {
+		return stacktrace.Propagate(err, "Invalid garbage_collector_spec")
+	}
+	if c.JobLeaseTTL <= 0 {
+		return stacktrace.NewError("Invalid job_lease_ttl %s", c.JobLeaseTTL)
+	}
+	if c.WatchPollInterval <= 0 {
+		return stacktrace.NewError("Invalid watch_poll_interval %s", c.WatchPollInterval)
+	}
+	if c.WatchEventRetention <= 0 {
+		return stacktrace.NewError("Invalid watch_event_retention %s", c.WatchEventRetention)
+	}
+	for name, spec := range map[string]string{
+		"rate_limit_read":   c.RateLimits.Read,
+		"rate_limit_write":  c.RateLimits.Write,
+		"rate_limit_search": c.RateLimits.Search,
+	} {
+		if _, err := ratelimit.ParseRate(spec); err != nil {
+			return stacktrace.Propagate(err, "Invalid %s", name)
+		}
+	}
+	if _, err := cockroach.ParseStalenessPolicy(c.ReadStaleness); err != nil {
+		return stacktrace.Propagate(err, "Invalid read_staleness")
+	}
+	if c.SCDLimits.MaxSubscriptionsPerArea < 0 || c.SCDLimits.MaxActiveOperationalIntents < 0 || c.SCDLimits.MaxImplicitSubscriptions < 0 || c.SCDLimits.MaxBulkChanges < 0 {
+		return stacktrace.NewError("SCD limits may not be negative")
+	}
+	if err := c.Cockroach.Validate(); err != nil {
+		return stacktrace.Propagate(err, "Invalid cockroach configuration")
+	}
+	return nil
+}
@@ -1,11 +1,15 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"expvar"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"net"
+	"net/http"
 	"net/url"
 	"os"
 	"os/signal"
@@ -15,17 +19,27 @@ import (
 	"time"
 
 	"cloud.google.com/go/profiler"
+	"github.com/interuss/dss/pkg/admin"
+	"github.com/interuss/dss/pkg/api/v1/adminpb"
 	"github.com/interuss/dss/pkg/api/v1/auxpb"
+	"github.com/interuss/dss/pkg/api/v1/bulkpb"
+	"github.com/interuss/dss/pkg/api/v1/historypb"
 	"github.com/interuss/dss/pkg/api/v1/ridpbv1"
 	"github.com/interuss/dss/pkg/api/v1/scdpb"
+	"github.com/interuss/dss/pkg/api/v1/watchpb"
 	"github.com/interuss/dss/pkg/api/v2/ridpbv2"
 	"github.com/interuss/dss/pkg/auth"
 	aux "github.com/interuss/dss/pkg/aux_"
 	"github.com/interuss/dss/pkg/build"
 	"github.com/interuss/dss/pkg/cockroach"
 	"github.com/interuss/dss/pkg/cockroach/flags" // Force command line flag registration
+	"github.com/interuss/dss/pkg/config"
 	uss_errors "github.com/interuss/dss/pkg/errors"
+	"github.com/interuss/dss/pkg/healthcheck"
+	"github.com/interuss/dss/pkg/lease"
+	"github.com/interuss/dss/pkg/lifecycle"
 	"github.com/interuss/dss/pkg/logging"
+	"github.com/interuss/dss/pkg/ratelimit"
 	application "github.com/interuss/dss/pkg/rid/application"
 	rid_v1 "github.com/interuss/dss/pkg/rid/server/v1"
 	rid_v2 "github.com/interuss/dss/pkg/rid/server/v2"
@@ -33,35 +47,19 @@ import (
 	"github.com/interuss/dss/pkg/scd"
 	scdc "github.com/interuss/dss/pkg/scd/store/cockroach"
 	"github.com/interuss/dss/pkg/validations"
+	"github.com/interuss/dss/pkg/watch"
 	"github.com/interuss/stacktrace"
 	"github.com/robfig/cron/v3"
 
+	"github.com/google/uuid"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
-var (
-	address              = flag.String("addr", ":8081", "address")
-	pkFile               = flag.String("public_key_files", "", "Path to public Keys to use for JWT decoding, separated by commas.")
-	jwksEndpoint         = flag.String("jwks_endpoint", "", "URL pointing to an endpoint serving JWKS")
-	jwksKeyIDs           = flag.String("jwks_key_ids", "", "IDs of a set of key in a JWKS, separated by commas")
-	keyRefreshTimeout    = flag.Duration("key_refresh_timeout", 1*time.Minute, "Timeout for refreshing keys for JWT verification")
-	timeout              = flag.Duration("server timeout", 10*time.Second, "Default timeout for server calls")
-	reflectAPI           = flag.Bool("reflect_api", false, "Whether to reflect the API.")
-	logFormat            = flag.String("log_format", logging.DefaultFormat, "The log format in {json, console}")
-	logLevel             = flag.String("log_level", logging.DefaultLevel.String(), "The log level")
-	dumpRequests         = flag.Bool("dump_requests", false, "Log request and response protos")
-	profServiceName      = flag.String("gcp_prof_service_name", "", "Service name for the Go profiler")
-	enableSCD            = flag.Bool("enable_scd", false, "Enables the Strategic Conflict Detection API")
-	enableHTTP           = flag.Bool("enable_http", false, "Enables http scheme for Strategic Conflict Detection API")
-	locality             = flag.String("locality", "", "self-identification string used as CRDB table writer column")
-	garbageCollectorSpec = flag.String("garbage_collector_spec", "@every 30m", "Garbage collector schedule. The value must follow robfig/cron format. See https://godoc.org/github.com/robfig/cron#hdr-Usage for more detail.")
-
-	jwtAudiences = flag.String("accepted_jwt_audiences", "", "comma-separated acceptable JWT `aud` claims")
-)
-
 const (
 	codeRetryable = stacktrace.ErrorCode(1)
 )
@@ -88,35 +86,57 @@ func getDBStats(ctx context.Context, db *cockroach.DB, databaseName string) {
 
 func createKeyResolver() (auth.KeyResolver, error) {
 	switch {
-	case *pkFile != "":
+	case cfg.PublicKeyFiles != "":
 		return &auth.FromFileKeyResolver{
-			KeyFiles: strings.Split(*pkFile, ","),
+			KeyFiles: strings.Split(cfg.PublicKeyFiles, ","),
 		}, nil
-	case *jwksEndpoint != "" && *jwksKeyIDs != "":
-		u, err := url.Parse(*jwksEndpoint)
+	case cfg.JWKSEndpoint != "" && cfg.JWKSKeyIDs != "":
+		u, err := url.Parse(cfg.JWKSEndpoint)
 		if err != nil {
 			return nil, stacktrace.Propagate(err, "Error parsing JWKS URL")
 		}
 
 		return &auth.JWKSResolver{
 			Endpoint: u,
-			KeyIDs:   strings.Split(*jwksKeyIDs, ","),
+			KeyIDs:   strings.Split(cfg.JWKSKeyIDs, ","),
 		}, nil
 	default:
 		return nil, nil
 	}
 }
 
-func createRIDServer(ctx context.Context, locality string, logger *zap.Logger) (*rid_v1.Server, *rid_v2.Server, error) {
+func createRateLimiter() (*ratelimit.Limiter, error) {
+	rates := map[ratelimit.Class]ratelimit.Rate{}
+	for class, spec := range map[ratelimit.Class]string{
+		ratelimit.Read:   cfg.RateLimits.Read,
+		ratelimit.Write:  cfg.RateLimits.Write,
+		ratelimit.Search: cfg.RateLimits.Search,
+	} {
+		rate, err := ratelimit.ParseRate(spec)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Invalid %s rate limit", class)
+		}
+		if !rate.Unlimited() {
+			rates[class] = rate
+		}
+	}
+	if len(rates) == 0 {
+		return nil, nil
+	}
+	return ratelimit.NewLimiter(rates, nil, ratelimit.DefaultClock), nil
+}
+
+func createRIDServer(ctx context.Context, lc *lifecycle.Manager, locality string, logger *zap.Logger) (*rid_v1.Server, *rid_v2.Server, *ridc.Store, error) {
 	connectParameters := flags.ConnectParameters()
 	connectParameters.DBName = "rid"
+	connectParameters.Region = locality
 	ridCrdb, err := cockroach.Dial(ctx, connectParameters)
 	if err != nil {
 		// TODO: More robustly detect failure to create RID server is due to a problem that may be temporary
 		if strings.Contains(err.Error(), "connect: connection refused") {
-			return nil, nil, stacktrace.PropagateWithCode(err, codeRetryable, "Failed to connect to CRDB server for remote ID store")
+			return nil, nil, nil, stacktrace.PropagateWithCode(err, codeRetryable, "Failed to connect to CRDB server for remote ID store")
 		}
-		return nil, nil, stacktrace.Propagate(err, "Failed to connect to remote ID database; verify your database configuration is current with https://github.com/interuss/dss/tree/master/build#upgrading-database-schemas")
+		return nil, nil, nil, stacktrace.Propagate(err, "Failed to connect to remote ID database; verify your database configuration is current with https://github.com/interuss/dss/tree/master/build#upgrading-database-schemas")
 	}
 
 	ridStore, err := ridc.NewStore(ctx, ridCrdb, connectParameters.DBName, logger)
@@ -124,59 +144,74 @@ func createRIDServer(ctx context.Context, locality string, logger *zap.Logger) (
 		// try DBName of defaultdb for older versions.
 		ridCrdb.Pool.Close()
 		connectParameters.DBName = "defaultdb"
-		ridCrdb, err := cockroach.Dial(ctx, connectParameters)
+		ridCrdb, err = cockroach.Dial(ctx, connectParameters)
 		if err != nil {
-			return nil, nil, stacktrace.Propagate(err, "Failed to connect to remote ID database for older version <defaultdb>; verify your database configuration is current with https://github.com/interuss/dss/tree/master/build#upgrading-database-schemas")
+			return nil, nil, nil, stacktrace.Propagate(err, "Failed to connect to remote ID database for older version <defaultdb>; verify your database configuration is current with https://github.com/interuss/dss/tree/master/build#upgrading-database-schemas")
 		}
 		ridStore, err = ridc.NewStore(ctx, ridCrdb, connectParameters.DBName, logger)
 		if err != nil {
 			// TODO: More robustly detect failure to create RID server is due to a problem that may be temporary
 			if strings.Contains(err.Error(), "connect: connection refused") || strings.Contains(err.Error(), "database has not been bootstrapped with Schema Manager") {
 				ridCrdb.Pool.Close()
-				return nil, nil, stacktrace.PropagateWithCode(err, codeRetryable, "Failed to connect to CRDB server for remote ID store")
+				return nil, nil, nil, stacktrace.PropagateWithCode(err, codeRetryable, "Failed to connect to CRDB server for remote ID store")
 			}
-			return nil, nil, stacktrace.Propagate(err, "Failed to create remote ID store")
+			return nil, nil, nil, stacktrace.Propagate(err, "Failed to create remote ID store")
 		}
 	}
+	lc.Add("remote ID database", func(context.Context) error { return ridStore.Close() })
+	if _, err := ridCrdb.ValidateLocality(ctx, connectParameters.DBName, locality); err != nil {
+		return nil, nil, nil, stacktrace.Propagate(err, "Invalid locality for remote ID database")
+	}
 
 	repo, err := ridStore.Interact(ctx)
 	if err != nil {
-		return nil, nil, stacktrace.Propagate(err, "Unable to interact with store")
+		return nil, nil, nil, stacktrace.Propagate(err, "Unable to interact with store")
 	}
 	gc := ridc.NewGarbageCollector(repo, locality)
 
+	// Elect the instance of the locality running each scheduled job. The
+	// leases are released once the cron is stopped.
+	elector := lease.NewElector(ridStore.Leases(), locality, leaseHolder, cfg.JobLeaseTTL, logger)
+	lc.Add("remote ID job leases", elector.Release)
+
 	// schedule period tasks for RID Server
 	ridCron := cron.New()
 	// schedule printing of DB connection stats every minute for the underlying storage for RID Server
 	if _, err := ridCron.AddFunc("@every 1m", func() { getDBStats(ctx, ridCrdb, connectParameters.DBName) }); err != nil {
-		return nil, nil, stacktrace.Propagate(err, "Failed to schedule periodic db stat check to %s", connectParameters.DBName)
+		return nil, nil, nil, stacktrace.Propagate(err, "Failed to schedule periodic db stat check to %s", connectParameters.DBName)
 	}
 
 	cronLogger := cron.VerbosePrintfLogger(log.New(os.Stdout, "RIDGarbageCollectorJob: ", log.LstdFlags))
-	if _, err = ridCron.AddJob(*garbageCollectorSpec, cron.NewChain(cron.SkipIfStillRunning(cronLogger)).Then(RIDGarbageCollectorJob{"delete rid expired records", *gc, ctx})); err != nil {
-		return nil, nil, stacktrace.Propagate(err, "Failed to schedule periodic delete rid expired records to %s", connectParameters.DBName)
+	if _, err = ridCron.AddJob(cfg.GarbageCollectorSpec, cron.NewChain(cron.SkipIfStillRunning(cronLogger)).Then(elector.Job(ctx, "delete rid expired records", RIDGarbageCollectorJob{"delete rid expired records", *gc}.Run))); err != nil {
+		return nil, nil, nil, stacktrace.Propagate(err, "Failed to schedule periodic delete rid expired records to %s", connectParameters.DBName)
+	}
+	eventsLogger := cron.VerbosePrintfLogger(log.New(os.Stdout, "SubscriptionEventsPurgeJob: ", log.LstdFlags))
+	if _, err = ridCron.AddJob(cfg.GarbageCollectorSpec, cron.NewChain(cron.SkipIfStillRunning(eventsLogger)).Then(elector.Job(ctx, "delete rid expired subscription events", SubscriptionEventsPurgeJob{"delete rid expired subscription events", repo.DeleteSubscriptionEventsBefore}.Run))); err != nil {
+		return nil, nil, nil, stacktrace.Propagate(err, "Failed to schedule periodic delete rid expired subscription events to %s", connectParameters.DBName)
 	}
 	ridCron.Start()
+	lc.AddCron("remote ID cron", ridCron)
 
 	app := application.NewFromTransactor(ridStore, logger)
 	return &rid_v1.Server{
 			App:        app,
-			Timeout:    *timeout,
+			Timeout:    cfg.ServerTimeout,
 			Locality:   locality,
-			EnableHTTP: *enableHTTP,
+			EnableHTTP: cfg.EnableHTTP,
 			Cron:       ridCron,
 		}, &rid_v2.Server{
 			App:        app,
-			Timeout:    *timeout,
+			Timeout:    cfg.ServerTimeout,
 			Locality:   locality,
-			EnableHTTP: *enableHTTP,
+			EnableHTTP: cfg.EnableHTTP,
 			Cron:       ridCron,
-		}, nil
+		}, ridStore, nil
 }
 
-func createSCDServer(ctx context.Context, logger *zap.Logger) (*scd.Server, error) {
+func createSCDServer(ctx context.Context, lc *lifecycle.Manager, locality string, logger *zap.Logger) (*scd.Server, error) {
 	connectParameters := flags.ConnectParameters()
 	connectParameters.DBName = scdc.DatabaseName
+	connectParameters.Region = locality
 	scdCrdb, err := cockroach.Dial(ctx, connectParameters)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Failed to connect to strategic conflict detection database; verify your database configuration is current with https://github.com/interuss/dss/tree/master/build#upgrading-database-schemas")
@@ -191,6 +226,26 @@ func createSCDServer(ctx context.Context, logger *zap.Logger) (*scd.Server, erro
 		}
 		return nil, stacktrace.Propagate(err, "Failed to create strategic conflict detection store")
 	}
+	lc.Add("strategic conflict detection database", func(context.Context) error { return scdStore.Close() })
+	if _, err := scdCrdb.ValidateLocality(ctx, scdc.DatabaseName, locality); err != nil {
+		return nil, stacktrace.Propagate(err, "Invalid locality for strategic conflict detection database")
+	}
+	if cfg.OVNKeyFile != "" {
+		key, err := ioutil.ReadFile(cfg.OVNKeyFile)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Failed to read OVN key file %s", cfg.OVNKeyFile)
+		}
+		key = bytes.TrimSpace(key)
+		if len(key) == 0 {
+			return nil, stacktrace.NewError("OVN key file %s is empty", cfg.OVNKeyFile)
+		}
+		scdStore.SetOVNKey(key)
+	}
+
+	// Elect the instance of the locality running each scheduled job. The
+	// leases are released once the cron is stopped.
+	elector := lease.NewElector(scdStore.Leases(), locality, leaseHolder, cfg.JobLeaseTTL, logger)
+	lc.Add("strategic conflict detection job leases", elector.Release)
 
 	// schedule period tasks for SCD Server
 	scdCron := cron.New()
@@ -199,21 +254,66 @@ func createSCDServer(ctx context.Context, logger *zap.Logger) (*scd.Server, erro
 		return nil, stacktrace.Propagate(err, "Failed to schedule periodic db stat check to %s", scdc.DatabaseName)
 	}
 
+	repo, err := scdStore.Interact(ctx)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Unable to interact with store")
+	}
+	eventsLogger := cron.VerbosePrintfLogger(log.New(os.Stdout, "SubscriptionEventsPurgeJob: ", log.LstdFlags))
+	if _, err = scdCron.AddJob(cfg.GarbageCollectorSpec, cron.NewChain(cron.SkipIfStillRunning(eventsLogger)).Then(elector.Job(ctx, "delete scd expired subscription events", SubscriptionEventsPurgeJob{"delete scd expired subscription events", repo.DeleteSubscriptionEventsBefore}.Run))); err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to schedule periodic delete scd expired subscription events to %s", scdc.DatabaseName)
+	}
+
 	scdCron.Start()
+	lc.AddCron("strategic conflict detection cron", scdCron)
 
 	return &scd.Server{
 		Store:      scdStore,
-		Timeout:    *timeout,
-		EnableHTTP: *enableHTTP,
+		Timeout:    cfg.ServerTimeout,
+		EnableHTTP: cfg.EnableHTTP,
+		Limits: scd.Limits{
+			MaxSubscriptionsPerArea:     cfg.SCDLimits.MaxSubscriptionsPerArea,
+			MaxActiveOperationalIntents: cfg.SCDLimits.MaxActiveOperationalIntents,
+			MaxImplicitSubscriptions:    cfg.SCDLimits.MaxImplicitSubscriptions,
+			MaxBulkChanges:              cfg.SCDLimits.MaxBulkChanges,
+		},
+		PriorityAwareKeys: cfg.PriorityAwareKeys,
 	}, nil
 }
 
+// shutdown stops the components owned by lc, allowing them at most
+// cfg.ShutdownTimeout to complete their work.
+func shutdown(lc *lifecycle.Manager, logger *zap.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := lc.Shutdown(ctx); err != nil {
+		logger.Warn("unclean shutdown", zap.Error(err))
+	}
+}
+
 // RunGRPCServer starts the example gRPC service.
 // "network" and "address" are passed to net.Listen.
 func RunGRPCServer(ctx context.Context, ctxCanceler func(), address string, locality string) error {
 	logger := logging.WithValuesFromContext(ctx, logging.Logger)
 
-	if len(*jwtAudiences) == 0 {
+	// Whether the server stops or fails to start, stop whatever was started.
+	lc := lifecycle.NewManager(logger)
+	defer shutdown(lc, logger)
+
+	logger.Info("build", zap.Any("description", build.Describe()), zap.Any("config", config.Redact(&cfg)))
+
+	if cfg.MetricsAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/debug/vars", expvar.Handler())
+		metricsServer := &http.Server{Addr: cfg.MetricsAddr, Handler: mux}
+		go func() {
+			if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Error("Metrics server failed", zap.Error(err))
+			}
+		}()
+		lc.Add("metrics server", metricsServer.Shutdown)
+	}
+
+	if len(cfg.AcceptedJWTAudiences) == 0 {
 		// TODO: Make this flag required once all parties can set audiences
 		// correctly.
 		logger.Warn("missing required --accepted_jwt_audiences")
@@ -224,15 +324,18 @@ func RunGRPCServer(ctx context.Context, ctxCanceler func(), address string, loca
 		ridServerV2 *rid_v2.Server
 		scdServer   *scd.Server
 		auxServer   = &aux.Server{}
+		adminServer = &admin.Server{Timeout: cfg.ServerTimeout}
 	)
 
 	// Initialize remote ID
-	serverV1, serverV2, err := createRIDServer(ctx, locality, logger)
+	serverV1, serverV2, ridStore, err := createRIDServer(ctx, lc, locality, logger)
 	if err != nil {
 		return stacktrace.Propagate(err, "Failed to create remote ID server")
 	}
 	ridServerV1 = serverV1
 	ridServerV2 = serverV2
+	adminServer.RIDStore = ridStore
+	adminServer.RIDApp = ridServerV1.App
 
 	scopesValidators := auth.MergeOperationsAndScopesValidators(
 		ridServerV1.AuthScopes(), ridServerV2.AuthScopes(),
@@ -243,20 +346,38 @@ func RunGRPCServer(ctx context.Context, ctxCanceler func(), address string, loca
 
 	// Initialize strategic conflict detection
 
-	if *enableSCD {
-		server, err := createSCDServer(ctx, logger)
+	if cfg.EnableSCD {
+		server, err := createSCDServer(ctx, lc, locality, logger)
 		if err != nil {
-			ridServerV1.Cron.Stop()
-			ridServerV2.Cron.Stop()
 			return stacktrace.Propagate(err, "Failed to create strategic conflict detection server")
 		}
 		scdServer = server
+		adminServer.SCDStore = scdServer.Store
 
 		scopesValidators = auth.MergeOperationsAndScopesValidators(
 			scopesValidators, scdServer.AuthScopes(),
 		)
 	}
 
+	scopesValidators = auth.MergeOperationsAndScopesValidators(
+		scopesValidators, adminServer.AuthScopes(),
+	)
+
+	var watchServer *watch.Server
+	if cfg.EnableWatch {
+		watchServer = &watch.Server{
+			RIDStore:     ridStore,
+			Timeout:      cfg.ServerTimeout,
+			PollInterval: cfg.WatchPollInterval,
+		}
+		if cfg.EnableSCD {
+			watchServer.SCDStore = scdServer.Store
+		}
+		scopesValidators = auth.MergeOperationsAndScopesValidators(
+			scopesValidators, watchServer.AuthScopes(),
+		)
+	}
+
 	// Initialize access token validation
 	keyResolver, err := createKeyResolver()
 	switch {
@@ -269,14 +390,16 @@ func RunGRPCServer(ctx context.Context, ctxCanceler func(), address string, loca
 	authorizer, err := auth.NewRSAAuthorizer(
 		ctx, auth.Configuration{
 			KeyResolver:       keyResolver,
-			KeyRefreshTimeout: *keyRefreshTimeout,
+			KeyRefreshTimeout: cfg.KeyRefreshTimeout,
 			ScopesValidators:  scopesValidators,
-			AcceptedAudiences: strings.Split(*jwtAudiences, ","),
+			AcceptedAudiences: strings.Split(cfg.AcceptedJWTAudiences, ","),
+			Unauthenticated:   []auth.Operation{"/grpc.health.v1.Health/Check"},
 		},
 	)
 	if err != nil {
 		return stacktrace.Propagate(err, "Error creating RSA authorizer")
 	}
+	lc.Add("key refresher", authorizer.Stop)
 
 	// Set up server functionality
 	interceptors := []grpc.UnaryServerInterceptor{
@@ -285,47 +408,117 @@ func RunGRPCServer(ctx context.Context, ctxCanceler func(), address string, loca
 		authorizer.AuthInterceptor,
 		validations.ValidationInterceptor,
 	}
-	if *dumpRequests {
+	rateLimiter, err := createRateLimiter()
+	if err != nil {
+		return stacktrace.Propagate(err, "Error creating rate limiter")
+	}
+	if rateLimiter != nil {
+		interceptors = append(interceptors, rateLimiter.Interceptor)
+	} else {
+		logger.Warn("operating without rate limiting interceptor")
+	}
+	stalenessPolicy, err := cockroach.ParseStalenessPolicy(cfg.ReadStaleness)
+	if err != nil {
+		return stacktrace.Propagate(err, "Error parsing read staleness")
+	}
+	if len(stalenessPolicy) > 0 {
+		interceptors = append(interceptors, stalenessPolicy.Interceptor)
+	}
+	if cfg.DumpRequests {
 		interceptors = append(interceptors, logging.DumpRequestResponseInterceptor(logger))
 	}
 
-	s := grpc.NewServer(grpc_middleware.WithUnaryServerChain(interceptors...))
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		uss_errors.StreamInterceptor(logger),
+		authorizer.AuthStreamInterceptor,
+	}
+	if rateLimiter != nil {
+		streamInterceptors = append(streamInterceptors, rateLimiter.StreamInterceptor)
+	}
+	if len(stalenessPolicy) > 0 {
+		streamInterceptors = append(streamInterceptors, stalenessPolicy.StreamInterceptor)
+	}
+
+	s := grpc.NewServer(
+		grpc_middleware.WithUnaryServerChain(interceptors...),
+		grpc_middleware.WithStreamServerChain(streamInterceptors...),
+	)
 	if err != nil {
 		return stacktrace.Propagate(err, "Error creating new gRPC server")
 	}
-	if *reflectAPI {
+	lc.AddGRPCServer("gRPC server", s)
+	if cfg.ReflectAPI {
 		reflection.Register(s)
 	}
 
-	logger.Info("build", zap.Any("description", build.Describe()))
-
 	ridpbv1.RegisterDiscoveryAndSynchronizationServiceServer(s, ridServerV1)
 	ridpbv2.RegisterStandardRemoteIDAPIInterfacesServiceServer(s, ridServerV2)
 	auxpb.RegisterDSSAuxServiceServer(s, auxServer)
-	if *enableSCD {
+	adminpb.RegisterDSSAdminServiceServer(s, adminServer)
+	if cfg.EnableSCD {
 		logger.Info("config", zap.Any("scd", "enabled"))
 		scdpb.RegisterUTMAPIUSSDSSAndUSSUSSServiceServer(s, scdServer)
+		bulkpb.RegisterDSSBulkServiceServer(s, scdServer)
+		historypb.RegisterDSSHistoryServiceServer(s, scdServer)
 	} else {
 		logger.Info("config", zap.Any("scd", "disabled"))
 	}
+	if cfg.EnableWatch {
+		logger.Info("config", zap.Any("watch", "enabled"))
+		watchpb.RegisterDSSWatchServiceServer(s, watchServer)
+	} else {
+		logger.Info("config", zap.Any("watch", "disabled"))
+	}
+
+	// Report the health of each service based on its dependencies
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(s, healthServer)
+	monitor := healthcheck.NewMonitor(healthServer, cfg.HealthCheckInterval, logger)
+	var (
+		keysHealthy = func(context.Context) error { return authorizer.KeyRefreshError() }
+		ridHealthy  = func(ctx context.Context) error {
+			_, err := ridStore.GetVersion(ctx)
+			return err
+		}
+		adminChecks = []healthcheck.Check{ridHealthy, keysHealthy}
+	)
+	monitor.Register(healthcheck.RIDV1Service, ridHealthy, keysHealthy)
+	monitor.Register(healthcheck.RIDV2Service, ridHealthy, keysHealthy)
+	monitor.Register(healthcheck.AuxService, keysHealthy)
+	if cfg.EnableSCD {
+		scdHealthy := func(ctx context.Context) error {
+			_, err := scdServer.Store.GetVersion(ctx)
+			return err
+		}
+		monitor.Register(healthcheck.SCDService, scdHealthy, keysHealthy)
+		monitor.Register(healthcheck.BulkService, scdHealthy, keysHealthy)
+		monitor.Register(healthcheck.HistoryService, scdHealthy, keysHealthy)
+		adminChecks = append(adminChecks, scdHealthy)
+	}
+	monitor.Register(healthcheck.AdminService, adminChecks...)
+	if cfg.EnableWatch {
+		// Like the admin service, the watch service reads from all stores.
+		monitor.Register(healthcheck.WatchService, adminChecks...)
+	}
+	// The monitor is stopped before the server so that the services are
+	// reported as not serving while in-flight requests are drained.
+	lc.Go(ctx, "health monitor", monitor.Run)
 
-	signals := make(chan os.Signal)
+	signals := make(chan os.Signal, 1)
 	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
 	defer signal.Stop(signals)
 
 	go func() {
-		defer s.GracefulStop()
-
-		for {
-			select {
-			case <-ctx.Done():
-				logger.Info("stopping server due to context having been canceled")
-				return
-			case s := <-signals:
-				logger.Info("received OS signal", zap.Stringer("signal", s))
-				ctxCanceler()
-			}
+		select {
+		case <-ctx.Done():
+			logger.Info("stopping server due to context having been canceled")
+		case s := <-signals:
+			logger.Info("received OS signal", zap.Stringer("signal", s))
+		case <-lc.Done():
+			return
 		}
+		shutdown(lc, logger)
+		ctxCanceler()
 	}()
 	l, err := net.Listen("tcp", address)
 	if err != nil {
@@ -344,15 +537,26 @@ func RunGRPCServer(ctx context.Context, ctxCanceler func(), address string, loca
 	return s.Serve(l)
 }
 
+// leaseHolder identifies this instance among the instances sharing the
+// leases of the scheduled jobs.
+var leaseHolder = newLeaseHolder()
+
+func newLeaseHolder() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "core-service"
+	}
+	return host + "-" + uuid.New().String()[:8]
+}
+
 type RIDGarbageCollectorJob struct {
 	name string
 	gc   ridc.GarbageCollector
-	ctx  context.Context
 }
 
-func (gcj RIDGarbageCollectorJob) Run() {
-	logger := logging.WithValuesFromContext(gcj.ctx, logging.Logger)
-	err := gcj.gc.DeleteRIDExpiredRecords(gcj.ctx)
+func (gcj RIDGarbageCollectorJob) Run(ctx context.Context) {
+	logger := logging.WithValuesFromContext(ctx, logging.Logger)
+	err := gcj.gc.DeleteRIDExpiredRecords(ctx)
 	if err != nil {
 		logger.Warn("Fail to delete expired records", zap.Error(err))
 	} else {
@@ -360,10 +564,30 @@ func (gcj RIDGarbageCollectorJob) Run() {
 	}
 }
 
+// SubscriptionEventsPurgeJob deletes the subscription events older than the
+// configured retention.
+type SubscriptionEventsPurgeJob struct {
+	name   string
+	delete func(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+func (j SubscriptionEventsPurgeJob) Run(ctx context.Context) {
+	logger := logging.WithValuesFromContext(ctx, logging.Logger)
+	n, err := j.delete(ctx, time.Now().Add(-cfg.WatchEventRetention))
+	if err != nil {
+		logger.Warn("Fail to delete expired subscription events", zap.String("job", j.name), zap.Error(err))
+	} else {
+		logger.Info("Successful delete expired subscription events", zap.String("job", j.name), zap.Int64("deleted", n))
+	}
+}
+
 func main() {
 	flag.Parse()
+	if err := config.Load(&cfg, flag.CommandLine, *configFile, envPrefix); err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
 
-	if err := logging.Configure(*logLevel, *logFormat); err != nil {
+	if err := logging.Configure(cfg.LogLevel, cfg.LogFormat); err != nil {
 		panic(fmt.Sprintf("Failed to configure logging: %s", err.Error()))
 	}
 
@@ -373,9 +597,9 @@ func main() {
 	)
 	defer cancel()
 
-	if *profServiceName != "" {
+	if cfg.ProfServiceName != "" {
 		if err := profiler.Start(profiler.Config{
-			Service: *profServiceName,
+			Service: cfg.ProfServiceName,
 		}); err != nil {
 			logger.Panic("Failed to start the profiler ", zap.Error(err))
 		}
@@ -386,7 +610,7 @@ func main() {
 		1 * time.Minute, 5 * time.Minute}
 	backoff := 0
 	for {
-		if err := RunGRPCServer(ctx, cancel, *address, *locality); err != nil {
+		if err := RunGRPCServer(ctx, cancel, cfg.Addr, cfg.Locality); err != nil {
 			if stacktrace.GetCode(err) == codeRetryable {
 				logger.Info(fmt.Sprintf("Prerequisites not yet satisfied; waiting %ds to retry...", backoffs[backoff]/1000000000), zap.Error(err))
 				time.Sleep(backoffs[backoff])
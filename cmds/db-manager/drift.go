@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/interuss/dss/pkg/cockroach"
+	"github.com/interuss/stacktrace"
+	"github.com/jackc/pgconn"
+)
+
+// appliedMigrationsTable records the checksums of the migration files
+// applied to each database, next to the migration locks.
+const appliedMigrationsTable = "postgres.public.db_manager_migrations"
+
+// pgUndefinedTable is the error code of queries of missing tables.
+const pgUndefinedTable = "42P01"
+
+// snapshotPath returns the path to the snapshot of the schema of version in
+// schemasDir.
+func snapshotPath(schemasDir string, version semver.Version) string {
+	return filepath.Join(schemasDir, "snapshots", fmt.Sprintf("v%s.json", version))
+}
+
+// writeSnapshot writes the live schema of database dbName as the snapshot of
+// its version in schemasDir.
+func writeSnapshot(ctx context.Context, crdb *cockroach.DB, schemasDir string, dbName string, version semver.Version) error {
+	schema, err := crdb.Schema(ctx, dbName)
+	if err != nil {
+		return stacktrace.Propagate(err, "Failed to read the schema of %s", dbName)
+	}
+	b, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return stacktrace.Propagate(err, "Failed to marshal the schema of %s", dbName)
+	}
+	path := snapshotPath(schemasDir, version)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return stacktrace.Propagate(err, "Failed to create the snapshots directory")
+	}
+	if err := ioutil.WriteFile(path, append(b, '\n'), 0644); err != nil {
+		return stacktrace.Propagate(err, "Failed to write snapshot %s", path)
+	}
+	log.Printf("Wrote the snapshot of %s schema version %v to %s", dbName, version, path)
+	return nil
+}
+
+// checkDrift compares the live schema of database dbName with the snapshot
+// of its version in schemasDir, and the checksums of the migration files
+// applied to the database with the files of schemasDir. It logs the
+// differences and fails with exitDrift if any.
+func checkDrift(ctx context.Context, crdb *cockroach.DB, schemasDir string, dbName string, steps []MigrationStep, version semver.Version) error {
+	path := snapshotPath(schemasDir, version)
+	b, err := ioutil.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return stacktrace.NewError("No snapshot of schema version %v at %s; generate it with `make schema-snapshots`", version, path)
+	} else if err != nil {
+		return stacktrace.Propagate(err, "Failed to read snapshot %s", path)
+	}
+	expected := &cockroach.Schema{}
+	if err := json.Unmarshal(b, expected); err != nil {
+		return stacktrace.Propagate(err, "Failed to parse snapshot %s", path)
+	}
+	live, err := crdb.Schema(ctx, dbName)
+	if err != nil {
+		return stacktrace.Propagate(err, "Failed to read the schema of %s", dbName)
+	}
+	diffs := expected.Diff(live)
+
+	applied, err := appliedChecksums(ctx, crdb, filepath.Base(schemasDir))
+	if err != nil {
+		return err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	for i := 1; i <= stepIndex(steps, version); i++ {
+		file := steps[i].upToFile
+		recorded, ok := applied[file]
+		if file == "" || !ok {
+			// Data migration, or applied before checksums were recorded
+			continue
+		}
+		checksum, err := fileChecksum(filepath.Join(schemasDir, file))
+		if err != nil {
+			return err // No need to Propagate this error as this stack layer does not add useful information
+		}
+		if checksum != recorded {
+			diffs = append(diffs, fmt.Sprintf("migration %s was edited after being applied", file))
+		}
+	}
+
+	if len(diffs) == 0 {
+		log.Printf("Database %s matches schema version %v", dbName, version)
+		return nil
+	}
+	for _, diff := range diffs {
+		log.Printf("Drift of %s from schema version %v: %s", dbName, version, diff)
+	}
+	return stacktrace.NewErrorWithCode(exitDrift, "Database %s drifted from schema version %v in %d ways", dbName, version, len(diffs))
+}
+
+// fileChecksum returns the SHA-256 checksum of the file at path.
+func fileChecksum(path string) (string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "Failed to read %s", path)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// createAppliedMigrationsTable creates the table recording the checksums of
+// the applied migration files, if missing.
+func createAppliedMigrationsTable(ctx context.Context, crdb *cockroach.DB) error {
+	query := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			database_name STRING NOT NULL,
+			file STRING NOT NULL,
+			checksum STRING NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+			PRIMARY KEY (database_name, file)
+		)`, appliedMigrationsTable)
+	if _, err := crdb.Pool.Exec(ctx, query); err != nil {
+		return stacktrace.Propagate(err, "Failed to create the applied migrations table")
+	}
+	return nil
+}
+
+// recordMigration records the checksum of file, applied to the database of
+// the schemas named name.
+func recordMigration(ctx context.Context, crdb *cockroach.DB, name string, file string, checksum string) error {
+	query := fmt.Sprintf(`UPSERT INTO %s (database_name, file, checksum, applied_at) VALUES ($1, $2, $3, now())`, appliedMigrationsTable)
+	if _, err := crdb.Pool.Exec(ctx, query, name, file, checksum); err != nil {
+		return stacktrace.Propagate(err, "Failed to record the checksum of %s", file)
+	}
+	return nil
+}
+
+// forgetMigration removes the checksum of file, reverted from the database of
+// the schemas named name.
+func forgetMigration(ctx context.Context, crdb *cockroach.DB, name string, file string) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE database_name = $1 AND file = $2`, appliedMigrationsTable)
+	if _, err := crdb.Pool.Exec(ctx, query, name, file); err != nil {
+		return stacktrace.Propagate(err, "Failed to remove the checksum of %s", file)
+	}
+	return nil
+}
+
+// appliedChecksums returns the checksums of the migration files applied to
+// the database of the schemas named name, by file.
+func appliedChecksums(ctx context.Context, crdb *cockroach.DB, name string) (map[string]string, error) {
+	checksums := map[string]string{}
+	query := fmt.Sprintf(`SELECT file, checksum FROM %s WHERE database_name = $1`, appliedMigrationsTable)
+	rows, err := crdb.Pool.Query(ctx, query, name)
+	if isUndefinedTable(err) {
+		// No migration was applied since checksums are recorded
+		return checksums, nil
+	} else if err != nil {
+		return nil, stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var file, checksum string
+		if err := rows.Scan(&file, &checksum); err != nil {
+			return nil, stacktrace.Propagate(err, "Error scanning applied migration row")
+		}
+		checksums[file] = checksum
+	}
+	if err := rows.Err(); isUndefinedTable(err) {
+		return map[string]string{}, nil
+	} else if err != nil {
+		return nil, stacktrace.Propagate(err, "Error in rows query result")
+	}
+	return checksums, nil
+}
+
+func isUndefinedTable(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgUndefinedTable
+}
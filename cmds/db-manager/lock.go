@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/interuss/dss/pkg/cockroach"
+	"github.com/interuss/stacktrace"
+)
+
+// migrationLocksTable stores the locks preventing concurrent migrations of
+// each database, in the initial database which exists whatever the state of
+// the migrated databases. The table is fully qualified since migrations
+// change the database of their connection.
+const migrationLocksTable = "postgres.public.db_manager_locks"
+
+// migrationLock is a lock on the migrations of a database, renewed until
+// released.
+type migrationLock struct {
+	locks   *cockroach.LeaseTable
+	name    string
+	holder  string
+	done    chan struct{}
+	renewed chan struct{}
+	// lost is closed once the lock could not be renewed.
+	lost chan struct{}
+}
+
+// acquireMigrationLock acquires the lock on the migrations of the database
+// of the schemas named name for ttl, failing fast with exitLocked if another
+// db-manager holds it. The returned context, derived from ctx, is canceled
+// once the lock could not be renewed, so that the migration stops before
+// another db-manager may acquire the lock.
+func acquireMigrationLock(ctx context.Context, crdb *cockroach.DB, name string, ttl time.Duration) (*migrationLock, context.Context, error) {
+	// The locality of the locks is unused, since the migrations of a
+	// database are exclusive across all localities.
+	createTable := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			name STRING NOT NULL,
+			locality STRING NOT NULL,
+			holder STRING NOT NULL,
+			expires_at TIMESTAMPTZ NOT NULL,
+			PRIMARY KEY (name, locality)
+		)`, migrationLocksTable)
+	if _, err := crdb.Pool.Exec(ctx, createTable); err != nil {
+		return nil, nil, stacktrace.Propagate(err, "Failed to create the migration locks table")
+	}
+
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "db-manager"
+	}
+	l := &migrationLock{
+		locks:   cockroach.NewLeaseTable(crdb.Pool, migrationLocksTable, true),
+		name:    name,
+		holder:  fmt.Sprintf("%s-%d-%s", host, os.Getpid(), uuid.New().String()[:8]),
+		done:    make(chan struct{}),
+		renewed: make(chan struct{}),
+		lost:    make(chan struct{}),
+	}
+	acquired, err := l.locks.AcquireLease(ctx, name, "", l.holder, ttl)
+	if err != nil {
+		return nil, nil, stacktrace.Propagate(err, "Failed to acquire the migration lock of %s", name)
+	}
+	if !acquired {
+		return nil, nil, stacktrace.NewErrorWithCode(exitLocked, "Database %s is being migrated by another db-manager; retry once it completes, or once its lock expires within %s if it was stopped", name, ttl)
+	}
+	log.Printf("Acquired the migration lock of %s as %s", name, l.holder)
+
+	lockedCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		defer close(l.renewed)
+		ticker := time.NewTicker(ttl / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-l.done:
+				return
+			case <-ticker.C:
+				acquired, err := l.locks.AcquireLease(ctx, name, "", l.holder, ttl)
+				if err != nil {
+					log.Printf("Failed to renew the migration lock of %s: %v", name, err)
+				} else if !acquired {
+					log.Printf("Lost the migration lock of %s", name)
+				}
+				if err != nil || !acquired {
+					close(l.lost)
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+	return l, lockedCtx, nil
+}
+
+// isLost returns true if l could not be renewed.
+func (l *migrationLock) isLost() bool {
+	select {
+	case <-l.lost:
+		return true
+	default:
+		return false
+	}
+}
+
+// release stops renewing l and releases it unless lost.
+func (l *migrationLock) release(ctx context.Context) error {
+	close(l.done)
+	<-l.renewed
+	if l.isLost() {
+		return nil
+	}
+	if err := l.locks.ReleaseLease(ctx, l.name, "", l.holder); err != nil {
+		return stacktrace.Propagate(err, "Failed to release the migration lock of %s", l.name)
+	}
+	log.Printf("Released the migration lock of %s", l.name)
+	return nil
+}
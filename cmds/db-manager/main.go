@@ -8,13 +8,17 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/coreos/go-semver/semver"
 	"github.com/interuss/dss/pkg/cockroach"
 	"github.com/interuss/dss/pkg/cockroach/flags"
+	"github.com/interuss/dss/pkg/datamigration"
+	_ "github.com/interuss/dss/pkg/datamigration/steps" // Registers the data migration steps
 	"github.com/interuss/stacktrace"
 )
 
@@ -22,156 +26,479 @@ type MigrationStep struct {
 	version      semver.Version
 	upToFile     string
 	downFromFile string
+	// dataStep is the step of the version if coded in Go rather than SQL.
+	dataStep *datamigration.Step
 }
 
+// PlannedStep is a migration step to run, in order, to reach a target
+// version. File is the name of the data migration step if Go.
+type PlannedStep struct {
+	File string `json:"file"`
+	Go   bool   `json:"go,omitempty"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+const (
+	// exitFailure is the exit code of failed runs.
+	exitFailure = 1
+	// exitUsage is the exit code of runs with invalid flags.
+	exitUsage stacktrace.ErrorCode = 2
+	// exitLocked is the exit code of runs failing because another run is
+	// migrating the same database.
+	exitLocked stacktrace.ErrorCode = 3
+	// exitDrift is the exit code of runs finding that the database drifted
+	// from its schema version.
+	exitDrift stacktrace.ErrorCode = 4
+)
+
 var (
 	// Pattern to match files describing migration steps
 	migrationStepRegexp = "(upto|downfrom)-v(\\d+\\.\\d+\\.\\d+)-(.*)\\.sql"
 )
 
 var (
-	path      = flag.String("schemas_dir", "", "path to db migration files directory. the migrations found there will be applied to the database whose name matches the folder name.")
-	dbVersion = flag.String("db_version", "", "the db version to migrate to (ex: 1.0.0) or use \"latest\" to automatically upgrade to the latest version or leave blank to print the current version")
+	path              = flag.String("schemas_dir", "", "path to db migration files directory. the migrations found there will be applied to the database whose name matches the folder name. With --status, may list several comma-separated directories")
+	dbVersion         = flag.String("db_version", "", "the db version to migrate to (ex: 1.0.0) or use \"latest\" to automatically upgrade to the latest version or leave blank to print the current version")
+	regions           = flag.String("regions", "", "comma-separated regions of the database, the primary region first. If set, the database is made multi-region with these regions and the table localities of its current schema version are applied before migrating")
+	dryRun            = flag.Bool("dry_run", false, "print the migration steps and SQL files that would run to reach db_version, without changing the database")
+	status            = flag.Bool("status", false, "print as JSON the current version, the available versions and the steps pending to reach db_version, or the latest version if blank, of the database of each schemas_dir, without changing them")
+	checkDriftFlag    = flag.Bool("check_drift", false, "once migrated to db_version if set, compare the live tables, columns and indexes of the database with the snapshot of its schema version, and the applied migration files with those of schemas_dir, and fail if they differ")
+	writeSnapshotFlag = flag.Bool("write_snapshot", false, "once migrated to db_version if set, write the live schema of the database as the snapshot of its schema version in schemas_dir")
+	batchSize         = flag.Int("batch_size", datamigration.DefaultBatchSize, "maximum number of rows migrated per transaction by the data migration steps coded in Go")
+	listVersions      = flag.Bool("list_versions", false, "print the schema versions of schemas_dir, including those reached by data migrations coded in Go, one per line in ascending order, without connecting to the database")
+	lockTTL           = flag.Duration("lock_ttl", 5*time.Minute, "duration of the lock preventing concurrent migrations of the database, renewed while migrating. The lock of a db-manager stopped while migrating expires after this duration")
 )
 
 func main() {
-	// Read and validate schemas_dir input
 	flag.Parse()
-	if *path == "" {
-		log.Panic("Must specify schemas_dir path")
+	if err := run(context.Background()); err != nil {
+		log.Printf("%v", err)
+		if code := stacktrace.GetCode(err); code != stacktrace.NoCode {
+			os.Exit(int(code))
+		}
+		os.Exit(exitFailure)
 	}
-	dbName := filepath.Base(*path)
+}
 
-	// Enumerate schema versions
-	steps, err := enumerateMigrationSteps(path)
-	if err != nil {
-		log.Panicf("Failed to read schema version migration definitions: %v", err)
+func run(ctx context.Context) error {
+	// Read and validate schemas_dir input
+	if *path == "" {
+		return stacktrace.NewErrorWithCode(exitUsage, "Must specify schemas_dir path")
 	}
-	if len(steps) == 0 {
-		log.Panicf("No migration definitions found in schemas_dir=%s", *path)
+	if *status && *dryRun {
+		return stacktrace.NewErrorWithCode(exitUsage, "Only one of status and dry_run may be set")
 	}
-
-	// Determine target version
-	var targetVersion *semver.Version
-	if strings.ToLower(*dbVersion) == "latest" {
-		targetVersion = &steps[len(steps)-1].version
-	} else if strings.TrimSpace(*dbVersion) == "" {
-		// User just wants to print the current version
-		targetVersion = nil
-	} else {
-		targetVersion, err = semver.NewVersion(*dbVersion)
-		if err != nil {
-			log.Panicf("Failed to parse desired db_version: %v", err)
-		}
+	if (*status || *dryRun) && (*checkDriftFlag || *writeSnapshotFlag) {
+		return stacktrace.NewErrorWithCode(exitUsage, "Neither check_drift nor write_snapshot may be set with status or dry_run")
+	}
+	if *listVersions {
+		return printVersions(*path)
 	}
 
 	// Connect to database server
 	connectParameters := flags.ConnectParameters()
 	connectParameters.ApplicationName = "db-manager"
 	connectParameters.DBName = "postgres" // Use an initial database that is known to always be present
-	crdb, err := cockroach.Dial(context.Background(), connectParameters)
+	crdb, err := cockroach.Dial(ctx, connectParameters)
 	if err != nil {
-		log.Panicf("Failed to connect to database with %+v: %v", connectParameters, err)
+		return stacktrace.Propagate(err, "Failed to connect to database")
 	}
 	defer func() {
 		crdb.Pool.Close()
 	}()
 
-	// Make sure specified database exists
-	exists, err := doesDatabaseExist(crdb, dbName)
+	if *status {
+		return printStatus(ctx, crdb, strings.Split(*path, ","), *dbVersion)
+	}
+	return migrate(ctx, crdb, *path)
+}
+
+// migrate migrates the database of schemasDir to the version requested by
+// the flags. It fails with exitLocked if the migration lock is lost while
+// migrating.
+func migrate(ctx context.Context, crdb *cockroach.DB, schemasDir string) (err error) {
+	dbName := filepath.Base(schemasDir)
+
+	// Enumerate schema versions
+	steps, err := enumerateMigrationSteps(&schemasDir)
 	if err != nil {
-		log.Panicf("Failed to check whether database %s exists: %v", dbName, err)
+		return stacktrace.Propagate(err, "Failed to read schema version migration definitions")
 	}
-	if !exists && dbName == "rid" {
-		// In the special case of rid, the database was previously named defaultdb
-		log.Printf("Database %s does not exist; checking for older \"defaultdb\" database", dbName)
-		dbName = "defaultdb"
-		exists, err = doesDatabaseExist(crdb, dbName)
-		if err != nil {
-			log.Panicf("Failed to check whether old defaultdb database exists: %v", err)
+	if len(steps) <= 1 {
+		return stacktrace.NewErrorWithCode(exitUsage, "No migration definitions found in schemas_dir=%s", schemasDir)
+	}
+
+	// Determine target version
+	targetVersion, err := parseTargetVersion(*dbVersion, steps)
+	if err != nil {
+		return err // No need to Propagate this error as this stack layer does not add useful information
+	}
+
+	if targetVersion != nil && !*dryRun {
+		// Prevent concurrent migrations until this one completes
+		lock, lockedCtx, lockErr := acquireMigrationLock(ctx, crdb, filepath.Base(schemasDir), *lockTTL)
+		if lockErr != nil {
+			return lockErr // No need to Propagate this error as this stack layer does not add useful information
 		}
+		releaseCtx := ctx
+		ctx = lockedCtx
+		defer func() {
+			if releaseErr := lock.release(releaseCtx); releaseErr != nil {
+				log.Printf("Failed to release the migration lock of %s: %v", dbName, releaseErr)
+			}
+			if err != nil && lock.isLost() {
+				err = stacktrace.PropagateWithCode(err, exitLocked, "Lost the migration lock of %s while migrating; another db-manager may now migrate it", dbName)
+			}
+		}()
 	}
-	if !exists {
+
+	// Make sure specified database exists
+	dbName, exists, err := resolveDatabase(ctx, crdb, dbName)
+	if err != nil {
+		return err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	if !exists && targetVersion != nil && !*dryRun {
 		log.Printf("Database %s does not exist; creating now", dbName)
 		createDB := fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s", dbName)
-		if _, err := crdb.Pool.Exec(context.Background(), createDB); err != nil {
-			log.Panicf("Failed to create new database %s: %v", dbName, err)
+		if _, err := crdb.Pool.Exec(ctx, createDB); err != nil {
+			return stacktrace.Propagate(err, "Failed to create new database %s", dbName)
 		}
-	} else {
+		exists = true
+	} else if exists {
 		log.Printf("Database %s already exists; reading current state", dbName)
 	}
 
 	// Read current schema version of database
-	currentVersion, err := crdb.GetVersion(context.Background(), dbName)
-	if err != nil {
-		log.Panicf("Failed to get current database version for %s: %v", dbName, err)
+	currentVersion := cockroach.UnknownVersion
+	if exists {
+		currentVersion, err = crdb.GetVersion(ctx, dbName)
+		if err != nil {
+			return stacktrace.Propagate(err, "Failed to get current database version for %s", dbName)
+		}
 	}
 	log.Printf("Initial %s database schema version is %v, target is %v", dbName, currentVersion, targetVersion)
 	if targetVersion == nil {
-		return
+		return checkSchema(ctx, crdb, schemasDir, dbName, exists, steps, *currentVersion)
+	}
+
+	// Compute the migration steps to run
+	plan, err := planMigration(steps, *currentVersion, *targetVersion)
+	if err != nil {
+		return stacktrace.Propagate(err, "Failed to plan the migration of %s", dbName)
+	}
+	currentStepIndex := stepIndex(steps, *currentVersion)
+
+	if *dryRun {
+		printPlan(dbName, exists, currentVersion, targetVersion, schemasDir, steps[1:currentStepIndex+1], plan)
+		return nil
 	}
 
-	// Compute index of current version
-	var currentStepIndex int = -1
-	for i, version := range steps {
-		if version.version == *currentVersion {
-			currentStepIndex = i
+	// Make the database multi-region if requested
+	if *regions != "" {
+		if err := crdb.SetDatabaseRegions(ctx, dbName, strings.Split(*regions, ",")); err != nil {
+			return stacktrace.Propagate(err, "Failed to set the regions of database %s", dbName)
+		}
+		// Apply the multi-region statements of the migrations already run, in
+		// case the database was migrated before being made multi-region.
+		for i := 1; i <= currentStepIndex; i++ {
+			if steps[i].upToFile == "" {
+				continue
+			}
+			fullFilePath := filepath.Join(schemasDir, steps[i].upToFile)
+			rawMigrationSQL, err := ioutil.ReadFile(fullFilePath)
+			if err != nil {
+				return stacktrace.Propagate(err, "Failed to load SQL content from %s", fullFilePath)
+			}
+			_, multiRegionSQL, err := cockroach.SplitMultiRegionSQL(string(rawMigrationSQL))
+			if err != nil {
+				return stacktrace.Propagate(err, "Failed to parse multi-region sections of %s", fullFilePath)
+			}
+			if strings.TrimSpace(multiRegionSQL) == "" {
+				continue
+			}
+			log.Printf("Applying multi-region statements of %s", steps[i].upToFile)
+			if _, err := crdb.Pool.Exec(ctx, fmt.Sprintf("USE %s;\n", dbName)+multiRegionSQL); err != nil {
+				return stacktrace.Propagate(err, "Failed to execute multi-region statements of %s", fullFilePath)
+			}
 		}
 	}
+	dbRegions, err := crdb.DatabaseRegions(ctx, dbName)
+	if err != nil {
+		return stacktrace.Propagate(err, "Failed to list the regions of database %s", dbName)
+	}
+	multiRegion := len(dbRegions) > 0
+	if multiRegion {
+		log.Printf("Database %s is multi-region with regions %s", dbName, strings.Join(dbRegions, ", "))
+	}
 
-	// Perform migration steps until current version matches target version
-	for !currentVersion.Equal(*targetVersion) {
-		// Compute which migration step to run next and how it will change the schema version
-		var newCurrentStepIndex int
-		var sqlFile string
-		var newVersion *semver.Version
-		if currentVersion.LessThan(*targetVersion) {
-			// Migrate up to next version
-			sqlFile = steps[currentStepIndex+1].upToFile
-			newVersion = &steps[currentStepIndex+1].version
-			newCurrentStepIndex = currentStepIndex + 1
-		} else {
-			// Migrate down from current version
-			sqlFile = steps[currentStepIndex].downFromFile
-			newCurrentStepIndex = currentStepIndex - 1
-			newVersion = &steps[newCurrentStepIndex].version
+	// Record the checksums of the applied migration files
+	if len(plan) > 0 {
+		if err := createAppliedMigrationsTable(ctx, crdb); err != nil {
+			return err // No need to Propagate this error as this stack layer does not add useful information
 		}
-		log.Printf("Running %s to migrate %v to %v", sqlFile, currentVersion, newVersion)
+	}
 
-		// Read migration SQL into string
-		fullFilePath := filepath.Join(*path, sqlFile)
-		rawMigrationSQL, err := ioutil.ReadFile(fullFilePath)
-		if err != nil {
-			log.Panicf("Failed to load SQL content from %s: %v", fullFilePath, err)
+	// Perform migration steps until current version matches target version
+	var dataDB *cockroach.DB
+	defer func() {
+		if dataDB != nil {
+			dataDB.Pool.Close()
 		}
-		migrationSQL := fmt.Sprintf("USE %s;\n", dbName) + string(rawMigrationSQL)
+	}()
+	for _, step := range plan {
+		newVersion := semver.New(step.To)
+		up := currentVersion.LessThan(*newVersion)
 
-		// Execute migration step
-		if _, err := crdb.Pool.Exec(context.Background(), migrationSQL); err != nil {
-			log.Panicf("Failed to execute %s migration step %s: %v", dbName, fullFilePath, err)
+		if step.Go {
+			// Data migrations run against their database rather than through
+			// USE statements, as they span several transactions.
+			log.Printf("Running data migration %s to migrate %v to %v", step.File, currentVersion, newVersion)
+			if dataDB == nil {
+				if dataDB, err = dialDatabase(ctx, dbName); err != nil {
+					return err // No need to Propagate this error as this stack layer does not add useful information
+				}
+				if err := datamigration.CreateProgressTable(ctx, crdb); err != nil {
+					return stacktrace.Propagate(err, "Failed to create the data migration progress table")
+				}
+			}
+			runner := &datamigration.Runner{DB: dataDB, Schemas: filepath.Base(schemasDir), BatchSize: *batchSize, Logf: log.Printf}
+			direction := datamigration.Up
+			dataStep := steps[stepIndex(steps, *newVersion)].dataStep
+			if !up {
+				direction = datamigration.Down
+				dataStep = steps[stepIndex(steps, *currentVersion)].dataStep
+			}
+			if err := runner.Run(ctx, *dataStep, direction, step.To); err != nil {
+				return stacktrace.Propagate(err, "Failed to execute %s data migration step %s", dbName, step.File)
+			}
+		} else {
+			log.Printf("Running %s to migrate %v to %v", step.File, currentVersion, newVersion)
+			if err := runSQLStep(ctx, crdb, filepath.Join(schemasDir, step.File), dbName, multiRegion); err != nil {
+				return err // No need to Propagate this error as this stack layer does not add useful information
+			}
 		}
 
 		// Update current state
-		if dbName == "defaultdb" && newVersion.String() == "4.0.0" && newCurrentStepIndex > currentStepIndex {
+		if dbName == "defaultdb" && newVersion.String() == "4.0.0" && up {
 			// RID database changes from `defaultdb` to `rid` when moving up to 4.0.0
 			dbName = "rid"
 		}
-		if dbName == "rid" && currentVersion.String() == "4.0.0" && newCurrentStepIndex < currentStepIndex {
+		if dbName == "rid" && currentVersion.String() == "4.0.0" && !up {
 			// RID database changes from `rid` to `defaultdb` when moving down from 4.0.0
 			dbName = "defaultdb"
 		}
-		actualVersion, err := crdb.GetVersion(context.Background(), dbName)
+		actualVersion, err := crdb.GetVersion(ctx, dbName)
 		if err != nil {
-			log.Panicf("Failed to get current database version for %s: %v", dbName, err)
+			return stacktrace.Propagate(err, "Failed to get current database version for %s", dbName)
 		}
 		if !actualVersion.Equal(*newVersion) {
-			log.Panicf("Migration %s should have migrated %s schema version %v to %v, but instead resulted in %v", fullFilePath, dbName, currentVersion, newVersion, currentVersion)
+			return stacktrace.NewError("Migration %s should have migrated %s schema version %v to %v, but instead resulted in %v", step.File, dbName, currentVersion, newVersion, actualVersion)
+		}
+		if up && !step.Go {
+			checksum, err := fileChecksum(filepath.Join(schemasDir, step.File))
+			if err != nil {
+				return err // No need to Propagate this error as this stack layer does not add useful information
+			}
+			if err := recordMigration(ctx, crdb, filepath.Base(schemasDir), step.File, checksum); err != nil {
+				return err // No need to Propagate this error as this stack layer does not add useful information
+			}
+		} else if upToFile := steps[stepIndex(steps, *currentVersion)].upToFile; !up && upToFile != "" {
+			if err := forgetMigration(ctx, crdb, filepath.Base(schemasDir), upToFile); err != nil {
+				return err // No need to Propagate this error as this stack layer does not add useful information
+			}
 		}
 		currentVersion = actualVersion
-		currentStepIndex = newCurrentStepIndex
 	}
 
 	log.Printf("Final %s version: %v", dbName, currentVersion)
+	return checkSchema(ctx, crdb, schemasDir, dbName, true, steps, *currentVersion)
+}
+
+// printVersions prints the schema versions of schemasDir, in ascending order.
+func printVersions(schemasDir string) error {
+	steps, err := enumerateMigrationSteps(&schemasDir)
+	if err != nil {
+		return stacktrace.Propagate(err, "Failed to read schema version migration definitions")
+	}
+	for _, step := range steps[1:] {
+		fmt.Println(step.version)
+	}
+	return nil
+}
+
+// runSQLStep runs the SQL migration file at path against database dbName,
+// including its multi-region sections if multiRegion.
+func runSQLStep(ctx context.Context, crdb *cockroach.DB, path string, dbName string, multiRegion bool) error {
+	// Read migration SQL into string
+	rawMigrationSQL, err := ioutil.ReadFile(path)
+	if err != nil {
+		return stacktrace.Propagate(err, "Failed to load SQL content from %s", path)
+	}
+	commonSQL, _, err := cockroach.SplitMultiRegionSQL(string(rawMigrationSQL))
+	if err != nil {
+		return stacktrace.Propagate(err, "Failed to parse multi-region sections of %s", path)
+	}
+	if multiRegion {
+		// The multi-region markers are comments, so the full script may run.
+		commonSQL = string(rawMigrationSQL)
+	}
+	migrationSQL := fmt.Sprintf("USE %s;\n", dbName) + commonSQL
+
+	// Execute migration step
+	if _, err := crdb.Pool.Exec(ctx, migrationSQL); err != nil {
+		return stacktrace.Propagate(err, "Failed to execute %s migration step %s", dbName, path)
+	}
+	return nil
+}
+
+// dialDatabase connects to database dbName.
+func dialDatabase(ctx context.Context, dbName string) (*cockroach.DB, error) {
+	connectParameters := flags.ConnectParameters()
+	connectParameters.ApplicationName = "db-manager"
+	connectParameters.DBName = dbName
+	db, err := cockroach.Dial(ctx, connectParameters)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to connect to database %s", dbName)
+	}
+	return db, nil
+}
+
+// checkSchema writes the snapshot of the schema of database dbName, at
+// version, and checks its drift, as requested by the flags.
+func checkSchema(ctx context.Context, crdb *cockroach.DB, schemasDir string, dbName string, exists bool, steps []MigrationStep, version semver.Version) error {
+	if !*writeSnapshotFlag && !*checkDriftFlag {
+		return nil
+	}
+	if !exists {
+		return stacktrace.NewError("Database %s does not exist", dbName)
+	}
+	if stepIndex(steps, version) < 0 {
+		return stacktrace.NewError("Schema version %v of %s has no migration definition", version, dbName)
+	}
+	if *writeSnapshotFlag {
+		if err := writeSnapshot(ctx, crdb, schemasDir, dbName, version); err != nil {
+			return err // No need to Propagate this error as this stack layer does not add useful information
+		}
+	}
+	if *checkDriftFlag {
+		return checkDrift(ctx, crdb, schemasDir, dbName, steps, version)
+	}
+	return nil
+}
+
+// parseTargetVersion returns the version requested by dbVersion, or nil if
+// the current version is only to be printed.
+func parseTargetVersion(dbVersion string, steps []MigrationStep) (*semver.Version, error) {
+	if strings.ToLower(dbVersion) == "latest" {
+		return &steps[len(steps)-1].version, nil
+	} else if strings.TrimSpace(dbVersion) == "" {
+		// User just wants to print the current version
+		return nil, nil
+	}
+	targetVersion, err := semver.NewVersion(dbVersion)
+	if err != nil {
+		return nil, stacktrace.PropagateWithCode(err, exitUsage, "Failed to parse desired db_version")
+	}
+	return targetVersion, nil
+}
+
+// resolveDatabase returns the name of the database of the schemas named
+// dbName, and whether it exists.
+func resolveDatabase(ctx context.Context, crdb *cockroach.DB, dbName string) (string, bool, error) {
+	exists, err := doesDatabaseExist(crdb, dbName)
+	if err != nil {
+		return "", false, stacktrace.Propagate(err, "Failed to check whether database %s exists", dbName)
+	}
+	if !exists && dbName == "rid" {
+		// In the special case of rid, the database was previously named defaultdb
+		log.Printf("Database %s does not exist; checking for older \"defaultdb\" database", dbName)
+		dbName = "defaultdb"
+		exists, err = doesDatabaseExist(crdb, dbName)
+		if err != nil {
+			return "", false, stacktrace.Propagate(err, "Failed to check whether old defaultdb database exists")
+		}
+	}
+	return dbName, exists, nil
+}
+
+// stepIndex returns the index of the step of version in steps, or -1 if
+// none.
+func stepIndex(steps []MigrationStep, version semver.Version) int {
+	for i, step := range steps {
+		if step.version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+// planMigration returns the steps to run, in order, to migrate a database
+// from version current to version target.
+func planMigration(steps []MigrationStep, current semver.Version, target semver.Version) ([]PlannedStep, error) {
+	currentIndex := stepIndex(steps, current)
+	if currentIndex < 0 {
+		return nil, stacktrace.NewError("Current version %v has no migration definition", current)
+	}
+	targetIndex := stepIndex(steps, target)
+	if targetIndex < 0 {
+		return nil, stacktrace.NewErrorWithCode(exitUsage, "Desired version %v has no migration definition", target)
+	}
+
+	plan := []PlannedStep{}
+	for i := currentIndex; i < targetIndex; i++ {
+		if dataStep := steps[i+1].dataStep; dataStep != nil {
+			plan = append(plan, PlannedStep{File: dataStep.Name, Go: true, From: steps[i].version.String(), To: steps[i+1].version.String()})
+			continue
+		}
+		if steps[i+1].upToFile == "" {
+			return nil, stacktrace.NewError("Missing migration definition up to version %v", steps[i+1].version)
+		}
+		plan = append(plan, PlannedStep{File: steps[i+1].upToFile, From: steps[i].version.String(), To: steps[i+1].version.String()})
+	}
+	for i := currentIndex; i > targetIndex; i-- {
+		if dataStep := steps[i].dataStep; dataStep != nil {
+			if dataStep.Down == nil {
+				return nil, stacktrace.NewError("Data migration %s may not be reverted from version %v", dataStep.Name, steps[i].version)
+			}
+			plan = append(plan, PlannedStep{File: dataStep.Name, Go: true, From: steps[i].version.String(), To: steps[i-1].version.String()})
+			continue
+		}
+		if steps[i].downFromFile == "" {
+			return nil, stacktrace.NewError("Missing migration definition down from version %v", steps[i].version)
+		}
+		plan = append(plan, PlannedStep{File: steps[i].downFromFile, From: steps[i].version.String(), To: steps[i-1].version.String()})
+	}
+	return plan, nil
+}
+
+// printPlan prints the steps of plan, which would migrate database dbName
+// from version current, reached by the applied steps, to version target.
+func printPlan(dbName string, exists bool, current *semver.Version, target *semver.Version, schemasDir string, applied []MigrationStep, plan []PlannedStep) {
+	fmt.Printf("Dry run of the migration of database %s from version %v to %v\n", dbName, current, target)
+	if !exists {
+		fmt.Printf("Would create database %s\n", dbName)
+	}
+	if *regions != "" {
+		fmt.Printf("Would set the regions of database %s to %s, and apply the multi-region statements of:\n", dbName, *regions)
+		for _, step := range applied {
+			if step.upToFile != "" {
+				fmt.Printf("  %s\n", filepath.Join(schemasDir, step.upToFile))
+			}
+		}
+	}
+	if len(plan) == 0 {
+		fmt.Printf("No migration step to run\n")
+		return
+	}
+	fmt.Printf("Would run:\n")
+	for i, step := range plan {
+		if step.Go {
+			fmt.Printf("  %d. data migration %s (%s to %s)\n", i+1, step.File, step.From, step.To)
+		} else {
+			fmt.Printf("  %d. %s (%s to %s)\n", i+1, filepath.Join(schemasDir, step.File), step.From, step.To)
+		}
+	}
 }
 
 func enumerateMigrationSteps(path *string) ([]MigrationStep, error) {
@@ -202,6 +529,15 @@ func enumerateMigrationSteps(path *string) ([]MigrationStep, error) {
 		}
 	}
 
+	// Add the data migration steps coded in Go
+	for _, dataStep := range datamigration.Steps(filepath.Base(*path)) {
+		dataStep := dataStep
+		if step, ok := steps[dataStep.Version]; ok {
+			return make([]MigrationStep, 0), stacktrace.NewError("Version %v has both SQL migration files (%s) and data migration %s", dataStep.Version, strings.TrimSpace(step.upToFile+" "+step.downFromFile), dataStep.Name)
+		}
+		steps[dataStep.Version] = MigrationStep{version: dataStep.Version, dataStep: &dataStep}
+	}
+
 	// Sort versions in ascending order
 	versions := make([]*semver.Version, len(steps))
 	i := 0
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/interuss/dss/pkg/datamigration"
+	dsssql "github.com/interuss/dss/pkg/sql"
+	"github.com/interuss/stacktrace"
+	"github.com/stretchr/testify/require"
+)
+
+func noopBatch(context.Context, dsssql.Queryable, string, int) (datamigration.Batch, error) {
+	return datamigration.Batch{Done: true}, nil
+}
+
+// testSteps returns the steps of schemas with SQL migrations up to and down
+// from 1.0.0 and 1.1.0, a reversible data migration to 1.2.0, SQL migrations
+// up to 1.3.0 without down migration, and an irreversible data migration to
+// 1.4.0.
+func testSteps() []MigrationStep {
+	return []MigrationStep{
+		{version: *semver.New("0.0.0")},
+		{version: *semver.New("1.0.0"), upToFile: "upto-v1.0.0-init.sql", downFromFile: "downfrom-v1.0.0-init.sql"},
+		{version: *semver.New("1.1.0"), upToFile: "upto-v1.1.0-add.sql", downFromFile: "downfrom-v1.1.0-add.sql"},
+		{version: *semver.New("1.2.0"), dataStep: &datamigration.Step{Name: "rewrite", Up: noopBatch, Down: noopBatch}},
+		{version: *semver.New("1.3.0"), upToFile: "upto-v1.3.0-index.sql"},
+		{version: *semver.New("1.4.0"), dataStep: &datamigration.Step{Name: "recompute", Up: noopBatch}},
+	}
+}
+
+func TestPlanMigration(t *testing.T) {
+	withoutUpFile := testSteps()
+	withoutUpFile[2].upToFile = ""
+
+	cases := []struct {
+		name    string
+		steps   []MigrationStep
+		current string
+		target  string
+		want    []PlannedStep
+		code    stacktrace.ErrorCode
+	}{
+		{
+			name:    "up from scratch",
+			current: "0.0.0",
+			target:  "1.1.0",
+			want: []PlannedStep{
+				{File: "upto-v1.0.0-init.sql", From: "0.0.0", To: "1.0.0"},
+				{File: "upto-v1.1.0-add.sql", From: "1.0.0", To: "1.1.0"},
+			},
+		},
+		{
+			name:    "up through data migrations",
+			current: "1.1.0",
+			target:  "1.4.0",
+			want: []PlannedStep{
+				{File: "rewrite", Go: true, From: "1.1.0", To: "1.2.0"},
+				{File: "upto-v1.3.0-index.sql", From: "1.2.0", To: "1.3.0"},
+				{File: "recompute", Go: true, From: "1.3.0", To: "1.4.0"},
+			},
+		},
+		{
+			name:    "down through a data migration",
+			current: "1.2.0",
+			target:  "1.0.0",
+			want: []PlannedStep{
+				{File: "rewrite", Go: true, From: "1.2.0", To: "1.1.0"},
+				{File: "downfrom-v1.1.0-add.sql", From: "1.1.0", To: "1.0.0"},
+			},
+		},
+		{
+			name:    "current version",
+			current: "1.1.0",
+			target:  "1.1.0",
+			want:    []PlannedStep{},
+		},
+		{
+			name:    "missing up file",
+			steps:   withoutUpFile,
+			current: "1.0.0",
+			target:  "1.1.0",
+			code:    stacktrace.NoCode,
+		},
+		{
+			name:    "missing down file",
+			current: "1.3.0",
+			target:  "1.2.0",
+			code:    stacktrace.NoCode,
+		},
+		{
+			name:    "irreversible data migration",
+			current: "1.4.0",
+			target:  "1.3.0",
+			code:    stacktrace.NoCode,
+		},
+		{
+			name:    "unknown current version",
+			current: "0.9.0",
+			target:  "1.0.0",
+			code:    stacktrace.NoCode,
+		},
+		{
+			name:    "unknown target version",
+			current: "1.0.0",
+			target:  "2.0.0",
+			code:    exitUsage,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			steps := tc.steps
+			if steps == nil {
+				steps = testSteps()
+			}
+			plan, err := planMigration(steps, *semver.New(tc.current), *semver.New(tc.target))
+			if tc.want == nil {
+				require.Error(t, err)
+				require.Equal(t, tc.code, stacktrace.GetCode(err))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, plan)
+		})
+	}
+}
+
+func TestParseTargetVersion(t *testing.T) {
+	cases := []struct {
+		name      string
+		dbVersion string
+		want      *semver.Version
+		invalid   bool
+	}{
+		{name: "latest", dbVersion: "latest", want: semver.New("1.4.0")},
+		{name: "latest in capitals", dbVersion: "LATEST", want: semver.New("1.4.0")},
+		{name: "version", dbVersion: "1.1.0", want: semver.New("1.1.0")},
+		{name: "blank", dbVersion: " "},
+		{name: "invalid", dbVersion: "1.1", invalid: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			version, err := parseTargetVersion(tc.dbVersion, testSteps())
+			if tc.invalid {
+				require.Error(t, err)
+				require.Equal(t, exitUsage, stacktrace.GetCode(err))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, version)
+		})
+	}
+}
+
+func TestMarshalStatus(t *testing.T) {
+	cases := []struct {
+		name     string
+		statuses []databaseStatus
+		want     string
+	}{
+		{
+			name:     "no database",
+			statuses: []databaseStatus{},
+			want:     `{"databases":[]}`,
+		},
+		{
+			name: "pending steps",
+			statuses: []databaseStatus{{
+				SchemasDir:        "/db-schemas/scd",
+				Database:          "scd",
+				Exists:            true,
+				CurrentVersion:    "1.1.0",
+				TargetVersion:     "1.2.0",
+				AvailableVersions: []string{"1.0.0", "1.1.0", "1.2.0"},
+				PendingSteps:      []PlannedStep{{File: "rewrite", Go: true, From: "1.1.0", To: "1.2.0"}},
+			}},
+			want: `{"databases":[{
+				"schemas_dir":"/db-schemas/scd",
+				"database":"scd",
+				"exists":true,
+				"current_version":"1.1.0",
+				"target_version":"1.2.0",
+				"available_versions":["1.0.0","1.1.0","1.2.0"],
+				"pending_steps":[{"file":"rewrite","go":true,"from":"1.1.0","to":"1.2.0"}]
+			}]}`,
+		},
+		{
+			name: "unplannable",
+			statuses: []databaseStatus{{
+				SchemasDir:        "/db-schemas/rid",
+				Database:          "rid",
+				CurrentVersion:    "0.0.0",
+				TargetVersion:     "1.0.0",
+				AvailableVersions: []string{"1.0.0"},
+				PendingSteps:      []PlannedStep{},
+				Error:             "Missing migration definition up to version 1.0.0",
+			}, {
+				SchemasDir:        "/db-schemas/scd",
+				Database:          "scd",
+				Exists:            true,
+				CurrentVersion:    "1.0.0",
+				TargetVersion:     "1.0.0",
+				AvailableVersions: []string{"1.0.0"},
+				PendingSteps:      []PlannedStep{{File: "upto-v1.0.0-init.sql", From: "0.0.0", To: "1.0.0"}},
+			}},
+			want: `{"databases":[{
+				"schemas_dir":"/db-schemas/rid",
+				"database":"rid",
+				"exists":false,
+				"current_version":"0.0.0",
+				"target_version":"1.0.0",
+				"available_versions":["1.0.0"],
+				"pending_steps":[],
+				"error":"Missing migration definition up to version 1.0.0"
+			}, {
+				"schemas_dir":"/db-schemas/scd",
+				"database":"scd",
+				"exists":true,
+				"current_version":"1.0.0",
+				"target_version":"1.0.0",
+				"available_versions":["1.0.0"],
+				"pending_steps":[{"file":"upto-v1.0.0-init.sql","from":"0.0.0","to":"1.0.0"}]
+			}]}`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := marshalStatus(tc.statuses)
+			require.NoError(t, err)
+			require.JSONEq(t, tc.want, string(b))
+		})
+	}
+}
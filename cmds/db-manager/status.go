@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/interuss/dss/pkg/cockroach"
+	"github.com/interuss/stacktrace"
+)
+
+// databaseStatus is the status of the database of a schemas directory.
+type databaseStatus struct {
+	SchemasDir        string        `json:"schemas_dir"`
+	Database          string        `json:"database"`
+	Exists            bool          `json:"exists"`
+	CurrentVersion    string        `json:"current_version"`
+	TargetVersion     string        `json:"target_version"`
+	AvailableVersions []string      `json:"available_versions"`
+	PendingSteps      []PlannedStep `json:"pending_steps"`
+	// Error explains why the pending steps could not be planned, e.g.
+	// because the current version is unknown to the schemas directory.
+	Error string `json:"error,omitempty"`
+}
+
+// printStatus prints as JSON the status of the databases of schemasDirs,
+// with the steps pending to reach dbVersion, or the latest version if empty.
+func printStatus(ctx context.Context, crdb *cockroach.DB, schemasDirs []string, dbVersion string) error {
+	if dbVersion == "" {
+		dbVersion = "latest"
+	}
+
+	statuses := []databaseStatus{}
+	for _, schemasDir := range schemasDirs {
+		status, err := getStatus(ctx, crdb, schemasDir, dbVersion)
+		if err != nil {
+			return stacktrace.Propagate(err, "Failed to get the status of the database of %s", schemasDir)
+		}
+		statuses = append(statuses, status)
+	}
+
+	b, err := marshalStatus(statuses)
+	if err != nil {
+		return err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	fmt.Println(string(b))
+	return nil
+}
+
+// marshalStatus returns the JSON document printed for statuses.
+func marshalStatus(statuses []databaseStatus) ([]byte, error) {
+	result := struct {
+		Databases []databaseStatus `json:"databases"`
+	}{Databases: statuses}
+	b, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to marshal status")
+	}
+	return b, nil
+}
+
+func getStatus(ctx context.Context, crdb *cockroach.DB, schemasDir string, dbVersion string) (databaseStatus, error) {
+	steps, err := enumerateMigrationSteps(&schemasDir)
+	if err != nil {
+		return databaseStatus{}, stacktrace.Propagate(err, "Failed to read schema version migration definitions")
+	}
+	if len(steps) <= 1 {
+		return databaseStatus{}, stacktrace.NewErrorWithCode(exitUsage, "No migration definitions found in schemas_dir=%s", schemasDir)
+	}
+	targetVersion, err := parseTargetVersion(dbVersion, steps)
+	if err != nil {
+		return databaseStatus{}, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+
+	dbName, exists, err := resolveDatabase(ctx, crdb, filepath.Base(schemasDir))
+	if err != nil {
+		return databaseStatus{}, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	currentVersion := cockroach.UnknownVersion
+	if exists {
+		currentVersion, err = crdb.GetVersion(ctx, dbName)
+		if err != nil {
+			return databaseStatus{}, stacktrace.Propagate(err, "Failed to get current database version for %s", dbName)
+		}
+	}
+
+	status := databaseStatus{
+		SchemasDir:        schemasDir,
+		Database:          dbName,
+		Exists:            exists,
+		CurrentVersion:    currentVersion.String(),
+		TargetVersion:     targetVersion.String(),
+		AvailableVersions: []string{},
+		PendingSteps:      []PlannedStep{},
+	}
+	for _, step := range steps[1:] {
+		status.AvailableVersions = append(status.AvailableVersions, step.version.String())
+	}
+	plan, err := planMigration(steps, *currentVersion, *targetVersion)
+	if err != nil {
+		status.Error = stacktrace.RootCause(err).Error()
+	} else {
+		status.PendingSteps = plan
+	}
+	return status, nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/interuss/stacktrace"
+)
+
+// envPrefix prefixes the names of the environment variables overriding the
+// configuration, e.g. DSS_GATEWAY_CORE_SERVICE.
+const envPrefix = "DSS_GATEWAY_"
+
+// Config is the configuration of http-gateway.
+type Config struct {
+	Addr            string `yaml:"addr"`
+	TraceRequests   bool   `yaml:"trace_requests"`
+	CoreService     string `yaml:"core_service"`
+	ProfServiceName string `yaml:"gcp_prof_service_name"`
+	EnableSCD       bool   `yaml:"enable_scd"`
+	EnableWatch     bool   `yaml:"enable_watch"`
+}
+
+var (
+	configFile = flag.String("config", "", "Path to a YAML configuration file. Values set on the command line or in DSS_GATEWAY_* environment variables take precedence over the file.")
+
+	cfg Config
+)
+
+func init() {
+	flag.StringVar(&cfg.Addr, "addr", ":8080", "Local address that the gateway binds to and listens on for incoming connections")
+	flag.BoolVar(&cfg.TraceRequests, "trace_requests", false, "Logs HTTP request/response pairs to stderr if true")
+	flag.BoolVar(&cfg.TraceRequests, "trace-requests", false, "Deprecated: use trace_requests")
+	flag.StringVar(&cfg.CoreService, "core_service", "", "Endpoint for core service. Only to be set if run in proxy mode")
+	flag.StringVar(&cfg.CoreService, "core-service", "", "Deprecated: use core_service")
+	flag.StringVar(&cfg.ProfServiceName, "gcp_prof_service_name", "", "Service name for the Go profiler")
+	flag.BoolVar(&cfg.EnableSCD, "enable_scd", false, "Enables the Strategic Conflict Detection API")
+	flag.BoolVar(&cfg.EnableWatch, "enable_watch", false, "Enables the server-sent events endpoint of the subscription watch API")
+}
+
+// Validate implements config.Validator.
+func (c *Config) Validate() error {
+	if c.Addr == "" {
+		return stacktrace.NewError("Missing addr")
+	}
+	if c.CoreService == "" {
+		return stacktrace.NewError("Missing core_service")
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/interuss/dss/pkg/healthcheck"
+
+	"go.uber.org/zap"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const healthCheckTimeout = 5 * time.Second
+
+// healthHandler answers container health checks, querying the gRPC health
+// service of the core service for readiness.
+type healthHandler struct {
+	client   healthpb.HealthClient
+	services []string // Services of the core service exposed by the gateway
+	logger   *zap.Logger
+}
+
+func newHealthHandler(client healthpb.HealthClient, enableSCD bool, enableWatch bool, logger *zap.Logger) *healthHandler {
+	services := []string{
+		healthcheck.RIDV1Service,
+		healthcheck.RIDV2Service,
+		healthcheck.AuxService,
+		healthcheck.AdminService,
+	}
+	if enableSCD {
+		services = append(services, healthcheck.SCDService, healthcheck.BulkService, healthcheck.HistoryService)
+	}
+	if enableWatch {
+		services = append(services, healthcheck.WatchService)
+	}
+	return &healthHandler{client: client, services: services, logger: logger}
+}
+
+func (h *healthHandler) check(ctx context.Context, service string) (healthpb.HealthCheckResponse_ServingStatus, error) {
+	res, err := h.client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		return healthpb.HealthCheckResponse_UNKNOWN, err
+	}
+	return res.Status, nil
+}
+
+// serveHealthy reports that the gateway is alive, regardless of the state of
+// the core service, so that the gateway is not restarted while the core
+// service is unavailable.
+func (h *healthHandler) serveHealthy(w http.ResponseWriter, r *http.Request) {
+	if _, err := w.Write([]byte("ok")); err != nil {
+		h.logger.Error("Error writing to /healthy")
+	}
+}
+
+// serveReady succeeds only if all the services of the core service the
+// gateway exposes are serving. As it is not authenticated, the status of each
+// service is logged rather than returned.
+func (h *healthHandler) serveReady(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+	defer cancel()
+
+	ready := true
+	for _, service := range h.services {
+		status, err := h.check(ctx, service)
+		if err != nil {
+			h.logger.Warn("core service health check failed", zap.String("service", service), zap.Error(err))
+		} else if status != healthpb.HealthCheckResponse_SERVING {
+			h.logger.Warn("core service not serving", zap.String("service", service), zap.Stringer("status", status))
+		}
+		if status != healthpb.HealthCheckResponse_SERVING {
+			ready = false
+		}
+	}
+
+	if !ready {
+		http.Error(w, "not ready", http.StatusServiceUnavailable)
+		return
+	}
+	if _, err := w.Write([]byte("ok")); err != nil {
+		h.logger.Error("Error writing to /ready", zap.Error(err))
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/interuss/dss/pkg/healthcheck"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// fakeHealthClient is a healthpb.HealthClient reporting the statuses of its
+// services, and failing for the others.
+type fakeHealthClient struct {
+	healthpb.HealthClient
+	statuses map[string]healthpb.HealthCheckResponse_ServingStatus
+}
+
+func (c *fakeHealthClient) Check(ctx context.Context, req *healthpb.HealthCheckRequest, opts ...grpc.CallOption) (*healthpb.HealthCheckResponse, error) {
+	s, ok := c.statuses[req.Service]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "unknown service")
+	}
+	return &healthpb.HealthCheckResponse{Status: s}, nil
+}
+
+func TestHealthHandler(t *testing.T) {
+	// The core service does not serve SCD, which the gateway only exposes if
+	// enabled.
+	client := &fakeHealthClient{statuses: map[string]healthpb.HealthCheckResponse_ServingStatus{
+		healthcheck.OverallService: healthpb.HealthCheckResponse_NOT_SERVING,
+		healthcheck.RIDV1Service:   healthpb.HealthCheckResponse_SERVING,
+		healthcheck.RIDV2Service:   healthpb.HealthCheckResponse_SERVING,
+		healthcheck.AuxService:     healthpb.HealthCheckResponse_SERVING,
+		healthcheck.AdminService:   healthpb.HealthCheckResponse_SERVING,
+		healthcheck.SCDService:     healthpb.HealthCheckResponse_NOT_SERVING,
+	}}
+
+	for _, tc := range []struct {
+		name      string
+		enableSCD bool
+		ready     int
+	}{
+		{name: "SCD disabled", ready: http.StatusOK},
+		{name: "SCD enabled", enableSCD: true, ready: http.StatusServiceUnavailable},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			h := newHealthHandler(client, tc.enableSCD, false, zap.NewNop())
+
+			w := httptest.NewRecorder()
+			h.serveReady(w, httptest.NewRequest(http.MethodGet, "/ready", nil))
+			require.Equal(t, tc.ready, w.Code)
+			require.NotContains(t, w.Body.String(), "SERVING")
+
+			// The gateway is alive regardless of the core service.
+			w = httptest.NewRecorder()
+			h.serveHealthy(w, httptest.NewRequest(http.MethodGet, "/healthy", nil))
+			require.Equal(t, http.StatusOK, w.Code)
+		})
+	}
+}
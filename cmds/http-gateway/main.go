@@ -5,6 +5,7 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"net/textproto"
 	"os"
@@ -15,13 +16,19 @@ import (
 	"time"
 
 	"cloud.google.com/go/profiler"
+	"github.com/interuss/dss/pkg/api/v1/adminpb"
 	"github.com/interuss/dss/pkg/api/v1/auxpb"
+	"github.com/interuss/dss/pkg/api/v1/bulkpb"
+	"github.com/interuss/dss/pkg/api/v1/historypb"
 	"github.com/interuss/dss/pkg/api/v1/ridpbv1"
 	"github.com/interuss/dss/pkg/api/v1/scdpb"
+	"github.com/interuss/dss/pkg/api/v1/watchpb"
 	"github.com/interuss/dss/pkg/api/v2/ridpbv2"
 	"github.com/interuss/dss/pkg/build"
+	"github.com/interuss/dss/pkg/config"
 	"github.com/interuss/dss/pkg/errors"
 	"github.com/interuss/dss/pkg/logging"
+	"github.com/interuss/dss/pkg/ratelimit"
 
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"github.com/interuss/stacktrace"
@@ -29,17 +36,10 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/grpclog"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
 )
 
-var (
-	address         = flag.String("addr", ":8080", "Local address that the gateway binds to and listens on for incoming connections")
-	traceRequests   = flag.Bool("trace-requests", false, "Logs HTTP request/response pairs to stderr if true")
-	coreService     = flag.String("core-service", "", "Endpoint for core service. Only to be set if run in proxy mode")
-	profServiceName = flag.String("gcp_prof_service_name", "", "Service name for the Go profiler")
-	enableSCD       = flag.Bool("enable_scd", false, "Enables the Strategic Conflict Detection API")
-)
-
 const (
 	codeRetryable = stacktrace.ErrorCode(1)
 )
@@ -51,7 +51,7 @@ func RunHTTPProxy(ctx context.Context, ctxCanceler func(), address, endpoint str
 		zap.String("address", address), zap.String("endpoint", endpoint),
 	)
 
-	logger.Info("build", zap.Any("description", build.Describe()))
+	logger.Info("build", zap.Any("description", build.Describe()), zap.Any("config", config.Redact(&cfg)))
 
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -60,12 +60,13 @@ func RunHTTPProxy(ctx context.Context, ctxCanceler func(), address, endpoint str
 
 	// Register gRPC server endpoint
 	// Note: Make sure the gRPC server is running properly and accessible
+	marshaler := &runtime.JSONPb{
+		OrigName:     true,
+		EmitDefaults: true, // Include empty JSON arrays.
+		Indent:       "  ",
+	}
 	grpcMux := runtime.NewServeMux(
-		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
-			OrigName:     true,
-			EmitDefaults: true, // Include empty JSON arrays.
-			Indent:       "  ",
-		}),
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, marshaler),
 	)
 
 	opts := []grpc.DialOption{
@@ -101,8 +102,16 @@ func RunHTTPProxy(ctx context.Context, ctxCanceler func(), address, endpoint str
 		return stacktrace.Propagate(err, "Error registering aux service handler")
 	}
 
+	logger.Info("Registering admin service")
+	if err := adminpb.RegisterDSSAdminServiceHandlerFromEndpoint(ctx, grpcMux, endpoint, opts); err != nil {
+		if strings.Contains(err.Error(), "context deadline exceeded") {
+			return stacktrace.PropagateWithCode(err, codeRetryable, "Failed to connect to core-service for admin")
+		}
+		return stacktrace.Propagate(err, "Error registering admin service handler")
+	}
+
 	logger.Info("Registering SCD service")
-	if *enableSCD {
+	if cfg.EnableSCD {
 		if err := scdpb.RegisterUTMAPIUSSDSSAndUSSUSSServiceHandlerFromEndpoint(ctx, grpcMux, endpoint, opts); err != nil {
 			// TODO: More robustly detect failure to create SCD server is due to a problem that may be temporary
 			if strings.Contains(err.Error(), "context deadline exceeded") {
@@ -110,26 +119,70 @@ func RunHTTPProxy(ctx context.Context, ctxCanceler func(), address, endpoint str
 			}
 			return stacktrace.Propagate(err, "Error registering SCD service handler")
 		}
+		if err := bulkpb.RegisterDSSBulkServiceHandlerFromEndpoint(ctx, grpcMux, endpoint, opts); err != nil {
+			if strings.Contains(err.Error(), "context deadline exceeded") {
+				return stacktrace.PropagateWithCode(err, codeRetryable, "Failed to connect to core-service for strategic conflict detection bulk operations")
+			}
+			return stacktrace.Propagate(err, "Error registering SCD bulk service handler")
+		}
+		if err := historypb.RegisterDSSHistoryServiceHandlerFromEndpoint(ctx, grpcMux, endpoint, opts); err != nil {
+			if strings.Contains(err.Error(), "context deadline exceeded") {
+				return stacktrace.PropagateWithCode(err, codeRetryable, "Failed to connect to core-service for strategic conflict detection history")
+			}
+			return stacktrace.Propagate(err, "Error registering SCD history service handler")
+		}
 		logger.Info("config", zap.Any("scd", "enabled"))
 	} else {
 		logger.Info("config", zap.Any("scd", "disabled"))
 	}
 
+	logger.Info("Connecting to core-service for health and watch")
+	coreConn, err := grpc.DialContext(ctx, endpoint, opts...)
+	if err != nil {
+		if strings.Contains(err.Error(), "context deadline exceeded") {
+			return stacktrace.PropagateWithCode(err, codeRetryable, "Failed to connect to core-service for health and watch")
+		}
+		return stacktrace.Propagate(err, "Error connecting to core-service for health and watch")
+	}
+	defer coreConn.Close()
+	health := newHealthHandler(healthpb.NewHealthClient(coreConn), cfg.EnableSCD, cfg.EnableWatch, logger)
+
+	var watch *watchHandler
+	if cfg.EnableWatch {
+		watch = &watchHandler{
+			// Streaming RPCs are not supported by grpc-gateway, so the watch
+			// service is called directly through the core-service connection.
+			client: watchpb.NewDSSWatchServiceClient(coreConn),
+			writeError: func(w http.ResponseWriter, r *http.Request, err error) {
+				runtime.HTTPError(r.Context(), grpcMux, marshaler, w, r, err)
+			},
+			// Server-sent event data may not span several lines.
+			marshaler: &runtime.JSONPb{OrigName: true, EmitDefaults: true},
+			logger:    logger,
+		}
+		logger.Info("config", zap.Any("watch", "enabled"))
+	} else {
+		logger.Info("config", zap.Any("watch", "disabled"))
+	}
+
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path == "/healthy" {
-			if _, err := w.Write([]byte("ok")); err != nil {
-				logger.Error("Error writing to /healthy")
-			}
-		} else {
+		switch {
+		case r.URL.Path == "/healthy":
+			health.serveHealthy(w, r)
+		case r.URL.Path == "/ready":
+			health.serveReady(w, r)
+		case watch != nil && isWatchPath(r.URL.Path):
+			watch.ServeHTTP(w, r)
+		default:
 			grpcMux.ServeHTTP(w, r)
 		}
 	})
 
-	if *traceRequests {
+	if cfg.TraceRequests {
 		handler = logging.HTTPMiddleware(logger, handler)
 	}
 
-	signals := make(chan os.Signal)
+	signals := make(chan os.Signal, 1)
 	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
 	defer signal.Stop(signals)
 
@@ -210,6 +263,8 @@ func myCodeToHTTPStatus(code codes.Code) int {
 		return http.StatusRequestEntityTooLarge
 	case codes.Code(uint16(errors.MissingOVNs)):
 		return http.StatusConflict
+	case codes.Code(uint16(errors.InvalidStateTransition)), codes.Code(uint16(errors.StateOutsideTimeWindow)):
+		return http.StatusBadRequest
 	}
 
 	grpclog.Warningf("Unknown gRPC error code: %v", code)
@@ -304,6 +359,7 @@ func myHTTPError(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.M
 	}
 
 	handleForwardResponseServerMetadata(w, mux, md)
+	handleForwardRetryAfter(w, md)
 	handleForwardResponseTrailerHeader(w, md)
 	st := myCodeToHTTPStatus(s.Code())
 	w.WriteHeader(st)
@@ -316,6 +372,9 @@ func myHTTPError(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.M
 
 func handleForwardResponseServerMetadata(w http.ResponseWriter, mux *runtime.ServeMux, md runtime.ServerMetadata) {
 	for k, vs := range md.HeaderMD {
+		if k == ratelimit.RetryAfterHeader {
+			continue
+		}
 		if h, ok := runtime.DefaultHeaderMatcher(k); ok {
 			for _, v := range vs {
 				w.Header().Add(h, v)
@@ -324,8 +383,22 @@ func handleForwardResponseServerMetadata(w http.ResponseWriter, mux *runtime.Ser
 	}
 }
 
+// handleForwardRetryAfter forwards the rate limiting hint of the Core Service
+// as the standard HTTP header. Rejected calls have no response, so the hint is
+// usually in the trailer of the gRPC response.
+func handleForwardRetryAfter(w http.ResponseWriter, md runtime.ServerMetadata) {
+	for _, vs := range [][]string{md.HeaderMD.Get(ratelimit.RetryAfterHeader), md.TrailerMD.Get(ratelimit.RetryAfterHeader)} {
+		for _, v := range vs {
+			w.Header().Add("Retry-After", v)
+		}
+	}
+}
+
 func handleForwardResponseTrailerHeader(w http.ResponseWriter, md runtime.ServerMetadata) {
 	for k := range md.TrailerMD {
+		if k == ratelimit.RetryAfterHeader {
+			continue
+		}
 		tKey := textproto.CanonicalMIMEHeaderKey(fmt.Sprintf("%s%s", runtime.MetadataTrailerPrefix, k))
 		w.Header().Add("Trailer", tKey)
 	}
@@ -333,6 +406,9 @@ func handleForwardResponseTrailerHeader(w http.ResponseWriter, md runtime.Server
 
 func handleForwardResponseTrailer(w http.ResponseWriter, md runtime.ServerMetadata) {
 	for k, vs := range md.TrailerMD {
+		if k == ratelimit.RetryAfterHeader {
+			continue
+		}
 		tKey := fmt.Sprintf("%s%s", runtime.MetadataTrailerPrefix, k)
 		for _, v := range vs {
 			w.Header().Add(tKey, v)
@@ -342,16 +418,19 @@ func handleForwardResponseTrailer(w http.ResponseWriter, md runtime.ServerMetada
 
 func main() {
 	flag.Parse()
+	if err := config.Load(&cfg, flag.CommandLine, *configFile, envPrefix); err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
 	var (
 		ctx, cancel = context.WithCancel(context.Background())
 		logger      = logging.WithValuesFromContext(ctx, logging.Logger)
 	)
 	defer cancel()
 
-	if *profServiceName != "" {
+	if cfg.ProfServiceName != "" {
 		err := profiler.Start(
 			profiler.Config{
-				Service: *profServiceName})
+				Service: cfg.ProfServiceName})
 		if err != nil {
 			logger.Panic("Failed to start the profiler ", zap.Error(err))
 		}
@@ -362,7 +441,7 @@ func main() {
 		1 * time.Minute, 5 * time.Minute}
 	backoff := 0
 	for {
-		if err := RunHTTPProxy(ctx, cancel, *address, *coreService); err != nil {
+		if err := RunHTTPProxy(ctx, cancel, cfg.Addr, cfg.CoreService); err != nil {
 			if stacktrace.GetCode(err) == codeRetryable {
 				logger.Info(fmt.Sprintf("Prerequisites not yet satisfied; waiting %ds to retry...", backoffs[backoff]/1000000000), zap.Error(err))
 				time.Sleep(backoffs[backoff])
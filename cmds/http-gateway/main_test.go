@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/interuss/dss/pkg/api/v1/auxpb"
+	"github.com/interuss/dss/pkg/auth"
+	aux "github.com/interuss/dss/pkg/aux_"
+	uss_errors "github.com/interuss/dss/pkg/errors"
+	"github.com/interuss/dss/pkg/logging"
+	"github.com/interuss/dss/pkg/models"
+	"github.com/interuss/dss/pkg/ratelimit"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestRetryAfterReachesGateway(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The Core Service limits uss1 to a single call.
+	limiter := ratelimit.NewLimiter(map[ratelimit.Class]ratelimit.Rate{ratelimit.Read: {PerSecond: 0.5, Burst: 1}}, nil, clockwork.NewFakeClock())
+	withOwner := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(auth.ContextWithOwner(ctx, models.Owner("uss1")), req)
+	}
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(uss_errors.Interceptor(logging.Logger), withOwner, limiter.Interceptor))
+	auxpb.RegisterDSSAuxServiceServer(s, &aux.Server{})
+	listener := bufconn.Listen(1 << 20)
+	go func() {
+		_ = s.Serve(listener)
+	}()
+	defer s.Stop()
+
+	conn, err := grpc.DialContext(ctx, "bufconn",
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	runtime.HTTPError = myHTTPError
+	mux := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{OrigName: true}))
+	require.NoError(t, auxpb.RegisterDSSAuxServiceHandler(ctx, mux, conn))
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/aux/v1/version", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Empty(t, w.Header().Get("Retry-After"))
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/aux/v1/version", nil))
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Equal(t, "2", w.Header().Get("Retry-After"))
+	require.Empty(t, w.Header().Get(runtime.MetadataTrailerPrefix+ratelimit.RetryAfterHeader))
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/interuss/dss/pkg/api/v1/watchpb"
+	"github.com/interuss/stacktrace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	// watchPathPrefix and watchPathSuffix surround the subscription ID in the
+	// path of the server-sent events endpoint of the watch service.
+	watchPathPrefix = "/watch/v1/subscriptions/"
+	watchPathSuffix = "/events"
+
+	// watchKeepAliveInterval is the interval at which comments are sent on
+	// idle event streams, to keep intermediate proxies from closing them.
+	watchKeepAliveInterval = 15 * time.Second
+)
+
+// watchHandler exposes the watch service of the core service as server-sent
+// events.
+type watchHandler struct {
+	client watchpb.DSSWatchServiceClient
+	// writeError writes an error returned by the core service in the same
+	// format as the errors of the other endpoints.
+	writeError func(w http.ResponseWriter, r *http.Request, err error)
+	marshaler  runtime.Marshaler
+	logger     *zap.Logger
+}
+
+// isWatchPath returns whether path is the path of the server-sent events
+// endpoint of the watch service.
+func isWatchPath(path string) bool {
+	return strings.HasPrefix(path, watchPathPrefix) && strings.HasSuffix(path, watchPathSuffix)
+}
+
+// request returns the WatchSubscriptionRequest corresponding to r. The stream
+// resumes after the notification index in the Last-Event-ID header, set by
+// clients reconnecting to a stream, or else in the after_notification_index
+// query parameter.
+func (h *watchHandler) request(r *http.Request) (*watchpb.WatchSubscriptionRequest, error) {
+	req := &watchpb.WatchSubscriptionRequest{
+		SubscriptionId: strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, watchPathPrefix), watchPathSuffix),
+	}
+	after := r.Header.Get("Last-Event-ID")
+	if after == "" {
+		after = r.URL.Query().Get("after_notification_index")
+	}
+	if after != "" {
+		index, err := strconv.ParseInt(after, 10, 32)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid notification index `%s`", after)
+		}
+		req.AfterNotificationIndex = wrapperspb.Int32(int32(index))
+	}
+	return req, nil
+}
+
+func (h *watchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		h.writeError(w, r, status.Error(codes.Internal, "Streaming is not supported by the server"))
+		return
+	}
+	req, err := h.request(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", auth)
+	}
+
+	stream, err := h.client.WatchSubscription(ctx, req)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	// The core service sends the headers once it accepted the request, or
+	// else the error which it was rejected with.
+	if _, err := stream.Header(); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	type received struct {
+		event *watchpb.SubscriptionEvent
+		err   error
+	}
+	events := make(chan received)
+	go func() {
+		for {
+			e, err := stream.Recv()
+			select {
+			case events <- received{event: e, err: err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	keepAlive := time.NewTicker(watchKeepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepAlive.C:
+			if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case rcv := <-events:
+			if rcv.err == io.EOF {
+				return
+			}
+			if rcv.err != nil {
+				// The status has already been sent: report the error as an event.
+				h.logger.Warn("watch stream failed", zap.Error(rcv.err))
+				if err := h.writeEvent(w, "error", "", status.Convert(rcv.err).Proto()); err != nil {
+					h.logger.Warn("failed to write watch error", zap.Error(err))
+				}
+				flusher.Flush()
+				return
+			}
+			if err := h.writeEvent(w, "", strconv.Itoa(int(rcv.event.NotificationIndex)), rcv.event); err != nil {
+				h.logger.Warn("failed to write watch event", zap.Error(err))
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// writeEvent writes v as the data of a server-sent event of type event (the
+// default type if empty) and ID id (none if empty).
+func (h *watchHandler) writeEvent(w io.Writer, event string, id string, v interface{}) error {
+	data, err := h.marshaler.Marshal(v)
+	if err != nil {
+		return stacktrace.Propagate(err, "Error marshaling event")
+	}
+	var frame strings.Builder
+	if event != "" {
+		fmt.Fprintf(&frame, "event: %s\n", event)
+	}
+	if id != "" {
+		fmt.Fprintf(&frame, "id: %s\n", id)
+	}
+	fmt.Fprintf(&frame, "data: %s\n\n", data)
+	if _, err := io.WriteString(w, frame.String()); err != nil {
+		return stacktrace.Propagate(err, "Error writing event")
+	}
+	return nil
+}
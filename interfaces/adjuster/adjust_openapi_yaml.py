@@ -44,6 +44,81 @@ def fix_key_type(tree):
     }
 
 
+# Add the property `name` to schema with the specified definition.  openapi2proto
+# numbers fields in the alphabetical order of their properties unless they have
+# an `x-proto-tag`, so the existing properties are pinned to their current field
+# numbers and the new property gets the next free one, leaving the wire format
+# of the existing fields unchanged.
+def add_property(schema, name, definition):
+  properties = schema['properties']
+  for i, existing in enumerate(sorted(properties)):
+    properties[existing].setdefault('x-proto-tag', i + 1)
+  definition['x-proto-tag'] = max(p['x-proto-tag'] for p in properties.values()) + 1
+  properties[name] = definition
+
+
+# Specific to the SCD API, add the DSS extension `priority` field to operational
+# intent references and to the parameters creating or updating them
+def add_priority(tree):
+  schemas = tree['components']['schemas']
+  for name in ('OperationalIntentReference', 'PutOperationalIntentReferenceParameters'):
+    add_property(schemas[name], 'priority', {
+      'type': 'integer',
+      'format': 'int32',
+      'minimum': 0,
+      'default': 0,
+      'description': 'Priority of this operational intent.  Higher values denote higher priorities, 0 being the lowest and default priority.',
+    })
+
+
+# Specific to the SCD API, add the DSS extension `off_nominal_volumes` field to
+# the parameters creating or updating operational intent references
+def add_off_nominal_volumes(tree):
+  schemas = tree['components']['schemas']
+  add_property(schemas['PutOperationalIntentReferenceParameters'], 'off_nominal_volumes', {
+    'type': 'array',
+    'items': {
+      '$ref': '#/components/schemas/Volume4D'
+    },
+    'description': 'Volumes that contain the anticipated area of non-conformance while the aircraft is in the Nonconforming or Contingent states.  May only be specified when the operational intent is Nonconforming or Contingent.',
+  })
+
+
+# Specific to the SCD API, add the DSS extension constraint categories and
+# their filters to constraint references and subscriptions
+def add_constraint_categories(tree):
+  schemas = tree['components']['schemas']
+  category_description = 'Category of this constraint: Restricted, Advisory or TemporaryFlightRestriction.  Constraints without a category are Restricted.  The OVNs of Advisory constraints are not required in the keys of operational intents.'
+  for name in ('ConstraintReference', 'PutConstraintReferenceParameters'):
+    add_property(schemas[name], 'category', {
+      'type': 'string',
+      'description': category_description,
+    })
+    add_property(schemas[name], 'authority', {
+      'type': 'string',
+      'description': 'Authority on behalf of which this constraint is issued, if any.',
+    })
+    add_property(schemas[name], 'reason', {
+      'type': 'string',
+      'description': 'Reason for which this constraint is issued, if any.',
+    })
+  for name in ('Subscription', 'PutSubscriptionParameters'):
+    add_property(schemas[name], 'constraint_categories', {
+      'type': 'array',
+      'items': {
+        'type': 'string'
+      },
+      'description': 'Categories of the constraints whose changes trigger notifications, if `notify_for_constraints` is true.  Changes in constraints of any category trigger notifications if empty.',
+    })
+  add_property(schemas['QueryConstraintReferenceParameters'], 'categories', {
+    'type': 'array',
+    'items': {
+      'type': 'string'
+    },
+    'description': 'Categories of the constraints to return.  Constraints of any category are returned if empty.',
+  })
+
+
 # Prepend the specified prefix to all paths in the tree
 def prefix_path(tree, prefix: str):
     tree['paths'] = {prefix + path: tree['paths'][path]
@@ -70,6 +145,9 @@ fix_string_enums(spec)
 # Make custom adjustments depending on type of file
 if args.adjustment_profile == 'scd':
     fix_key_type(spec)
+    add_priority(spec)
+    add_off_nominal_volumes(spec)
+    add_constraint_categories(spec)
 elif args.adjustment_profile == 'rid':
     pass
 else:
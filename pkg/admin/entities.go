@@ -0,0 +1,275 @@
+package admin
+
+import (
+	"context"
+	"time"
+
+	"github.com/interuss/dss/pkg/api/v1/adminpb"
+	dsserr "github.com/interuss/dss/pkg/errors"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	ridmodels "github.com/interuss/dss/pkg/rid/models"
+	"github.com/interuss/dss/pkg/scd"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/stacktrace"
+	tspb "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func entitySummary(entityType dssmodels.AuditEntityType, id string, owner string, version string, url string, start, end *time.Time) *adminpb.EntitySummary {
+	result := &adminpb.EntitySummary{
+		EntityType: string(entityType),
+		Id:         id,
+		Owner:      owner,
+		Version:    version,
+		Url:        url,
+	}
+	if start != nil {
+		result.TimeStart = tspb.New(*start)
+	}
+	if end != nil {
+		result.TimeEnd = tspb.New(*end)
+	}
+	return result
+}
+
+func isaSummary(isa *ridmodels.IdentificationServiceArea) *adminpb.EntitySummary {
+	return entitySummary(dssmodels.AuditEntityISA, isa.ID.String(), isa.Owner.String(),
+		isa.Version.String(), isa.URL, isa.StartTime, isa.EndTime)
+}
+
+func operationalIntentSummary(o *scdmodels.OperationalIntent) *adminpb.EntitySummary {
+	return entitySummary(dssmodels.AuditEntityOperationalIntent, o.ID.String(), o.Manager.String(),
+		o.OVN.String(), o.USSBaseURL, o.StartTime, o.EndTime)
+}
+
+// subscribersToNotify groups the notification indices of subscriptions by
+// the URL of their subscriber.
+type subscribersToNotify struct {
+	urls        []string
+	subscribers map[string]*adminpb.SubscriberToNotify
+}
+
+func (s *subscribersToNotify) add(url string, id dssmodels.ID, notificationIndex int) {
+	if s.subscribers == nil {
+		s.subscribers = map[string]*adminpb.SubscriberToNotify{}
+	}
+	subscriber, ok := s.subscribers[url]
+	if !ok {
+		subscriber = &adminpb.SubscriberToNotify{Url: url}
+		s.subscribers[url] = subscriber
+		s.urls = append(s.urls, url)
+	}
+	subscriber.Subscriptions = append(subscriber.Subscriptions, &adminpb.SubscriptionState{
+		SubscriptionId:    id.String(),
+		NotificationIndex: int32(notificationIndex),
+	})
+}
+
+func (s *subscribersToNotify) toProto() []*adminpb.SubscriberToNotify {
+	result := []*adminpb.SubscriberToNotify{}
+	for _, url := range s.urls {
+		result = append(result, s.subscribers[url])
+	}
+	return result
+}
+
+// ListEntitiesByManager returns the remote ID and strategic conflict
+// detection entities owned by the requested manager.
+func (a *Server) ListEntitiesByManager(ctx context.Context, req *adminpb.ListEntitiesByManagerRequest) (*adminpb.ListEntitiesByManagerResponse, error) {
+	if req.GetManager() == "" {
+		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Missing manager")
+	}
+	ctx, cancel := context.WithTimeout(ctx, a.Timeout)
+	defer cancel()
+
+	var (
+		owner    = dssmodels.Owner(req.GetManager())
+		manager  = dssmodels.Manager(req.GetManager())
+		response = &adminpb.ListEntitiesByManagerResponse{}
+	)
+
+	ridRepo, err := a.RIDStore.Interact(ctx)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Unable to interact with remote ID store")
+	}
+	isas, err := ridRepo.ListISAsByOwner(ctx, owner)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Unable to list ISAs of %s", owner)
+	}
+	for _, isa := range isas {
+		response.Entities = append(response.Entities, isaSummary(isa))
+	}
+	ridSubs, err := ridRepo.ListSubscriptionsByOwner(ctx, owner)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Unable to list remote ID Subscriptions of %s", owner)
+	}
+	for _, sub := range ridSubs {
+		response.Entities = append(response.Entities, entitySummary(dssmodels.AuditEntityRIDSubscription,
+			sub.ID.String(), sub.Owner.String(), sub.Version.String(), sub.URL, sub.StartTime, sub.EndTime))
+	}
+
+	if a.SCDStore == nil {
+		return response, nil
+	}
+	scdRepo, err := a.SCDStore.Interact(ctx)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Unable to interact with strategic conflict detection store")
+	}
+	ops, err := scdRepo.ListOperationalIntentsByManager(ctx, manager)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Unable to list OperationalIntents of %s", manager)
+	}
+	for _, op := range ops {
+		response.Entities = append(response.Entities, operationalIntentSummary(op))
+	}
+	constraints, err := scdRepo.ListConstraintsByManager(ctx, manager)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Unable to list Constraints of %s", manager)
+	}
+	for _, c := range constraints {
+		response.Entities = append(response.Entities, entitySummary(dssmodels.AuditEntityConstraint,
+			c.ID.String(), c.Manager.String(), c.OVN.String(), c.USSBaseURL, c.StartTime, c.EndTime))
+	}
+	scdSubs, err := scdRepo.ListSubscriptionsByManager(ctx, manager)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Unable to list strategic conflict detection Subscriptions of %s", manager)
+	}
+	for _, sub := range scdSubs {
+		response.Entities = append(response.Entities, entitySummary(dssmodels.AuditEntitySCDSubscription,
+			sub.ID.String(), sub.Manager.String(), sub.Version.String(), sub.USSBaseURL, sub.StartTime, sub.EndTime))
+	}
+
+	return response, nil
+}
+
+// ForceDeleteIdentificationServiceArea deletes an ISA regardless of its owner
+// and returns the subscribers to notify of the deletion.
+func (a *Server) ForceDeleteIdentificationServiceArea(ctx context.Context, req *adminpb.ForceDeleteIdentificationServiceAreaRequest) (*adminpb.ForceDeleteResponse, error) {
+	id, err := dssmodels.IDFromString(req.GetId())
+	if err != nil {
+		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Invalid ID format: `%s`", req.GetId())
+	}
+	ctx, cancel := context.WithTimeout(ctx, a.Timeout)
+	defer cancel()
+
+	isa, subs, err := a.RIDApp.ForceDeleteISA(ctx, id)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Could not delete ISA")
+	}
+
+	var subscribers subscribersToNotify
+	for _, sub := range subs {
+		subscribers.add(sub.URL, sub.ID, sub.NotificationIndex)
+	}
+	return &adminpb.ForceDeleteResponse{
+		Entity:      isaSummary(isa),
+		Subscribers: subscribers.toProto(),
+	}, nil
+}
+
+// ForceDeleteOperationalIntent deletes an OperationalIntent regardless of its
+// manager and returns the subscribers to notify of the deletion.
+func (a *Server) ForceDeleteOperationalIntent(ctx context.Context, req *adminpb.ForceDeleteOperationalIntentRequest) (*adminpb.ForceDeleteResponse, error) {
+	if a.SCDStore == nil {
+		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Strategic conflict detection is not enabled")
+	}
+	id, err := dssmodels.IDFromString(req.GetId())
+	if err != nil {
+		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Invalid ID format: `%s`", req.GetId())
+	}
+	ctx, cancel := context.WithTimeout(ctx, a.Timeout)
+	defer cancel()
+
+	op, subs, err := scd.ForceDeleteOperationalIntent(ctx, a.SCDStore, id)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Could not delete OperationalIntent")
+	}
+
+	var subscribers subscribersToNotify
+	for _, sub := range subs {
+		subscribers.add(sub.USSBaseURL, sub.ID, sub.NotificationIndex)
+	}
+	return &adminpb.ForceDeleteResponse{
+		Entity:      operationalIntentSummary(op),
+		Subscribers: subscribers.toProto(),
+	}, nil
+}
+
+// GetSubscriptionDetails returns the state of a remote ID or strategic
+// conflict detection subscription.
+func (a *Server) GetSubscriptionDetails(ctx context.Context, req *adminpb.GetSubscriptionDetailsRequest) (*adminpb.GetSubscriptionDetailsResponse, error) {
+	id, err := dssmodels.IDFromString(req.GetId())
+	if err != nil {
+		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Invalid ID format: `%s`", req.GetId())
+	}
+	ctx, cancel := context.WithTimeout(ctx, a.Timeout)
+	defer cancel()
+
+	ridRepo, err := a.RIDStore.Interact(ctx)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Unable to interact with remote ID store")
+	}
+	ridSub, err := ridRepo.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Unable to get remote ID Subscription")
+	}
+	if ridSub != nil {
+		details := &adminpb.SubscriptionDetails{
+			EntityType:        string(dssmodels.AuditEntityRIDSubscription),
+			Id:                ridSub.ID.String(),
+			Owner:             ridSub.Owner.String(),
+			Version:           ridSub.Version.String(),
+			Url:               ridSub.URL,
+			NotificationIndex: int32(ridSub.NotificationIndex),
+			CellCount:         int32(len(ridSub.Cells)),
+		}
+		if ridSub.StartTime != nil {
+			details.TimeStart = tspb.New(*ridSub.StartTime)
+		}
+		if ridSub.EndTime != nil {
+			details.TimeEnd = tspb.New(*ridSub.EndTime)
+		}
+		return &adminpb.GetSubscriptionDetailsResponse{Subscription: details}, nil
+	}
+
+	if a.SCDStore == nil {
+		return nil, stacktrace.NewErrorWithCode(dsserr.NotFound, "Subscription %s not found", id)
+	}
+	scdRepo, err := a.SCDStore.Interact(ctx)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Unable to interact with strategic conflict detection store")
+	}
+	scdSub, err := scdRepo.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Unable to get strategic conflict detection Subscription")
+	}
+	if scdSub == nil {
+		return nil, stacktrace.NewErrorWithCode(dsserr.NotFound, "Subscription %s not found", id)
+	}
+	dependentOps, err := scdRepo.GetDependentOperationalIntents(ctx, id)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Unable to get dependent OperationalIntents")
+	}
+
+	details := &adminpb.SubscriptionDetails{
+		EntityType:                  string(dssmodels.AuditEntitySCDSubscription),
+		Id:                          scdSub.ID.String(),
+		Owner:                       scdSub.Manager.String(),
+		Version:                     scdSub.Version.String(),
+		Url:                         scdSub.USSBaseURL,
+		NotificationIndex:           int32(scdSub.NotificationIndex),
+		CellCount:                   int32(len(scdSub.Cells)),
+		Implicit:                    scdSub.ImplicitSubscription,
+		NotifyForOperationalIntents: scdSub.NotifyForOperationalIntents,
+		NotifyForConstraints:        scdSub.NotifyForConstraints,
+	}
+	if scdSub.StartTime != nil {
+		details.TimeStart = tspb.New(*scdSub.StartTime)
+	}
+	if scdSub.EndTime != nil {
+		details.TimeEnd = tspb.New(*scdSub.EndTime)
+	}
+	for _, opID := range dependentOps {
+		details.DependentOperationalIntents = append(details.DependentOperationalIntents, opID.String())
+	}
+	return &adminpb.GetSubscriptionDetailsResponse{Subscription: details}, nil
+}
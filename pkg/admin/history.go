@@ -0,0 +1,144 @@
+package admin
+
+import (
+	"context"
+	"time"
+
+	"github.com/interuss/dss/pkg/api/v1/adminpb"
+	dsserr "github.com/interuss/dss/pkg/errors"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/stacktrace"
+	tspb "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func timestampFromProto(ts *tspb.Timestamp, name string) (*time.Time, error) {
+	if ts == nil {
+		return nil, nil
+	}
+	if err := ts.CheckValid(); err != nil {
+		return nil, stacktrace.PropagateWithCode(err, dsserr.BadRequest, "Invalid %s", name)
+	}
+	t := ts.AsTime()
+	return &t, nil
+}
+
+func volume4DFromSearchRequest(req *adminpb.SearchAirspaceAsOfRequest) (*dssmodels.Volume4D, error) {
+	if len(req.GetFootprint()) < 3 {
+		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Footprint must have at least 3 vertices")
+	}
+	footprint := &dssmodels.GeoPolygon{}
+	for _, v := range req.GetFootprint() {
+		footprint.Vertices = append(footprint.Vertices, &dssmodels.LatLngPoint{Lat: v.GetLat(), Lng: v.GetLng()})
+	}
+
+	result := &dssmodels.Volume4D{
+		SpatialVolume: &dssmodels.Volume3D{Footprint: footprint},
+	}
+	if altitude := req.GetAltitude(); altitude != nil {
+		lower, upper := altitude.GetLower(), altitude.GetUpper()
+		if lower > upper {
+			return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Lower altitude is above upper altitude")
+		}
+		result.SpatialVolume.AltitudeLo = &lower
+		result.SpatialVolume.AltitudeHi = &upper
+	}
+
+	var err error
+	if result.StartTime, err = timestampFromProto(req.GetTimeStart(), "time_start"); err != nil {
+		return nil, err
+	}
+	if result.EndTime, err = timestampFromProto(req.GetTimeEnd(), "time_end"); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func airspaceEntityExtents(e *adminpb.AirspaceEntity, start, end *time.Time, lower, upper *float32) {
+	if start != nil {
+		e.TimeStart = tspb.New(*start)
+	}
+	if end != nil {
+		e.TimeEnd = tspb.New(*end)
+	}
+	if lower != nil {
+		e.AltitudeLower = *lower
+	}
+	if upper != nil {
+		e.AltitudeUpper = *upper
+	}
+}
+
+func operationalIntentToAirspaceEntity(o *scdmodels.OperationalIntent) *adminpb.AirspaceEntity {
+	result := &adminpb.AirspaceEntity{
+		EntityType:     string(dssmodels.AuditEntityOperationalIntent),
+		Id:             o.ID.String(),
+		Manager:        o.Manager.String(),
+		Version:        int32(o.Version),
+		Ovn:            o.OVN.String(),
+		State:          o.State.String(),
+		UssBaseUrl:     o.USSBaseURL,
+		SubscriptionId: o.SubscriptionID.String(),
+	}
+	airspaceEntityExtents(result, o.StartTime, o.EndTime, o.AltitudeLower, o.AltitudeUpper)
+	return result
+}
+
+func constraintToAirspaceEntity(c *scdmodels.Constraint) *adminpb.AirspaceEntity {
+	result := &adminpb.AirspaceEntity{
+		EntityType: string(dssmodels.AuditEntityConstraint),
+		Id:         c.ID.String(),
+		Manager:    c.Manager.String(),
+		Version:    int32(c.Version),
+		Ovn:        c.OVN.String(),
+		UssBaseUrl: c.USSBaseURL,
+	}
+	airspaceEntityExtents(result, c.StartTime, c.EndTime, c.AltitudeLower, c.AltitudeUpper)
+	return result
+}
+
+// SearchAirspaceAsOf returns the operational intents and constraints
+// intersecting the requested volume in the versions that were current at the
+// requested time.
+func (a *Server) SearchAirspaceAsOf(ctx context.Context, req *adminpb.SearchAirspaceAsOfRequest) (*adminpb.SearchAirspaceAsOfResponse, error) {
+	if a.SCDStore == nil {
+		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Strategic conflict detection is not enabled")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, a.Timeout)
+	defer cancel()
+
+	asOf, err := timestampFromProto(req.GetAsOf(), "as_of")
+	if err != nil {
+		return nil, err
+	}
+	if asOf == nil {
+		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Missing as_of")
+	}
+	vol4, err := volume4DFromSearchRequest(req)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Invalid area of interest")
+	}
+
+	repo, err := a.SCDStore.Interact(ctx)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Unable to interact with strategic conflict detection store")
+	}
+	ops, err := repo.SearchOperationalIntentsAsOf(ctx, vol4, *asOf)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Unable to search operational intents as of %s", asOf)
+	}
+	constraints, err := repo.SearchConstraintsAsOf(ctx, vol4, *asOf)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Unable to search constraints as of %s", asOf)
+	}
+
+	response := &adminpb.SearchAirspaceAsOfResponse{}
+	for _, o := range ops {
+		response.OperationalIntents = append(response.OperationalIntents, operationalIntentToAirspaceEntity(o))
+	}
+	for _, c := range constraints {
+		response.Constraints = append(response.Constraints, constraintToAirspaceEntity(c))
+	}
+	return response, nil
+}
@@ -0,0 +1,171 @@
+package admin
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/interuss/dss/pkg/api/v1/adminpb"
+	"github.com/interuss/dss/pkg/auth"
+	dsserr "github.com/interuss/dss/pkg/errors"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	ridapp "github.com/interuss/dss/pkg/rid/application"
+	ridstore "github.com/interuss/dss/pkg/rid/store"
+	scdstore "github.com/interuss/dss/pkg/scd/store"
+	"github.com/interuss/stacktrace"
+	tspb "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// Scope grants access to the administrative operations of the DSS.
+	Scope auth.Scope = "dss.admin"
+)
+
+// Server implements adminpb.DSSAdminService.
+type Server struct {
+	RIDStore ridstore.Store
+	RIDApp   ridapp.App
+	// SCDStore is nil if strategic conflict detection is disabled.
+	SCDStore scdstore.Store
+	Timeout  time.Duration
+}
+
+// AuthScopes returns a map of endpoint to required Oauth scope.
+func (a *Server) AuthScopes() map[auth.Operation]auth.KeyClaimedScopesValidator {
+	return map[auth.Operation]auth.KeyClaimedScopesValidator{
+		"/adminpb.DSSAdminService/ForceDeleteIdentificationServiceArea": auth.RequireAllScopes(Scope),
+		"/adminpb.DSSAdminService/ForceDeleteOperationalIntent":         auth.RequireAllScopes(Scope),
+		"/adminpb.DSSAdminService/GetSchemaVersions":                    auth.RequireAllScopes(Scope),
+		"/adminpb.DSSAdminService/GetSubscriptionDetails":               auth.RequireAllScopes(Scope),
+		"/adminpb.DSSAdminService/ListEntitiesByManager":                auth.RequireAllScopes(Scope),
+		"/adminpb.DSSAdminService/QueryAuditLog":                        auth.RequireAllScopes(Scope),
+		"/adminpb.DSSAdminService/SearchAirspaceAsOf":                   auth.RequireAllScopes(Scope),
+	}
+}
+
+func auditRecordToProto(r *dssmodels.AuditRecord) *adminpb.AuditRecord {
+	result := &adminpb.AuditRecord{
+		EntityType: string(r.EntityType),
+		EntityId:   r.EntityID,
+		Operation:  string(r.Operation),
+		Owner:      r.Owner.String(),
+		OldVersion: r.OldVersion,
+		NewVersion: r.NewVersion,
+		RecordedAt: tspb.New(r.RecordedAt),
+	}
+	if r.StartTime != nil {
+		result.TimeStart = tspb.New(*r.StartTime)
+	}
+	if r.EndTime != nil {
+		result.TimeEnd = tspb.New(*r.EndTime)
+	}
+	if r.AltitudeLo != nil {
+		result.AltitudeLower = *r.AltitudeLo
+	}
+	if r.AltitudeHi != nil {
+		result.AltitudeUpper = *r.AltitudeHi
+	}
+	return result
+}
+
+// QueryAuditLog returns the audit records matching the request, most recent
+// first.
+func (a *Server) QueryAuditLog(ctx context.Context, req *adminpb.QueryAuditLogRequest) (*adminpb.QueryAuditLogResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.Timeout)
+	defer cancel()
+
+	filter := &dssmodels.AuditFilter{
+		EntityType: dssmodels.AuditEntityType(req.GetEntityType()),
+		EntityID:   req.GetEntityId(),
+		Owner:      dssmodels.Owner(req.GetOwner()),
+	}
+	if filter.EntityType != "" && !filter.EntityType.Valid() {
+		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Invalid entity_type: `%s`", filter.EntityType)
+	}
+	if ts := req.GetEarliestTime(); ts != nil {
+		if err := ts.CheckValid(); err != nil {
+			return nil, stacktrace.PropagateWithCode(err, dsserr.BadRequest, "Invalid earliest_time")
+		}
+		t := ts.AsTime()
+		filter.Earliest = &t
+	}
+	if ts := req.GetLatestTime(); ts != nil {
+		if err := ts.CheckValid(); err != nil {
+			return nil, stacktrace.PropagateWithCode(err, dsserr.BadRequest, "Invalid latest_time")
+		}
+		t := ts.AsTime()
+		filter.Latest = &t
+	}
+
+	var (
+		queryRID = filter.EntityType == "" || filter.EntityType.IsRID()
+		querySCD = filter.EntityType == "" || !filter.EntityType.IsRID()
+		records  []*dssmodels.AuditRecord
+	)
+
+	if queryRID {
+		repo, err := a.RIDStore.Interact(ctx)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Unable to interact with remote ID store")
+		}
+		ridRecords, err := repo.SearchAuditRecords(ctx, filter)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Unable to search remote ID audit log")
+		}
+		records = append(records, ridRecords...)
+	}
+
+	if querySCD {
+		if a.SCDStore == nil {
+			if filter.EntityType != "" {
+				return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Strategic conflict detection is not enabled")
+			}
+		} else {
+			repo, err := a.SCDStore.Interact(ctx)
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "Unable to interact with strategic conflict detection store")
+			}
+			scdRecords, err := repo.SearchAuditRecords(ctx, filter)
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "Unable to search strategic conflict detection audit log")
+			}
+			records = append(records, scdRecords...)
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].RecordedAt.After(records[j].RecordedAt)
+	})
+	if len(records) > dssmodels.MaxResultLimit {
+		records = records[:dssmodels.MaxResultLimit]
+	}
+
+	response := &adminpb.QueryAuditLogResponse{}
+	for _, r := range records {
+		response.Records = append(response.Records, auditRecordToProto(r))
+	}
+	return response, nil
+}
+
+// GetSchemaVersions returns the schema versions of the remote ID and, if
+// enabled, strategic conflict detection databases.
+func (a *Server) GetSchemaVersions(ctx context.Context, req *adminpb.GetSchemaVersionsRequest) (*adminpb.GetSchemaVersionsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.Timeout)
+	defer cancel()
+
+	response := &adminpb.GetSchemaVersionsResponse{}
+	vs, err := a.RIDStore.GetVersion(ctx)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Unable to get remote ID schema version")
+	}
+	response.RidSchemaVersion = vs.String()
+
+	if a.SCDStore != nil {
+		vs, err := a.SCDStore.GetVersion(ctx)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Unable to get strategic conflict detection schema version")
+		}
+		response.ScdSchemaVersion = vs.String()
+	}
+	return response, nil
+}
@@ -0,0 +1,2486 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.22.0
+// 	protoc        v3.11.2
+// source: pkg/api/v1/adminpb/admin_service.proto
+
+package adminpb
+
+import (
+	context "context"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+// Record of a single mutation of a DSS entity.
+type AuditRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Type of the mutated entity, e.g. `operational_intent` or
+	// `identification_service_area`.
+	EntityType string `protobuf:"bytes,1,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	// ID of the mutated entity.
+	EntityId string `protobuf:"bytes,2,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	// Mutation performed: `Create`, `Update` or `Delete`.
+	Operation string `protobuf:"bytes,3,opt,name=operation,proto3" json:"operation,omitempty"`
+	// Owner (or manager) of the entity.
+	Owner string `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	// Version (or OVN) of the entity before the mutation, if any.
+	OldVersion string `protobuf:"bytes,5,opt,name=old_version,json=oldVersion,proto3" json:"old_version,omitempty"`
+	// Version (or OVN) of the entity after the mutation, if any.
+	NewVersion string `protobuf:"bytes,6,opt,name=new_version,json=newVersion,proto3" json:"new_version,omitempty"`
+	// Start time of the extents of the entity, if any.
+	TimeStart *timestamp.Timestamp `protobuf:"bytes,7,opt,name=time_start,json=timeStart,proto3" json:"time_start,omitempty"`
+	// End time of the extents of the entity, if any.
+	TimeEnd *timestamp.Timestamp `protobuf:"bytes,8,opt,name=time_end,json=timeEnd,proto3" json:"time_end,omitempty"`
+	// Lower altitude bound of the extents of the entity, if any.
+	AltitudeLower float32 `protobuf:"fixed32,9,opt,name=altitude_lower,json=altitudeLower,proto3" json:"altitude_lower,omitempty"`
+	// Upper altitude bound of the extents of the entity, if any.
+	AltitudeUpper float32 `protobuf:"fixed32,10,opt,name=altitude_upper,json=altitudeUpper,proto3" json:"altitude_upper,omitempty"`
+	// Time at which the mutation was committed.
+	RecordedAt *timestamp.Timestamp `protobuf:"bytes,11,opt,name=recorded_at,json=recordedAt,proto3" json:"recorded_at,omitempty"`
+}
+
+func (x *AuditRecord) Reset() {
+	*x = AuditRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuditRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditRecord) ProtoMessage() {}
+
+func (x *AuditRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditRecord.ProtoReflect.Descriptor instead.
+func (*AuditRecord) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_adminpb_admin_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AuditRecord) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetOldVersion() string {
+	if x != nil {
+		return x.OldVersion
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetNewVersion() string {
+	if x != nil {
+		return x.NewVersion
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetTimeStart() *timestamp.Timestamp {
+	if x != nil {
+		return x.TimeStart
+	}
+	return nil
+}
+
+func (x *AuditRecord) GetTimeEnd() *timestamp.Timestamp {
+	if x != nil {
+		return x.TimeEnd
+	}
+	return nil
+}
+
+func (x *AuditRecord) GetAltitudeLower() float32 {
+	if x != nil {
+		return x.AltitudeLower
+	}
+	return 0
+}
+
+func (x *AuditRecord) GetAltitudeUpper() float32 {
+	if x != nil {
+		return x.AltitudeUpper
+	}
+	return 0
+}
+
+func (x *AuditRecord) GetRecordedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.RecordedAt
+	}
+	return nil
+}
+
+type QueryAuditLogRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// If specified, only return records about this type of entity.
+	EntityType string `protobuf:"bytes,1,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	// If specified, only return records about the entity with this ID.
+	EntityId string `protobuf:"bytes,2,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	// If specified, only return records about entities of this owner.
+	Owner string `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
+	// If specified, only return records committed at or after this time.
+	EarliestTime *timestamp.Timestamp `protobuf:"bytes,4,opt,name=earliest_time,json=earliestTime,proto3" json:"earliest_time,omitempty"`
+	// If specified, only return records committed at or before this time.
+	LatestTime *timestamp.Timestamp `protobuf:"bytes,5,opt,name=latest_time,json=latestTime,proto3" json:"latest_time,omitempty"`
+}
+
+func (x *QueryAuditLogRequest) Reset() {
+	*x = QueryAuditLogRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryAuditLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAuditLogRequest) ProtoMessage() {}
+
+func (x *QueryAuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryAuditLogRequest.ProtoReflect.Descriptor instead.
+func (*QueryAuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_adminpb_admin_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *QueryAuditLogRequest) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetEarliestTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.EarliestTime
+	}
+	return nil
+}
+
+func (x *QueryAuditLogRequest) GetLatestTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.LatestTime
+	}
+	return nil
+}
+
+type QueryAuditLogResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Matching records, most recent first.
+	Records []*AuditRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+}
+
+func (x *QueryAuditLogResponse) Reset() {
+	*x = QueryAuditLogResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryAuditLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAuditLogResponse) ProtoMessage() {}
+
+func (x *QueryAuditLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryAuditLogResponse.ProtoReflect.Descriptor instead.
+func (*QueryAuditLogResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_adminpb_admin_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *QueryAuditLogResponse) GetRecords() []*AuditRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+// Point on the earth's surface.
+type LatLngPoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lat float64 `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lng float64 `protobuf:"fixed64,2,opt,name=lng,proto3" json:"lng,omitempty"`
+}
+
+func (x *LatLngPoint) Reset() {
+	*x = LatLngPoint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LatLngPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LatLngPoint) ProtoMessage() {}
+
+func (x *LatLngPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LatLngPoint.ProtoReflect.Descriptor instead.
+func (*LatLngPoint) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_adminpb_admin_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LatLngPoint) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *LatLngPoint) GetLng() float64 {
+	if x != nil {
+		return x.Lng
+	}
+	return 0
+}
+
+// Altitude range, in meters WGS84.
+type AltitudeRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lower float32 `protobuf:"fixed32,1,opt,name=lower,proto3" json:"lower,omitempty"`
+	Upper float32 `protobuf:"fixed32,2,opt,name=upper,proto3" json:"upper,omitempty"`
+}
+
+func (x *AltitudeRange) Reset() {
+	*x = AltitudeRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AltitudeRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AltitudeRange) ProtoMessage() {}
+
+func (x *AltitudeRange) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AltitudeRange.ProtoReflect.Descriptor instead.
+func (*AltitudeRange) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_adminpb_admin_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AltitudeRange) GetLower() float32 {
+	if x != nil {
+		return x.Lower
+	}
+	return 0
+}
+
+func (x *AltitudeRange) GetUpper() float32 {
+	if x != nil {
+		return x.Upper
+	}
+	return 0
+}
+
+// Strategic conflict detection entity in the version current at a past time.
+type AirspaceEntity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Type of the entity: `operational_intent` or `constraint`.
+	EntityType string `protobuf:"bytes,1,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	Id         string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Manager    string `protobuf:"bytes,3,opt,name=manager,proto3" json:"manager,omitempty"`
+	Version    int32  `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	Ovn        string `protobuf:"bytes,5,opt,name=ovn,proto3" json:"ovn,omitempty"`
+	// State of the operational intent; empty for constraints.
+	State         string               `protobuf:"bytes,6,opt,name=state,proto3" json:"state,omitempty"`
+	UssBaseUrl    string               `protobuf:"bytes,7,opt,name=uss_base_url,json=ussBaseUrl,proto3" json:"uss_base_url,omitempty"`
+	TimeStart     *timestamp.Timestamp `protobuf:"bytes,8,opt,name=time_start,json=timeStart,proto3" json:"time_start,omitempty"`
+	TimeEnd       *timestamp.Timestamp `protobuf:"bytes,9,opt,name=time_end,json=timeEnd,proto3" json:"time_end,omitempty"`
+	AltitudeLower float32              `protobuf:"fixed32,10,opt,name=altitude_lower,json=altitudeLower,proto3" json:"altitude_lower,omitempty"`
+	AltitudeUpper float32              `protobuf:"fixed32,11,opt,name=altitude_upper,json=altitudeUpper,proto3" json:"altitude_upper,omitempty"`
+	// Subscription of the operational intent; empty for constraints.
+	SubscriptionId string `protobuf:"bytes,12,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+}
+
+func (x *AirspaceEntity) Reset() {
+	*x = AirspaceEntity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AirspaceEntity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AirspaceEntity) ProtoMessage() {}
+
+func (x *AirspaceEntity) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AirspaceEntity.ProtoReflect.Descriptor instead.
+func (*AirspaceEntity) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_adminpb_admin_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AirspaceEntity) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *AirspaceEntity) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AirspaceEntity) GetManager() string {
+	if x != nil {
+		return x.Manager
+	}
+	return ""
+}
+
+func (x *AirspaceEntity) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *AirspaceEntity) GetOvn() string {
+	if x != nil {
+		return x.Ovn
+	}
+	return ""
+}
+
+func (x *AirspaceEntity) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *AirspaceEntity) GetUssBaseUrl() string {
+	if x != nil {
+		return x.UssBaseUrl
+	}
+	return ""
+}
+
+func (x *AirspaceEntity) GetTimeStart() *timestamp.Timestamp {
+	if x != nil {
+		return x.TimeStart
+	}
+	return nil
+}
+
+func (x *AirspaceEntity) GetTimeEnd() *timestamp.Timestamp {
+	if x != nil {
+		return x.TimeEnd
+	}
+	return nil
+}
+
+func (x *AirspaceEntity) GetAltitudeLower() float32 {
+	if x != nil {
+		return x.AltitudeLower
+	}
+	return 0
+}
+
+func (x *AirspaceEntity) GetAltitudeUpper() float32 {
+	if x != nil {
+		return x.AltitudeUpper
+	}
+	return 0
+}
+
+func (x *AirspaceEntity) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+type SearchAirspaceAsOfRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Time at which to reconstruct the airspace.
+	AsOf *timestamp.Timestamp `protobuf:"bytes,1,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	// Vertices of the polygon delimiting the area of interest.
+	Footprint []*LatLngPoint `protobuf:"bytes,2,rep,name=footprint,proto3" json:"footprint,omitempty"`
+	// If specified, only return entities intersecting this altitude range.
+	Altitude *AltitudeRange `protobuf:"bytes,3,opt,name=altitude,proto3" json:"altitude,omitempty"`
+	// If specified, only return entities ending at or after this time.
+	TimeStart *timestamp.Timestamp `protobuf:"bytes,4,opt,name=time_start,json=timeStart,proto3" json:"time_start,omitempty"`
+	// If specified, only return entities starting at or before this time.
+	TimeEnd *timestamp.Timestamp `protobuf:"bytes,5,opt,name=time_end,json=timeEnd,proto3" json:"time_end,omitempty"`
+}
+
+func (x *SearchAirspaceAsOfRequest) Reset() {
+	*x = SearchAirspaceAsOfRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchAirspaceAsOfRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchAirspaceAsOfRequest) ProtoMessage() {}
+
+func (x *SearchAirspaceAsOfRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchAirspaceAsOfRequest.ProtoReflect.Descriptor instead.
+func (*SearchAirspaceAsOfRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_adminpb_admin_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SearchAirspaceAsOfRequest) GetAsOf() *timestamp.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+func (x *SearchAirspaceAsOfRequest) GetFootprint() []*LatLngPoint {
+	if x != nil {
+		return x.Footprint
+	}
+	return nil
+}
+
+func (x *SearchAirspaceAsOfRequest) GetAltitude() *AltitudeRange {
+	if x != nil {
+		return x.Altitude
+	}
+	return nil
+}
+
+func (x *SearchAirspaceAsOfRequest) GetTimeStart() *timestamp.Timestamp {
+	if x != nil {
+		return x.TimeStart
+	}
+	return nil
+}
+
+func (x *SearchAirspaceAsOfRequest) GetTimeEnd() *timestamp.Timestamp {
+	if x != nil {
+		return x.TimeEnd
+	}
+	return nil
+}
+
+type SearchAirspaceAsOfResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OperationalIntents []*AirspaceEntity `protobuf:"bytes,1,rep,name=operational_intents,json=operationalIntents,proto3" json:"operational_intents,omitempty"`
+	Constraints        []*AirspaceEntity `protobuf:"bytes,2,rep,name=constraints,proto3" json:"constraints,omitempty"`
+}
+
+func (x *SearchAirspaceAsOfResponse) Reset() {
+	*x = SearchAirspaceAsOfResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchAirspaceAsOfResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchAirspaceAsOfResponse) ProtoMessage() {}
+
+func (x *SearchAirspaceAsOfResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchAirspaceAsOfResponse.ProtoReflect.Descriptor instead.
+func (*SearchAirspaceAsOfResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_adminpb_admin_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SearchAirspaceAsOfResponse) GetOperationalIntents() []*AirspaceEntity {
+	if x != nil {
+		return x.OperationalIntents
+	}
+	return nil
+}
+
+func (x *SearchAirspaceAsOfResponse) GetConstraints() []*AirspaceEntity {
+	if x != nil {
+		return x.Constraints
+	}
+	return nil
+}
+
+// Summary of a DSS entity.
+type EntitySummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Type of the entity, e.g. `operational_intent` or
+	// `identification_service_area`.
+	EntityType string `protobuf:"bytes,1,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	Id         string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Owner (or manager) of the entity.
+	Owner string `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
+	// Version (or OVN) of the entity.
+	Version string `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	// Base URL of the USS managing the entity.
+	Url       string               `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
+	TimeStart *timestamp.Timestamp `protobuf:"bytes,6,opt,name=time_start,json=timeStart,proto3" json:"time_start,omitempty"`
+	TimeEnd   *timestamp.Timestamp `protobuf:"bytes,7,opt,name=time_end,json=timeEnd,proto3" json:"time_end,omitempty"`
+}
+
+func (x *EntitySummary) Reset() {
+	*x = EntitySummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EntitySummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntitySummary) ProtoMessage() {}
+
+func (x *EntitySummary) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntitySummary.ProtoReflect.Descriptor instead.
+func (*EntitySummary) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_adminpb_admin_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EntitySummary) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *EntitySummary) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EntitySummary) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *EntitySummary) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *EntitySummary) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *EntitySummary) GetTimeStart() *timestamp.Timestamp {
+	if x != nil {
+		return x.TimeStart
+	}
+	return nil
+}
+
+func (x *EntitySummary) GetTimeEnd() *timestamp.Timestamp {
+	if x != nil {
+		return x.TimeEnd
+	}
+	return nil
+}
+
+type ListEntitiesByManagerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Owner (or manager) of the entities to list.
+	Manager string `protobuf:"bytes,1,opt,name=manager,proto3" json:"manager,omitempty"`
+}
+
+func (x *ListEntitiesByManagerRequest) Reset() {
+	*x = ListEntitiesByManagerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListEntitiesByManagerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEntitiesByManagerRequest) ProtoMessage() {}
+
+func (x *ListEntitiesByManagerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEntitiesByManagerRequest.ProtoReflect.Descriptor instead.
+func (*ListEntitiesByManagerRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_adminpb_admin_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListEntitiesByManagerRequest) GetManager() string {
+	if x != nil {
+		return x.Manager
+	}
+	return ""
+}
+
+type ListEntitiesByManagerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entities []*EntitySummary `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
+}
+
+func (x *ListEntitiesByManagerResponse) Reset() {
+	*x = ListEntitiesByManagerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListEntitiesByManagerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEntitiesByManagerResponse) ProtoMessage() {}
+
+func (x *ListEntitiesByManagerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEntitiesByManagerResponse.ProtoReflect.Descriptor instead.
+func (*ListEntitiesByManagerResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_adminpb_admin_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListEntitiesByManagerResponse) GetEntities() []*EntitySummary {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
+}
+
+type SubscriptionState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SubscriptionId    string `protobuf:"bytes,1,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	NotificationIndex int32  `protobuf:"varint,2,opt,name=notification_index,json=notificationIndex,proto3" json:"notification_index,omitempty"`
+}
+
+func (x *SubscriptionState) Reset() {
+	*x = SubscriptionState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscriptionState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriptionState) ProtoMessage() {}
+
+func (x *SubscriptionState) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriptionState.ProtoReflect.Descriptor instead.
+func (*SubscriptionState) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_adminpb_admin_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SubscriptionState) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+func (x *SubscriptionState) GetNotificationIndex() int32 {
+	if x != nil {
+		return x.NotificationIndex
+	}
+	return 0
+}
+
+// Subscriber to notify of a change to an entity.
+type SubscriberToNotify struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url           string               `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Subscriptions []*SubscriptionState `protobuf:"bytes,2,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+}
+
+func (x *SubscriberToNotify) Reset() {
+	*x = SubscriberToNotify{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscriberToNotify) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriberToNotify) ProtoMessage() {}
+
+func (x *SubscriberToNotify) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriberToNotify.ProtoReflect.Descriptor instead.
+func (*SubscriberToNotify) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_adminpb_admin_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SubscriberToNotify) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *SubscriberToNotify) GetSubscriptions() []*SubscriptionState {
+	if x != nil {
+		return x.Subscriptions
+	}
+	return nil
+}
+
+type ForceDeleteIdentificationServiceAreaRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *ForceDeleteIdentificationServiceAreaRequest) Reset() {
+	*x = ForceDeleteIdentificationServiceAreaRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ForceDeleteIdentificationServiceAreaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceDeleteIdentificationServiceAreaRequest) ProtoMessage() {}
+
+func (x *ForceDeleteIdentificationServiceAreaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceDeleteIdentificationServiceAreaRequest.ProtoReflect.Descriptor instead.
+func (*ForceDeleteIdentificationServiceAreaRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_adminpb_admin_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ForceDeleteIdentificationServiceAreaRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ForceDeleteOperationalIntentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *ForceDeleteOperationalIntentRequest) Reset() {
+	*x = ForceDeleteOperationalIntentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ForceDeleteOperationalIntentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceDeleteOperationalIntentRequest) ProtoMessage() {}
+
+func (x *ForceDeleteOperationalIntentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceDeleteOperationalIntentRequest.ProtoReflect.Descriptor instead.
+func (*ForceDeleteOperationalIntentRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_adminpb_admin_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ForceDeleteOperationalIntentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ForceDeleteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Deleted entity.
+	Entity *EntitySummary `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	// Subscribers which must be notified of the deletion by the operator on
+	// behalf of the USS which managed the entity.
+	Subscribers []*SubscriberToNotify `protobuf:"bytes,2,rep,name=subscribers,proto3" json:"subscribers,omitempty"`
+}
+
+func (x *ForceDeleteResponse) Reset() {
+	*x = ForceDeleteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ForceDeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceDeleteResponse) ProtoMessage() {}
+
+func (x *ForceDeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceDeleteResponse.ProtoReflect.Descriptor instead.
+func (*ForceDeleteResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_adminpb_admin_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ForceDeleteResponse) GetEntity() *EntitySummary {
+	if x != nil {
+		return x.Entity
+	}
+	return nil
+}
+
+func (x *ForceDeleteResponse) GetSubscribers() []*SubscriberToNotify {
+	if x != nil {
+		return x.Subscribers
+	}
+	return nil
+}
+
+type GetSubscriptionDetailsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ID of a remote ID or strategic conflict detection subscription.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetSubscriptionDetailsRequest) Reset() {
+	*x = GetSubscriptionDetailsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSubscriptionDetailsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSubscriptionDetailsRequest) ProtoMessage() {}
+
+func (x *GetSubscriptionDetailsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSubscriptionDetailsRequest.ProtoReflect.Descriptor instead.
+func (*GetSubscriptionDetailsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_adminpb_admin_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetSubscriptionDetailsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type SubscriptionDetails struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Type of the subscription: `rid_subscription` or `scd_subscription`.
+	EntityType        string               `protobuf:"bytes,1,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	Id                string               `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Owner             string               `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
+	Version           string               `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	Url               string               `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
+	NotificationIndex int32                `protobuf:"varint,6,opt,name=notification_index,json=notificationIndex,proto3" json:"notification_index,omitempty"`
+	TimeStart         *timestamp.Timestamp `protobuf:"bytes,7,opt,name=time_start,json=timeStart,proto3" json:"time_start,omitempty"`
+	TimeEnd           *timestamp.Timestamp `protobuf:"bytes,8,opt,name=time_end,json=timeEnd,proto3" json:"time_end,omitempty"`
+	// Number of S2 cells covered by the subscription.
+	CellCount int32 `protobuf:"varint,9,opt,name=cell_count,json=cellCount,proto3" json:"cell_count,omitempty"`
+	// Whether the subscription was created implicitly for operational intents.
+	Implicit                    bool `protobuf:"varint,10,opt,name=implicit,proto3" json:"implicit,omitempty"`
+	NotifyForOperationalIntents bool `protobuf:"varint,11,opt,name=notify_for_operational_intents,json=notifyForOperationalIntents,proto3" json:"notify_for_operational_intents,omitempty"`
+	NotifyForConstraints        bool `protobuf:"varint,12,opt,name=notify_for_constraints,json=notifyForConstraints,proto3" json:"notify_for_constraints,omitempty"`
+	// IDs of the operational intents relying on the subscription.
+	DependentOperationalIntents []string `protobuf:"bytes,13,rep,name=dependent_operational_intents,json=dependentOperationalIntents,proto3" json:"dependent_operational_intents,omitempty"`
+}
+
+func (x *SubscriptionDetails) Reset() {
+	*x = SubscriptionDetails{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscriptionDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriptionDetails) ProtoMessage() {}
+
+func (x *SubscriptionDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriptionDetails.ProtoReflect.Descriptor instead.
+func (*SubscriptionDetails) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_adminpb_admin_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SubscriptionDetails) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *SubscriptionDetails) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SubscriptionDetails) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *SubscriptionDetails) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *SubscriptionDetails) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *SubscriptionDetails) GetNotificationIndex() int32 {
+	if x != nil {
+		return x.NotificationIndex
+	}
+	return 0
+}
+
+func (x *SubscriptionDetails) GetTimeStart() *timestamp.Timestamp {
+	if x != nil {
+		return x.TimeStart
+	}
+	return nil
+}
+
+func (x *SubscriptionDetails) GetTimeEnd() *timestamp.Timestamp {
+	if x != nil {
+		return x.TimeEnd
+	}
+	return nil
+}
+
+func (x *SubscriptionDetails) GetCellCount() int32 {
+	if x != nil {
+		return x.CellCount
+	}
+	return 0
+}
+
+func (x *SubscriptionDetails) GetImplicit() bool {
+	if x != nil {
+		return x.Implicit
+	}
+	return false
+}
+
+func (x *SubscriptionDetails) GetNotifyForOperationalIntents() bool {
+	if x != nil {
+		return x.NotifyForOperationalIntents
+	}
+	return false
+}
+
+func (x *SubscriptionDetails) GetNotifyForConstraints() bool {
+	if x != nil {
+		return x.NotifyForConstraints
+	}
+	return false
+}
+
+func (x *SubscriptionDetails) GetDependentOperationalIntents() []string {
+	if x != nil {
+		return x.DependentOperationalIntents
+	}
+	return nil
+}
+
+type GetSubscriptionDetailsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Subscription *SubscriptionDetails `protobuf:"bytes,1,opt,name=subscription,proto3" json:"subscription,omitempty"`
+}
+
+func (x *GetSubscriptionDetailsResponse) Reset() {
+	*x = GetSubscriptionDetailsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSubscriptionDetailsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSubscriptionDetailsResponse) ProtoMessage() {}
+
+func (x *GetSubscriptionDetailsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSubscriptionDetailsResponse.ProtoReflect.Descriptor instead.
+func (*GetSubscriptionDetailsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_adminpb_admin_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetSubscriptionDetailsResponse) GetSubscription() *SubscriptionDetails {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+type GetSchemaVersionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetSchemaVersionsRequest) Reset() {
+	*x = GetSchemaVersionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSchemaVersionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSchemaVersionsRequest) ProtoMessage() {}
+
+func (x *GetSchemaVersionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSchemaVersionsRequest.ProtoReflect.Descriptor instead.
+func (*GetSchemaVersionsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_adminpb_admin_service_proto_rawDescGZIP(), []int{19}
+}
+
+type GetSchemaVersionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RidSchemaVersion string `protobuf:"bytes,1,opt,name=rid_schema_version,json=ridSchemaVersion,proto3" json:"rid_schema_version,omitempty"`
+	// Empty if strategic conflict detection is disabled.
+	ScdSchemaVersion string `protobuf:"bytes,2,opt,name=scd_schema_version,json=scdSchemaVersion,proto3" json:"scd_schema_version,omitempty"`
+}
+
+func (x *GetSchemaVersionsResponse) Reset() {
+	*x = GetSchemaVersionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSchemaVersionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSchemaVersionsResponse) ProtoMessage() {}
+
+func (x *GetSchemaVersionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSchemaVersionsResponse.ProtoReflect.Descriptor instead.
+func (*GetSchemaVersionsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_adminpb_admin_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetSchemaVersionsResponse) GetRidSchemaVersion() string {
+	if x != nil {
+		return x.RidSchemaVersion
+	}
+	return ""
+}
+
+func (x *GetSchemaVersionsResponse) GetScdSchemaVersion() string {
+	if x != nil {
+		return x.ScdSchemaVersion
+	}
+	return ""
+}
+
+var File_pkg_api_v1_adminpb_admin_service_proto protoreflect.FileDescriptor
+
+var file_pkg_api_v1_adminpb_admin_service_proto_rawDesc = []byte{
+	0x0a, 0x26, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x64, 0x6d,
+	0x69, 0x6e, 0x70, 0x62, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70,
+	0x62, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e,
+	0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0xbe, 0x03, 0x0a, 0x0b, 0x41, 0x75, 0x64, 0x69, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x64, 0x12, 0x1c,
+	0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05,
+	0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e,
+	0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x6c, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x6c, 0x64, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x77, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x77, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12,
+	0x35, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x74,
+	0x69, 0x6d, 0x65, 0x45, 0x6e, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x6c, 0x74, 0x69, 0x74, 0x75,
+	0x64, 0x65, 0x5f, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0d,
+	0x61, 0x6c, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x4c, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x25, 0x0a,
+	0x0e, 0x61, 0x6c, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x5f, 0x75, 0x70, 0x70, 0x65, 0x72, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0d, 0x61, 0x6c, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x55,
+	0x70, 0x70, 0x65, 0x72, 0x12, 0x3b, 0x0a, 0x0b, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x64,
+	0x5f, 0x61, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x64, 0x41,
+	0x74, 0x22, 0xe8, 0x01, 0x0a, 0x14, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x75, 0x64, 0x69, 0x74,
+	0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x65,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x3f,
+	0x0a, 0x0d, 0x65, 0x61, 0x72, 0x6c, 0x69, 0x65, 0x73, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x0c, 0x65, 0x61, 0x72, 0x6c, 0x69, 0x65, 0x73, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x3b, 0x0a, 0x0b, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x0a, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x47, 0x0a, 0x15,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62,
+	0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x07, 0x72, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x73, 0x22, 0x31, 0x0a, 0x0b, 0x4c, 0x61, 0x74, 0x4c, 0x6e, 0x67, 0x50,
+	0x6f, 0x69, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x03, 0x6c, 0x61, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x6e, 0x67, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x03, 0x6c, 0x6e, 0x67, 0x22, 0x3b, 0x0a, 0x0d, 0x41, 0x6c, 0x74, 0x69,
+	0x74, 0x75, 0x64, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x77,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x12,
+	0x14, 0x0a, 0x05, 0x75, 0x70, 0x70, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05,
+	0x75, 0x70, 0x70, 0x65, 0x72, 0x22, 0xa8, 0x03, 0x0a, 0x0e, 0x41, 0x69, 0x72, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x61, 0x6e,
+	0x61, 0x67, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x6e, 0x61,
+	0x67, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a,
+	0x03, 0x6f, 0x76, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f, 0x76, 0x6e, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x75, 0x73, 0x73, 0x5f, 0x62, 0x61, 0x73,
+	0x65, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x75, 0x73, 0x73,
+	0x42, 0x61, 0x73, 0x65, 0x55, 0x72, 0x6c, 0x12, 0x39, 0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x5f,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x12, 0x35, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x45, 0x6e, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x6c, 0x74,
+	0x69, 0x74, 0x75, 0x64, 0x65, 0x5f, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x02, 0x52, 0x0d, 0x61, 0x6c, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x4c, 0x6f, 0x77, 0x65, 0x72,
+	0x12, 0x25, 0x0a, 0x0e, 0x61, 0x6c, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x5f, 0x75, 0x70, 0x70,
+	0x65, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0d, 0x61, 0x6c, 0x74, 0x69, 0x74, 0x75,
+	0x64, 0x65, 0x55, 0x70, 0x70, 0x65, 0x72, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x22, 0xa6, 0x02, 0x0a, 0x19, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x41, 0x69, 0x72, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x41, 0x73, 0x4f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f,
+	0x0a, 0x05, 0x61, 0x73, 0x5f, 0x6f, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x61, 0x73, 0x4f, 0x66, 0x12,
+	0x32, 0x0a, 0x09, 0x66, 0x6f, 0x6f, 0x74, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x4c, 0x61, 0x74,
+	0x4c, 0x6e, 0x67, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x09, 0x66, 0x6f, 0x6f, 0x74, 0x70, 0x72,
+	0x69, 0x6e, 0x74, 0x12, 0x32, 0x0a, 0x08, 0x61, 0x6c, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e,
+	0x41, 0x6c, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x08, 0x61,
+	0x6c, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x5f,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x12, 0x35, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x45, 0x6e, 0x64, 0x22, 0xa1, 0x01, 0x0a, 0x1a, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x41, 0x69, 0x72, 0x73, 0x70, 0x61, 0x63, 0x65, 0x41, 0x73, 0x4f, 0x66,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x13, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e,
+	0x41, 0x69, 0x72, 0x73, 0x70, 0x61, 0x63, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x12,
+	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x73, 0x12, 0x39, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70,
+	0x62, 0x2e, 0x41, 0x69, 0x72, 0x73, 0x70, 0x61, 0x63, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x22, 0xf4, 0x01,
+	0x0a, 0x0d, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12,
+	0x1f, 0x0a, 0x0b, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75,
+	0x72, 0x6c, 0x12, 0x39, 0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x35, 0x0a,
+	0x08, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x74, 0x69, 0x6d,
+	0x65, 0x45, 0x6e, 0x64, 0x22, 0x38, 0x0a, 0x1c, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x69,
+	0x74, 0x69, 0x65, 0x73, 0x42, 0x79, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x22, 0x53,
+	0x0a, 0x1d, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x42, 0x79,
+	0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x32, 0x0a, 0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x45, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x08, 0x65, 0x6e, 0x74, 0x69, 0x74,
+	0x69, 0x65, 0x73, 0x22, 0x6b, 0x0a, 0x11, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x64, 0x12, 0x2d, 0x0a, 0x12, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x6e,
+	0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x22, 0x68, 0x0a, 0x12, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x54, 0x6f,
+	0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x40, 0x0a, 0x0d, 0x73, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0d, 0x73, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x3d, 0x0a, 0x2b, 0x46, 0x6f,
+	0x72, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x72,
+	0x65, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x35, 0x0a, 0x23, 0x46, 0x6f, 0x72,
+	0x63, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x22, 0x84, 0x01, 0x0a, 0x13, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x65, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e,
+	0x70, 0x62, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
+	0x52, 0x06, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x3d, 0x0a, 0x0b, 0x73, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x72, 0x54, 0x6f, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x52, 0x0b, 0x73, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x73, 0x22, 0x2f, 0x0a, 0x1d, 0x47, 0x65, 0x74, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0xa3, 0x04, 0x0a, 0x13, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73,
+	0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x75, 0x72, 0x6c, 0x12, 0x2d, 0x0a, 0x12, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x11, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x12, 0x39, 0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x35, 0x0a,
+	0x08, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x74, 0x69, 0x6d,
+	0x65, 0x45, 0x6e, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x65, 0x6c, 0x6c, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x63, 0x65, 0x6c, 0x6c, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6d, 0x70, 0x6c, 0x69, 0x63, 0x69, 0x74, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x6d, 0x70, 0x6c, 0x69, 0x63, 0x69, 0x74, 0x12,
+	0x43, 0x0a, 0x1e, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x5f, 0x66, 0x6f, 0x72, 0x5f, 0x6f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1b, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x46,
+	0x6f, 0x72, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x73, 0x12, 0x34, 0x0a, 0x16, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x5f, 0x66,
+	0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x14, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x46, 0x6f, 0x72, 0x43,
+	0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x42, 0x0a, 0x1d, 0x64, 0x65,
+	0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x74, 0x5f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x61, 0x6c, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x1b, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x74, 0x4f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x62,
+	0x0a, 0x1e, 0x47, 0x65, 0x74, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x40, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62,
+	0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x73, 0x52, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x1a, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x77,
+	0x0a, 0x19, 0x47, 0x65, 0x74, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x72,
+	0x69, 0x64, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x72, 0x69, 0x64, 0x53, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x12, 0x73, 0x63, 0x64,
+	0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x73, 0x63, 0x64, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x32, 0xff, 0x07, 0x0a, 0x0f, 0x44, 0x53, 0x53, 0x41,
+	0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x6b, 0x0a, 0x0d, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x12, 0x1d, 0x2e, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x75, 0x64, 0x69,
+	0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x61, 0x64,
+	0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x75, 0x64, 0x69, 0x74,
+	0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x15, 0x12, 0x13, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x76, 0x31, 0x2f, 0x61,
+	0x75, 0x64, 0x69, 0x74, 0x5f, 0x6c, 0x6f, 0x67, 0x12, 0x82, 0x01, 0x0a, 0x12, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x41, 0x69, 0x72, 0x73, 0x70, 0x61, 0x63, 0x65, 0x41, 0x73, 0x4f, 0x66, 0x12,
+	0x22, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x41, 0x69, 0x72, 0x73, 0x70, 0x61, 0x63, 0x65, 0x41, 0x73, 0x4f, 0x66, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x41, 0x69, 0x72, 0x73, 0x70, 0x61, 0x63, 0x65, 0x41, 0x73, 0x4f, 0x66,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x23, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1d,
+	0x22, 0x18, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x69, 0x72, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x2f, 0x61, 0x73, 0x5f, 0x6f, 0x66, 0x3a, 0x01, 0x2a, 0x12, 0x95, 0x01,
+	0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x42, 0x79,
+	0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x12, 0x25, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70,
+	0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x42, 0x79,
+	0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26,
+	0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x74,
+	0x69, 0x74, 0x69, 0x65, 0x73, 0x42, 0x79, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x27, 0x12, 0x25,
+	0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x76, 0x31, 0x2f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65,
+	0x72, 0x73, 0x2f, 0x7b, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x7d, 0x2f, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0xb3, 0x01, 0x0a, 0x24, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x72, 0x65, 0x61, 0x12, 0x34,
+	0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x72, 0x65, 0x61, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x46,
+	0x6f, 0x72, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x37, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x31, 0x2a, 0x2f, 0x2f, 0x61, 0x64, 0x6d,
+	0x69, 0x6e, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x69, 0x64, 0x2f, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x5f, 0x61, 0x72, 0x65, 0x61, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x9a, 0x01, 0x0a, 0x1c,
+	0x46, 0x6f, 0x72, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x2c, 0x2e, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x61, 0x64, 0x6d,
+	0x69, 0x6e, 0x70, 0x62, 0x2e, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x28,
+	0x2a, 0x26, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x63, 0x64, 0x2f,
+	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x69, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x8f, 0x01, 0x0a, 0x16, 0x47, 0x65, 0x74,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x73, 0x12, 0x26, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x47, 0x65,
+	0x74, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x61, 0x64,
+	0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x24, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1e, 0x12, 0x1c, 0x2f, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x7d, 0x0a, 0x11, 0x47, 0x65,
+	0x74, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x21, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x22, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74,
+	0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x21, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x12, 0x19,
+	0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x14, 0x5a, 0x12, 0x70, 0x6b, 0x67,
+	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x70, 0x62, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_pkg_api_v1_adminpb_admin_service_proto_rawDescOnce sync.Once
+	file_pkg_api_v1_adminpb_admin_service_proto_rawDescData = file_pkg_api_v1_adminpb_admin_service_proto_rawDesc
+)
+
+func file_pkg_api_v1_adminpb_admin_service_proto_rawDescGZIP() []byte {
+	file_pkg_api_v1_adminpb_admin_service_proto_rawDescOnce.Do(func() {
+		file_pkg_api_v1_adminpb_admin_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_pkg_api_v1_adminpb_admin_service_proto_rawDescData)
+	})
+	return file_pkg_api_v1_adminpb_admin_service_proto_rawDescData
+}
+
+var file_pkg_api_v1_adminpb_admin_service_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_pkg_api_v1_adminpb_admin_service_proto_goTypes = []interface{}{
+	(*AuditRecord)(nil),                                 // 0: adminpb.AuditRecord
+	(*QueryAuditLogRequest)(nil),                        // 1: adminpb.QueryAuditLogRequest
+	(*QueryAuditLogResponse)(nil),                       // 2: adminpb.QueryAuditLogResponse
+	(*LatLngPoint)(nil),                                 // 3: adminpb.LatLngPoint
+	(*AltitudeRange)(nil),                               // 4: adminpb.AltitudeRange
+	(*AirspaceEntity)(nil),                              // 5: adminpb.AirspaceEntity
+	(*SearchAirspaceAsOfRequest)(nil),                   // 6: adminpb.SearchAirspaceAsOfRequest
+	(*SearchAirspaceAsOfResponse)(nil),                  // 7: adminpb.SearchAirspaceAsOfResponse
+	(*EntitySummary)(nil),                               // 8: adminpb.EntitySummary
+	(*ListEntitiesByManagerRequest)(nil),                // 9: adminpb.ListEntitiesByManagerRequest
+	(*ListEntitiesByManagerResponse)(nil),               // 10: adminpb.ListEntitiesByManagerResponse
+	(*SubscriptionState)(nil),                           // 11: adminpb.SubscriptionState
+	(*SubscriberToNotify)(nil),                          // 12: adminpb.SubscriberToNotify
+	(*ForceDeleteIdentificationServiceAreaRequest)(nil), // 13: adminpb.ForceDeleteIdentificationServiceAreaRequest
+	(*ForceDeleteOperationalIntentRequest)(nil),         // 14: adminpb.ForceDeleteOperationalIntentRequest
+	(*ForceDeleteResponse)(nil),                         // 15: adminpb.ForceDeleteResponse
+	(*GetSubscriptionDetailsRequest)(nil),               // 16: adminpb.GetSubscriptionDetailsRequest
+	(*SubscriptionDetails)(nil),                         // 17: adminpb.SubscriptionDetails
+	(*GetSubscriptionDetailsResponse)(nil),              // 18: adminpb.GetSubscriptionDetailsResponse
+	(*GetSchemaVersionsRequest)(nil),                    // 19: adminpb.GetSchemaVersionsRequest
+	(*GetSchemaVersionsResponse)(nil),                   // 20: adminpb.GetSchemaVersionsResponse
+	(*timestamp.Timestamp)(nil),                         // 21: google.protobuf.Timestamp
+}
+var file_pkg_api_v1_adminpb_admin_service_proto_depIdxs = []int32{
+	21, // 0: adminpb.AuditRecord.time_start:type_name -> google.protobuf.Timestamp
+	21, // 1: adminpb.AuditRecord.time_end:type_name -> google.protobuf.Timestamp
+	21, // 2: adminpb.AuditRecord.recorded_at:type_name -> google.protobuf.Timestamp
+	21, // 3: adminpb.QueryAuditLogRequest.earliest_time:type_name -> google.protobuf.Timestamp
+	21, // 4: adminpb.QueryAuditLogRequest.latest_time:type_name -> google.protobuf.Timestamp
+	0,  // 5: adminpb.QueryAuditLogResponse.records:type_name -> adminpb.AuditRecord
+	21, // 6: adminpb.AirspaceEntity.time_start:type_name -> google.protobuf.Timestamp
+	21, // 7: adminpb.AirspaceEntity.time_end:type_name -> google.protobuf.Timestamp
+	21, // 8: adminpb.SearchAirspaceAsOfRequest.as_of:type_name -> google.protobuf.Timestamp
+	3,  // 9: adminpb.SearchAirspaceAsOfRequest.footprint:type_name -> adminpb.LatLngPoint
+	4,  // 10: adminpb.SearchAirspaceAsOfRequest.altitude:type_name -> adminpb.AltitudeRange
+	21, // 11: adminpb.SearchAirspaceAsOfRequest.time_start:type_name -> google.protobuf.Timestamp
+	21, // 12: adminpb.SearchAirspaceAsOfRequest.time_end:type_name -> google.protobuf.Timestamp
+	5,  // 13: adminpb.SearchAirspaceAsOfResponse.operational_intents:type_name -> adminpb.AirspaceEntity
+	5,  // 14: adminpb.SearchAirspaceAsOfResponse.constraints:type_name -> adminpb.AirspaceEntity
+	21, // 15: adminpb.EntitySummary.time_start:type_name -> google.protobuf.Timestamp
+	21, // 16: adminpb.EntitySummary.time_end:type_name -> google.protobuf.Timestamp
+	8,  // 17: adminpb.ListEntitiesByManagerResponse.entities:type_name -> adminpb.EntitySummary
+	11, // 18: adminpb.SubscriberToNotify.subscriptions:type_name -> adminpb.SubscriptionState
+	8,  // 19: adminpb.ForceDeleteResponse.entity:type_name -> adminpb.EntitySummary
+	12, // 20: adminpb.ForceDeleteResponse.subscribers:type_name -> adminpb.SubscriberToNotify
+	21, // 21: adminpb.SubscriptionDetails.time_start:type_name -> google.protobuf.Timestamp
+	21, // 22: adminpb.SubscriptionDetails.time_end:type_name -> google.protobuf.Timestamp
+	17, // 23: adminpb.GetSubscriptionDetailsResponse.subscription:type_name -> adminpb.SubscriptionDetails
+	1,  // 24: adminpb.DSSAdminService.QueryAuditLog:input_type -> adminpb.QueryAuditLogRequest
+	6,  // 25: adminpb.DSSAdminService.SearchAirspaceAsOf:input_type -> adminpb.SearchAirspaceAsOfRequest
+	9,  // 26: adminpb.DSSAdminService.ListEntitiesByManager:input_type -> adminpb.ListEntitiesByManagerRequest
+	13, // 27: adminpb.DSSAdminService.ForceDeleteIdentificationServiceArea:input_type -> adminpb.ForceDeleteIdentificationServiceAreaRequest
+	14, // 28: adminpb.DSSAdminService.ForceDeleteOperationalIntent:input_type -> adminpb.ForceDeleteOperationalIntentRequest
+	16, // 29: adminpb.DSSAdminService.GetSubscriptionDetails:input_type -> adminpb.GetSubscriptionDetailsRequest
+	19, // 30: adminpb.DSSAdminService.GetSchemaVersions:input_type -> adminpb.GetSchemaVersionsRequest
+	2,  // 31: adminpb.DSSAdminService.QueryAuditLog:output_type -> adminpb.QueryAuditLogResponse
+	7,  // 32: adminpb.DSSAdminService.SearchAirspaceAsOf:output_type -> adminpb.SearchAirspaceAsOfResponse
+	10, // 33: adminpb.DSSAdminService.ListEntitiesByManager:output_type -> adminpb.ListEntitiesByManagerResponse
+	15, // 34: adminpb.DSSAdminService.ForceDeleteIdentificationServiceArea:output_type -> adminpb.ForceDeleteResponse
+	15, // 35: adminpb.DSSAdminService.ForceDeleteOperationalIntent:output_type -> adminpb.ForceDeleteResponse
+	18, // 36: adminpb.DSSAdminService.GetSubscriptionDetails:output_type -> adminpb.GetSubscriptionDetailsResponse
+	20, // 37: adminpb.DSSAdminService.GetSchemaVersions:output_type -> adminpb.GetSchemaVersionsResponse
+	31, // [31:38] is the sub-list for method output_type
+	24, // [24:31] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
+}
+
+func init() { file_pkg_api_v1_adminpb_admin_service_proto_init() }
+func file_pkg_api_v1_adminpb_admin_service_proto_init() {
+	if File_pkg_api_v1_adminpb_admin_service_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuditRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryAuditLogRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryAuditLogResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LatLngPoint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AltitudeRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AirspaceEntity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchAirspaceAsOfRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchAirspaceAsOfResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EntitySummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListEntitiesByManagerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListEntitiesByManagerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriptionState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriberToNotify); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ForceDeleteIdentificationServiceAreaRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ForceDeleteOperationalIntentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ForceDeleteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSubscriptionDetailsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriptionDetails); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSubscriptionDetailsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSchemaVersionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_adminpb_admin_service_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSchemaVersionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_pkg_api_v1_adminpb_admin_service_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   21,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_api_v1_adminpb_admin_service_proto_goTypes,
+		DependencyIndexes: file_pkg_api_v1_adminpb_admin_service_proto_depIdxs,
+		MessageInfos:      file_pkg_api_v1_adminpb_admin_service_proto_msgTypes,
+	}.Build()
+	File_pkg_api_v1_adminpb_admin_service_proto = out.File
+	file_pkg_api_v1_adminpb_admin_service_proto_rawDesc = nil
+	file_pkg_api_v1_adminpb_admin_service_proto_goTypes = nil
+	file_pkg_api_v1_adminpb_admin_service_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// DSSAdminServiceClient is the client API for DSSAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DSSAdminServiceClient interface {
+	// /admin/v1/audit_log
+	//
+	// Queries the audit log of mutations of DSS entities.
+	QueryAuditLog(ctx context.Context, in *QueryAuditLogRequest, opts ...grpc.CallOption) (*QueryAuditLogResponse, error)
+	// /admin/v1/airspace/as_of
+	//
+	// Searches the operational intents and constraints of the area of interest
+	// as they were at a past time.
+	SearchAirspaceAsOf(ctx context.Context, in *SearchAirspaceAsOfRequest, opts ...grpc.CallOption) (*SearchAirspaceAsOfResponse, error)
+	// /admin/v1/managers/{manager}/entities
+	//
+	// Lists the entities owned by a manager.
+	ListEntitiesByManager(ctx context.Context, in *ListEntitiesByManagerRequest, opts ...grpc.CallOption) (*ListEntitiesByManagerResponse, error)
+	// /admin/v1/rid/identification_service_areas/{id}
+	//
+	// Deletes an identification service area regardless of its owner.
+	ForceDeleteIdentificationServiceArea(ctx context.Context, in *ForceDeleteIdentificationServiceAreaRequest, opts ...grpc.CallOption) (*ForceDeleteResponse, error)
+	// /admin/v1/scd/operational_intents/{id}
+	//
+	// Deletes an operational intent regardless of its manager.
+	ForceDeleteOperationalIntent(ctx context.Context, in *ForceDeleteOperationalIntentRequest, opts ...grpc.CallOption) (*ForceDeleteResponse, error)
+	// /admin/v1/subscriptions/{id}
+	//
+	// Inspects a subscription, including its notification index.
+	GetSubscriptionDetails(ctx context.Context, in *GetSubscriptionDetailsRequest, opts ...grpc.CallOption) (*GetSubscriptionDetailsResponse, error)
+	// /admin/v1/schema_versions
+	//
+	// Returns the schema versions of the DSS databases.
+	GetSchemaVersions(ctx context.Context, in *GetSchemaVersionsRequest, opts ...grpc.CallOption) (*GetSchemaVersionsResponse, error)
+}
+
+type dSSAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDSSAdminServiceClient(cc grpc.ClientConnInterface) DSSAdminServiceClient {
+	return &dSSAdminServiceClient{cc}
+}
+
+func (c *dSSAdminServiceClient) QueryAuditLog(ctx context.Context, in *QueryAuditLogRequest, opts ...grpc.CallOption) (*QueryAuditLogResponse, error) {
+	out := new(QueryAuditLogResponse)
+	err := c.cc.Invoke(ctx, "/adminpb.DSSAdminService/QueryAuditLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dSSAdminServiceClient) SearchAirspaceAsOf(ctx context.Context, in *SearchAirspaceAsOfRequest, opts ...grpc.CallOption) (*SearchAirspaceAsOfResponse, error) {
+	out := new(SearchAirspaceAsOfResponse)
+	err := c.cc.Invoke(ctx, "/adminpb.DSSAdminService/SearchAirspaceAsOf", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dSSAdminServiceClient) ListEntitiesByManager(ctx context.Context, in *ListEntitiesByManagerRequest, opts ...grpc.CallOption) (*ListEntitiesByManagerResponse, error) {
+	out := new(ListEntitiesByManagerResponse)
+	err := c.cc.Invoke(ctx, "/adminpb.DSSAdminService/ListEntitiesByManager", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dSSAdminServiceClient) ForceDeleteIdentificationServiceArea(ctx context.Context, in *ForceDeleteIdentificationServiceAreaRequest, opts ...grpc.CallOption) (*ForceDeleteResponse, error) {
+	out := new(ForceDeleteResponse)
+	err := c.cc.Invoke(ctx, "/adminpb.DSSAdminService/ForceDeleteIdentificationServiceArea", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dSSAdminServiceClient) ForceDeleteOperationalIntent(ctx context.Context, in *ForceDeleteOperationalIntentRequest, opts ...grpc.CallOption) (*ForceDeleteResponse, error) {
+	out := new(ForceDeleteResponse)
+	err := c.cc.Invoke(ctx, "/adminpb.DSSAdminService/ForceDeleteOperationalIntent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dSSAdminServiceClient) GetSubscriptionDetails(ctx context.Context, in *GetSubscriptionDetailsRequest, opts ...grpc.CallOption) (*GetSubscriptionDetailsResponse, error) {
+	out := new(GetSubscriptionDetailsResponse)
+	err := c.cc.Invoke(ctx, "/adminpb.DSSAdminService/GetSubscriptionDetails", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dSSAdminServiceClient) GetSchemaVersions(ctx context.Context, in *GetSchemaVersionsRequest, opts ...grpc.CallOption) (*GetSchemaVersionsResponse, error) {
+	out := new(GetSchemaVersionsResponse)
+	err := c.cc.Invoke(ctx, "/adminpb.DSSAdminService/GetSchemaVersions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DSSAdminServiceServer is the server API for DSSAdminService service.
+type DSSAdminServiceServer interface {
+	// /admin/v1/audit_log
+	//
+	// Queries the audit log of mutations of DSS entities.
+	QueryAuditLog(context.Context, *QueryAuditLogRequest) (*QueryAuditLogResponse, error)
+	// /admin/v1/airspace/as_of
+	//
+	// Searches the operational intents and constraints of the area of interest
+	// as they were at a past time.
+	SearchAirspaceAsOf(context.Context, *SearchAirspaceAsOfRequest) (*SearchAirspaceAsOfResponse, error)
+	// /admin/v1/managers/{manager}/entities
+	//
+	// Lists the entities owned by a manager.
+	ListEntitiesByManager(context.Context, *ListEntitiesByManagerRequest) (*ListEntitiesByManagerResponse, error)
+	// /admin/v1/rid/identification_service_areas/{id}
+	//
+	// Deletes an identification service area regardless of its owner.
+	ForceDeleteIdentificationServiceArea(context.Context, *ForceDeleteIdentificationServiceAreaRequest) (*ForceDeleteResponse, error)
+	// /admin/v1/scd/operational_intents/{id}
+	//
+	// Deletes an operational intent regardless of its manager.
+	ForceDeleteOperationalIntent(context.Context, *ForceDeleteOperationalIntentRequest) (*ForceDeleteResponse, error)
+	// /admin/v1/subscriptions/{id}
+	//
+	// Inspects a subscription, including its notification index.
+	GetSubscriptionDetails(context.Context, *GetSubscriptionDetailsRequest) (*GetSubscriptionDetailsResponse, error)
+	// /admin/v1/schema_versions
+	//
+	// Returns the schema versions of the DSS databases.
+	GetSchemaVersions(context.Context, *GetSchemaVersionsRequest) (*GetSchemaVersionsResponse, error)
+}
+
+// UnimplementedDSSAdminServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedDSSAdminServiceServer struct {
+}
+
+func (*UnimplementedDSSAdminServiceServer) QueryAuditLog(context.Context, *QueryAuditLogRequest) (*QueryAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryAuditLog not implemented")
+}
+func (*UnimplementedDSSAdminServiceServer) SearchAirspaceAsOf(context.Context, *SearchAirspaceAsOfRequest) (*SearchAirspaceAsOfResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchAirspaceAsOf not implemented")
+}
+func (*UnimplementedDSSAdminServiceServer) ListEntitiesByManager(context.Context, *ListEntitiesByManagerRequest) (*ListEntitiesByManagerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEntitiesByManager not implemented")
+}
+func (*UnimplementedDSSAdminServiceServer) ForceDeleteIdentificationServiceArea(context.Context, *ForceDeleteIdentificationServiceAreaRequest) (*ForceDeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForceDeleteIdentificationServiceArea not implemented")
+}
+func (*UnimplementedDSSAdminServiceServer) ForceDeleteOperationalIntent(context.Context, *ForceDeleteOperationalIntentRequest) (*ForceDeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForceDeleteOperationalIntent not implemented")
+}
+func (*UnimplementedDSSAdminServiceServer) GetSubscriptionDetails(context.Context, *GetSubscriptionDetailsRequest) (*GetSubscriptionDetailsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSubscriptionDetails not implemented")
+}
+func (*UnimplementedDSSAdminServiceServer) GetSchemaVersions(context.Context, *GetSchemaVersionsRequest) (*GetSchemaVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSchemaVersions not implemented")
+}
+
+func RegisterDSSAdminServiceServer(s *grpc.Server, srv DSSAdminServiceServer) {
+	s.RegisterService(&_DSSAdminService_serviceDesc, srv)
+}
+
+func _DSSAdminService_QueryAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DSSAdminServiceServer).QueryAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/adminpb.DSSAdminService/QueryAuditLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DSSAdminServiceServer).QueryAuditLog(ctx, req.(*QueryAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DSSAdminService_SearchAirspaceAsOf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchAirspaceAsOfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DSSAdminServiceServer).SearchAirspaceAsOf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/adminpb.DSSAdminService/SearchAirspaceAsOf",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DSSAdminServiceServer).SearchAirspaceAsOf(ctx, req.(*SearchAirspaceAsOfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DSSAdminService_ListEntitiesByManager_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEntitiesByManagerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DSSAdminServiceServer).ListEntitiesByManager(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/adminpb.DSSAdminService/ListEntitiesByManager",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DSSAdminServiceServer).ListEntitiesByManager(ctx, req.(*ListEntitiesByManagerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DSSAdminService_ForceDeleteIdentificationServiceArea_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForceDeleteIdentificationServiceAreaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DSSAdminServiceServer).ForceDeleteIdentificationServiceArea(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/adminpb.DSSAdminService/ForceDeleteIdentificationServiceArea",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DSSAdminServiceServer).ForceDeleteIdentificationServiceArea(ctx, req.(*ForceDeleteIdentificationServiceAreaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DSSAdminService_ForceDeleteOperationalIntent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForceDeleteOperationalIntentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DSSAdminServiceServer).ForceDeleteOperationalIntent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/adminpb.DSSAdminService/ForceDeleteOperationalIntent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DSSAdminServiceServer).ForceDeleteOperationalIntent(ctx, req.(*ForceDeleteOperationalIntentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DSSAdminService_GetSubscriptionDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSubscriptionDetailsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DSSAdminServiceServer).GetSubscriptionDetails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/adminpb.DSSAdminService/GetSubscriptionDetails",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DSSAdminServiceServer).GetSubscriptionDetails(ctx, req.(*GetSubscriptionDetailsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DSSAdminService_GetSchemaVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSchemaVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DSSAdminServiceServer).GetSchemaVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/adminpb.DSSAdminService/GetSchemaVersions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DSSAdminServiceServer).GetSchemaVersions(ctx, req.(*GetSchemaVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _DSSAdminService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "adminpb.DSSAdminService",
+	HandlerType: (*DSSAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "QueryAuditLog",
+			Handler:    _DSSAdminService_QueryAuditLog_Handler,
+		},
+		{
+			MethodName: "SearchAirspaceAsOf",
+			Handler:    _DSSAdminService_SearchAirspaceAsOf_Handler,
+		},
+		{
+			MethodName: "ListEntitiesByManager",
+			Handler:    _DSSAdminService_ListEntitiesByManager_Handler,
+		},
+		{
+			MethodName: "ForceDeleteIdentificationServiceArea",
+			Handler:    _DSSAdminService_ForceDeleteIdentificationServiceArea_Handler,
+		},
+		{
+			MethodName: "ForceDeleteOperationalIntent",
+			Handler:    _DSSAdminService_ForceDeleteOperationalIntent_Handler,
+		},
+		{
+			MethodName: "GetSubscriptionDetails",
+			Handler:    _DSSAdminService_GetSubscriptionDetails_Handler,
+		},
+		{
+			MethodName: "GetSchemaVersions",
+			Handler:    _DSSAdminService_GetSchemaVersions_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/api/v1/adminpb/admin_service.proto",
+}
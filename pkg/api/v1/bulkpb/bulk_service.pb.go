@@ -0,0 +1,777 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.22.0
+// 	protoc        v3.11.2
+// source: pkg/api/v1/bulkpb/bulk_service.proto
+
+package bulkpb
+
+import (
+	context "context"
+	proto "github.com/golang/protobuf/proto"
+	scdpb "github.com/interuss/dss/pkg/api/v1/scdpb"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+// A creation, update or deletion of an operational intent reference.
+type OperationalIntentReferenceChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// EntityID of the operational intent.
+	Entityid string `protobuf:"bytes,1,opt,name=entityid,proto3" json:"entityid,omitempty"`
+	// Opaque version number of the existing operational intent reference, or
+	// empty to create it.
+	Ovn string `protobuf:"bytes,2,opt,name=ovn,proto3" json:"ovn,omitempty"`
+	// If true, the operational intent reference is deleted and params must not
+	// be set.
+	Delete bool `protobuf:"varint,3,opt,name=delete,proto3" json:"delete,omitempty"`
+	// Parameters of the created or updated operational intent reference.
+	Params *scdpb.PutOperationalIntentReferenceParameters `protobuf:"bytes,4,opt,name=params,proto3" json:"params,omitempty"`
+}
+
+func (x *OperationalIntentReferenceChange) Reset() {
+	*x = OperationalIntentReferenceChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_bulkpb_bulk_service_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OperationalIntentReferenceChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationalIntentReferenceChange) ProtoMessage() {}
+
+func (x *OperationalIntentReferenceChange) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_bulkpb_bulk_service_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationalIntentReferenceChange.ProtoReflect.Descriptor instead.
+func (*OperationalIntentReferenceChange) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_bulkpb_bulk_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OperationalIntentReferenceChange) GetEntityid() string {
+	if x != nil {
+		return x.Entityid
+	}
+	return ""
+}
+
+func (x *OperationalIntentReferenceChange) GetOvn() string {
+	if x != nil {
+		return x.Ovn
+	}
+	return ""
+}
+
+func (x *OperationalIntentReferenceChange) GetDelete() bool {
+	if x != nil {
+		return x.Delete
+	}
+	return false
+}
+
+func (x *OperationalIntentReferenceChange) GetParams() *scdpb.PutOperationalIntentReferenceParameters {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+type BulkChangeOperationalIntentReferencesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Changes to apply, in order. An operational intent may only be changed
+	// once per request.
+	Changes []*OperationalIntentReferenceChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+}
+
+func (x *BulkChangeOperationalIntentReferencesRequest) Reset() {
+	*x = BulkChangeOperationalIntentReferencesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_bulkpb_bulk_service_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkChangeOperationalIntentReferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkChangeOperationalIntentReferencesRequest) ProtoMessage() {}
+
+func (x *BulkChangeOperationalIntentReferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_bulkpb_bulk_service_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkChangeOperationalIntentReferencesRequest.ProtoReflect.Descriptor instead.
+func (*BulkChangeOperationalIntentReferencesRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_bulkpb_bulk_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BulkChangeOperationalIntentReferencesRequest) GetChanges() []*OperationalIntentReferenceChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type BulkChangeOperationalIntentReferencesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Operational intent references resulting from the changes, in the order of
+	// the changes. Deleted references are returned as they were before their
+	// deletion.
+	OperationalIntentReferences []*scdpb.OperationalIntentReference `protobuf:"bytes,1,rep,name=operational_intent_references,json=operationalIntentReferences,proto3" json:"operational_intent_references,omitempty"`
+	// DSS subscribers that this client now has the obligation to notify of the
+	// changes just made, with the notification index resulting from all the
+	// changes.
+	Subscribers []*scdpb.SubscriberToNotify `protobuf:"bytes,2,rep,name=subscribers,proto3" json:"subscribers,omitempty"`
+}
+
+func (x *BulkChangeOperationalIntentReferencesResponse) Reset() {
+	*x = BulkChangeOperationalIntentReferencesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_bulkpb_bulk_service_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkChangeOperationalIntentReferencesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkChangeOperationalIntentReferencesResponse) ProtoMessage() {}
+
+func (x *BulkChangeOperationalIntentReferencesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_bulkpb_bulk_service_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkChangeOperationalIntentReferencesResponse.ProtoReflect.Descriptor instead.
+func (*BulkChangeOperationalIntentReferencesResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_bulkpb_bulk_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BulkChangeOperationalIntentReferencesResponse) GetOperationalIntentReferences() []*scdpb.OperationalIntentReference {
+	if x != nil {
+		return x.OperationalIntentReferences
+	}
+	return nil
+}
+
+func (x *BulkChangeOperationalIntentReferencesResponse) GetSubscribers() []*scdpb.SubscriberToNotify {
+	if x != nil {
+		return x.Subscribers
+	}
+	return nil
+}
+
+// A creation, update or deletion of a constraint reference.
+type ConstraintReferenceChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// EntityID of the constraint.
+	Entityid string `protobuf:"bytes,1,opt,name=entityid,proto3" json:"entityid,omitempty"`
+	// Opaque version number of the existing constraint reference, or empty to
+	// create it.
+	Ovn string `protobuf:"bytes,2,opt,name=ovn,proto3" json:"ovn,omitempty"`
+	// If true, the constraint reference is deleted and params must not be set.
+	Delete bool `protobuf:"varint,3,opt,name=delete,proto3" json:"delete,omitempty"`
+	// Parameters of the created or updated constraint reference.
+	Params *scdpb.PutConstraintReferenceParameters `protobuf:"bytes,4,opt,name=params,proto3" json:"params,omitempty"`
+}
+
+func (x *ConstraintReferenceChange) Reset() {
+	*x = ConstraintReferenceChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_bulkpb_bulk_service_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConstraintReferenceChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConstraintReferenceChange) ProtoMessage() {}
+
+func (x *ConstraintReferenceChange) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_bulkpb_bulk_service_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConstraintReferenceChange.ProtoReflect.Descriptor instead.
+func (*ConstraintReferenceChange) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_bulkpb_bulk_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ConstraintReferenceChange) GetEntityid() string {
+	if x != nil {
+		return x.Entityid
+	}
+	return ""
+}
+
+func (x *ConstraintReferenceChange) GetOvn() string {
+	if x != nil {
+		return x.Ovn
+	}
+	return ""
+}
+
+func (x *ConstraintReferenceChange) GetDelete() bool {
+	if x != nil {
+		return x.Delete
+	}
+	return false
+}
+
+func (x *ConstraintReferenceChange) GetParams() *scdpb.PutConstraintReferenceParameters {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+type BulkChangeConstraintReferencesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Changes to apply, in order. A constraint may only be changed once per
+	// request.
+	Changes []*ConstraintReferenceChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+}
+
+func (x *BulkChangeConstraintReferencesRequest) Reset() {
+	*x = BulkChangeConstraintReferencesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_bulkpb_bulk_service_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkChangeConstraintReferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkChangeConstraintReferencesRequest) ProtoMessage() {}
+
+func (x *BulkChangeConstraintReferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_bulkpb_bulk_service_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkChangeConstraintReferencesRequest.ProtoReflect.Descriptor instead.
+func (*BulkChangeConstraintReferencesRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_bulkpb_bulk_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BulkChangeConstraintReferencesRequest) GetChanges() []*ConstraintReferenceChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type BulkChangeConstraintReferencesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Constraint references resulting from the changes, in the order of the
+	// changes. Deleted references are returned as they were before their
+	// deletion.
+	ConstraintReferences []*scdpb.ConstraintReference `protobuf:"bytes,1,rep,name=constraint_references,json=constraintReferences,proto3" json:"constraint_references,omitempty"`
+	// DSS subscribers that this client now has the obligation to notify of the
+	// changes just made, with the notification index resulting from all the
+	// changes.
+	Subscribers []*scdpb.SubscriberToNotify `protobuf:"bytes,2,rep,name=subscribers,proto3" json:"subscribers,omitempty"`
+}
+
+func (x *BulkChangeConstraintReferencesResponse) Reset() {
+	*x = BulkChangeConstraintReferencesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_bulkpb_bulk_service_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkChangeConstraintReferencesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkChangeConstraintReferencesResponse) ProtoMessage() {}
+
+func (x *BulkChangeConstraintReferencesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_bulkpb_bulk_service_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkChangeConstraintReferencesResponse.ProtoReflect.Descriptor instead.
+func (*BulkChangeConstraintReferencesResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_bulkpb_bulk_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BulkChangeConstraintReferencesResponse) GetConstraintReferences() []*scdpb.ConstraintReference {
+	if x != nil {
+		return x.ConstraintReferences
+	}
+	return nil
+}
+
+func (x *BulkChangeConstraintReferencesResponse) GetSubscribers() []*scdpb.SubscriberToNotify {
+	if x != nil {
+		return x.Subscribers
+	}
+	return nil
+}
+
+var File_pkg_api_v1_bulkpb_bulk_service_proto protoreflect.FileDescriptor
+
+var file_pkg_api_v1_bulkpb_bulk_service_proto_rawDesc = []byte{
+	0x0a, 0x24, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x75, 0x6c,
+	0x6b, 0x70, 0x62, 0x2f, 0x62, 0x75, 0x6c, 0x6b, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x62, 0x75, 0x6c, 0x6b, 0x70, 0x62, 0x1a, 0x1c,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1a, 0x70, 0x6b,
+	0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x63, 0x64, 0x70, 0x62, 0x2f, 0x73,
+	0x63, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb0, 0x01, 0x0a, 0x20, 0x4f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x76, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f, 0x76, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x64,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x12, 0x46, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x73, 0x63, 0x64, 0x70, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x4f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74,
+	0x65, 0x72, 0x73, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0x72, 0x0a, 0x2c, 0x42,
+	0x75, 0x6c, 0x6b, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x42, 0x0a, 0x07, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x62,
+	0x75, 0x6c, 0x6b, 0x70, 0x62, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61,
+	0x6c, 0x49, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22,
+	0xd3, 0x01, 0x0a, 0x2d, 0x42, 0x75, 0x6c, 0x6b, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x52,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x65, 0x0a, 0x1d, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c,
+	0x5f, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x73, 0x63, 0x64, 0x70, 0x62,
+	0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x1b, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x3b, 0x0a, 0x0b, 0x73, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x73, 0x63, 0x64, 0x70, 0x62, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72,
+	0x54, 0x6f, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x52, 0x0b, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x62, 0x65, 0x72, 0x73, 0x22, 0xa2, 0x01, 0x0a, 0x19, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72,
+	0x61, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x69, 0x64, 0x12,
+	0x10, 0x0a, 0x03, 0x6f, 0x76, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f, 0x76,
+	0x6e, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x3f, 0x0a, 0x06, 0x70, 0x61, 0x72,
+	0x61, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x73, 0x63, 0x64, 0x70,
+	0x62, 0x2e, 0x50, 0x75, 0x74, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x52,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65,
+	0x72, 0x73, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0x64, 0x0a, 0x25, 0x42, 0x75,
+	0x6c, 0x6b, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69,
+	0x6e, 0x74, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x3b, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x62, 0x75, 0x6c, 0x6b, 0x70, 0x62, 0x2e, 0x43, 0x6f,
+	0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73,
+	0x22, 0xb6, 0x01, 0x0a, 0x26, 0x42, 0x75, 0x6c, 0x6b, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x43,
+	0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x15, 0x63,
+	0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x63, 0x64,
+	0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x14, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69,
+	0x6e, 0x74, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x3b, 0x0a, 0x0b,
+	0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x73, 0x63, 0x64, 0x70, 0x62, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x62, 0x65, 0x72, 0x54, 0x6f, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x52, 0x0b, 0x73, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x73, 0x32, 0x8f, 0x03, 0x0a, 0x0e, 0x44, 0x53,
+	0x53, 0x42, 0x75, 0x6c, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0xcb, 0x01, 0x0a,
+	0x25, 0x42, 0x75, 0x6c, 0x6b, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x34, 0x2e, 0x62, 0x75, 0x6c, 0x6b, 0x70, 0x62, 0x2e,
+	0x42, 0x75, 0x6c, 0x6b, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x35, 0x2e, 0x62,
+	0x75, 0x6c, 0x6b, 0x70, 0x62, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x35, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x2f, 0x3a, 0x01, 0x2a, 0x22, 0x2a,
+	0x2f, 0x64, 0x73, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x61, 0x6c, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x73, 0x2f, 0x62, 0x75, 0x6c, 0x6b, 0x12, 0xae, 0x01, 0x0a, 0x1e, 0x42,
+	0x75, 0x6c, 0x6b, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61,
+	0x69, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x2d, 0x2e,
+	0x62, 0x75, 0x6c, 0x6b, 0x70, 0x62, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x62,
+	0x75, 0x6c, 0x6b, 0x70, 0x62, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2d, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x27, 0x3a, 0x01, 0x2a, 0x22, 0x22, 0x2f, 0x64, 0x73, 0x73, 0x2f, 0x76, 0x31,
+	0x2f, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x2f, 0x62, 0x75, 0x6c, 0x6b, 0x42, 0x13, 0x5a, 0x11, 0x70,
+	0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x75, 0x6c, 0x6b, 0x70, 0x62,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_pkg_api_v1_bulkpb_bulk_service_proto_rawDescOnce sync.Once
+	file_pkg_api_v1_bulkpb_bulk_service_proto_rawDescData = file_pkg_api_v1_bulkpb_bulk_service_proto_rawDesc
+)
+
+func file_pkg_api_v1_bulkpb_bulk_service_proto_rawDescGZIP() []byte {
+	file_pkg_api_v1_bulkpb_bulk_service_proto_rawDescOnce.Do(func() {
+		file_pkg_api_v1_bulkpb_bulk_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_pkg_api_v1_bulkpb_bulk_service_proto_rawDescData)
+	})
+	return file_pkg_api_v1_bulkpb_bulk_service_proto_rawDescData
+}
+
+var file_pkg_api_v1_bulkpb_bulk_service_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_pkg_api_v1_bulkpb_bulk_service_proto_goTypes = []interface{}{
+	(*OperationalIntentReferenceChange)(nil),              // 0: bulkpb.OperationalIntentReferenceChange
+	(*BulkChangeOperationalIntentReferencesRequest)(nil),  // 1: bulkpb.BulkChangeOperationalIntentReferencesRequest
+	(*BulkChangeOperationalIntentReferencesResponse)(nil), // 2: bulkpb.BulkChangeOperationalIntentReferencesResponse
+	(*ConstraintReferenceChange)(nil),                     // 3: bulkpb.ConstraintReferenceChange
+	(*BulkChangeConstraintReferencesRequest)(nil),         // 4: bulkpb.BulkChangeConstraintReferencesRequest
+	(*BulkChangeConstraintReferencesResponse)(nil),        // 5: bulkpb.BulkChangeConstraintReferencesResponse
+	(*scdpb.PutOperationalIntentReferenceParameters)(nil), // 6: scdpb.PutOperationalIntentReferenceParameters
+	(*scdpb.OperationalIntentReference)(nil),              // 7: scdpb.OperationalIntentReference
+	(*scdpb.SubscriberToNotify)(nil),                      // 8: scdpb.SubscriberToNotify
+	(*scdpb.PutConstraintReferenceParameters)(nil),        // 9: scdpb.PutConstraintReferenceParameters
+	(*scdpb.ConstraintReference)(nil),                     // 10: scdpb.ConstraintReference
+}
+var file_pkg_api_v1_bulkpb_bulk_service_proto_depIdxs = []int32{
+	6,  // 0: bulkpb.OperationalIntentReferenceChange.params:type_name -> scdpb.PutOperationalIntentReferenceParameters
+	0,  // 1: bulkpb.BulkChangeOperationalIntentReferencesRequest.changes:type_name -> bulkpb.OperationalIntentReferenceChange
+	7,  // 2: bulkpb.BulkChangeOperationalIntentReferencesResponse.operational_intent_references:type_name -> scdpb.OperationalIntentReference
+	8,  // 3: bulkpb.BulkChangeOperationalIntentReferencesResponse.subscribers:type_name -> scdpb.SubscriberToNotify
+	9,  // 4: bulkpb.ConstraintReferenceChange.params:type_name -> scdpb.PutConstraintReferenceParameters
+	3,  // 5: bulkpb.BulkChangeConstraintReferencesRequest.changes:type_name -> bulkpb.ConstraintReferenceChange
+	10, // 6: bulkpb.BulkChangeConstraintReferencesResponse.constraint_references:type_name -> scdpb.ConstraintReference
+	8,  // 7: bulkpb.BulkChangeConstraintReferencesResponse.subscribers:type_name -> scdpb.SubscriberToNotify
+	1,  // 8: bulkpb.DSSBulkService.BulkChangeOperationalIntentReferences:input_type -> bulkpb.BulkChangeOperationalIntentReferencesRequest
+	4,  // 9: bulkpb.DSSBulkService.BulkChangeConstraintReferences:input_type -> bulkpb.BulkChangeConstraintReferencesRequest
+	2,  // 10: bulkpb.DSSBulkService.BulkChangeOperationalIntentReferences:output_type -> bulkpb.BulkChangeOperationalIntentReferencesResponse
+	5,  // 11: bulkpb.DSSBulkService.BulkChangeConstraintReferences:output_type -> bulkpb.BulkChangeConstraintReferencesResponse
+	10, // [10:12] is the sub-list for method output_type
+	8,  // [8:10] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_pkg_api_v1_bulkpb_bulk_service_proto_init() }
+func file_pkg_api_v1_bulkpb_bulk_service_proto_init() {
+	if File_pkg_api_v1_bulkpb_bulk_service_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_pkg_api_v1_bulkpb_bulk_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OperationalIntentReferenceChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_bulkpb_bulk_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkChangeOperationalIntentReferencesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_bulkpb_bulk_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkChangeOperationalIntentReferencesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_bulkpb_bulk_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConstraintReferenceChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_bulkpb_bulk_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkChangeConstraintReferencesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_bulkpb_bulk_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkChangeConstraintReferencesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_pkg_api_v1_bulkpb_bulk_service_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_api_v1_bulkpb_bulk_service_proto_goTypes,
+		DependencyIndexes: file_pkg_api_v1_bulkpb_bulk_service_proto_depIdxs,
+		MessageInfos:      file_pkg_api_v1_bulkpb_bulk_service_proto_msgTypes,
+	}.Build()
+	File_pkg_api_v1_bulkpb_bulk_service_proto = out.File
+	file_pkg_api_v1_bulkpb_bulk_service_proto_rawDesc = nil
+	file_pkg_api_v1_bulkpb_bulk_service_proto_goTypes = nil
+	file_pkg_api_v1_bulkpb_bulk_service_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// DSSBulkServiceClient is the client API for DSSBulkService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DSSBulkServiceClient interface {
+	// Applies several changes to operational intent references of the caller.
+	// When validating the key of a change, the OVNs in the keys of all the
+	// changes and the new OVNs of the entities changed by the request are
+	// considered known to the caller.
+	BulkChangeOperationalIntentReferences(ctx context.Context, in *BulkChangeOperationalIntentReferencesRequest, opts ...grpc.CallOption) (*BulkChangeOperationalIntentReferencesResponse, error)
+	// Applies several changes to constraint references of the caller.
+	BulkChangeConstraintReferences(ctx context.Context, in *BulkChangeConstraintReferencesRequest, opts ...grpc.CallOption) (*BulkChangeConstraintReferencesResponse, error)
+}
+
+type dSSBulkServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDSSBulkServiceClient(cc grpc.ClientConnInterface) DSSBulkServiceClient {
+	return &dSSBulkServiceClient{cc}
+}
+
+func (c *dSSBulkServiceClient) BulkChangeOperationalIntentReferences(ctx context.Context, in *BulkChangeOperationalIntentReferencesRequest, opts ...grpc.CallOption) (*BulkChangeOperationalIntentReferencesResponse, error) {
+	out := new(BulkChangeOperationalIntentReferencesResponse)
+	err := c.cc.Invoke(ctx, "/bulkpb.DSSBulkService/BulkChangeOperationalIntentReferences", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dSSBulkServiceClient) BulkChangeConstraintReferences(ctx context.Context, in *BulkChangeConstraintReferencesRequest, opts ...grpc.CallOption) (*BulkChangeConstraintReferencesResponse, error) {
+	out := new(BulkChangeConstraintReferencesResponse)
+	err := c.cc.Invoke(ctx, "/bulkpb.DSSBulkService/BulkChangeConstraintReferences", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DSSBulkServiceServer is the server API for DSSBulkService service.
+type DSSBulkServiceServer interface {
+	// Applies several changes to operational intent references of the caller.
+	// When validating the key of a change, the OVNs in the keys of all the
+	// changes and the new OVNs of the entities changed by the request are
+	// considered known to the caller.
+	BulkChangeOperationalIntentReferences(context.Context, *BulkChangeOperationalIntentReferencesRequest) (*BulkChangeOperationalIntentReferencesResponse, error)
+	// Applies several changes to constraint references of the caller.
+	BulkChangeConstraintReferences(context.Context, *BulkChangeConstraintReferencesRequest) (*BulkChangeConstraintReferencesResponse, error)
+}
+
+// UnimplementedDSSBulkServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedDSSBulkServiceServer struct {
+}
+
+func (*UnimplementedDSSBulkServiceServer) BulkChangeOperationalIntentReferences(context.Context, *BulkChangeOperationalIntentReferencesRequest) (*BulkChangeOperationalIntentReferencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkChangeOperationalIntentReferences not implemented")
+}
+func (*UnimplementedDSSBulkServiceServer) BulkChangeConstraintReferences(context.Context, *BulkChangeConstraintReferencesRequest) (*BulkChangeConstraintReferencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkChangeConstraintReferences not implemented")
+}
+
+func RegisterDSSBulkServiceServer(s *grpc.Server, srv DSSBulkServiceServer) {
+	s.RegisterService(&_DSSBulkService_serviceDesc, srv)
+}
+
+func _DSSBulkService_BulkChangeOperationalIntentReferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkChangeOperationalIntentReferencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DSSBulkServiceServer).BulkChangeOperationalIntentReferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bulkpb.DSSBulkService/BulkChangeOperationalIntentReferences",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DSSBulkServiceServer).BulkChangeOperationalIntentReferences(ctx, req.(*BulkChangeOperationalIntentReferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DSSBulkService_BulkChangeConstraintReferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkChangeConstraintReferencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DSSBulkServiceServer).BulkChangeConstraintReferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bulkpb.DSSBulkService/BulkChangeConstraintReferences",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DSSBulkServiceServer).BulkChangeConstraintReferences(ctx, req.(*BulkChangeConstraintReferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _DSSBulkService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "bulkpb.DSSBulkService",
+	HandlerType: (*DSSBulkServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "BulkChangeOperationalIntentReferences",
+			Handler:    _DSSBulkService_BulkChangeOperationalIntentReferences_Handler,
+		},
+		{
+			MethodName: "BulkChangeConstraintReferences",
+			Handler:    _DSSBulkService_BulkChangeConstraintReferences_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/api/v1/bulkpb/bulk_service.proto",
+}
@@ -0,0 +1,437 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.22.0
+// 	protoc        v3.11.2
+// source: pkg/api/v1/historypb/history_service.proto
+
+package historypb
+
+import (
+	context "context"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+// A change of the state of an operational intent.
+type StateTransition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// State of the operational intent before the change, or empty if the
+	// operational intent was created.
+	FromState string `protobuf:"bytes,1,opt,name=from_state,json=fromState,proto3" json:"from_state,omitempty"`
+	// State of the operational intent after the change, or `Ended` if the
+	// operational intent was deleted.
+	ToState string `protobuf:"bytes,2,opt,name=to_state,json=toState,proto3" json:"to_state,omitempty"`
+	// Version of the operational intent resulting from the change, or the
+	// deleted version.
+	Version int32 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	// Time at which the change was committed.
+	RecordedAt *timestamp.Timestamp `protobuf:"bytes,4,opt,name=recorded_at,json=recordedAt,proto3" json:"recorded_at,omitempty"`
+}
+
+func (x *StateTransition) Reset() {
+	*x = StateTransition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_historypb_history_service_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StateTransition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateTransition) ProtoMessage() {}
+
+func (x *StateTransition) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_historypb_history_service_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateTransition.ProtoReflect.Descriptor instead.
+func (*StateTransition) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_historypb_history_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StateTransition) GetFromState() string {
+	if x != nil {
+		return x.FromState
+	}
+	return ""
+}
+
+func (x *StateTransition) GetToState() string {
+	if x != nil {
+		return x.ToState
+	}
+	return ""
+}
+
+func (x *StateTransition) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *StateTransition) GetRecordedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.RecordedAt
+	}
+	return nil
+}
+
+type GetOperationalIntentStateHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// EntityID of the operational intent.
+	Entityid string `protobuf:"bytes,1,opt,name=entityid,proto3" json:"entityid,omitempty"`
+}
+
+func (x *GetOperationalIntentStateHistoryRequest) Reset() {
+	*x = GetOperationalIntentStateHistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_historypb_history_service_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetOperationalIntentStateHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOperationalIntentStateHistoryRequest) ProtoMessage() {}
+
+func (x *GetOperationalIntentStateHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_historypb_history_service_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOperationalIntentStateHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetOperationalIntentStateHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_historypb_history_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetOperationalIntentStateHistoryRequest) GetEntityid() string {
+	if x != nil {
+		return x.Entityid
+	}
+	return ""
+}
+
+type GetOperationalIntentStateHistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// State transitions of the operational intent, in chronological order.
+	StateTransitions []*StateTransition `protobuf:"bytes,1,rep,name=state_transitions,json=stateTransitions,proto3" json:"state_transitions,omitempty"`
+}
+
+func (x *GetOperationalIntentStateHistoryResponse) Reset() {
+	*x = GetOperationalIntentStateHistoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_historypb_history_service_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetOperationalIntentStateHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOperationalIntentStateHistoryResponse) ProtoMessage() {}
+
+func (x *GetOperationalIntentStateHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_historypb_history_service_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOperationalIntentStateHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetOperationalIntentStateHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_historypb_history_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetOperationalIntentStateHistoryResponse) GetStateTransitions() []*StateTransition {
+	if x != nil {
+		return x.StateTransitions
+	}
+	return nil
+}
+
+var File_pkg_api_v1_historypb_history_service_proto protoreflect.FileDescriptor
+
+var file_pkg_api_v1_historypb_history_service_proto_rawDesc = []byte{
+	0x0a, 0x2a, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x68, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x70, 0x62, 0x2f, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x68, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x70, 0x62, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa2, 0x01, 0x0a, 0x0f, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x72,
+	0x6f, 0x6d, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x66, 0x72, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x6f, 0x5f,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x6f, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3b,
+	0x0a, 0x0b, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x0a, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x64, 0x41, 0x74, 0x22, 0x45, 0x0a, 0x27, 0x47,
+	0x65, 0x74, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x69, 0x64, 0x22, 0x73, 0x0a, 0x28, 0x47, 0x65, 0x74, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47,
+	0x0a, 0x11, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x68, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x10, 0x73, 0x74, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x32, 0xe9, 0x01, 0x0a, 0x11, 0x44, 0x53, 0x53, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0xd3, 0x01,
+	0x0a, 0x20, 0x47, 0x65, 0x74, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c,
+	0x49, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x12, 0x32, 0x2e, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x70, 0x62, 0x2e, 0x47,
+	0x65, 0x74, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x33, 0x2e, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61,
+	0x6c, 0x49, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x46, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x40, 0x12, 0x3e, 0x2f, 0x64, 0x73, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f,
+	0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x2f, 0x7b, 0x65, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x69, 0x64, 0x7d, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x68, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x42, 0x16, 0x5a, 0x14, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76,
+	0x31, 0x2f, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_pkg_api_v1_historypb_history_service_proto_rawDescOnce sync.Once
+	file_pkg_api_v1_historypb_history_service_proto_rawDescData = file_pkg_api_v1_historypb_history_service_proto_rawDesc
+)
+
+func file_pkg_api_v1_historypb_history_service_proto_rawDescGZIP() []byte {
+	file_pkg_api_v1_historypb_history_service_proto_rawDescOnce.Do(func() {
+		file_pkg_api_v1_historypb_history_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_pkg_api_v1_historypb_history_service_proto_rawDescData)
+	})
+	return file_pkg_api_v1_historypb_history_service_proto_rawDescData
+}
+
+var file_pkg_api_v1_historypb_history_service_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_pkg_api_v1_historypb_history_service_proto_goTypes = []interface{}{
+	(*StateTransition)(nil),                          // 0: historypb.StateTransition
+	(*GetOperationalIntentStateHistoryRequest)(nil),  // 1: historypb.GetOperationalIntentStateHistoryRequest
+	(*GetOperationalIntentStateHistoryResponse)(nil), // 2: historypb.GetOperationalIntentStateHistoryResponse
+	(*timestamp.Timestamp)(nil),                      // 3: google.protobuf.Timestamp
+}
+var file_pkg_api_v1_historypb_history_service_proto_depIdxs = []int32{
+	3, // 0: historypb.StateTransition.recorded_at:type_name -> google.protobuf.Timestamp
+	0, // 1: historypb.GetOperationalIntentStateHistoryResponse.state_transitions:type_name -> historypb.StateTransition
+	1, // 2: historypb.DSSHistoryService.GetOperationalIntentStateHistory:input_type -> historypb.GetOperationalIntentStateHistoryRequest
+	2, // 3: historypb.DSSHistoryService.GetOperationalIntentStateHistory:output_type -> historypb.GetOperationalIntentStateHistoryResponse
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_pkg_api_v1_historypb_history_service_proto_init() }
+func file_pkg_api_v1_historypb_history_service_proto_init() {
+	if File_pkg_api_v1_historypb_history_service_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_pkg_api_v1_historypb_history_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StateTransition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_historypb_history_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetOperationalIntentStateHistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_historypb_history_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetOperationalIntentStateHistoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_pkg_api_v1_historypb_history_service_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_api_v1_historypb_history_service_proto_goTypes,
+		DependencyIndexes: file_pkg_api_v1_historypb_history_service_proto_depIdxs,
+		MessageInfos:      file_pkg_api_v1_historypb_history_service_proto_msgTypes,
+	}.Build()
+	File_pkg_api_v1_historypb_history_service_proto = out.File
+	file_pkg_api_v1_historypb_history_service_proto_rawDesc = nil
+	file_pkg_api_v1_historypb_history_service_proto_goTypes = nil
+	file_pkg_api_v1_historypb_history_service_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// DSSHistoryServiceClient is the client API for DSSHistoryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DSSHistoryServiceClient interface {
+	// Returns the state transitions of an operational intent of the caller,
+	// including after its deletion.
+	GetOperationalIntentStateHistory(ctx context.Context, in *GetOperationalIntentStateHistoryRequest, opts ...grpc.CallOption) (*GetOperationalIntentStateHistoryResponse, error)
+}
+
+type dSSHistoryServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDSSHistoryServiceClient(cc grpc.ClientConnInterface) DSSHistoryServiceClient {
+	return &dSSHistoryServiceClient{cc}
+}
+
+func (c *dSSHistoryServiceClient) GetOperationalIntentStateHistory(ctx context.Context, in *GetOperationalIntentStateHistoryRequest, opts ...grpc.CallOption) (*GetOperationalIntentStateHistoryResponse, error) {
+	out := new(GetOperationalIntentStateHistoryResponse)
+	err := c.cc.Invoke(ctx, "/historypb.DSSHistoryService/GetOperationalIntentStateHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DSSHistoryServiceServer is the server API for DSSHistoryService service.
+type DSSHistoryServiceServer interface {
+	// Returns the state transitions of an operational intent of the caller,
+	// including after its deletion.
+	GetOperationalIntentStateHistory(context.Context, *GetOperationalIntentStateHistoryRequest) (*GetOperationalIntentStateHistoryResponse, error)
+}
+
+// UnimplementedDSSHistoryServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedDSSHistoryServiceServer struct {
+}
+
+func (*UnimplementedDSSHistoryServiceServer) GetOperationalIntentStateHistory(context.Context, *GetOperationalIntentStateHistoryRequest) (*GetOperationalIntentStateHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOperationalIntentStateHistory not implemented")
+}
+
+func RegisterDSSHistoryServiceServer(s *grpc.Server, srv DSSHistoryServiceServer) {
+	s.RegisterService(&_DSSHistoryService_serviceDesc, srv)
+}
+
+func _DSSHistoryService_GetOperationalIntentStateHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOperationalIntentStateHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DSSHistoryServiceServer).GetOperationalIntentStateHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/historypb.DSSHistoryService/GetOperationalIntentStateHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DSSHistoryServiceServer).GetOperationalIntentStateHistory(ctx, req.(*GetOperationalIntentStateHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _DSSHistoryService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "historypb.DSSHistoryService",
+	HandlerType: (*DSSHistoryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetOperationalIntentStateHistory",
+			Handler:    _DSSHistoryService_GetOperationalIntentStateHistory_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/api/v1/historypb/history_service.proto",
+}
@@ -0,0 +1,183 @@
+// Code generated by protoc-gen-grpc-gateway. DO NOT EDIT.
+// source: pkg/api/v1/historypb/history_service.proto
+
+/*
+Package historypb is a reverse proxy.
+
+It translates gRPC into RESTful JSON APIs.
+*/
+package historypb
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/golang/protobuf/descriptor"
+	"github.com/golang/protobuf/proto"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/utilities"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/status"
+)
+
+// Suppress "imported and not used" errors
+var _ codes.Code
+var _ io.Reader
+var _ status.Status
+var _ = runtime.String
+var _ = utilities.NewDoubleArray
+var _ = descriptor.ForMessage
+
+func request_DSSHistoryService_GetOperationalIntentStateHistory_0(ctx context.Context, marshaler runtime.Marshaler, client DSSHistoryServiceClient, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
+	var protoReq GetOperationalIntentStateHistoryRequest
+	var metadata runtime.ServerMetadata
+
+	var (
+		val string
+		ok  bool
+		err error
+		_   = err
+	)
+
+	val, ok = pathParams["entityid"]
+	if !ok {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "entityid")
+	}
+
+	protoReq.Entityid, err = runtime.String(val)
+
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "entityid", err)
+	}
+
+	msg, err := client.GetOperationalIntentStateHistory(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
+	return msg, metadata, err
+
+}
+
+func local_request_DSSHistoryService_GetOperationalIntentStateHistory_0(ctx context.Context, marshaler runtime.Marshaler, server DSSHistoryServiceServer, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
+	var protoReq GetOperationalIntentStateHistoryRequest
+	var metadata runtime.ServerMetadata
+
+	var (
+		val string
+		ok  bool
+		err error
+		_   = err
+	)
+
+	val, ok = pathParams["entityid"]
+	if !ok {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "entityid")
+	}
+
+	protoReq.Entityid, err = runtime.String(val)
+
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "entityid", err)
+	}
+
+	msg, err := server.GetOperationalIntentStateHistory(ctx, &protoReq)
+	return msg, metadata, err
+
+}
+
+// RegisterDSSHistoryServiceHandlerServer registers the http handlers for service DSSHistoryService to "mux".
+// UnaryRPC     :call DSSHistoryServiceServer directly.
+// StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
+func RegisterDSSHistoryServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server DSSHistoryServiceServer) error {
+
+	mux.Handle("GET", pattern_DSSHistoryService_GetOperationalIntentStateHistory_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		rctx, err := runtime.AnnotateIncomingContext(ctx, mux, req)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := local_request_DSSHistoryService_GetOperationalIntentStateHistory_0(rctx, inboundMarshaler, server, req, pathParams)
+		ctx = runtime.NewServerMetadataContext(ctx, md)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		forward_DSSHistoryService_GetOperationalIntentStateHistory_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+
+	})
+
+	return nil
+}
+
+// RegisterDSSHistoryServiceHandlerFromEndpoint is same as RegisterDSSHistoryServiceHandler but
+// automatically dials to "endpoint" and closes the connection when "ctx" gets done.
+func RegisterDSSHistoryServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
+	conn, err := grpc.Dial(endpoint, opts...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if cerr := conn.Close(); cerr != nil {
+				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
+			}
+			return
+		}
+		go func() {
+			<-ctx.Done()
+			if cerr := conn.Close(); cerr != nil {
+				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
+			}
+		}()
+	}()
+
+	return RegisterDSSHistoryServiceHandler(ctx, mux, conn)
+}
+
+// RegisterDSSHistoryServiceHandler registers the http handlers for service DSSHistoryService to "mux".
+// The handlers forward requests to the grpc endpoint over "conn".
+func RegisterDSSHistoryServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
+	return RegisterDSSHistoryServiceHandlerClient(ctx, mux, NewDSSHistoryServiceClient(conn))
+}
+
+// RegisterDSSHistoryServiceHandlerClient registers the http handlers for service DSSHistoryService
+// to "mux". The handlers forward requests to the grpc endpoint over the given implementation of "DSSHistoryServiceClient".
+// Note: the gRPC framework executes interceptors within the gRPC handler. If the passed in "DSSHistoryServiceClient"
+// doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
+// "DSSHistoryServiceClient" to call the correct interceptors.
+func RegisterDSSHistoryServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client DSSHistoryServiceClient) error {
+
+	mux.Handle("GET", pattern_DSSHistoryService_GetOperationalIntentStateHistory_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		rctx, err := runtime.AnnotateContext(ctx, mux, req)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := request_DSSHistoryService_GetOperationalIntentStateHistory_0(rctx, inboundMarshaler, client, req, pathParams)
+		ctx = runtime.NewServerMetadataContext(ctx, md)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		forward_DSSHistoryService_GetOperationalIntentStateHistory_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+
+	})
+
+	return nil
+}
+
+var (
+	pattern_DSSHistoryService_GetOperationalIntentStateHistory_0 = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 2, 2, 1, 0, 4, 1, 5, 3, 2, 4}, []string{"dss", "v1", "operational_intent_references", "entityid", "state_history"}, "", runtime.AssumeColonVerbOpt(true)))
+)
+
+var (
+	forward_DSSHistoryService_GetOperationalIntentStateHistory_0 = runtime.ForwardResponseMessage
+)
@@ -0,0 +1,441 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.22.0
+// 	protoc        v3.11.2
+// source: pkg/api/v1/watchpb/watch_service.proto
+
+package watchpb
+
+import (
+	context "context"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+// Parameters of a request to watch the changes relevant to a subscription.
+type WatchSubscriptionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ID of the remote ID or strategic conflict detection subscription to
+	// watch. The subscription must be owned by the caller.
+	SubscriptionId string `protobuf:"bytes,1,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	// If set, the stream resumes with the first change following the one which
+	// set the notification index of the subscription to this value. Otherwise,
+	// only changes occurring after the start of the stream are sent.
+	AfterNotificationIndex *wrapperspb.Int32Value `protobuf:"bytes,2,opt,name=after_notification_index,json=afterNotificationIndex,proto3" json:"after_notification_index,omitempty"`
+}
+
+func (x *WatchSubscriptionRequest) Reset() {
+	*x = WatchSubscriptionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_watchpb_watch_service_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchSubscriptionRequest) ProtoMessage() {}
+
+func (x *WatchSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_watchpb_watch_service_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*WatchSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_watchpb_watch_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WatchSubscriptionRequest) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+func (x *WatchSubscriptionRequest) GetAfterNotificationIndex() *wrapperspb.Int32Value {
+	if x != nil {
+		return x.AfterNotificationIndex
+	}
+	return nil
+}
+
+// A change of an entity which incremented the notification index of a
+// subscription.
+type SubscriptionEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ID of the subscription notified of the change.
+	SubscriptionId string `protobuf:"bytes,1,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	// Notification index of the subscription resulting from the change.
+	NotificationIndex int32 `protobuf:"varint,2,opt,name=notification_index,json=notificationIndex,proto3" json:"notification_index,omitempty"`
+	// Type of the changed entity, e.g. `operational_intent` or
+	// `identification_service_area`.
+	EntityType string `protobuf:"bytes,3,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	// ID of the changed entity.
+	EntityId string `protobuf:"bytes,4,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	// Version (or OVN) of the entity after the change, empty if the entity was
+	// deleted.
+	Version string `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	// Whether the entity was deleted.
+	Deleted bool `protobuf:"varint,6,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	// Time at which the change was recorded by the DSS.
+	RecordedAt *timestamp.Timestamp `protobuf:"bytes,7,opt,name=recorded_at,json=recordedAt,proto3" json:"recorded_at,omitempty"`
+}
+
+func (x *SubscriptionEvent) Reset() {
+	*x = SubscriptionEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_watchpb_watch_service_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscriptionEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriptionEvent) ProtoMessage() {}
+
+func (x *SubscriptionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_watchpb_watch_service_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriptionEvent.ProtoReflect.Descriptor instead.
+func (*SubscriptionEvent) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_watchpb_watch_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SubscriptionEvent) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+func (x *SubscriptionEvent) GetNotificationIndex() int32 {
+	if x != nil {
+		return x.NotificationIndex
+	}
+	return 0
+}
+
+func (x *SubscriptionEvent) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *SubscriptionEvent) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *SubscriptionEvent) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *SubscriptionEvent) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *SubscriptionEvent) GetRecordedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.RecordedAt
+	}
+	return nil
+}
+
+var File_pkg_api_v1_watchpb_watch_service_proto protoreflect.FileDescriptor
+
+var file_pkg_api_v1_watchpb_watch_service_proto_rawDesc = []byte{
+	0x0a, 0x26, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x77, 0x61, 0x74,
+	0x63, 0x68, 0x70, 0x62, 0x2f, 0x77, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x77, 0x61, 0x74, 0x63, 0x68, 0x70,
+	0x62, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0x9a, 0x01, 0x0a, 0x18, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x27, 0x0a, 0x0f, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x55, 0x0a, 0x18, 0x61, 0x66, 0x74, 0x65,
+	0x72, 0x5f, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74,
+	0x33, 0x32, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x16, 0x61, 0x66, 0x74, 0x65, 0x72, 0x4e, 0x6f,
+	0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22,
+	0x9a, 0x02, 0x0a, 0x11, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x2d,
+	0x0a, 0x12, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x6e, 0x6f, 0x74, 0x69,
+	0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1f, 0x0a,
+	0x0b, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12,
+	0x3b, 0x0a, 0x0b, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x0a, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x64, 0x41, 0x74, 0x32, 0x69, 0x0a, 0x0f,
+	0x44, 0x53, 0x53, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x56, 0x0a, 0x11, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x2e, 0x77, 0x61, 0x74, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x77, 0x61, 0x74, 0x63, 0x68, 0x70,
+	0x62, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x22, 0x00, 0x30, 0x01, 0x42, 0x14, 0x5a, 0x12, 0x70, 0x6b, 0x67, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x77, 0x61, 0x74, 0x63, 0x68, 0x70, 0x62, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_pkg_api_v1_watchpb_watch_service_proto_rawDescOnce sync.Once
+	file_pkg_api_v1_watchpb_watch_service_proto_rawDescData = file_pkg_api_v1_watchpb_watch_service_proto_rawDesc
+)
+
+func file_pkg_api_v1_watchpb_watch_service_proto_rawDescGZIP() []byte {
+	file_pkg_api_v1_watchpb_watch_service_proto_rawDescOnce.Do(func() {
+		file_pkg_api_v1_watchpb_watch_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_pkg_api_v1_watchpb_watch_service_proto_rawDescData)
+	})
+	return file_pkg_api_v1_watchpb_watch_service_proto_rawDescData
+}
+
+var file_pkg_api_v1_watchpb_watch_service_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pkg_api_v1_watchpb_watch_service_proto_goTypes = []interface{}{
+	(*WatchSubscriptionRequest)(nil), // 0: watchpb.WatchSubscriptionRequest
+	(*SubscriptionEvent)(nil),        // 1: watchpb.SubscriptionEvent
+	(*wrapperspb.Int32Value)(nil),    // 2: google.protobuf.Int32Value
+	(*timestamp.Timestamp)(nil),      // 3: google.protobuf.Timestamp
+}
+var file_pkg_api_v1_watchpb_watch_service_proto_depIdxs = []int32{
+	2, // 0: watchpb.WatchSubscriptionRequest.after_notification_index:type_name -> google.protobuf.Int32Value
+	3, // 1: watchpb.SubscriptionEvent.recorded_at:type_name -> google.protobuf.Timestamp
+	0, // 2: watchpb.DSSWatchService.WatchSubscription:input_type -> watchpb.WatchSubscriptionRequest
+	1, // 3: watchpb.DSSWatchService.WatchSubscription:output_type -> watchpb.SubscriptionEvent
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_pkg_api_v1_watchpb_watch_service_proto_init() }
+func file_pkg_api_v1_watchpb_watch_service_proto_init() {
+	if File_pkg_api_v1_watchpb_watch_service_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_pkg_api_v1_watchpb_watch_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchSubscriptionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_watchpb_watch_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriptionEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_pkg_api_v1_watchpb_watch_service_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_api_v1_watchpb_watch_service_proto_goTypes,
+		DependencyIndexes: file_pkg_api_v1_watchpb_watch_service_proto_depIdxs,
+		MessageInfos:      file_pkg_api_v1_watchpb_watch_service_proto_msgTypes,
+	}.Build()
+	File_pkg_api_v1_watchpb_watch_service_proto = out.File
+	file_pkg_api_v1_watchpb_watch_service_proto_rawDesc = nil
+	file_pkg_api_v1_watchpb_watch_service_proto_goTypes = nil
+	file_pkg_api_v1_watchpb_watch_service_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// DSSWatchServiceClient is the client API for DSSWatchService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DSSWatchServiceClient interface {
+	// Streams, in order of notification index, the changes which increment the
+	// notification index of a subscription.
+	WatchSubscription(ctx context.Context, in *WatchSubscriptionRequest, opts ...grpc.CallOption) (DSSWatchService_WatchSubscriptionClient, error)
+}
+
+type dSSWatchServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDSSWatchServiceClient(cc grpc.ClientConnInterface) DSSWatchServiceClient {
+	return &dSSWatchServiceClient{cc}
+}
+
+func (c *dSSWatchServiceClient) WatchSubscription(ctx context.Context, in *WatchSubscriptionRequest, opts ...grpc.CallOption) (DSSWatchService_WatchSubscriptionClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DSSWatchService_serviceDesc.Streams[0], "/watchpb.DSSWatchService/WatchSubscription", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dSSWatchServiceWatchSubscriptionClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DSSWatchService_WatchSubscriptionClient interface {
+	Recv() (*SubscriptionEvent, error)
+	grpc.ClientStream
+}
+
+type dSSWatchServiceWatchSubscriptionClient struct {
+	grpc.ClientStream
+}
+
+func (x *dSSWatchServiceWatchSubscriptionClient) Recv() (*SubscriptionEvent, error) {
+	m := new(SubscriptionEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// DSSWatchServiceServer is the server API for DSSWatchService service.
+type DSSWatchServiceServer interface {
+	// Streams, in order of notification index, the changes which increment the
+	// notification index of a subscription.
+	WatchSubscription(*WatchSubscriptionRequest, DSSWatchService_WatchSubscriptionServer) error
+}
+
+// UnimplementedDSSWatchServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedDSSWatchServiceServer struct {
+}
+
+func (*UnimplementedDSSWatchServiceServer) WatchSubscription(*WatchSubscriptionRequest, DSSWatchService_WatchSubscriptionServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchSubscription not implemented")
+}
+
+func RegisterDSSWatchServiceServer(s *grpc.Server, srv DSSWatchServiceServer) {
+	s.RegisterService(&_DSSWatchService_serviceDesc, srv)
+}
+
+func _DSSWatchService_WatchSubscription_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchSubscriptionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DSSWatchServiceServer).WatchSubscription(m, &dSSWatchServiceWatchSubscriptionServer{stream})
+}
+
+type DSSWatchService_WatchSubscriptionServer interface {
+	Send(*SubscriptionEvent) error
+	grpc.ServerStream
+}
+
+type dSSWatchServiceWatchSubscriptionServer struct {
+	grpc.ServerStream
+}
+
+func (x *dSSWatchServiceWatchSubscriptionServer) Send(m *SubscriptionEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _DSSWatchService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "watchpb.DSSWatchService",
+	HandlerType: (*DSSWatchServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchSubscription",
+			Handler:       _DSSWatchService_WatchSubscription_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pkg/api/v1/watchpb/watch_service.proto",
+}
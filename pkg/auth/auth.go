@@ -29,6 +29,8 @@ import (
 var (
 	// ContextKeyOwner is the key to an owner value.
 	ContextKeyOwner ContextKey = "owner"
+	// ContextKeyScopes is the key to the scopes claimed by an access token.
+	ContextKeyScopes ContextKey = "scopes"
 )
 
 // ContextKey models auth-specific keys in a context.
@@ -54,6 +56,19 @@ func OwnerFromContext(ctx context.Context) (models.Owner, bool) {
 	return owner, ok
 }
 
+// ContextWithScopes adds "scopes" to "ctx".
+func ContextWithScopes(ctx context.Context, scopes ScopeSet) context.Context {
+	return context.WithValue(ctx, ContextKeyScopes, scopes)
+}
+
+// ScopesFromContext returns the scopes claimed by the access token of the
+// request from "ctx" and a boolean indicating whether a valid value was
+// present or not.
+func ScopesFromContext(ctx context.Context) (ScopeSet, bool) {
+	scopes, ok := ctx.Value(ContextKeyScopes).(ScopeSet)
+	return scopes, ok
+}
+
 // ManagerFromContext returns the value for manager from "ctx" and a boolean
 // indicating whether a valid value was present or not.
 func ManagerFromContext(ctx context.Context) (models.Manager, bool) {
@@ -257,8 +272,13 @@ type Authorizer struct {
 	logger            *zap.Logger
 	keys              []interface{}
 	keyGuard          sync.RWMutex
+	keyRefreshErr     error
 	scopesValidators  map[Operation]KeyClaimedScopesValidator
 	acceptedAudiences map[string]bool
+	unauthenticated   map[Operation]bool
+
+	stopKeyRefresh    context.CancelFunc
+	keyRefreshStopped chan struct{}
 }
 
 // Configuration bundles up creation-time parameters for an Authorizer instance.
@@ -267,6 +287,7 @@ type Configuration struct {
 	KeyRefreshTimeout time.Duration                           // Keys are refreshed on this cadence.
 	ScopesValidators  map[Operation]KeyClaimedScopesValidator // ScopesValidators are used to enforce authorization for operations.
 	AcceptedAudiences []string                                // AcceptedAudiences enforces the aud keyClaim on the jwt. An empty string allows no aud keyClaim.
+	Unauthenticated   []Operation                             // Unauthenticated operations are served without an access token.
 }
 
 // NewRSAAuthorizer returns an Authorizer instance using values from configuration.
@@ -283,14 +304,24 @@ func NewRSAAuthorizer(ctx context.Context, configuration Configuration) (*Author
 		auds[s] = true
 	}
 
+	unauthenticated := make(map[Operation]bool)
+	for _, op := range configuration.Unauthenticated {
+		unauthenticated[op] = true
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
 	authorizer := &Authorizer{
 		scopesValidators:  configuration.ScopesValidators,
 		acceptedAudiences: auds,
+		unauthenticated:   unauthenticated,
 		logger:            logger,
 		keys:              keys,
+		stopKeyRefresh:    cancel,
+		keyRefreshStopped: make(chan struct{}),
 	}
 
 	go func() {
+		defer close(authorizer.keyRefreshStopped)
 		ticker := time.NewTicker(configuration.KeyRefreshTimeout)
 		defer ticker.Stop()
 
@@ -299,7 +330,11 @@ func NewRSAAuthorizer(ctx context.Context, configuration Configuration) (*Author
 			case <-ticker.C:
 				keys, err := configuration.KeyResolver.ResolveKeys(ctx)
 				if err != nil {
-					logger.Panic("failed to refresh key", zap.Error(err))
+					// Keep using the previous keys; the failure is surfaced
+					// through KeyRefreshError for health checking.
+					logger.Error("failed to refresh key", zap.Error(err))
+					authorizer.setKeyRefreshError(err)
+					continue
 				}
 
 				authorizer.setKeys(keys)
@@ -313,15 +348,76 @@ func NewRSAAuthorizer(ctx context.Context, configuration Configuration) (*Author
 	return authorizer, nil
 }
 
+// Stop stops refreshing keys, waiting until ctx is done for an ongoing
+// refresh to complete.
+func (a *Authorizer) Stop(ctx context.Context) error {
+	a.stopKeyRefresh()
+	select {
+	case <-a.keyRefreshStopped:
+		return nil
+	case <-ctx.Done():
+		return stacktrace.Propagate(ctx.Err(), "Key refresh did not stop")
+	}
+}
+
 func (a *Authorizer) setKeys(keys []interface{}) {
 	a.keyGuard.Lock()
 	a.keys = keys
+	a.keyRefreshErr = nil
 	a.keyGuard.Unlock()
 }
 
+func (a *Authorizer) setKeyRefreshError(err error) {
+	a.keyGuard.Lock()
+	a.keyRefreshErr = err
+	a.keyGuard.Unlock()
+}
+
+// KeyRefreshError returns the error of the latest attempt to refresh the keys,
+// or nil if it succeeded.
+func (a *Authorizer) KeyRefreshError() error {
+	a.keyGuard.RLock()
+	defer a.keyGuard.RUnlock()
+	return a.keyRefreshErr
+}
+
 // AuthInterceptor intercepts incoming gRPC requests and extracts and verifies
 // accompanying bearer tokens.
 func (a *Authorizer) AuthInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := a.authorize(ctx, Operation(info.FullMethod))
+	if err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	return handler(ctx, req)
+}
+
+// AuthStreamInterceptor is the equivalent of AuthInterceptor for streaming
+// RPCs.
+func (a *Authorizer) AuthStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authorize(ss.Context(), Operation(info.FullMethod))
+	if err != nil {
+		return err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	return handler(srv, &serverStreamWithContext{ServerStream: ss, ctx: ctx})
+}
+
+// serverStreamWithContext overrides the context of a grpc.ServerStream.
+type serverStreamWithContext struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStreamWithContext) Context() context.Context {
+	return s.ctx
+}
+
+// authorize verifies the access token of a request to "op" in "ctx" and
+// returns the context to handle the request with, which contains the owner
+// and the scopes of the token.
+func (a *Authorizer) authorize(ctx context.Context, op Operation) (context.Context, error) {
+	if a.unauthenticated[op] {
+		return ctx, nil
+	}
 
 	tknStr, ok := getToken(ctx)
 	if !ok {
@@ -355,19 +451,19 @@ func (a *Authorizer) AuthInterceptor(ctx context.Context, req interface{}, info
 			"Invalid access token audience: %v", keyClaims.Audience)
 	}
 
-	expectation, err := a.validateKeyClaimedScopes(ctx, info, keyClaims.Scopes)
+	expectation, err := a.validateKeyClaimedScopes(ctx, op, keyClaims.Scopes)
 	if err != nil {
 		return nil, stacktrace.NewErrorWithCode(dsserr.PermissionDenied, "Access token missing scopes; found %v while expecting %v", scopeSetToString(keyClaims.Scopes, ", "), expectation)
 	}
 
-	return handler(ContextWithOwner(ctx, models.Owner(keyClaims.Subject)), req)
+	return ContextWithScopes(ContextWithOwner(ctx, models.Owner(keyClaims.Subject)), keyClaims.Scopes), nil
 }
 
 // Matches keyClaimedScopes against the required scopes and returns nil, nil if
 // keyClaimedScopes satisifies the authorizer, otherwise returns the expectation
 // and the error.
-func (a *Authorizer) validateKeyClaimedScopes(ctx context.Context, info *grpc.UnaryServerInfo, keyClaimedScopes ScopeSet) (string, error) {
-	if validator, known := a.scopesValidators[Operation(info.FullMethod)]; known {
+func (a *Authorizer) validateKeyClaimedScopes(ctx context.Context, op Operation, keyClaimedScopes ScopeSet) (string, error) {
+	if validator, known := a.scopesValidators[op]; known {
 		err := validator.ValidateKeyClaimedScopes(ctx, keyClaimedScopes)
 		expectation := ""
 		if err != nil {
@@ -125,6 +125,57 @@ func TestRSAAuthInterceptor(t *testing.T) {
 	}
 }
 
+func TestUnauthenticatedOperations(t *testing.T) {
+	ac := &Authorizer{unauthenticated: map[Operation]bool{
+		"/grpc.health.v1.Health/Check": true,
+	}}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+
+	_, err := ac.AuthInterceptor(context.Background(), nil,
+		&grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}, handler)
+	require.NoError(t, err)
+
+	_, err = ac.AuthInterceptor(context.Background(), nil,
+		&grpc.UnaryServerInfo{FullMethod: "/dss.SyncService/PutFoo"}, handler)
+	require.Equal(t, dsserr.Unauthenticated, stacktrace.GetCode(err))
+}
+
+type failingKeyResolver struct {
+	calls int
+	keys  []interface{}
+}
+
+func (r *failingKeyResolver) ResolveKeys(context.Context) ([]interface{}, error) {
+	r.calls++
+	if r.calls > 1 {
+		return nil, stacktrace.NewError("Keys unavailable")
+	}
+	return r.keys, nil
+}
+
+func TestKeyRefreshError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	a, err := NewRSAAuthorizer(ctx, Configuration{
+		KeyResolver:       &failingKeyResolver{keys: []interface{}{&key.PublicKey}},
+		KeyRefreshTimeout: 1 * time.Millisecond,
+		AcceptedAudiences: []string{""},
+	})
+	require.NoError(t, err)
+	require.NoError(t, a.KeyRefreshError())
+
+	require.Eventually(t, func() bool { return a.KeyRefreshError() != nil }, time.Second, time.Millisecond)
+
+	// The keys resolved initially are still used after a failed refresh.
+	_, err = a.AuthInterceptor(rsaTokenCtx(ctx, key, time.Now().Add(time.Hour).Unix(), 20), nil, &grpc.UnaryServerInfo{},
+		func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil })
+	require.NoError(t, err)
+}
+
 func TestMissingScopes(t *testing.T) {
 	ac := &Authorizer{scopesValidators: map[Operation]KeyClaimedScopesValidator{
 		"/dss.SyncService/PutFoo": RequireAnyScope(("required1"), Scope("required2")),
@@ -164,7 +215,7 @@ func TestMissingScopes(t *testing.T) {
 		},
 	}
 	for _, tc := range tests {
-		_, err := ac.validateKeyClaimedScopes(context.Background(), tc.info, tc.scopes)
+		_, err := ac.validateKeyClaimedScopes(context.Background(), Operation(tc.info.FullMethod), tc.scopes)
 		require.Equal(t, tc.matchesRequiredScopes, err == nil)
 	}
 }
@@ -210,3 +261,14 @@ func TestContextWithOwner(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, models.Owner("real_owner"), owner)
 }
+
+func TestContextWithScopes(t *testing.T) {
+	ctx := context.Background()
+	_, ok := ScopesFromContext(ctx)
+	require.False(t, ok)
+
+	ctx = ContextWithScopes(ctx, ScopeSet{"one": struct{}{}})
+	scopes, ok := ScopesFromContext(ctx)
+	require.True(t, ok)
+	require.Contains(t, scopes, Scope("one"))
+}
@@ -0,0 +1,301 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	dssmodels "github.com/interuss/dss/pkg/models"
+	ridmodels "github.com/interuss/dss/pkg/rid/models"
+	ridrepos "github.com/interuss/dss/pkg/rid/repos"
+	ridstore "github.com/interuss/dss/pkg/rid/store"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	scdrepos "github.com/interuss/dss/pkg/scd/repos"
+	scdstore "github.com/interuss/dss/pkg/scd/store"
+	"github.com/interuss/stacktrace"
+)
+
+// FormatVersion is the version of the format of the backups, recorded in
+// their manifest. Backups of other versions may not be imported.
+const FormatVersion = 1
+
+// DefaultPageSize is the default number of entities read or restored at once.
+const DefaultPageSize = 1000
+
+// manifestFile is the name of the manifest of a backup in its directory.
+const manifestFile = "manifest.json"
+
+// Kind is a kind of entity, stored in the file <Kind>.jsonl of a backup.
+type Kind string
+
+const (
+	// RIDISAs are remote ID IdentificationServiceAreas.
+	RIDISAs Kind = "rid_isas"
+	// RIDSubscriptions are remote ID Subscriptions.
+	RIDSubscriptions Kind = "rid_subscriptions"
+	// SCDSubscriptions are strategic conflict detection Subscriptions.
+	SCDSubscriptions Kind = "scd_subscriptions"
+	// SCDOperationalIntents are strategic conflict detection
+	// OperationalIntents.
+	SCDOperationalIntents Kind = "scd_operational_intents"
+	// SCDConstraints are strategic conflict detection Constraints.
+	SCDConstraints Kind = "scd_constraints"
+	// SCDUssAvailabilities are the availabilities of USSs.
+	SCDUssAvailabilities Kind = "scd_uss_availabilities"
+)
+
+// Manifest describes a backup.
+type Manifest struct {
+	FormatVersion int       `json:"format_version"`
+	ExportedAt    time.Time `json:"exported_at"`
+	// RIDSchemaVersion and SCDSchemaVersion are the schema versions of the
+	// exported stores. SCDSchemaVersion is empty if no strategic conflict
+	// detection store was exported.
+	RIDSchemaVersion string `json:"rid_schema_version"`
+	SCDSchemaVersion string `json:"scd_schema_version,omitempty"`
+	// Counts are the numbers of entities exported, by kind.
+	Counts map[Kind]int `json:"counts"`
+}
+
+// Stores are the stores exported from or imported into.
+type Stores struct {
+	RID ridstore.Store
+	// SCD is nil if strategic conflict detection is not enabled.
+	SCD scdstore.Store
+	// PageSize is the maximum number of entities read or restored at once,
+	// or DefaultPageSize if 0.
+	PageSize int
+	// Logf reports the progress of exports and imports, if not nil.
+	Logf func(format string, args ...interface{})
+}
+
+// table accesses the entities of one kind.
+type table struct {
+	kind Kind
+	// list returns at most limit entities following the entity identified
+	// by after, or the first entities if after is empty, in order, along
+	// with the identifier of the last one.
+	list func(ctx context.Context, after string, limit int) ([]interface{}, string, error)
+	// decode decodes the next entity of dec.
+	decode func(dec *json.Decoder) (interface{}, error)
+	// restore restores entities in a transaction.
+	restore func(ctx context.Context, entities []interface{}) error
+}
+
+// tables returns the tables of s, in the order in which they are restored so
+// that the entities referenced by others are restored first.
+func (s *Stores) tables() []table {
+	tables := []table{
+		{
+			kind: RIDISAs,
+			list: func(ctx context.Context, after string, limit int) ([]interface{}, string, error) {
+				repo, err := s.RID.Interact(ctx)
+				if err != nil {
+					return nil, "", stacktrace.Propagate(err, "Unable to interact with remote ID store")
+				}
+				isas, err := repo.ListISAs(ctx, dssmodels.ID(after), limit)
+				if err != nil {
+					return nil, "", err // No need to Propagate this error as this stack layer does not add useful information
+				}
+				entities := make([]interface{}, len(isas))
+				for i, isa := range isas {
+					entities[i], after = isa, isa.ID.String()
+				}
+				return entities, after, nil
+			},
+			decode: func(dec *json.Decoder) (interface{}, error) {
+				isa := &ridmodels.IdentificationServiceArea{}
+				return isa, dec.Decode(isa)
+			},
+			restore: func(ctx context.Context, entities []interface{}) error {
+				return s.RID.Transact(ctx, func(repo ridrepos.Repository) error {
+					for _, entity := range entities {
+						if err := repo.RestoreISA(ctx, entity.(*ridmodels.IdentificationServiceArea)); err != nil {
+							return err // No need to Propagate this error as this stack layer does not add useful information
+						}
+					}
+					return nil
+				})
+			},
+		},
+		{
+			kind: RIDSubscriptions,
+			list: func(ctx context.Context, after string, limit int) ([]interface{}, string, error) {
+				repo, err := s.RID.Interact(ctx)
+				if err != nil {
+					return nil, "", stacktrace.Propagate(err, "Unable to interact with remote ID store")
+				}
+				subs, err := repo.ListSubscriptions(ctx, dssmodels.ID(after), limit)
+				if err != nil {
+					return nil, "", err // No need to Propagate this error as this stack layer does not add useful information
+				}
+				entities := make([]interface{}, len(subs))
+				for i, sub := range subs {
+					entities[i], after = sub, sub.ID.String()
+				}
+				return entities, after, nil
+			},
+			decode: func(dec *json.Decoder) (interface{}, error) {
+				sub := &ridmodels.Subscription{}
+				return sub, dec.Decode(sub)
+			},
+			restore: func(ctx context.Context, entities []interface{}) error {
+				return s.RID.Transact(ctx, func(repo ridrepos.Repository) error {
+					for _, entity := range entities {
+						if err := repo.RestoreSubscription(ctx, entity.(*ridmodels.Subscription)); err != nil {
+							return err // No need to Propagate this error as this stack layer does not add useful information
+						}
+					}
+					return nil
+				})
+			},
+		},
+	}
+	if s.SCD == nil {
+		return tables
+	}
+
+	return append(tables,
+		table{
+			kind: SCDSubscriptions,
+			list: func(ctx context.Context, after string, limit int) ([]interface{}, string, error) {
+				repo, err := s.SCD.Interact(ctx)
+				if err != nil {
+					return nil, "", stacktrace.Propagate(err, "Unable to interact with strategic conflict detection store")
+				}
+				subs, err := repo.ListSubscriptions(ctx, dssmodels.ID(after), limit)
+				if err != nil {
+					return nil, "", err // No need to Propagate this error as this stack layer does not add useful information
+				}
+				entities := make([]interface{}, len(subs))
+				for i, sub := range subs {
+					entities[i], after = sub, sub.ID.String()
+				}
+				return entities, after, nil
+			},
+			decode: func(dec *json.Decoder) (interface{}, error) {
+				sub := &scdmodels.Subscription{}
+				return sub, dec.Decode(sub)
+			},
+			restore: func(ctx context.Context, entities []interface{}) error {
+				return s.SCD.Transact(ctx, func(ctx context.Context, repo scdrepos.Repository) error {
+					for _, entity := range entities {
+						if err := repo.RestoreSubscription(ctx, entity.(*scdmodels.Subscription)); err != nil {
+							return err // No need to Propagate this error as this stack layer does not add useful information
+						}
+					}
+					return nil
+				})
+			},
+		},
+		table{
+			kind: SCDOperationalIntents,
+			list: func(ctx context.Context, after string, limit int) ([]interface{}, string, error) {
+				repo, err := s.SCD.Interact(ctx)
+				if err != nil {
+					return nil, "", stacktrace.Propagate(err, "Unable to interact with strategic conflict detection store")
+				}
+				ops, err := repo.ListOperationalIntents(ctx, dssmodels.ID(after), limit)
+				if err != nil {
+					return nil, "", err // No need to Propagate this error as this stack layer does not add useful information
+				}
+				entities := make([]interface{}, len(ops))
+				for i, op := range ops {
+					entities[i], after = op, op.ID.String()
+				}
+				return entities, after, nil
+			},
+			decode: func(dec *json.Decoder) (interface{}, error) {
+				op := &scdmodels.OperationalIntent{}
+				return op, dec.Decode(op)
+			},
+			restore: func(ctx context.Context, entities []interface{}) error {
+				return s.SCD.Transact(ctx, func(ctx context.Context, repo scdrepos.Repository) error {
+					for _, entity := range entities {
+						if err := repo.RestoreOperationalIntent(ctx, entity.(*scdmodels.OperationalIntent)); err != nil {
+							return err // No need to Propagate this error as this stack layer does not add useful information
+						}
+					}
+					return nil
+				})
+			},
+		},
+		table{
+			kind: SCDConstraints,
+			list: func(ctx context.Context, after string, limit int) ([]interface{}, string, error) {
+				repo, err := s.SCD.Interact(ctx)
+				if err != nil {
+					return nil, "", stacktrace.Propagate(err, "Unable to interact with strategic conflict detection store")
+				}
+				constraints, err := repo.ListConstraints(ctx, dssmodels.ID(after), limit)
+				if err != nil {
+					return nil, "", err // No need to Propagate this error as this stack layer does not add useful information
+				}
+				entities := make([]interface{}, len(constraints))
+				for i, constraint := range constraints {
+					entities[i], after = constraint, constraint.ID.String()
+				}
+				return entities, after, nil
+			},
+			decode: func(dec *json.Decoder) (interface{}, error) {
+				constraint := &scdmodels.Constraint{}
+				return constraint, dec.Decode(constraint)
+			},
+			restore: func(ctx context.Context, entities []interface{}) error {
+				return s.SCD.Transact(ctx, func(ctx context.Context, repo scdrepos.Repository) error {
+					for _, entity := range entities {
+						if err := repo.RestoreConstraint(ctx, entity.(*scdmodels.Constraint)); err != nil {
+							return err // No need to Propagate this error as this stack layer does not add useful information
+						}
+					}
+					return nil
+				})
+			},
+		},
+		table{
+			kind: SCDUssAvailabilities,
+			list: func(ctx context.Context, after string, limit int) ([]interface{}, string, error) {
+				repo, err := s.SCD.Interact(ctx)
+				if err != nil {
+					return nil, "", stacktrace.Propagate(err, "Unable to interact with strategic conflict detection store")
+				}
+				availabilities, err := repo.ListUssAvailabilities(ctx, dssmodels.Manager(after), limit)
+				if err != nil {
+					return nil, "", err // No need to Propagate this error as this stack layer does not add useful information
+				}
+				entities := make([]interface{}, len(availabilities))
+				for i, availability := range availabilities {
+					entities[i], after = availability, availability.Uss.String()
+				}
+				return entities, after, nil
+			},
+			decode: func(dec *json.Decoder) (interface{}, error) {
+				availability := &scdmodels.UssAvailabilityStatus{}
+				return availability, dec.Decode(availability)
+			},
+			restore: func(ctx context.Context, entities []interface{}) error {
+				return s.SCD.Transact(ctx, func(ctx context.Context, repo scdrepos.Repository) error {
+					for _, entity := range entities {
+						if err := repo.RestoreUssAvailability(ctx, entity.(*scdmodels.UssAvailabilityStatus)); err != nil {
+							return err // No need to Propagate this error as this stack layer does not add useful information
+						}
+					}
+					return nil
+				})
+			},
+		},
+	)
+}
+
+func (s *Stores) pageSize() int {
+	if s.PageSize <= 0 {
+		return DefaultPageSize
+	}
+	return s.PageSize
+}
+
+func (s *Stores) logf(format string, args ...interface{}) {
+	if s.Logf != nil {
+		s.Logf(format, args...)
+	}
+}
@@ -0,0 +1,432 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/golang/geo/s2"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	ridmodels "github.com/interuss/dss/pkg/rid/models"
+	ridrepos "github.com/interuss/dss/pkg/rid/repos"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	scdrepos "github.com/interuss/dss/pkg/scd/repos"
+	"github.com/stretchr/testify/require"
+)
+
+// ridStore is an in-memory store.Store of remote ID entities which only
+// supports listing and restoring them.
+type ridStore struct {
+	ridrepos.Repository
+	isas map[dssmodels.ID]*ridmodels.IdentificationServiceArea
+	subs map[dssmodels.ID]*ridmodels.Subscription
+}
+
+func newRIDStore() *ridStore {
+	return &ridStore{
+		isas: map[dssmodels.ID]*ridmodels.IdentificationServiceArea{},
+		subs: map[dssmodels.ID]*ridmodels.Subscription{},
+	}
+}
+
+func (s *ridStore) Interact(ctx context.Context) (ridrepos.Repository, error) {
+	return s, nil
+}
+
+func (s *ridStore) Transact(ctx context.Context, f func(repo ridrepos.Repository) error) error {
+	return f(s)
+}
+
+func (s *ridStore) TransactReadOnly(ctx context.Context, f func(repo ridrepos.Repository) error) error {
+	return f(s)
+}
+
+func (s *ridStore) GetVersion(ctx context.Context) (*semver.Version, error) {
+	return semver.NewVersion("4.4.0")
+}
+
+func (s *ridStore) Close() error {
+	return nil
+}
+
+func (s *ridStore) ListISAs(ctx context.Context, after dssmodels.ID, limit int) ([]*ridmodels.IdentificationServiceArea, error) {
+	var result []*ridmodels.IdentificationServiceArea
+	var ids []dssmodels.ID
+	for id := range s.isas {
+		ids = append(ids, id)
+	}
+	for _, id := range page(ids, after, limit) {
+		copied := *s.isas[id]
+		result = append(result, &copied)
+	}
+	return result, nil
+}
+
+func (s *ridStore) RestoreISA(ctx context.Context, isa *ridmodels.IdentificationServiceArea) error {
+	copied := *isa
+	s.isas[isa.ID] = &copied
+	return nil
+}
+
+func (s *ridStore) ListSubscriptions(ctx context.Context, after dssmodels.ID, limit int) ([]*ridmodels.Subscription, error) {
+	var result []*ridmodels.Subscription
+	var ids []dssmodels.ID
+	for id := range s.subs {
+		ids = append(ids, id)
+	}
+	for _, id := range page(ids, after, limit) {
+		copied := *s.subs[id]
+		result = append(result, &copied)
+	}
+	return result, nil
+}
+
+func (s *ridStore) RestoreSubscription(ctx context.Context, sub *ridmodels.Subscription) error {
+	copied := *sub
+	s.subs[sub.ID] = &copied
+	return nil
+}
+
+// scdStore is an in-memory store.Store of strategic conflict detection
+// entities which only supports listing and restoring them.
+type scdStore struct {
+	scdrepos.Repository
+	ops            map[dssmodels.ID]*scdmodels.OperationalIntent
+	constraints    map[dssmodels.ID]*scdmodels.Constraint
+	subs           map[dssmodels.ID]*scdmodels.Subscription
+	availabilities map[dssmodels.Manager]*scdmodels.UssAvailabilityStatus
+}
+
+func newSCDStore() *scdStore {
+	return &scdStore{
+		ops:            map[dssmodels.ID]*scdmodels.OperationalIntent{},
+		constraints:    map[dssmodels.ID]*scdmodels.Constraint{},
+		subs:           map[dssmodels.ID]*scdmodels.Subscription{},
+		availabilities: map[dssmodels.Manager]*scdmodels.UssAvailabilityStatus{},
+	}
+}
+
+func (s *scdStore) Interact(ctx context.Context) (scdrepos.Repository, error) {
+	return s, nil
+}
+
+func (s *scdStore) Transact(ctx context.Context, f func(context.Context, scdrepos.Repository) error) error {
+	return f(ctx, s)
+}
+
+func (s *scdStore) TransactReadOnly(ctx context.Context, f func(context.Context, scdrepos.Repository) error) error {
+	return f(ctx, s)
+}
+
+func (s *scdStore) GetVersion(ctx context.Context) (*semver.Version, error) {
+	return semver.NewVersion("3.11.0")
+}
+
+func (s *scdStore) Close() error {
+	return nil
+}
+
+func (s *scdStore) ListOperationalIntents(ctx context.Context, after dssmodels.ID, limit int) ([]*scdmodels.OperationalIntent, error) {
+	var result []*scdmodels.OperationalIntent
+	var ids []dssmodels.ID
+	for id := range s.ops {
+		ids = append(ids, id)
+	}
+	for _, id := range page(ids, after, limit) {
+		copied := *s.ops[id]
+		result = append(result, &copied)
+	}
+	return result, nil
+}
+
+func (s *scdStore) RestoreOperationalIntent(ctx context.Context, op *scdmodels.OperationalIntent) error {
+	copied := *op
+	s.ops[op.ID] = &copied
+	return nil
+}
+
+func (s *scdStore) ListConstraints(ctx context.Context, after dssmodels.ID, limit int) ([]*scdmodels.Constraint, error) {
+	var result []*scdmodels.Constraint
+	var ids []dssmodels.ID
+	for id := range s.constraints {
+		ids = append(ids, id)
+	}
+	for _, id := range page(ids, after, limit) {
+		copied := *s.constraints[id]
+		result = append(result, &copied)
+	}
+	return result, nil
+}
+
+func (s *scdStore) RestoreConstraint(ctx context.Context, constraint *scdmodels.Constraint) error {
+	copied := *constraint
+	s.constraints[constraint.ID] = &copied
+	return nil
+}
+
+func (s *scdStore) ListSubscriptions(ctx context.Context, after dssmodels.ID, limit int) ([]*scdmodels.Subscription, error) {
+	var result []*scdmodels.Subscription
+	var ids []dssmodels.ID
+	for id := range s.subs {
+		ids = append(ids, id)
+	}
+	for _, id := range page(ids, after, limit) {
+		copied := *s.subs[id]
+		result = append(result, &copied)
+	}
+	return result, nil
+}
+
+func (s *scdStore) RestoreSubscription(ctx context.Context, sub *scdmodels.Subscription) error {
+	copied := *sub
+	s.subs[sub.ID] = &copied
+	return nil
+}
+
+func (s *scdStore) ListUssAvailabilities(ctx context.Context, after dssmodels.Manager, limit int) ([]*scdmodels.UssAvailabilityStatus, error) {
+	var result []*scdmodels.UssAvailabilityStatus
+	var ids []dssmodels.ID
+	for uss := range s.availabilities {
+		ids = append(ids, dssmodels.ID(uss))
+	}
+	for _, id := range page(ids, dssmodels.ID(after), limit) {
+		copied := *s.availabilities[dssmodels.Manager(id)]
+		result = append(result, &copied)
+	}
+	return result, nil
+}
+
+func (s *scdStore) RestoreUssAvailability(ctx context.Context, availability *scdmodels.UssAvailabilityStatus) error {
+	copied := *availability
+	s.availabilities[availability.Uss] = &copied
+	return nil
+}
+
+// page returns at most limit of ids which are greater than after, in order.
+func page(ids []dssmodels.ID, after dssmodels.ID, limit int) []dssmodels.ID {
+	var result []dssmodels.ID
+	for _, id := range ids {
+		if id > after {
+			result = append(result, id)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+func newVersion(t *testing.T, at time.Time) *dssmodels.Version {
+	// Versions parsed from their string representation are compared, as
+	// they are imported.
+	version, err := dssmodels.VersionFromString(dssmodels.VersionFromTime(at).String())
+	require.NoError(t, err)
+	return version
+}
+
+func populate(t *testing.T, rid *ridStore, scd *scdStore) {
+	var (
+		start = time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+		end   = start.Add(time.Hour)
+		lower = float32(10)
+		upper = float32(120)
+		cells = s2.CellUnion{s2.CellID(12494535935418957824), s2.CellID(12494535943472549888)}
+		ids   = []dssmodels.ID{
+			"0a7c4a9e-3b4d-4e9b-8d0e-1f6b2f9f3a01",
+			"1b8d5baf-4c5e-4fac-9e1f-2a7c3aaf4b02",
+			"2c9e6cb0-5d6f-40bd-af20-3b8d4bb05c03",
+		}
+	)
+	for i, id := range ids {
+		rid.isas[id] = &ridmodels.IdentificationServiceArea{
+			ID:        id,
+			URL:       "https://uss.example.com/isas",
+			Owner:     "uss1",
+			Cells:     cells,
+			StartTime: &start,
+			EndTime:   &end,
+			Version:   newVersion(t, start.Add(time.Duration(i)*time.Microsecond)),
+			Writer:    "us-east1",
+		}
+		rid.subs[id] = &ridmodels.Subscription{
+			ID:                id,
+			URL:               "https://uss.example.com/subscriptions",
+			NotificationIndex: 7 + i,
+			Owner:             "uss2",
+			Cells:             cells,
+			StartTime:         &start,
+			EndTime:           &end,
+			Version:           newVersion(t, start.Add(time.Duration(i)*time.Second)),
+		}
+		scd.subs[id] = &scdmodels.Subscription{
+			ID:                          id,
+			Version:                     scdmodels.OVN("sub-ovn-" + id.String()),
+			NotificationIndex:           3,
+			Manager:                     "uss1",
+			StartTime:                   &start,
+			EndTime:                     &end,
+			AltitudeLo:                  &lower,
+			AltitudeHi:                  &upper,
+			USSBaseURL:                  "https://uss.example.com",
+			NotifyForOperationalIntents: true,
+			ImplicitSubscription:        i == 0,
+			Cells:                       cells,
+			ConstraintCategories:        []scdmodels.ConstraintCategory{"military"},
+			UpdatedAt:                   start.Add(-time.Duration(i) * time.Minute),
+		}
+		scd.ops[id] = &scdmodels.OperationalIntent{
+			ID:             id,
+			Manager:        "uss1",
+			Version:        scdmodels.VersionNumber(i + 1),
+			State:          scdmodels.OperationalIntentStateAccepted,
+			OVN:            scdmodels.OVN("op-ovn-" + id.String()),
+			StartTime:      &start,
+			EndTime:        &end,
+			USSBaseURL:     "https://uss.example.com",
+			SubscriptionID: id,
+			AltitudeLower:  &lower,
+			AltitudeUpper:  &upper,
+			Cells:          cells,
+			Priority:       int32(i),
+			OffNominalVolume: &scdmodels.OffNominalVolume{
+				StartTime: &start,
+				EndTime:   &end,
+				Cells:     cells[:1],
+			},
+			UpdatedAt: start.Add(-time.Duration(i) * time.Second),
+		}
+		scd.constraints[id] = &scdmodels.Constraint{
+			ID:            id,
+			Manager:       "uss3",
+			Version:       scdmodels.VersionNumber(i + 2),
+			OVN:           scdmodels.OVN("constraint-ovn-" + id.String()),
+			StartTime:     &start,
+			EndTime:       &end,
+			USSBaseURL:    "https://zones.example.com",
+			AltitudeLower: &lower,
+			AltitudeUpper: &upper,
+			Cells:         cells,
+			Category:      "military",
+			Authority:     "CAA",
+			Reason:        "exercise",
+			UpdatedAt:     start.Add(-time.Duration(i) * time.Microsecond),
+		}
+	}
+	updatedAt := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	scd.availabilities["uss1"] = &scdmodels.UssAvailabilityStatus{
+		Uss:          "uss1",
+		Availability: scdmodels.UssAvailabilityStateDown,
+		Version:      scdmodels.NewOVNFromTime(updatedAt, "uss1"),
+		UpdatedAt:    updatedAt,
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	from := &Stores{RID: newRIDStore(), SCD: newSCDStore(), PageSize: 2}
+	populate(t, from.RID.(*ridStore), from.SCD.(*scdStore))
+	manifest, err := from.Export(ctx, dir)
+	require.NoError(t, err)
+	require.Equal(t, FormatVersion, manifest.FormatVersion)
+	require.Equal(t, "4.4.0", manifest.RIDSchemaVersion)
+	require.Equal(t, "3.11.0", manifest.SCDSchemaVersion)
+	require.Equal(t, map[Kind]int{
+		RIDISAs:               3,
+		RIDSubscriptions:      3,
+		SCDSubscriptions:      3,
+		SCDOperationalIntents: 3,
+		SCDConstraints:        3,
+		SCDUssAvailabilities:  1,
+	}, manifest.Counts)
+
+	to := &Stores{RID: newRIDStore(), SCD: newSCDStore(), PageSize: 2}
+	imported, err := to.Import(ctx, dir)
+	require.NoError(t, err)
+	require.Equal(t, manifest.Counts, imported.Counts)
+	require.Equal(t, from.RID.(*ridStore).isas, to.RID.(*ridStore).isas)
+	require.Equal(t, from.RID.(*ridStore).subs, to.RID.(*ridStore).subs)
+	require.Equal(t, from.SCD.(*scdStore).subs, to.SCD.(*scdStore).subs)
+	require.Equal(t, from.SCD.(*scdStore).ops, to.SCD.(*scdStore).ops)
+	require.Equal(t, from.SCD.(*scdStore).constraints, to.SCD.(*scdStore).constraints)
+	require.Equal(t, from.SCD.(*scdStore).availabilities, to.SCD.(*scdStore).availabilities)
+
+	// Stores which are not empty are not imported into.
+	_, err = to.Import(ctx, dir)
+	require.Error(t, err)
+}
+
+func TestImportWithoutSCDStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	from := &Stores{RID: newRIDStore(), SCD: newSCDStore()}
+	populate(t, from.RID.(*ridStore), from.SCD.(*scdStore))
+	_, err := from.Export(ctx, dir)
+	require.NoError(t, err)
+
+	_, err = (&Stores{RID: newRIDStore()}).Import(ctx, dir)
+	require.Error(t, err)
+
+	// Backups of the remote ID store alone may be imported without
+	// strategic conflict detection store.
+	ridOnly := t.TempDir()
+	_, err = (&Stores{RID: from.RID}).Export(ctx, ridOnly)
+	require.NoError(t, err)
+	to := &Stores{RID: newRIDStore()}
+	_, err = to.Import(ctx, ridOnly)
+	require.NoError(t, err)
+	require.Len(t, to.RID.(*ridStore).isas, 3)
+}
+
+func TestImportChecksBackup(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name   string
+		modify func(t *testing.T, dir string, manifest *Manifest)
+	}{
+		{
+			name: "unsupported format version",
+			modify: func(t *testing.T, dir string, manifest *Manifest) {
+				manifest.FormatVersion = FormatVersion + 1
+			},
+		},
+		{
+			name: "truncated file",
+			modify: func(t *testing.T, dir string, manifest *Manifest) {
+				manifest.Counts[RIDISAs]++
+			},
+		},
+		{
+			name: "invalid entity",
+			modify: func(t *testing.T, dir string, manifest *Manifest) {
+				path := filepath.Join(dir, string(RIDSubscriptions)+".jsonl")
+				require.NoError(t, ioutil.WriteFile(path, []byte(`{"ID": 42}`), 0644))
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			from := &Stores{RID: newRIDStore()}
+			populate(t, from.RID.(*ridStore), newSCDStore())
+			manifest, err := from.Export(ctx, dir)
+			require.NoError(t, err)
+
+			tc.modify(t, dir, manifest)
+			b, err := json.Marshal(manifest)
+			require.NoError(t, err)
+			require.NoError(t, ioutil.WriteFile(filepath.Join(dir, manifestFile), b, 0644))
+
+			_, err = (&Stores{RID: newRIDStore()}).Import(ctx, dir)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,6 @@
+// Package backup exports the remote ID and strategic conflict detection
+// entities of a DSS to a directory of JSON Lines files, one per kind of
+// entity, and imports them into empty stores. Entities are written as their
+// models, so that a backup may be restored into any store.Store
+// implementation with their IDs, managers, versions and cells.
+package backup
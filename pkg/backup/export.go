@@ -0,0 +1,101 @@
+package backup
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/interuss/stacktrace"
+)
+
+// Export writes the entities of s to dir, which is created if missing, and
+// returns the manifest of the backup. Entities are read a page at a time
+// without isolation, so the backup is only consistent if the stores are not
+// written to during the export.
+func (s *Stores) Export(ctx context.Context, dir string) (*Manifest, error) {
+	manifest := &Manifest{
+		FormatVersion: FormatVersion,
+		ExportedAt:    time.Now().UTC(),
+		Counts:        map[Kind]int{},
+	}
+	ridVersion, err := s.RID.GetVersion(ctx)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to get the schema version of the remote ID store")
+	}
+	manifest.RIDSchemaVersion = ridVersion.String()
+	if s.SCD != nil {
+		scdVersion, err := s.SCD.GetVersion(ctx)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Failed to get the schema version of the strategic conflict detection store")
+		}
+		manifest.SCDSchemaVersion = scdVersion.String()
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to create backup directory %s", dir)
+	}
+	for _, t := range s.tables() {
+		count, err := s.exportTable(ctx, t, filepath.Join(dir, string(t.kind)+".jsonl"))
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Failed to export %s", t.kind)
+		}
+		manifest.Counts[t.kind] = count
+		s.logf("Exported %d %s", count, t.kind)
+	}
+
+	// The manifest is written last, so that incomplete backups have none.
+	b, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to marshal the manifest")
+	}
+	path := filepath.Join(dir, manifestFile)
+	if err := ioutil.WriteFile(path, append(b, '\n'), 0644); err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to write manifest %s", path)
+	}
+	return manifest, nil
+}
+
+// exportTable writes the entities of t to the file at path, one JSON object
+// per line, and returns how many were written.
+func (s *Stores) exportTable(ctx context.Context, t table, path string) (int, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, stacktrace.Propagate(err, "Failed to create %s", path)
+	}
+	defer f.Close()
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+
+	var (
+		count int
+		after string
+	)
+	for {
+		entities, last, err := t.list(ctx, after, s.pageSize())
+		if err != nil {
+			return 0, err // No need to Propagate this error as this stack layer does not add useful information
+		}
+		for _, entity := range entities {
+			if err := enc.Encode(entity); err != nil {
+				return 0, stacktrace.Propagate(err, "Failed to write %s", path)
+			}
+		}
+		count += len(entities)
+		if len(entities) < s.pageSize() {
+			break
+		}
+		after = last
+	}
+
+	if err := w.Flush(); err != nil {
+		return 0, stacktrace.Propagate(err, "Failed to write %s", path)
+	}
+	if err := f.Close(); err != nil {
+		return 0, stacktrace.Propagate(err, "Failed to close %s", path)
+	}
+	return count, nil
+}
@@ -0,0 +1,114 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/interuss/stacktrace"
+)
+
+// ReadManifest reads the manifest of the backup in dir.
+func ReadManifest(dir string) (*Manifest, error) {
+	path := filepath.Join(dir, manifestFile)
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to read manifest %s", path)
+	}
+	manifest := &Manifest{}
+	if err := json.Unmarshal(b, manifest); err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to parse manifest %s", path)
+	}
+	return manifest, nil
+}
+
+// Import restores the entities of the backup in dir into the stores of s,
+// which must be empty, and returns the manifest of the backup. Entities are
+// restored a page at a time, so an interrupted import leaves the stores
+// partially restored, to be emptied before importing again.
+func (s *Stores) Import(ctx context.Context, dir string) (*Manifest, error) {
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	if manifest.FormatVersion != FormatVersion {
+		return nil, stacktrace.NewError("Unsupported backup format version %d, expected %d", manifest.FormatVersion, FormatVersion)
+	}
+
+	tables := s.tables()
+	known := map[Kind]bool{}
+	for _, t := range tables {
+		known[t.kind] = true
+		entities, _, err := t.list(ctx, "", 1)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Failed to check for existing %s", t.kind)
+		}
+		if len(entities) > 0 {
+			return nil, stacktrace.NewError("Unable to import into a store which already has %s", t.kind)
+		}
+	}
+	for kind, count := range manifest.Counts {
+		if !known[kind] && count > 0 {
+			return nil, stacktrace.NewError("Unable to import %d %s, missing store", count, kind)
+		}
+	}
+
+	for _, t := range tables {
+		expected, ok := manifest.Counts[t.kind]
+		if !ok {
+			continue
+		}
+		count, err := s.importTable(ctx, t, filepath.Join(dir, string(t.kind)+".jsonl"))
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Failed to import %s", t.kind)
+		}
+		if count != expected {
+			return nil, stacktrace.NewError("Imported %d %s where the manifest lists %d", count, t.kind, expected)
+		}
+		s.logf("Imported %d %s", count, t.kind)
+	}
+	return manifest, nil
+}
+
+// importTable restores the entities of t in the file at path and returns how
+// many were restored.
+func (s *Stores) importTable(ctx context.Context, t table, path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, stacktrace.Propagate(err, "Failed to open %s", path)
+	}
+	defer f.Close()
+	dec := json.NewDecoder(f)
+
+	var (
+		count    int
+		entities []interface{}
+	)
+	for {
+		entity, err := t.decode(dec)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return 0, stacktrace.Propagate(err, "Failed to parse entity %d of %s", count+len(entities)+1, path)
+		}
+		entities = append(entities, entity)
+		if len(entities) < s.pageSize() {
+			continue
+		}
+		if err := t.restore(ctx, entities); err != nil {
+			return 0, stacktrace.Propagate(err, "Failed to restore entities %d to %d", count+1, count+len(entities))
+		}
+		count += len(entities)
+		entities = nil
+	}
+	if len(entities) > 0 {
+		if err := t.restore(ctx, entities); err != nil {
+			return 0, stacktrace.Propagate(err, "Failed to restore entities %d to %d", count+1, count+len(entities))
+		}
+		count += len(entities)
+	}
+	return count, nil
+}
@@ -0,0 +1,138 @@
+package cockroach
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	dssmodels "github.com/interuss/dss/pkg/models"
+	dsssql "github.com/interuss/dss/pkg/sql"
+	"github.com/interuss/stacktrace"
+)
+
+const (
+	auditLogFields = "entity_type, entity_id, operation, owner, old_version, new_version, starts_at, ends_at, altitude_lower, altitude_upper, recorded_at"
+)
+
+// AuditLog stores dssmodels.AuditRecords in an append-only table of a
+// database.
+type AuditLog struct {
+	q       dsssql.Queryable
+	table   string
+	enabled bool
+}
+
+// NewAuditLog returns an AuditLog accessing table through q. If enabled is
+// false, which is expected when the database schema predates the audit table,
+// records are discarded and searches fail.
+func NewAuditLog(q dsssql.Queryable, table string, enabled bool) *AuditLog {
+	return &AuditLog{
+		q:       q,
+		table:   table,
+		enabled: enabled,
+	}
+}
+
+// InsertAuditRecord appends r to the audit log. RecordedAt is set to the
+// timestamp of the current transaction.
+func (l *AuditLog) InsertAuditRecord(ctx context.Context, r *dssmodels.AuditRecord) error {
+	if !l.enabled {
+		return nil
+	}
+	var query = fmt.Sprintf(`
+		INSERT INTO
+			%s
+			(%s)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, transaction_timestamp())`, l.table, auditLogFields)
+
+	_, err := l.q.Exec(ctx, query,
+		r.EntityType,
+		r.EntityID,
+		r.Operation,
+		r.Owner,
+		r.OldVersion,
+		r.NewVersion,
+		r.StartTime,
+		r.EndTime,
+		r.AltitudeLo,
+		r.AltitudeHi,
+	)
+	if err != nil {
+		return stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	return nil
+}
+
+// SearchAuditRecords returns the most recent audit records matching f.
+func (l *AuditLog) SearchAuditRecords(ctx context.Context, f *dssmodels.AuditFilter) ([]*dssmodels.AuditRecord, error) {
+	if !l.enabled {
+		return nil, stacktrace.NewError("Audit log is not supported by the current schema version of the database")
+	}
+
+	var (
+		conditions = []string{"TRUE"}
+		args       []interface{}
+	)
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if f.EntityType != "" {
+		addCondition("entity_type = $%d", f.EntityType)
+	}
+	if f.EntityID != "" {
+		addCondition("entity_id = $%d", f.EntityID)
+	}
+	if f.Owner != "" {
+		addCondition("owner = $%d", f.Owner)
+	}
+	if f.Earliest != nil {
+		addCondition("recorded_at >= $%d", *f.Earliest)
+	}
+	if f.Latest != nil {
+		addCondition("recorded_at <= $%d", *f.Latest)
+	}
+	args = append(args, dssmodels.MaxResultLimit)
+
+	var query = fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			%s
+		WHERE
+			%s
+		ORDER BY recorded_at DESC
+		LIMIT $%d`, auditLogFields, l.table, strings.Join(conditions, " AND "), len(args))
+
+	rows, err := l.q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	defer rows.Close()
+
+	var records []*dssmodels.AuditRecord
+	for rows.Next() {
+		r := new(dssmodels.AuditRecord)
+		if err := rows.Scan(
+			&r.EntityType,
+			&r.EntityID,
+			&r.Operation,
+			&r.Owner,
+			&r.OldVersion,
+			&r.NewVersion,
+			&r.StartTime,
+			&r.EndTime,
+			&r.AltitudeLo,
+			&r.AltitudeHi,
+			&r.RecordedAt,
+		); err != nil {
+			return nil, stacktrace.Propagate(err, "Error scanning audit record row")
+		}
+		records = append(records, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, stacktrace.Propagate(err, "Error in rows query result")
+	}
+	return records, nil
+}
@@ -3,13 +3,18 @@ package cockroach
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"os"
 	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/coreos/go-semver/semver"
 	"github.com/interuss/stacktrace"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
@@ -20,33 +25,63 @@ var (
 type (
 	// Credentials models connect credentials.
 	Credentials struct {
-		Username string
-		Password string
+		Username string `yaml:"user"`
+		// Password is neither loaded from the configuration nor part of
+		// its dump: it is read from PasswordFile or from the environment
+		// variable named PasswordEnv if empty.
+		Password     string `yaml:"-"`
+		PasswordFile string `yaml:"password_file"`
+		PasswordEnv  string `yaml:"password_env"`
 	}
 
 	// SSL models SSL configuration parameters.
 	SSL struct {
-		Mode string
-		Dir  string
+		Mode string `yaml:"mode"`
+		// Dir contains the ca.crt CA certificate and, unless
+		// authenticating with a password, the client.<user>.crt and
+		// client.<user>.key client certificate and key, which are
+		// overridden by RootCert, Cert and Key.
+		Dir      string `yaml:"dir"`
+		RootCert string `yaml:"root_cert"`
+		Cert     string `yaml:"cert"`
+		Key      string `yaml:"key"`
 	}
 
 	// ConnectParameters bundles up parameters used for connecting to a CRDB instance.
 	ConnectParameters struct {
-		ApplicationName    string
-		Host               string
-		Port               int
-		DBName             string
-		Credentials        Credentials
-		SSL                SSL
-		MaxOpenConns       int
-		MaxConnIdleSeconds int
-		MaxRetries         int
+		ApplicationName string `yaml:"application_name"`
+		// URL is a postgresql:// connection URL, which replaces Host,
+		// Port, DBName and SSL if set. It may not contain a password.
+		URL string `yaml:"url"`
+		// Host may list several hosts, as parsed by ParseHosts, which
+		// are attempted in order, or in turn if LoadBalanceHosts.
+		Host               string      `yaml:"host"`
+		Port               int         `yaml:"port"`
+		DBName             string      `yaml:"db_name"`
+		Credentials        Credentials `yaml:",inline"`
+		SSL                SSL         `yaml:"ssl"`
+		MaxOpenConns       int         `yaml:"max_open_conns"`
+		MaxConnIdleSeconds int         `yaml:"max_conn_idle_secs"`
+		MaxRetries         int         `yaml:"max_retries"`
+		// Region is the region of the DSS instance connecting, whose host
+		// in RegionHosts is connected to instead of Host if present.
+		Region string `yaml:"-"`
+		// RegionHosts lists the host to connect to in each region, as
+		// parsed by ParseRegionHosts.
+		RegionHosts string `yaml:"region_hosts"`
+		// LoadBalanceHosts spreads the connections over the hosts
+		// rather than connecting to the first available host.
+		LoadBalanceHosts bool `yaml:"load_balance_hosts"`
 	}
 )
 
 // DB models a connection to a CRDB instance.
 type DB struct {
 	Pool *pgxpool.Pool
+
+	// region is the region of the nodes to which the connections of Pool
+	// must be established, as a string, or nil if any node will do.
+	region atomic.Value
 }
 
 func parseIntOrDefault(port string, defaultPort int64) int64 {
@@ -64,15 +99,24 @@ func connectParametersFromMap(m map[string]string) ConnectParameters {
 		DBName:          m["db_name"],
 		Host:            m["host"],
 		Port:            int(parseIntOrDefault(m["port"], 0)),
+		URL:             m["url"],
 		Credentials: Credentials{
-			Username: m["user"],
+			Username:     m["user"],
+			Password:     m["password"],
+			PasswordFile: m["password_file"],
+			PasswordEnv:  m["password_env"],
 		},
 		SSL: SSL{
-			Mode: m["ssl_mode"],
-			Dir:  m["ssl_dir"],
+			Mode:     m["ssl_mode"],
+			Dir:      m["ssl_dir"],
+			RootCert: m["ssl_root_cert"],
+			Cert:     m["ssl_cert"],
+			Key:      m["ssl_key"],
 		},
 		MaxOpenConns:       int(parseIntOrDefault(m["max_open_conns"], 4)),
 		MaxConnIdleSeconds: int(parseIntOrDefault(m["max_conn_idle_secs"], 40)),
+		Region:             m["region"],
+		RegionHosts:        m["region_hosts"],
 	}
 }
 
@@ -81,15 +125,117 @@ func formatDSN(dsnMap map[string]string) string {
 	d := make([]string, 0)
 	for key, value := range dsnMap {
 		if value != "" {
-			d = append(d, fmt.Sprintf("%s=%s", key, value))
+			d = append(d, fmt.Sprintf("%s=%s", key, quoteDSNValue(value)))
 		}
 	}
 	sort.Strings(d)
 	return strings.Join(d, " ")
 }
 
-// BuildURI returns a URI built from p.
+// quoteDSNValue quotes value if it contains characters with a special
+// meaning in DSNs.
+func quoteDSNValue(value string) string {
+	if !strings.ContainsAny(value, " '\\\t\n") {
+		return value
+	}
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+	return "'" + value + "'"
+}
+
+// password returns the password to authenticate with, if any.
+func (c Credentials) password() (string, error) {
+	switch {
+	case c.Password != "":
+		return c.Password, nil
+	case c.PasswordFile != "":
+		b, err := os.ReadFile(c.PasswordFile)
+		if err != nil {
+			return "", stacktrace.Propagate(err, "Failed to read crdb password file")
+		}
+		return strings.TrimRight(string(b), "\r\n"), nil
+	case c.PasswordEnv != "":
+		password, ok := os.LookupEnv(c.PasswordEnv)
+		if !ok || password == "" {
+			return "", stacktrace.NewError("Missing crdb password in environment variable %s", c.PasswordEnv)
+		}
+		return password, nil
+	}
+	return "", nil
+}
+
+// hasPassword returns true if c authenticates with a password.
+func (c Credentials) hasPassword() bool {
+	return c.Password != "" || c.PasswordFile != "" || c.PasswordEnv != ""
+}
+
+// files returns the paths to the CA certificate and to the client
+// certificate and key, which are only read from s.Dir when not
+// authenticating with a password.
+func (s SSL) files(user string, password bool) (rootCert string, cert string, key string) {
+	rootCert, cert, key = s.RootCert, s.Cert, s.Key
+	if s.Dir == "" {
+		return rootCert, cert, key
+	}
+	if rootCert == "" {
+		rootCert = fmt.Sprintf("%s/ca.crt", s.Dir)
+	}
+	if cert == "" && key == "" && !password {
+		cert = fmt.Sprintf("%s/client.%s.crt", s.Dir, user)
+		key = fmt.Sprintf("%s/client.%s.key", s.Dir, user)
+	}
+	return rootCert, cert, key
+}
+
+// Validate returns an error if cp cannot be used to connect to a CRDB
+// instance.
+func (cp ConnectParameters) Validate() error {
+	if cp.Credentials.PasswordFile != "" && cp.Credentials.PasswordEnv != "" {
+		return stacktrace.NewError("Only one of crdb password file and password environment variable may be set")
+	}
+	if cp.URL != "" {
+		if _, err := parseURL(cp.URL); err != nil {
+			return err // No need to Propagate this error as this stack layer does not add useful information
+		}
+	} else {
+		if cp.Port <= 0 || cp.Port > 65535 {
+			return stacktrace.NewError("Invalid crdb port %d", cp.Port)
+		}
+		if _, err := ParseHosts(cp.Host); err != nil {
+			return stacktrace.Propagate(err, "Invalid crdb host")
+		}
+		if cp.SSL.Mode == "" {
+			return stacktrace.NewError("Missing crdb ssl mode")
+		}
+		if (cp.SSL.Cert == "") != (cp.SSL.Key == "") {
+			return stacktrace.NewError("Both or none of crdb ssl cert and key must be set")
+		}
+		if cp.SSL.Mode != "disable" && cp.SSL.Dir == "" && cp.SSL.Cert == "" && !cp.Credentials.hasPassword() {
+			return stacktrace.NewError("Missing crdb ssl dir, client certificate or password for ssl mode `%s`", cp.SSL.Mode)
+		}
+	}
+	if cp.MaxOpenConns <= 0 {
+		return stacktrace.NewError("Invalid maximum number of open connections %d", cp.MaxOpenConns)
+	}
+	if cp.MaxRetries < 0 {
+		return stacktrace.NewError("Invalid maximum number of retries %d", cp.MaxRetries)
+	}
+	if _, err := ParseRegionHosts(cp.RegionHosts); err != nil {
+		return stacktrace.Propagate(err, "Invalid crdb region hosts")
+	}
+	return nil
+}
+
+// BuildDSN returns a DSN, or a URL if cp.URL is set, built from cp.
 func (cp ConnectParameters) BuildDSN() (string, error) {
+	password, err := cp.Credentials.password()
+	if err != nil {
+		return "", err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	if cp.URL != "" {
+		return cp.buildURL(password)
+	}
+
 	dsnMap := make(map[string]string)
 
 	u := cp.Credentials.Username
@@ -97,18 +243,25 @@ func (cp ConnectParameters) BuildDSN() (string, error) {
 		return "", stacktrace.NewError("Missing crdb user")
 	}
 	dsnMap["user"] = u
+	dsnMap["password"] = password
 
-	h := cp.Host
-	if h == "" {
+	hosts, err := cp.regionalHosts()
+	if err != nil {
+		return "", err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	if len(hosts) == 0 {
 		return "", stacktrace.NewError("Missing crdb hostname")
 	}
-	dsnMap["host"] = h
-
-	port := cp.Port
-	if port == 0 {
-		return "", stacktrace.NewError("Missing crdb port")
+	var hostnames, ports []string
+	for _, hp := range hosts {
+		if hp.Port == 0 {
+			return "", stacktrace.NewError("Missing crdb port")
+		}
+		hostnames = append(hostnames, hp.Host)
+		ports = append(ports, strconv.Itoa(hp.Port))
 	}
-	dsnMap["port"] = fmt.Sprintf("%d", port)
+	dsnMap["host"] = strings.Join(hostnames, ",")
+	dsnMap["port"] = strings.Join(ports, ",")
 
 	an := cp.ApplicationName
 	if an == "" {
@@ -130,17 +283,100 @@ func (cp ConnectParameters) BuildDSN() (string, error) {
 		return formatDSN(dsnMap), nil
 	}
 
-	dir := cp.SSL.Dir
-	if dir == "" {
-		return "", stacktrace.NewError("Missing crdb ssl_dir")
+	rootCert, cert, key := cp.SSL.files(u, password != "")
+	if (cert == "") != (key == "") {
+		return "", stacktrace.NewError("Both or none of crdb ssl_cert and ssl_key must be set")
+	}
+	if cert == "" && password == "" {
+		return "", stacktrace.NewError("Missing crdb ssl_dir, client certificate or password")
 	}
-	dsnMap["sslrootcert"] = fmt.Sprintf("%s/ca.crt", dir)
-	dsnMap["sslcert"] = fmt.Sprintf("%s/client.%s.crt", dir, u)
-	dsnMap["sslkey"] = fmt.Sprintf("%s/client.%s.key", dir, u)
+	dsnMap["sslrootcert"] = rootCert
+	dsnMap["sslcert"] = cert
+	dsnMap["sslkey"] = key
 
 	return formatDSN(dsnMap), nil
 }
 
+// parseURL parses a postgresql:// connection URL without password.
+func parseURL(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		// The URL is not part of the error, which would leak a password.
+		return nil, stacktrace.NewError("Invalid crdb url: %s", err.(*url.Error).Err)
+	}
+	if u.Scheme != "postgresql" && u.Scheme != "postgres" {
+		return nil, stacktrace.NewError("Invalid crdb url scheme `%s`, must be postgresql", u.Scheme)
+	}
+	if _, ok := u.User.Password(); ok {
+		return nil, stacktrace.NewError("The crdb url may not contain a password, which must be set in a password file or environment variable")
+	}
+	return u, nil
+}
+
+// buildURL returns cp.URL, authenticating with password if not empty, with
+// the application name and pool size of cp unless set.
+func (cp ConnectParameters) buildURL(password string) (string, error) {
+	u, err := parseURL(cp.URL)
+	if err != nil {
+		return "", err // No need to Propagate this error as this stack layer does not add useful information
+	}
+
+	user := u.User.Username()
+	if user == "" {
+		user = cp.Credentials.Username
+	}
+	if user == "" {
+		return "", stacktrace.NewError("Missing crdb user")
+	}
+	if password != "" {
+		u.User = url.UserPassword(user, password)
+	} else {
+		u.User = url.User(user)
+	}
+
+	q := u.Query()
+	if q.Get("application_name") == "" {
+		an := cp.ApplicationName
+		if an == "" {
+			an = "dss"
+		}
+		q.Set("application_name", an)
+	}
+	if q.Get("pool_max_conns") == "" {
+		q.Set("pool_max_conns", strconv.Itoa(cp.MaxOpenConns))
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// rotateHosts rotates by n hosts the hosts attempted in order by config, so
+// that successive connections are spread over all hosts.
+func rotateHosts(config *pgconn.Config, n int) {
+	attempts := append([]*pgconn.FallbackConfig{{Host: config.Host, Port: config.Port, TLSConfig: config.TLSConfig}}, config.Fallbacks...)
+
+	// Consecutive attempts may target the same host, e.g. with and without
+	// TLS, and are rotated together.
+	var hosts [][]*pgconn.FallbackConfig
+	for _, attempt := range attempts {
+		if last := len(hosts) - 1; last >= 0 && hosts[last][0].Host == attempt.Host && hosts[last][0].Port == attempt.Port {
+			hosts[last] = append(hosts[last], attempt)
+			continue
+		}
+		hosts = append(hosts, []*pgconn.FallbackConfig{attempt})
+	}
+	if len(hosts) < 2 {
+		return
+	}
+
+	n %= len(hosts)
+	rotated := make([]*pgconn.FallbackConfig, 0, len(attempts))
+	for i := range hosts {
+		rotated = append(rotated, hosts[(n+i)%len(hosts)]...)
+	}
+	config.Host, config.Port, config.TLSConfig = rotated[0].Host, rotated[0].Port, rotated[0].TLSConfig
+	config.Fallbacks = rotated[1:]
+}
+
 // Dial returns a DB instance connected to a cockroach instance available at
 // "uri".
 // https://www.cockroachlabs.com/docs/stable/connection-parameters.html
@@ -156,21 +392,32 @@ func Dial(ctx context.Context, connParams ConnectParameters) (*DB, error) {
 	}
 
 	if connParams.SSL.Mode == "enable" {
-		config.ConnConfig.TLSConfig.ServerName = connParams.Host
+		config.ConnConfig.TLSConfig.ServerName = config.ConnConfig.Host
 	}
 	config.MaxConns = int32(connParams.MaxOpenConns)
 	config.MaxConnIdleTime = (time.Duration(connParams.MaxConnIdleSeconds) * time.Second)
 	config.HealthCheckPeriod = (1 * time.Second)
 	config.MinConns = 1
+	if connParams.LoadBalanceHosts {
+		var connections uint32
+		config.BeforeConnect = func(ctx context.Context, config *pgx.ConnConfig) error {
+			rotateHosts(&config.Config, int(atomic.AddUint32(&connections, 1)-1))
+			return nil
+		}
+	}
+
+	db := &DB{}
+	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+		return db.checkGatewayRegion(ctx, conn)
+	}
 
-	db, err := pgxpool.ConnectConfig(ctx, config)
+	pool, err := pgxpool.ConnectConfig(ctx, config)
 	if err != nil {
 		return nil, err
 	}
+	db.Pool = pool
 
-	return &DB{
-		Pool: db,
-	}, nil
+	return db, nil
 }
 
 // GetVersion returns the Schema Version of the requested DB Name
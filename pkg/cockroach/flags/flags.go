@@ -15,15 +15,30 @@ func ConnectParameters() cockroach.ConnectParameters {
 	return connectParameters
 }
 
+// BoundConnectParameters returns the ConnectParameters populated from
+// well-known CLI flags, so that they may also be loaded from a configuration
+// file or the environment.
+func BoundConnectParameters() *cockroach.ConnectParameters {
+	return &connectParameters
+}
+
 func init() {
 	flag.StringVar(&connectParameters.ApplicationName, "cockroach_application_name", "dss", "application name for tagging the connection to cockroach")
 	flag.StringVar(&connectParameters.DBName, "cockroach_db_name", "dss", "application name for tagging the connection to cockroach")
-	flag.StringVar(&connectParameters.Host, "cockroach_host", "", "cockroach host to connect to")
+	flag.StringVar(&connectParameters.URL, "cockroach_url", "", "postgresql:// URL of the cockroach database to connect to, without password, replacing cockroach_host, cockroach_port, cockroach_db_name and the cockroach_ssl_* flags")
+	flag.StringVar(&connectParameters.Host, "cockroach_host", "", "cockroach host to connect to, or comma-separated list of <host>[:<port>] attempted in order")
+	flag.BoolVar(&connectParameters.LoadBalanceHosts, "cockroach_load_balance_hosts", false, "spread the connections over all the hosts of cockroach_host rather than connecting to the first available host")
 	flag.IntVar(&connectParameters.Port, "cockroach_port", 26257, "cockroach port to connect to")
 	flag.StringVar(&connectParameters.SSL.Mode, "cockroach_ssl_mode", "disable", "cockroach sslmode")
-	flag.StringVar(&connectParameters.SSL.Dir, "cockroach_ssl_dir", "", "directory to ssl certificates. Must contain files: ca.crt, client.<user>.crt, client.<user>.key")
+	flag.StringVar(&connectParameters.SSL.Dir, "cockroach_ssl_dir", "", "directory to ssl certificates. Must contain files: ca.crt, and client.<user>.crt, client.<user>.key unless authenticating with a password")
+	flag.StringVar(&connectParameters.SSL.RootCert, "cockroach_ssl_root_cert", "", "path to the CA certificate, overriding ca.crt of cockroach_ssl_dir")
+	flag.StringVar(&connectParameters.SSL.Cert, "cockroach_ssl_cert", "", "path to the client certificate, overriding client.<user>.crt of cockroach_ssl_dir")
+	flag.StringVar(&connectParameters.SSL.Key, "cockroach_ssl_key", "", "path to the client key, overriding client.<user>.key of cockroach_ssl_dir")
 	flag.StringVar(&connectParameters.Credentials.Username, "cockroach_user", "root", "cockroach user to authenticate as")
+	flag.StringVar(&connectParameters.Credentials.PasswordFile, "cockroach_password_file", "", "path to a file containing the password to authenticate with")
+	flag.StringVar(&connectParameters.Credentials.PasswordEnv, "cockroach_password_env", "", "name of the environment variable containing the password to authenticate with")
 	flag.IntVar(&connectParameters.MaxOpenConns, "max_open_conns", 4, "maximum number of open connections to the database, default is 4")
 	flag.IntVar(&connectParameters.MaxConnIdleSeconds, "max_conn_idle_secs", 30, "maximum amount of time in seconds a connection may be idle, default is 30 seconds")
+	flag.StringVar(&connectParameters.RegionHosts, "cockroach_region_hosts", "", "cockroach host to connect to in each region, as a comma-separated list of <region>=<host>[:<port>]. The host of the region matching the locality of the DSS instance is connected to instead of cockroach_host")
 	flag.IntVar(&connectParameters.MaxRetries, "cockroach_max_retries", 100, "maximum number of attempts to retry a query in case of contention, default is 100")
 }
@@ -0,0 +1,89 @@
+package cockroach
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dsssql "github.com/interuss/dss/pkg/sql"
+	"github.com/interuss/stacktrace"
+	"github.com/jackc/pgx/v4"
+)
+
+// LeaseTable stores the leases electing, in each locality, the single holder
+// running each scheduled job, in a table of a database.
+type LeaseTable struct {
+	q       dsssql.Queryable
+	table   string
+	enabled bool
+}
+
+// NewLeaseTable returns a LeaseTable accessing table through q. If enabled is
+// false, which is expected when the database schema predates the leases
+// table, every holder is granted every lease.
+func NewLeaseTable(q dsssql.Queryable, table string, enabled bool) *LeaseTable {
+	return &LeaseTable{
+		q:       q,
+		table:   table,
+		enabled: enabled,
+	}
+}
+
+// AcquireLease acquires or renews the lease name in locality for holder, for
+// ttl from now. It returns false if the lease is held by another holder and
+// has not expired.
+func (t *LeaseTable) AcquireLease(ctx context.Context, name string, locality string, holder string, ttl time.Duration) (bool, error) {
+	if !t.enabled {
+		return true, nil
+	}
+
+	var query = fmt.Sprintf(`
+		INSERT INTO
+			%[1]s
+			(name, locality, holder, expires_at)
+		VALUES
+			($1, $2, $3, now() + $4::INT8 * INTERVAL '1 microsecond')
+		ON CONFLICT
+			(name, locality)
+		DO UPDATE SET
+			holder = excluded.holder,
+			expires_at = excluded.expires_at
+		WHERE
+			%[1]s.holder = excluded.holder
+		OR
+			%[1]s.expires_at < now()
+		RETURNING
+			holder`, t.table)
+
+	var got string
+	err := t.q.QueryRow(ctx, query, name, locality, holder, ttl.Microseconds()).Scan(&got)
+	switch {
+	case err == pgx.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	return got == holder, nil
+}
+
+// ReleaseLease releases the lease name in locality if held by holder.
+func (t *LeaseTable) ReleaseLease(ctx context.Context, name string, locality string, holder string) error {
+	if !t.enabled {
+		return nil
+	}
+
+	var query = fmt.Sprintf(`
+		DELETE FROM
+			%s
+		WHERE
+			name = $1
+		AND
+			locality = $2
+		AND
+			holder = $3`, t.table)
+
+	if _, err := t.q.Exec(ctx, query, name, locality, holder); err != nil {
+		return stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	return nil
+}
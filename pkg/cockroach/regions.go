@@ -0,0 +1,266 @@
+package cockroach
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/interuss/stacktrace"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	// multiRegionBegin and multiRegionEnd delimit the statements of a
+	// migration which may only run against multi-region databases.
+	multiRegionBegin = "-- multi-region: begin"
+	multiRegionEnd   = "-- multi-region: end"
+)
+
+// SplitMultiRegionSQL splits the statements of a migration script into the
+// statements running against any database and the statements delimited by
+// multi-region markers, which may only run against multi-region databases.
+func SplitMultiRegionSQL(sql string) (common string, multiRegion string, err error) {
+	var (
+		commonLines      []string
+		multiRegionLines []string
+		inMultiRegion    bool
+	)
+	for i, line := range strings.Split(sql, "\n") {
+		switch strings.TrimSpace(line) {
+		case multiRegionBegin:
+			if inMultiRegion {
+				return "", "", stacktrace.NewError("Nested multi-region section at line %d", i+1)
+			}
+			inMultiRegion = true
+		case multiRegionEnd:
+			if !inMultiRegion {
+				return "", "", stacktrace.NewError("Unopened multi-region section ended at line %d", i+1)
+			}
+			inMultiRegion = false
+		default:
+			if inMultiRegion {
+				multiRegionLines = append(multiRegionLines, line)
+			} else {
+				commonLines = append(commonLines, line)
+			}
+		}
+	}
+	if inMultiRegion {
+		return "", "", stacktrace.NewError("Unterminated multi-region section")
+	}
+	return strings.Join(commonLines, "\n"), strings.Join(multiRegionLines, "\n"), nil
+}
+
+// ParseRegionHosts parses the hosts to connect to in each region, expressed
+// as a comma-separated list of <region>=<host>[:<port>], e.g.
+// "us-east1=crdb.us-east1.example.com,europe-west1=crdb.europe-west1.example.com:26258".
+// A port of 0 denotes the default port.
+func ParseRegionHosts(s string) (map[string]HostPort, error) {
+	hosts := map[string]HostPort{}
+	if s == "" {
+		return hosts, nil
+	}
+	for _, entry := range strings.Split(s, ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, stacktrace.NewError("Region host `%s` must be of the form <region>=<host>[:<port>]", entry)
+		}
+		hp, err := parseHostPort(parts[1])
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Invalid region host `%s`", entry)
+		}
+		hosts[parts[0]] = hp
+	}
+	return hosts, nil
+}
+
+// ParseHosts parses the hosts to connect to, expressed as a comma-separated
+// list of <host>[:<port>], e.g. "crdb-1.example.com,crdb-2.example.com:26258".
+// A port of 0 denotes the default port.
+func ParseHosts(s string) ([]HostPort, error) {
+	var hosts []HostPort
+	if s == "" {
+		return hosts, nil
+	}
+	for _, entry := range strings.Split(s, ",") {
+		hp, err := parseHostPort(strings.TrimSpace(entry))
+		if err != nil {
+			return nil, err // No need to Propagate this error as this stack layer does not add useful information
+		}
+		hosts = append(hosts, hp)
+	}
+	return hosts, nil
+}
+
+func parseHostPort(s string) (HostPort, error) {
+	if s == "" {
+		return HostPort{}, stacktrace.NewError("Empty host")
+	}
+	i := strings.LastIndex(s, ":")
+	if i < 0 {
+		return HostPort{Host: s}, nil
+	}
+	port, err := strconv.Atoi(s[i+1:])
+	if err != nil || port <= 0 || port > 65535 || i == 0 {
+		return HostPort{}, stacktrace.NewError("Host `%s` must be of the form <host>[:<port>]", s)
+	}
+	return HostPort{Host: s[:i], Port: port}, nil
+}
+
+// HostPort identifies a CRDB node to connect to.
+type HostPort struct {
+	Host string
+	Port int
+}
+
+// regionalHosts returns the hosts to connect to, in order, which are the
+// host of the region of cp if one is configured, and the hosts of cp
+// otherwise. Hosts without port have the port of cp.
+func (cp ConnectParameters) regionalHosts() ([]HostPort, error) {
+	regionHosts, err := ParseRegionHosts(cp.RegionHosts)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Invalid crdb region hosts")
+	}
+	hosts := []HostPort{}
+	if hp, ok := regionHosts[cp.Region]; ok && cp.Region != "" {
+		hosts = append(hosts, hp)
+	} else {
+		hosts, err = ParseHosts(cp.Host)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Invalid crdb host")
+		}
+	}
+	for i := range hosts {
+		if hosts[i].Port == 0 {
+			hosts[i].Port = cp.Port
+		}
+	}
+	return hosts, nil
+}
+
+// ClusterRegions returns the regions of the nodes of the cluster.
+func (db *DB) ClusterRegions(ctx context.Context) ([]string, error) {
+	const query = `SELECT region FROM [SHOW REGIONS FROM CLUSTER] ORDER BY region`
+	return db.queryRegions(ctx, query)
+}
+
+// DatabaseRegions returns the regions of database dbName, the primary region
+// first, or none if dbName is not multi-region.
+func (db *DB) DatabaseRegions(ctx context.Context, dbName string) ([]string, error) {
+	query := fmt.Sprintf(`SELECT region FROM [SHOW REGIONS FROM DATABASE %s] ORDER BY "primary" DESC, region`, dbName)
+	return db.queryRegions(ctx, query)
+}
+
+func (db *DB) queryRegions(ctx context.Context, query string) ([]string, error) {
+	rows, err := db.Pool.Query(ctx, query)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	defer rows.Close()
+
+	var regions []string
+	for rows.Next() {
+		var region string
+		if err := rows.Scan(&region); err != nil {
+			return nil, stacktrace.Propagate(err, "Error scanning region row")
+		}
+		regions = append(regions, region)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, stacktrace.Propagate(err, "Error in rows query result")
+	}
+	return regions, nil
+}
+
+// SetDatabaseRegions makes database dbName multi-region, with regions[0] as
+// its primary region and the other regions as additional regions. All
+// regions must be regions of the cluster.
+func (db *DB) SetDatabaseRegions(ctx context.Context, dbName string, regions []string) error {
+	if len(regions) == 0 {
+		return stacktrace.NewError("Missing regions for database %s", dbName)
+	}
+	clusterRegions, err := db.ClusterRegions(ctx)
+	if err != nil {
+		return stacktrace.Propagate(err, "Failed to list the regions of the cluster")
+	}
+	for _, region := range regions {
+		if !containsRegion(clusterRegions, region) {
+			return stacktrace.NewError("Region `%s` is not one of the regions of the cluster: %s", region, strings.Join(clusterRegions, ", "))
+		}
+	}
+
+	queries := []string{fmt.Sprintf(`ALTER DATABASE %s SET PRIMARY REGION "%s"`, dbName, regions[0])}
+	for _, region := range regions[1:] {
+		queries = append(queries, fmt.Sprintf(`ALTER DATABASE %s ADD REGION IF NOT EXISTS "%s"`, dbName, region))
+	}
+	for _, query := range queries {
+		if _, err := db.Pool.Exec(ctx, query); err != nil {
+			return stacktrace.Propagate(err, "Error in query: %s", query)
+		}
+	}
+	return nil
+}
+
+// ValidateLocality returns whether database dbName is multi-region, in which
+// case it validates that locality, the locality of the DSS instance, is one
+// of the regions of the database, and that db is connected to nodes of that
+// region so that the rows written through db are homed in it. The
+// connections db establishes afterwards are validated as they are
+// established.
+func (db *DB) ValidateLocality(ctx context.Context, dbName string, locality string) (bool, error) {
+	regions, err := db.DatabaseRegions(ctx, dbName)
+	if err != nil {
+		return false, stacktrace.Propagate(err, "Failed to list the regions of database %s", dbName)
+	}
+	if len(regions) == 0 {
+		return false, nil
+	}
+	if !containsRegion(regions, locality) {
+		return false, stacktrace.NewError("Locality `%s` is not one of the regions of the multi-region database %s: %s", locality, dbName, strings.Join(regions, ", "))
+	}
+
+	db.region.Store(locality)
+	conns := db.Pool.AcquireAllIdle(ctx)
+	defer func() {
+		for _, conn := range conns {
+			conn.Release()
+		}
+	}()
+	for _, conn := range conns {
+		if err := db.checkGatewayRegion(ctx, conn.Conn()); err != nil {
+			// The connection is not returned to the pool.
+			conn.Conn().Close(ctx)
+			return false, err // No need to Propagate this error as this stack layer does not add useful information
+		}
+	}
+	return true, nil
+}
+
+// checkGatewayRegion returns an error if conn is not established to a node of
+// the region of db, if any.
+func (db *DB) checkGatewayRegion(ctx context.Context, conn *pgx.Conn) error {
+	region, _ := db.region.Load().(string)
+	if region == "" {
+		return nil
+	}
+
+	const query = `SELECT gateway_region()`
+	var gateway string
+	if err := conn.QueryRow(ctx, query).Scan(&gateway); err != nil {
+		return stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	if gateway != region {
+		return stacktrace.NewError("Connected to a node of region `%s` rather than of the locality `%s`; set the host of the region in the crdb region hosts", gateway, region)
+	}
+	return nil
+}
+
+func containsRegion(regions []string, region string) bool {
+	for _, r := range regions {
+		if r == region {
+			return true
+		}
+	}
+	return false
+}
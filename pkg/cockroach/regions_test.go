@@ -0,0 +1,69 @@
+package cockroach
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitMultiRegionSQL(t *testing.T) {
+	common, multiRegion, err := SplitMultiRegionSQL(`ALTER TABLE a ADD COLUMN b STRING;
+-- multi-region: begin
+ALTER TABLE a SET LOCALITY REGIONAL BY ROW;
+-- multi-region: end
+UPDATE schema_versions set schema_version = 'v1.1.0' WHERE onerow_enforcer = TRUE;`)
+	require.NoError(t, err)
+	require.Equal(t, "ALTER TABLE a ADD COLUMN b STRING;\nUPDATE schema_versions set schema_version = 'v1.1.0' WHERE onerow_enforcer = TRUE;", common)
+	require.Equal(t, "ALTER TABLE a SET LOCALITY REGIONAL BY ROW;", multiRegion)
+
+	for _, sql := range []string{
+		"-- multi-region: begin\n-- multi-region: begin",
+		"-- multi-region: end",
+		"-- multi-region: begin\nALTER TABLE a SET LOCALITY GLOBAL;",
+	} {
+		_, _, err := SplitMultiRegionSQL(sql)
+		require.Error(t, err)
+	}
+}
+
+func TestSplitMultiRegionSQLOfMigrations(t *testing.T) {
+	files, err := filepath.Glob("../../build/deploy/db_schemas/*/*.sql")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+	for _, file := range files {
+		sql, err := ioutil.ReadFile(file)
+		require.NoError(t, err)
+		_, _, err = SplitMultiRegionSQL(string(sql))
+		require.NoError(t, err, file)
+	}
+}
+
+func TestParseRegionHosts(t *testing.T) {
+	hosts, err := ParseRegionHosts("us-east1=crdb.us-east1, europe-west1=crdb.europe-west1:26258")
+	require.NoError(t, err)
+	require.Equal(t, map[string]HostPort{
+		"us-east1":     {Host: "crdb.us-east1"},
+		"europe-west1": {Host: "crdb.europe-west1", Port: 26258},
+	}, hosts)
+
+	for _, s := range []string{"us-east1", "=crdb.us-east1", "us-east1=crdb.us-east1:port"} {
+		_, err := ParseRegionHosts(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestParseHosts(t *testing.T) {
+	hosts, err := ParseHosts("crdb-1, crdb-2:26258")
+	require.NoError(t, err)
+	require.Equal(t, []HostPort{
+		{Host: "crdb-1"},
+		{Host: "crdb-2", Port: 26258},
+	}, hosts)
+
+	for _, s := range []string{"crdb-1,", ":26257", "crdb-1:0"} {
+		_, err := ParseHosts(s)
+		require.Error(t, err, s)
+	}
+}
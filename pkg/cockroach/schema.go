@@ -0,0 +1,270 @@
+package cockroach
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/interuss/stacktrace"
+)
+
+type (
+	// Schema describes the tables of a database, as compared to detect
+	// schema drift. Hidden columns and the implicit columns of indexes,
+	// such as the region of REGIONAL BY ROW tables, are not part of the
+	// schema, so that the schema does not depend on the regions of the
+	// database.
+	Schema struct {
+		Tables []Table `json:"tables"`
+	}
+
+	// Table describes a table of a Schema.
+	Table struct {
+		Name    string   `json:"name"`
+		Columns []Column `json:"columns"`
+		Indexes []Index  `json:"indexes"`
+	}
+
+	// Column describes a column of a Table.
+	Column struct {
+		Name     string `json:"name"`
+		Type     string `json:"type"`
+		Nullable bool   `json:"nullable"`
+	}
+
+	// Index describes an index of a Table. Its columns are listed in order
+	// along with their direction, e.g. "id ASC".
+	Index struct {
+		Name    string   `json:"name"`
+		Unique  bool     `json:"unique"`
+		Columns []string `json:"columns"`
+		Storing []string `json:"storing,omitempty"`
+	}
+)
+
+// Schema returns the schema of the public tables of database dbName.
+func (db *DB) Schema(ctx context.Context, dbName string) (*Schema, error) {
+	tables := map[string]*Table{}
+	var names []string
+	table := func(name string) *Table {
+		t, ok := tables[name]
+		if !ok {
+			t = &Table{Name: name, Columns: []Column{}, Indexes: []Index{}}
+			tables[name] = t
+			names = append(names, name)
+		}
+		return t
+	}
+
+	tablesQuery := fmt.Sprintf(`
+		SELECT
+			table_name
+		FROM
+			%s.information_schema.tables
+		WHERE
+			table_schema = 'public'
+		AND
+			table_type = 'BASE TABLE'
+		ORDER BY
+			table_name`, dbName)
+	rows, err := db.Pool.Query(ctx, tablesQuery)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error in query: %s", tablesQuery)
+	}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return nil, stacktrace.Propagate(err, "Error scanning table row")
+		}
+		table(name)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, stacktrace.Propagate(err, "Error in rows query result")
+	}
+
+	columnsQuery := fmt.Sprintf(`
+		SELECT
+			table_name, column_name, crdb_sql_type, is_nullable = 'YES'
+		FROM
+			%s.information_schema.columns
+		WHERE
+			table_schema = 'public'
+		AND
+			is_hidden = 'NO'
+		ORDER BY
+			table_name, ordinal_position`, dbName)
+	rows, err = db.Pool.Query(ctx, columnsQuery)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error in query: %s", columnsQuery)
+	}
+	for rows.Next() {
+		var (
+			tableName string
+			c         Column
+		)
+		if err := rows.Scan(&tableName, &c.Name, &c.Type, &c.Nullable); err != nil {
+			rows.Close()
+			return nil, stacktrace.Propagate(err, "Error scanning column row")
+		}
+		if t, ok := tables[tableName]; ok {
+			t.Columns = append(t.Columns, c)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, stacktrace.Propagate(err, "Error in rows query result")
+	}
+
+	indexesQuery := fmt.Sprintf(`
+		SELECT
+			table_name, index_name, NOT non_unique, column_name, direction, storing
+		FROM
+			[SHOW INDEXES FROM DATABASE %s]
+		WHERE
+			table_schema = 'public'
+		AND
+			NOT implicit
+		ORDER BY
+			table_name, index_name, seq_in_index`, dbName)
+	rows, err = db.Pool.Query(ctx, indexesQuery)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error in query: %s", indexesQuery)
+	}
+	for rows.Next() {
+		var (
+			tableName, indexName, column, direction string
+			unique, storing                         bool
+		)
+		if err := rows.Scan(&tableName, &indexName, &unique, &column, &direction, &storing); err != nil {
+			rows.Close()
+			return nil, stacktrace.Propagate(err, "Error scanning index row")
+		}
+		t, ok := tables[tableName]
+		if !ok {
+			continue
+		}
+		if len(t.Indexes) == 0 || t.Indexes[len(t.Indexes)-1].Name != indexName {
+			t.Indexes = append(t.Indexes, Index{Name: indexName, Unique: unique, Columns: []string{}})
+		}
+		index := &t.Indexes[len(t.Indexes)-1]
+		if storing {
+			index.Storing = append(index.Storing, column)
+		} else {
+			index.Columns = append(index.Columns, column+" "+direction)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, stacktrace.Propagate(err, "Error in rows query result")
+	}
+
+	schema := &Schema{Tables: []Table{}}
+	for _, name := range names {
+		t := tables[name]
+		sort.Slice(t.Indexes, func(i, j int) bool { return t.Indexes[i].Name < t.Indexes[j].Name })
+		for i := range t.Indexes {
+			sort.Strings(t.Indexes[i].Storing)
+		}
+		schema.Tables = append(schema.Tables, *t)
+	}
+	return schema, nil
+}
+
+// Diff returns the differences of schema live from the expected schema s,
+// e.g. "table scd_operations: unexpected column notes".
+func (s *Schema) Diff(live *Schema) []string {
+	var diffs []string
+	expectedTables, liveTables := tablesByName(s), tablesByName(live)
+	for _, t := range s.Tables {
+		lt, ok := liveTables[t.Name]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("missing table %s", t.Name))
+			continue
+		}
+		for _, d := range t.diff(lt) {
+			diffs = append(diffs, fmt.Sprintf("table %s: %s", t.Name, d))
+		}
+	}
+	for _, t := range live.Tables {
+		if _, ok := expectedTables[t.Name]; !ok {
+			diffs = append(diffs, fmt.Sprintf("unexpected table %s", t.Name))
+		}
+	}
+	return diffs
+}
+
+func (t Table) diff(live Table) []string {
+	var diffs []string
+
+	liveColumns := map[string]Column{}
+	for _, c := range live.Columns {
+		liveColumns[c.Name] = c
+	}
+	expectedColumns := map[string]bool{}
+	for _, c := range t.Columns {
+		expectedColumns[c.Name] = true
+		lc, ok := liveColumns[c.Name]
+		switch {
+		case !ok:
+			diffs = append(diffs, fmt.Sprintf("missing column %s", c.Name))
+		case lc != c:
+			diffs = append(diffs, fmt.Sprintf("column %s is %s, expected %s", c.Name, lc, c))
+		}
+	}
+	for _, c := range live.Columns {
+		if !expectedColumns[c.Name] {
+			diffs = append(diffs, fmt.Sprintf("unexpected column %s", c.Name))
+		}
+	}
+
+	liveIndexes := map[string]Index{}
+	for _, index := range live.Indexes {
+		liveIndexes[index.Name] = index
+	}
+	expectedIndexes := map[string]bool{}
+	for _, index := range t.Indexes {
+		expectedIndexes[index.Name] = true
+		li, ok := liveIndexes[index.Name]
+		switch {
+		case !ok:
+			diffs = append(diffs, fmt.Sprintf("missing index %s", index.Name))
+		case li.String() != index.String():
+			diffs = append(diffs, fmt.Sprintf("index %s is %s, expected %s", index.Name, li, index))
+		}
+	}
+	for _, index := range live.Indexes {
+		if !expectedIndexes[index.Name] {
+			diffs = append(diffs, fmt.Sprintf("unexpected index %s", index.Name))
+		}
+	}
+	return diffs
+}
+
+func tablesByName(s *Schema) map[string]Table {
+	tables := map[string]Table{}
+	for _, t := range s.Tables {
+		tables[t.Name] = t
+	}
+	return tables
+}
+
+func (c Column) String() string {
+	if c.Nullable {
+		return c.Type + " NULL"
+	}
+	return c.Type + " NOT NULL"
+}
+
+func (index Index) String() string {
+	s := "(" + strings.Join(index.Columns, ", ") + ")"
+	if index.Unique {
+		s = "UNIQUE " + s
+	}
+	if len(index.Storing) > 0 {
+		s += " STORING (" + strings.Join(index.Storing, ", ") + ")"
+	}
+	return s
+}
@@ -0,0 +1,52 @@
+package cockroach
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaDiff(t *testing.T) {
+	expected := &Schema{Tables: []Table{
+		{
+			Name: "scd_operations",
+			Columns: []Column{
+				{Name: "id", Type: "UUID"},
+				{Name: "owner", Type: "STRING"},
+				{Name: "priority", Type: "INT4", Nullable: true},
+			},
+			Indexes: []Index{
+				{Name: "owner_idx", Columns: []string{"owner ASC"}},
+				{Name: "primary", Unique: true, Columns: []string{"id ASC"}},
+			},
+		},
+		{Name: "scd_leases"},
+	}}
+	require.Empty(t, expected.Diff(expected))
+
+	live := &Schema{Tables: []Table{
+		{
+			Name: "scd_operations",
+			Columns: []Column{
+				{Name: "id", Type: "UUID"},
+				{Name: "owner", Type: "STRING", Nullable: true},
+				{Name: "notes", Type: "STRING", Nullable: true},
+			},
+			Indexes: []Index{
+				{Name: "owner_idx", Columns: []string{"owner DESC"}, Storing: []string{"id"}},
+				{Name: "primary", Unique: true, Columns: []string{"id ASC"}},
+				{Name: "notes_idx", Columns: []string{"notes ASC"}},
+			},
+		},
+		{Name: "scd_notes"},
+	}}
+	require.Equal(t, []string{
+		"table scd_operations: column owner is STRING NULL, expected STRING NOT NULL",
+		"table scd_operations: missing column priority",
+		"table scd_operations: unexpected column notes",
+		"table scd_operations: index owner_idx is (owner DESC) STORING (id), expected (owner ASC)",
+		"table scd_operations: unexpected index notes_idx",
+		"missing table scd_leases",
+		"unexpected table scd_notes",
+	}, expected.Diff(live))
+}
@@ -0,0 +1,149 @@
+package cockroach
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach-go/v2/crdb/crdbpgx"
+	"github.com/interuss/stacktrace"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"google.golang.org/grpc"
+)
+
+// followerRead is the Staleness specification requesting follower reads.
+const followerRead = "follower"
+
+// Staleness describes how stale the data read by a read-only transaction may
+// be. The zero value reads the latest data.
+type Staleness struct {
+	// FollowerRead reads as of follower_read_timestamp(), the most recent
+	// time at which any replica, including the closest one, may serve reads.
+	FollowerRead bool
+	// Bound reads as of Bound in the past. Ignored if FollowerRead is set.
+	Bound time.Duration
+}
+
+// Fresh returns true if s reads the latest data.
+func (s Staleness) Fresh() bool {
+	return !s.FollowerRead && s.Bound <= 0
+}
+
+// asOfSystemTime returns the AS OF SYSTEM TIME expression of s.
+func (s Staleness) asOfSystemTime() string {
+	if s.FollowerRead {
+		return "follower_read_timestamp()"
+	}
+	return fmt.Sprintf("'-%dus'", s.Bound.Microseconds())
+}
+
+// ParseStaleness parses a Staleness expressed either as "follower" or as a
+// duration, e.g. "5s". An empty string results in a fresh Staleness.
+func ParseStaleness(s string) (Staleness, error) {
+	switch s {
+	case "":
+		return Staleness{}, nil
+	case followerRead:
+		return Staleness{FollowerRead: true}, nil
+	}
+	bound, err := time.ParseDuration(s)
+	if err != nil || bound < 0 {
+		return Staleness{}, stacktrace.NewError("Staleness `%s` must be either `%s` or a non-negative duration", s, followerRead)
+	}
+	return Staleness{Bound: bound}, nil
+}
+
+// StalenessPolicy maps the full names of gRPC methods, e.g.
+// "/ridpbv1.DiscoveryAndSynchronizationService/SearchIdentificationServiceAreas",
+// to the Staleness of their read-only transactions.
+type StalenessPolicy map[string]Staleness
+
+// ParseStalenessPolicy parses a StalenessPolicy expressed as a
+// comma-separated list of <method>=<staleness>, where <method> is the full
+// name of a gRPC method, e.g.
+// "/scdpb.UTMAPIUSSDSSAndUSSUSSService/GetSubscription=follower".
+func ParseStalenessPolicy(s string) (StalenessPolicy, error) {
+	policy := StalenessPolicy{}
+	if s == "" {
+		return policy, nil
+	}
+	for _, entry := range strings.Split(s, ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), "=", 2)
+		if len(parts) != 2 {
+			return nil, stacktrace.NewError("Staleness policy entry `%s` must be of the form <method>=<staleness>", entry)
+		}
+		if names := strings.Split(parts[0], "/"); len(names) != 3 || names[0] != "" || names[1] == "" || names[2] == "" {
+			return nil, stacktrace.NewError("Method `%s` of staleness policy entry `%s` must be a full gRPC method name of the form /<service>/<method>", parts[0], entry)
+		}
+		staleness, err := ParseStaleness(parts[1])
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Invalid staleness of method %s", parts[0])
+		}
+		policy[parts[0]] = staleness
+	}
+	return policy, nil
+}
+
+// Interceptor is a grpc Interceptor attaching the Staleness of the called
+// method to the context, for read-only transactions to pick it up.
+func (p StalenessPolicy) Interceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if staleness, ok := p[info.FullMethod]; ok {
+		ctx = WithStaleness(ctx, staleness)
+	}
+	return handler(ctx, req)
+}
+
+// StreamInterceptor is the equivalent of Interceptor for streaming RPCs.
+func (p StalenessPolicy) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if staleness, ok := p[info.FullMethod]; ok {
+		ss = &serverStreamWithContext{ServerStream: ss, ctx: WithStaleness(ss.Context(), staleness)}
+	}
+	return handler(srv, ss)
+}
+
+// serverStreamWithContext overrides the context of a grpc.ServerStream.
+type serverStreamWithContext struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStreamWithContext) Context() context.Context {
+	return s.ctx
+}
+
+type stalenessKey struct{}
+
+// WithStaleness returns a context in which read-only transactions read with
+// staleness s.
+func WithStaleness(ctx context.Context, s Staleness) context.Context {
+	return context.WithValue(ctx, stalenessKey{}, s)
+}
+
+// StalenessFromContext returns the Staleness attached to ctx, or a fresh
+// Staleness if none is.
+func StalenessFromContext(ctx context.Context) Staleness {
+	s, _ := ctx.Value(stalenessKey{}).(Staleness)
+	return s
+}
+
+// ExecuteReadOnlyTx executes f in a read-only transaction on pool, reading
+// with the Staleness attached to ctx. Fresh transactions are retried on
+// retry-able errors. Stale transactions read historical data, which does not
+// conflict with writers, and are not retried: AS OF SYSTEM TIME must be their
+// first statement, which rules out the savepoint of the retry loop.
+func ExecuteReadOnlyTx(ctx context.Context, pool *pgxpool.Pool, f func(pgx.Tx) error) error {
+	txOptions := pgx.TxOptions{AccessMode: pgx.ReadOnly}
+	staleness := StalenessFromContext(ctx)
+	if staleness.Fresh() {
+		return crdbpgx.ExecuteTx(ctx, pool, txOptions, f)
+	}
+	return pool.BeginTxFunc(ctx, txOptions, func(tx pgx.Tx) error {
+		query := "SET TRANSACTION AS OF SYSTEM TIME " + staleness.asOfSystemTime()
+		if _, err := tx.Exec(ctx, query); err != nil {
+			return stacktrace.Propagate(err, "Error in query: %s", query)
+		}
+		return f(tx)
+	})
+}
@@ -0,0 +1,116 @@
+package cockroach
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestParseStaleness(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		input   string
+		want    Staleness
+		wantErr bool
+	}{
+		{name: "empty", input: "", want: Staleness{}},
+		{name: "follower", input: "follower", want: Staleness{FollowerRead: true}},
+		{name: "duration", input: "5s", want: Staleness{Bound: 5 * time.Second}},
+		{name: "negative", input: "-5s", wantErr: true},
+		{name: "garbage", input: "leader", wantErr: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseStaleness(test.input)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+const (
+	searchISAs = "/ridpbv1.DiscoveryAndSynchronizationService/SearchIdentificationServiceAreas"
+	getISA     = "/ridpbv1.DiscoveryAndSynchronizationService/GetIdentificationServiceArea"
+)
+
+func TestParseStalenessPolicy(t *testing.T) {
+	policy, err := ParseStalenessPolicy(searchISAs + "=follower, " + getISA + "=250ms")
+	require.NoError(t, err)
+	require.Equal(t, StalenessPolicy{
+		searchISAs: {FollowerRead: true},
+		getISA:     {Bound: 250 * time.Millisecond},
+	}, policy)
+
+	_, err = ParseStalenessPolicy(searchISAs)
+	require.Error(t, err)
+	_, err = ParseStalenessPolicy(searchISAs + "=leader")
+	require.Error(t, err)
+	// Methods are identified by their full name.
+	_, err = ParseStalenessPolicy("SearchIdentificationServiceAreas=follower")
+	require.Error(t, err)
+	_, err = ParseStalenessPolicy("/SearchIdentificationServiceAreas=follower")
+	require.Error(t, err)
+}
+
+func TestStalenessAsOfSystemTime(t *testing.T) {
+	require.True(t, Staleness{}.Fresh())
+	require.Equal(t, "follower_read_timestamp()", Staleness{FollowerRead: true, Bound: time.Second}.asOfSystemTime())
+	require.Equal(t, "'-1500000us'", Staleness{Bound: 1500 * time.Millisecond}.asOfSystemTime())
+}
+
+func TestStalenessPolicyInterceptor(t *testing.T) {
+	policy := StalenessPolicy{searchISAs: {FollowerRead: true}}
+
+	var got Staleness
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		got = StalenessFromContext(ctx)
+		return nil, nil
+	}
+
+	_, err := policy.Interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: searchISAs}, handler)
+	require.NoError(t, err)
+	require.Equal(t, Staleness{FollowerRead: true}, got)
+
+	_, err = policy.Interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: getISA}, handler)
+	require.NoError(t, err)
+	require.True(t, got.Fresh())
+
+	// Methods of other services with the same name are not affected.
+	_, err = policy.Interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/ridpbv2.StandardRemoteIDAPIInterfacesService/SearchIdentificationServiceAreas"}, handler)
+	require.NoError(t, err)
+	require.True(t, got.Fresh())
+}
+
+// contextStream is a grpc.ServerStream with a context.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestStalenessPolicyStreamInterceptor(t *testing.T) {
+	const watch = "/watchpb.DSSWatchService/WatchSubscription"
+	policy := StalenessPolicy{watch: {Bound: time.Second}}
+
+	var got Staleness
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		got = StalenessFromContext(ss.Context())
+		return nil
+	}
+	ss := &contextStream{ctx: context.Background()}
+
+	require.NoError(t, policy.StreamInterceptor(nil, ss, &grpc.StreamServerInfo{FullMethod: watch}, handler))
+	require.Equal(t, Staleness{Bound: time.Second}, got)
+
+	require.NoError(t, policy.StreamInterceptor(nil, ss, &grpc.StreamServerInfo{FullMethod: "/svc/Other"}, handler))
+	require.True(t, got.Fresh())
+}
@@ -0,0 +1,149 @@
+package cockroach
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	dssmodels "github.com/interuss/dss/pkg/models"
+	dsssql "github.com/interuss/dss/pkg/sql"
+	"github.com/interuss/stacktrace"
+)
+
+const (
+	subscriptionEventFields = "subscription_id, notification_index, entity_type, entity_id, version, deleted, recorded_at"
+)
+
+// SubscriptionEventLog stores the dssmodels.SubscriptionEvents of
+// subscriptions in a table of a database, from which they can be replayed in
+// order of notification index.
+type SubscriptionEventLog struct {
+	q           dsssql.Queryable
+	table       string
+	enabled     bool
+	multiRegion bool
+}
+
+// NewSubscriptionEventLog returns a SubscriptionEventLog accessing table
+// through q. If enabled is false, which is expected when the database schema
+// predates the events table, events are discarded and listings fail. If
+// multiRegion is true, the rows of table are homed in the region of the node
+// through which they were written.
+func NewSubscriptionEventLog(q dsssql.Queryable, table string, enabled bool, multiRegion bool) *SubscriptionEventLog {
+	return &SubscriptionEventLog{
+		q:           q,
+		table:       table,
+		enabled:     enabled,
+		multiRegion: multiRegion,
+	}
+}
+
+// InsertSubscriptionEvents stores events. RecordedAt is set to the timestamp
+// of the current transaction.
+func (l *SubscriptionEventLog) InsertSubscriptionEvents(ctx context.Context, events []*dssmodels.SubscriptionEvent) error {
+	if !l.enabled || len(events) == 0 {
+		return nil
+	}
+
+	var (
+		values []string
+		args   []interface{}
+	)
+	for _, e := range events {
+		n := len(args)
+		values = append(values, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, transaction_timestamp())", n+1, n+2, n+3, n+4, n+5, n+6))
+		args = append(args, e.SubscriptionID.String(), e.NotificationIndex, e.EntityType, e.EntityID, e.Version, e.Deleted)
+	}
+	var query = fmt.Sprintf(`
+		UPSERT INTO
+			%s
+			(%s)
+		VALUES
+			%s`, l.table, subscriptionEventFields, strings.Join(values, ", "))
+
+	if _, err := l.q.Exec(ctx, query, args...); err != nil {
+		return stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	return nil
+}
+
+// ListSubscriptionEvents returns at most limit events of the subscription
+// identified by id with a notification index greater than afterIndex, in
+// order of notification index.
+func (l *SubscriptionEventLog) ListSubscriptionEvents(ctx context.Context, id dssmodels.ID, afterIndex int, limit int) ([]*dssmodels.SubscriptionEvent, error) {
+	if !l.enabled {
+		return nil, stacktrace.NewError("Subscription events are not supported by the current schema version of the database")
+	}
+
+	var query = fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			%s
+		WHERE
+			subscription_id = $1
+		AND
+			notification_index > $2
+		ORDER BY notification_index
+		LIMIT $3`, subscriptionEventFields, l.table)
+
+	rows, err := l.q.Query(ctx, query, id.String(), afterIndex, limit)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	defer rows.Close()
+
+	var events []*dssmodels.SubscriptionEvent
+	for rows.Next() {
+		var (
+			e              = new(dssmodels.SubscriptionEvent)
+			subscriptionID string
+		)
+		if err := rows.Scan(
+			&subscriptionID,
+			&e.NotificationIndex,
+			&e.EntityType,
+			&e.EntityID,
+			&e.Version,
+			&e.Deleted,
+			&e.RecordedAt,
+		); err != nil {
+			return nil, stacktrace.Propagate(err, "Error scanning subscription event row")
+		}
+		e.SubscriptionID = dssmodels.ID(subscriptionID)
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, stacktrace.Propagate(err, "Error in rows query result")
+	}
+	return events, nil
+}
+
+// DeleteSubscriptionEventsBefore deletes the events recorded before cutoff
+// and returns how many were deleted. In multi-region databases, only the
+// events homed in the region of the node executing the query are deleted, so
+// that the instances of each region purge their own events.
+func (l *SubscriptionEventLog) DeleteSubscriptionEventsBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	if !l.enabled {
+		return 0, nil
+	}
+
+	regionQuery := ""
+	if l.multiRegion {
+		regionQuery = `
+		AND
+			crdb_region = gateway_region()::crdb_internal_region`
+	}
+	var query = fmt.Sprintf(`
+		DELETE FROM
+			%s
+		WHERE
+			recorded_at < $1%s`, l.table, regionQuery)
+
+	tag, err := l.q.Exec(ctx, query, cutoff)
+	if err != nil {
+		return 0, stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	return tag.RowsAffected(), nil
+}
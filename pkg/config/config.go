@@ -0,0 +1,231 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/interuss/stacktrace"
+	"gopkg.in/yaml.v3"
+)
+
+// Redacted replaces the value of secret fields in the output of Redact.
+const Redacted = "REDACTED"
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Validator is implemented by configurations checking their own consistency.
+type Validator interface {
+	Validate() error
+}
+
+// Load populates cfg, a pointer to a struct whose fields are bound to the
+// flags of fs, from the YAML file at path, if not empty, and from environment
+// variables named after the YAML keys of the fields prefixed with envPrefix.
+// In order of precedence, a field is set from the command line, the
+// environment, the file and finally the default value of its flag. fs must
+// already be parsed. cfg is validated if it implements Validator.
+func Load(cfg interface{}, fs *flag.FlagSet, path string, envPrefix string) error {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return stacktrace.NewError("Configuration must be a pointer to a struct, not %T", cfg)
+	}
+
+	explicit := map[string]string{}
+	fs.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = f.Value.String()
+	})
+
+	if path != "" {
+		if err := loadFile(cfg, path); err != nil {
+			return err // No need to Propagate this error as this stack layer does not add useful information
+		}
+	}
+	if err := loadEnv(v.Elem(), envPrefix); err != nil {
+		return err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	for name, value := range explicit {
+		if err := fs.Set(name, value); err != nil {
+			return stacktrace.Propagate(err, "Error restoring flag %s", name)
+		}
+	}
+
+	if validator, ok := cfg.(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			return stacktrace.Propagate(err, "Invalid configuration")
+		}
+	}
+	return nil
+}
+
+func loadFile(cfg interface{}, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return stacktrace.Propagate(err, "Error opening configuration file")
+	}
+	defer f.Close()
+
+	decoder := yaml.NewDecoder(f)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return stacktrace.Propagate(err, "Error parsing configuration file %s", path)
+	}
+	return nil
+}
+
+// field describes how a struct field is named in YAML.
+type field struct {
+	name   string
+	inline bool
+	secret bool
+}
+
+func fieldOf(f reflect.StructField) (field, bool) {
+	if f.PkgPath != "" {
+		return field{}, false
+	}
+	tag := strings.Split(f.Tag.Get("yaml"), ",")
+	if tag[0] == "-" {
+		return field{}, false
+	}
+	result := field{name: tag[0], secret: f.Tag.Get("secret") == "true"}
+	if result.name == "" {
+		result.name = strings.ToLower(f.Name)
+	}
+	for _, option := range tag[1:] {
+		if option == "inline" {
+			result.inline = true
+		}
+	}
+	return result, true
+}
+
+// structOf returns the struct held by v, directly or through a non-nil
+// pointer.
+func structOf(v reflect.Value) (reflect.Value, bool) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	return v, v.Kind() == reflect.Struct
+}
+
+func loadEnv(v reflect.Value, prefix string) error {
+	for i := 0; i < v.NumField(); i++ {
+		f, ok := fieldOf(v.Type().Field(i))
+		if !ok {
+			continue
+		}
+		fv := v.Field(i)
+		if s, ok := structOf(fv); ok {
+			nested := prefix + strings.ToUpper(f.name) + "_"
+			if f.inline {
+				nested = prefix
+			}
+			if err := loadEnv(s, nested); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name := prefix + strings.ToUpper(f.name)
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if err := setValue(fv, value); err != nil {
+			return stacktrace.Propagate(err, "Invalid value of environment variable %s", name)
+		}
+	}
+	return nil
+}
+
+func setValue(v reflect.Value, value string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return stacktrace.Propagate(err, "Invalid duration")
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return stacktrace.Propagate(err, "Invalid boolean")
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return stacktrace.Propagate(err, "Invalid integer")
+		}
+		v.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		x, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return stacktrace.Propagate(err, "Invalid number")
+		}
+		v.SetFloat(x)
+	default:
+		return stacktrace.NewError("Unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// Redact returns the content of cfg, a struct or a pointer to a struct, keyed
+// by YAML names, with the value of non-empty fields tagged `secret:"true"`
+// replaced by Redacted. It is meant for logging the effective configuration.
+func Redact(cfg interface{}) map[string]interface{} {
+	s, ok := structOf(reflect.ValueOf(cfg))
+	if !ok {
+		return nil
+	}
+	result := map[string]interface{}{}
+	redact(s, result)
+	return result
+}
+
+func redact(v reflect.Value, result map[string]interface{}) {
+	for i := 0; i < v.NumField(); i++ {
+		f, ok := fieldOf(v.Type().Field(i))
+		if !ok {
+			continue
+		}
+		fv := v.Field(i)
+		switch {
+		case f.secret:
+			if !fv.IsZero() {
+				result[f.name] = Redacted
+			} else {
+				result[f.name] = ""
+			}
+		case fv.Type() == durationType:
+			result[f.name] = time.Duration(fv.Int()).String()
+		default:
+			s, ok := structOf(fv)
+			if !ok {
+				result[f.name] = fv.Interface()
+				continue
+			}
+			if f.inline {
+				redact(s, result)
+				continue
+			}
+			nested := map[string]interface{}{}
+			redact(s, nested)
+			result[f.name] = nested
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/interuss/stacktrace"
+	"github.com/stretchr/testify/require"
+)
+
+type testDatabase struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	Password string `yaml:"password" secret:"true"`
+	// Token is neither loaded nor dumped.
+	Token string `yaml:"-"`
+}
+
+type testConfig struct {
+	Addr     string        `yaml:"addr"`
+	Timeout  time.Duration `yaml:"timeout"`
+	Verbose  bool          `yaml:"verbose"`
+	Database *testDatabase `yaml:"database"`
+}
+
+func (c *testConfig) Validate() error {
+	if c.Database.Port <= 0 {
+		return stacktrace.NewError("Invalid database port %d", c.Database.Port)
+	}
+	return nil
+}
+
+func newTestConfig(t *testing.T, args ...string) (*testConfig, *flag.FlagSet) {
+	cfg := &testConfig{Database: &testDatabase{}}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.StringVar(&cfg.Addr, "addr", ":8080", "")
+	fs.DurationVar(&cfg.Timeout, "timeout", 10*time.Second, "")
+	fs.BoolVar(&cfg.Verbose, "verbose", false, "")
+	fs.StringVar(&cfg.Database.Host, "db_host", "localhost", "")
+	fs.IntVar(&cfg.Database.Port, "db_port", 26257, "")
+	require.NoError(t, fs.Parse(args))
+	return cfg, fs
+}
+
+func writeFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestLoadDefaults(t *testing.T) {
+	cfg, fs := newTestConfig(t)
+	require.NoError(t, Load(cfg, fs, "", "TEST_"))
+	require.Equal(t, ":8080", cfg.Addr)
+	require.Equal(t, 10*time.Second, cfg.Timeout)
+	require.Equal(t, 26257, cfg.Database.Port)
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	path := writeFile(t, `
+addr: ":9000"
+timeout: 30s
+verbose: true
+database:
+  host: crdb
+  port: 1234
+`)
+	t.Setenv("TEST_TIMEOUT", "1m")
+	t.Setenv("TEST_DATABASE_PORT", "5678")
+	t.Setenv("TEST_DATABASE_PASSWORD", "hunter2")
+
+	cfg, fs := newTestConfig(t, "--db_port=4321")
+	require.NoError(t, Load(cfg, fs, path, "TEST_"))
+
+	require.Equal(t, ":9000", cfg.Addr)         // From the file
+	require.Equal(t, time.Minute, cfg.Timeout)  // From the environment
+	require.True(t, cfg.Verbose)                // From the file
+	require.Equal(t, "crdb", cfg.Database.Host) // From the file
+	require.Equal(t, 4321, cfg.Database.Port)   // From the command line
+	require.Equal(t, "hunter2", cfg.Database.Password)
+}
+
+func TestLoadErrors(t *testing.T) {
+	cfg, fs := newTestConfig(t)
+	require.Error(t, Load(cfg, fs, filepath.Join(t.TempDir(), "missing.yaml"), "TEST_"))
+
+	cfg, fs = newTestConfig(t)
+	require.Error(t, Load(cfg, fs, writeFile(t, "unknown_key: 1\n"), "TEST_"))
+
+	cfg, fs = newTestConfig(t)
+	t.Setenv("TEST_VERBOSE", "sometimes")
+	require.Error(t, Load(cfg, fs, "", "TEST_"))
+	os.Unsetenv("TEST_VERBOSE")
+
+	cfg, fs = newTestConfig(t, "--db_port=0")
+	require.Error(t, Load(cfg, fs, "", "TEST_"))
+}
+
+func TestRedact(t *testing.T) {
+	cfg := &testConfig{
+		Addr:     ":8080",
+		Timeout:  time.Second,
+		Database: &testDatabase{Host: "crdb", Port: 26257, Password: "hunter2", Token: "t0ken"},
+	}
+	require.Equal(t, map[string]interface{}{
+		"addr":    ":8080",
+		"timeout": "1s",
+		"verbose": false,
+		"database": map[string]interface{}{
+			"host":     "crdb",
+			"port":     26257,
+			"password": Redacted,
+		},
+	}, Redact(cfg))
+}
@@ -0,0 +1,3 @@
+// Package config loads typed service configurations from YAML files,
+// environment variables and command line flags.
+package config
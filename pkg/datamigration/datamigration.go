@@ -0,0 +1,67 @@
+package datamigration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/coreos/go-semver/semver"
+	dsssql "github.com/interuss/dss/pkg/sql"
+)
+
+// Batch is the outcome of a BatchFunc.
+type Batch struct {
+	// Cursor identifies the last row migrated by the batch, from which the
+	// next batch resumes.
+	Cursor string
+	// Rows is the number of rows migrated by the batch.
+	Rows int64
+	// Done is true if no row remains to migrate.
+	Done bool
+}
+
+// BatchFunc migrates, through q, at most limit of the rows following cursor,
+// or the first rows if cursor is empty. It runs in a transaction which may be
+// retried, and must only change the database through q.
+type BatchFunc func(ctx context.Context, q dsssql.Queryable, cursor string, limit int) (Batch, error)
+
+// Step is a data migration step, migrating the database of schemas Schemas
+// (e.g. "rid" or "scd") up to Version from the previous version, or down from
+// Version to the previous version.
+type Step struct {
+	Schemas string
+	Version semver.Version
+	Name    string
+	Up      BatchFunc
+	// Down is nil if the step may not be reverted.
+	Down BatchFunc
+}
+
+var registry = map[string]map[semver.Version]Step{}
+
+// Register registers step, for it to run along with the SQL migration steps
+// of its schemas. It is meant to be called from init functions, and panics
+// if step is invalid or its version is already registered.
+func Register(step Step) {
+	if step.Schemas == "" || step.Name == "" || step.Up == nil {
+		panic(fmt.Sprintf("datamigration: invalid step %+v", step))
+	}
+	if registry[step.Schemas] == nil {
+		registry[step.Schemas] = map[semver.Version]Step{}
+	}
+	if _, ok := registry[step.Schemas][step.Version]; ok {
+		panic(fmt.Sprintf("datamigration: step %s v%s registered twice", step.Schemas, step.Version))
+	}
+	registry[step.Schemas][step.Version] = step
+}
+
+// Steps returns the steps registered for schemas, in ascending order of
+// version.
+func Steps(schemas string) []Step {
+	var steps []Step
+	for _, step := range registry[schemas] {
+		steps = append(steps, step)
+	}
+	sort.Slice(steps, func(i, j int) bool { return steps[i].Version.LessThan(steps[j].Version) })
+	return steps
+}
@@ -0,0 +1,36 @@
+package datamigration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/coreos/go-semver/semver"
+	dsssql "github.com/interuss/dss/pkg/sql"
+	"github.com/stretchr/testify/require"
+)
+
+func noop(ctx context.Context, q dsssql.Queryable, cursor string, limit int) (Batch, error) {
+	return Batch{Done: true}, nil
+}
+
+func TestRegister(t *testing.T) {
+	defer func() { registry = map[string]map[semver.Version]Step{} }()
+
+	Register(Step{Schemas: "scd", Version: *semver.New("3.12.0"), Name: "b", Up: noop})
+	Register(Step{Schemas: "scd", Version: *semver.New("3.9.1"), Name: "a", Up: noop})
+	Register(Step{Schemas: "rid", Version: *semver.New("4.5.0"), Name: "c", Up: noop})
+
+	var names []string
+	for _, step := range Steps("scd") {
+		names = append(names, step.Name)
+	}
+	require.Equal(t, []string{"a", "b"}, names)
+	require.Empty(t, Steps("aux"))
+
+	require.Panics(t, func() {
+		Register(Step{Schemas: "scd", Version: *semver.New("3.12.0"), Name: "d", Up: noop})
+	})
+	require.Panics(t, func() {
+		Register(Step{Schemas: "scd", Version: *semver.New("3.13.0"), Name: "e"})
+	})
+}
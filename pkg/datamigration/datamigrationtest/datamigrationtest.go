@@ -0,0 +1,136 @@
+// Package datamigrationtest tests data migration steps against a temporary
+// database of the CockroachDB cluster designated by the cockroach flags.
+package datamigrationtest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/google/uuid"
+	"github.com/interuss/dss/pkg/cockroach"
+	"github.com/interuss/dss/pkg/cockroach/flags"
+	"github.com/interuss/dss/pkg/datamigration"
+	dsssql "github.com/interuss/dss/pkg/sql"
+	"github.com/stretchr/testify/require"
+)
+
+// Case is a test case of a data migration step.
+type Case struct {
+	Step datamigration.Step
+	// PreviousVersion is the version preceding the version of Step.
+	PreviousVersion semver.Version
+	// Setup creates, through q, the tables of PreviousVersion and the rows
+	// to migrate.
+	Setup func(ctx context.Context, q dsssql.Queryable) error
+	// CheckUp checks, through q, the rows once migrated up.
+	CheckUp func(t *testing.T, ctx context.Context, q dsssql.Queryable)
+	// CheckDown checks, through q, the rows once migrated back down, if
+	// Step may be reverted.
+	CheckDown func(t *testing.T, ctx context.Context, q dsssql.Queryable)
+}
+
+// Run runs c.Step against a temporary database set up by c.Setup, one row
+// per batch, interrupting it after its first batch and resuming it, and then
+// checks the migrated rows with c.CheckUp. If c.Step may be reverted, it is
+// then reverted the same way and the rows are checked with c.CheckDown. t is
+// skipped if no database is designated by the cockroach flags.
+func Run(t *testing.T, c Case) {
+	ctx := context.Background()
+	connectParameters := flags.ConnectParameters()
+	if connectParameters.Host == "" && connectParameters.URL == "" {
+		t.Skip()
+	}
+
+	// Create a temporary database, also identifying the progress of its
+	// migrations.
+	dbName := "datamigration_test_" + uuid.New().String()[:8]
+	connectParameters.DBName = "postgres"
+	admin, err := cockroach.Dial(ctx, connectParameters)
+	require.NoError(t, err)
+	defer admin.Pool.Close()
+	_, err = admin.Pool.Exec(ctx, fmt.Sprintf("CREATE DATABASE %s", dbName))
+	require.NoError(t, err)
+	defer func() {
+		_, err := admin.Pool.Exec(ctx, fmt.Sprintf("DROP DATABASE %s CASCADE", dbName))
+		require.NoError(t, err)
+		_, err = admin.Pool.Exec(ctx, fmt.Sprintf("DELETE FROM %s WHERE database_name = $1", datamigration.ProgressTable), dbName)
+		require.NoError(t, err)
+	}()
+	require.NoError(t, datamigration.CreateProgressTable(ctx, admin))
+
+	connectParameters.DBName = dbName
+	db, err := cockroach.Dial(ctx, connectParameters)
+	require.NoError(t, err)
+	defer db.Pool.Close()
+	_, err = db.Pool.Exec(ctx, `
+		CREATE TABLE schema_versions (
+			onerow_enforcer bool PRIMARY KEY DEFAULT TRUE CHECK(onerow_enforcer),
+			schema_version STRING NOT NULL
+		)`)
+	require.NoError(t, err)
+	_, err = db.Pool.Exec(ctx, `INSERT INTO schema_versions (schema_version) VALUES ($1)`, "v"+c.PreviousVersion.String())
+	require.NoError(t, err)
+	require.NoError(t, c.Setup(ctx, db.Pool))
+
+	runner := &datamigration.Runner{DB: db, Schemas: dbName, BatchSize: 1, Logf: t.Logf}
+
+	runInterrupted(t, ctx, runner, c.Step, datamigration.Up, c.Step.Version)
+	c.CheckUp(t, ctx, db.Pool)
+
+	if c.Step.Down == nil {
+		return
+	}
+	runInterrupted(t, ctx, runner, c.Step, datamigration.Down, c.PreviousVersion)
+	c.CheckDown(t, ctx, db.Pool)
+}
+
+// runInterrupted runs step in direction d, failing it after its first batch,
+// then resumes it and checks that it completes at version toVersion.
+func runInterrupted(t *testing.T, ctx context.Context, runner *datamigration.Runner, step datamigration.Step, d datamigration.Direction, toVersion semver.Version) {
+	f := step.Up
+	if d == datamigration.Down {
+		f = step.Down
+	}
+
+	var (
+		batches int
+		done    bool
+	)
+	interrupted := step
+	interrupting := func(ctx context.Context, q dsssql.Queryable, cursor string, limit int) (datamigration.Batch, error) {
+		if batches > 0 {
+			return datamigration.Batch{}, fmt.Errorf("interrupted")
+		}
+		batches++
+		batch, err := f(ctx, q, cursor, limit)
+		done = batch.Done
+		return batch, err
+	}
+	interrupted.Up, interrupted.Down = interrupting, interrupting
+
+	err := runner.Run(ctx, interrupted, d, toVersion.String())
+	if !done {
+		require.Error(t, err, "%s %s should have been interrupted", step.Name, d)
+	} else {
+		require.NoError(t, err)
+	}
+
+	var resumedCursors []string
+	resumed := step
+	resuming := func(ctx context.Context, q dsssql.Queryable, cursor string, limit int) (datamigration.Batch, error) {
+		resumedCursors = append(resumedCursors, cursor)
+		return f(ctx, q, cursor, limit)
+	}
+	resumed.Up, resumed.Down = resuming, resuming
+	if !done {
+		require.NoError(t, runner.Run(ctx, resumed, d, toVersion.String()))
+		require.NotEmpty(t, resumedCursors)
+		require.NotEmpty(t, resumedCursors[0], "%s %s should have resumed after its first batch", step.Name, d)
+	}
+
+	var version string
+	require.NoError(t, runner.DB.Pool.QueryRow(ctx, `SELECT schema_version FROM schema_versions`).Scan(&version))
+	require.Equal(t, "v"+toVersion.String(), version)
+}
@@ -0,0 +1,91 @@
+package datamigrationtest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/interuss/dss/pkg/datamigration"
+	dsssql "github.com/interuss/dss/pkg/sql"
+	"github.com/interuss/stacktrace"
+	"github.com/stretchr/testify/require"
+)
+
+// setCase returns a BatchFunc setting the names of the owners to their upper
+// or lower case.
+func setCase(upper bool) datamigration.BatchFunc {
+	return func(ctx context.Context, q dsssql.Queryable, cursor string, limit int) (datamigration.Batch, error) {
+		const query = `SELECT id, name FROM owners WHERE id > $1 ORDER BY id LIMIT $2`
+		rows, err := q.Query(ctx, query, cursor, limit)
+		if err != nil {
+			return datamigration.Batch{}, stacktrace.Propagate(err, "Error in query: %s", query)
+		}
+		names := map[string]string{}
+		var ids []string
+		for rows.Next() {
+			var id, name string
+			if err := rows.Scan(&id, &name); err != nil {
+				rows.Close()
+				return datamigration.Batch{}, stacktrace.Propagate(err, "Error scanning owner row")
+			}
+			names[id] = name
+			ids = append(ids, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return datamigration.Batch{}, stacktrace.Propagate(err, "Error in rows query result")
+		}
+		if len(ids) == 0 {
+			return datamigration.Batch{Cursor: cursor, Done: true}, nil
+		}
+
+		for _, id := range ids {
+			name := strings.ToLower(names[id])
+			if upper {
+				name = strings.ToUpper(names[id])
+			}
+			if _, err := q.Exec(ctx, `UPDATE owners SET name = $1 WHERE id = $2`, name, id); err != nil {
+				return datamigration.Batch{}, stacktrace.Propagate(err, "Failed to update owner %s", id)
+			}
+		}
+		return datamigration.Batch{Cursor: ids[len(ids)-1], Rows: int64(len(ids)), Done: len(ids) < limit}, nil
+	}
+}
+
+func checkNames(want ...string) func(t *testing.T, ctx context.Context, q dsssql.Queryable) {
+	return func(t *testing.T, ctx context.Context, q dsssql.Queryable) {
+		rows, err := q.Query(ctx, `SELECT name FROM owners ORDER BY id`)
+		require.NoError(t, err)
+		defer rows.Close()
+		var got []string
+		for rows.Next() {
+			var name string
+			require.NoError(t, rows.Scan(&name))
+			got = append(got, name)
+		}
+		require.NoError(t, rows.Err())
+		require.Equal(t, want, got)
+	}
+}
+
+func TestRun(t *testing.T) {
+	Run(t, Case{
+		Step: datamigration.Step{
+			Schemas: "test",
+			Version: *semver.New("1.1.0"),
+			Name:    "upper_case_owners",
+			Up:      setCase(true),
+			Down:    setCase(false),
+		},
+		PreviousVersion: *semver.New("1.0.0"),
+		Setup: func(ctx context.Context, q dsssql.Queryable) error {
+			_, err := q.Exec(ctx, `
+				CREATE TABLE owners (id STRING PRIMARY KEY, name STRING NOT NULL);
+				INSERT INTO owners (id, name) VALUES ('a', 'uss1'), ('b', 'uss2'), ('c', 'uss3');`)
+			return err
+		},
+		CheckUp:   checkNames("USS1", "USS2", "USS3"),
+		CheckDown: checkNames("uss1", "uss2", "uss3"),
+	})
+}
@@ -0,0 +1,5 @@
+// Package datamigration runs the data migrations of the DSS databases which
+// are coded in Go rather than in SQL, such as the migrations transforming
+// rows. Each migration runs in batches, recording its progress with each
+// batch, so that an interrupted migration resumes after its last batch.
+package datamigration
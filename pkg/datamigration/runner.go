@@ -0,0 +1,155 @@
+package datamigration
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/cockroach-go/v2/crdb/crdbpgx"
+	"github.com/interuss/dss/pkg/cockroach"
+	"github.com/interuss/stacktrace"
+	"github.com/jackc/pgx/v4"
+)
+
+// ProgressTable records the progress of the data migration steps being run
+// against each database, in the initial database which exists whatever the
+// state of the migrated databases.
+const ProgressTable = "postgres.public.db_manager_data_migrations"
+
+// DefaultBatchSize is the default number of rows migrated per batch.
+const DefaultBatchSize = 1000
+
+// Direction is the direction in which a step runs.
+type Direction string
+
+const (
+	// Up migrates up to the version of a step.
+	Up Direction = "up"
+	// Down migrates down from the version of a step.
+	Down Direction = "down"
+)
+
+// Runner runs data migration steps against a database.
+type Runner struct {
+	// DB is connected to the migrated database.
+	DB *cockroach.DB
+	// Schemas are the schemas of the migrated database, e.g. "rid".
+	Schemas string
+	// BatchSize is the maximum number of rows migrated per batch, or
+	// DefaultBatchSize if 0.
+	BatchSize int
+	// Logf reports the progress of the steps, if not nil.
+	Logf func(format string, args ...interface{})
+}
+
+// CreateProgressTable creates ProgressTable, if missing, through db.
+func CreateProgressTable(ctx context.Context, db *cockroach.DB) error {
+	query := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			database_name STRING NOT NULL,
+			version STRING NOT NULL,
+			direction STRING NOT NULL,
+			batch_cursor STRING NOT NULL,
+			migrated_rows INT8 NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+			PRIMARY KEY (database_name, version, direction)
+		)`, ProgressTable)
+	if _, err := db.Pool.Exec(ctx, query); err != nil {
+		return stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	return nil
+}
+
+// Run runs step in direction d in batches, resuming after the last batch
+// recorded if the step was interrupted, and then sets the schema version of
+// the database to toVersion.
+func (r *Runner) Run(ctx context.Context, step Step, d Direction, toVersion string) error {
+	f := step.Up
+	if d == Down {
+		f = step.Down
+	}
+	if f == nil {
+		return stacktrace.NewError("Data migration %s v%s may not be run %s", step.Name, step.Version, d)
+	}
+
+	batchSize := r.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	version := step.Version.String()
+
+	var (
+		cursor string
+		rows   int64
+	)
+	loadQuery := fmt.Sprintf(`
+		SELECT
+			batch_cursor, migrated_rows
+		FROM
+			%s
+		WHERE
+			database_name = $1
+		AND
+			version = $2
+		AND
+			direction = $3`, ProgressTable)
+	err := r.DB.Pool.QueryRow(ctx, loadQuery, r.Schemas, version, string(d)).Scan(&cursor, &rows)
+	switch {
+	case err == pgx.ErrNoRows:
+	case err != nil:
+		return stacktrace.Propagate(err, "Error in query: %s", loadQuery)
+	default:
+		r.logf("Resuming data migration %s v%s %s after %d rows", step.Name, version, d, rows)
+	}
+
+	saveQuery := fmt.Sprintf(`
+		UPSERT INTO
+			%s
+			(database_name, version, direction, batch_cursor, migrated_rows, updated_at)
+		VALUES
+			($1, $2, $3, $4, $5, now())`, ProgressTable)
+	start := time.Now()
+	for done := false; !done; {
+		var batch Batch
+		err := crdbpgx.ExecuteTx(ctx, r.DB.Pool, pgx.TxOptions{}, func(tx pgx.Tx) error {
+			var err error
+			batch, err = f(ctx, tx, cursor, batchSize)
+			if err != nil {
+				return err // No need to Propagate this error as this stack layer does not add useful information
+			}
+			if _, err := tx.Exec(ctx, saveQuery, r.Schemas, version, string(d), batch.Cursor, rows+batch.Rows); err != nil {
+				return stacktrace.Propagate(err, "Error in query: %s", saveQuery)
+			}
+			return nil
+		})
+		if err != nil {
+			return stacktrace.Propagate(err, "Failed to migrate the batch of data migration %s v%s %s after %d rows", step.Name, version, d, rows)
+		}
+		cursor, rows, done = batch.Cursor, rows+batch.Rows, batch.Done
+		r.logf("Data migration %s v%s %s migrated %d rows in %s", step.Name, version, d, rows, time.Since(start).Round(time.Millisecond))
+	}
+
+	completeQueries := []string{
+		`UPDATE schema_versions SET schema_version = $1 WHERE onerow_enforcer = TRUE`,
+		fmt.Sprintf(`DELETE FROM %s WHERE database_name = $1 AND version = $2 AND direction = $3`, ProgressTable),
+	}
+	err = crdbpgx.ExecuteTx(ctx, r.DB.Pool, pgx.TxOptions{}, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, completeQueries[0], "v"+toVersion); err != nil {
+			return stacktrace.Propagate(err, "Error in query: %s", completeQueries[0])
+		}
+		if _, err := tx.Exec(ctx, completeQueries[1], r.Schemas, version, string(d)); err != nil {
+			return stacktrace.Propagate(err, "Error in query: %s", completeQueries[1])
+		}
+		return nil
+	})
+	if err != nil {
+		return stacktrace.Propagate(err, "Failed to complete data migration %s v%s %s", step.Name, version, d)
+	}
+	return nil
+}
+
+func (r *Runner) logf(format string, args ...interface{}) {
+	if r.Logf != nil {
+		r.Logf(format, args...)
+	}
+}
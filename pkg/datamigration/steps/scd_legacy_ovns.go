@@ -0,0 +1,157 @@
+package steps
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/interuss/dss/pkg/datamigration"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	dsssql "github.com/interuss/dss/pkg/sql"
+	"github.com/interuss/stacktrace"
+	"github.com/jackc/pgtype"
+)
+
+// legacyOVNTables are the tables of the entities whose OVNs are stored from
+// schema version 3.9.0, in the order in which they are migrated.
+var legacyOVNTables = []string{"scd_constraints", "scd_operations", "scd_subscriptions"}
+
+// StoreLegacyOVNs stores in their rows the OVNs of the strategic conflict
+// detection entities written before OVNs were stored, which are derived from
+// the update time of the rows, so that all OVNs are read from and restored to
+// the ovn column. Reverting it clears the stored OVNs which are equal to the
+// OVN derived from the update time of their rows.
+var StoreLegacyOVNs = datamigration.Step{
+	Schemas: "scd",
+	Version: *semver.New("3.13.0"),
+	Name:    "store_legacy_ovns",
+	Up:      migrateLegacyOVNs(true),
+	Down:    migrateLegacyOVNs(false),
+}
+
+func init() {
+	datamigration.Register(StoreLegacyOVNs)
+}
+
+// legacyOVNCursor is the position of a batch of StoreLegacyOVNs, encoded as
+// <table>/<id>, id being empty before the first row of the table.
+type legacyOVNCursor struct {
+	table int
+	id    dssmodels.ID
+}
+
+func parseLegacyOVNCursor(cursor string) (legacyOVNCursor, error) {
+	if cursor == "" {
+		return legacyOVNCursor{}, nil
+	}
+	parts := strings.SplitN(cursor, "/", 2)
+	if len(parts) == 2 {
+		for i, table := range legacyOVNTables {
+			if table == parts[0] {
+				return legacyOVNCursor{table: i, id: dssmodels.ID(parts[1])}, nil
+			}
+		}
+	}
+	return legacyOVNCursor{}, stacktrace.NewError("Invalid cursor `%s`", cursor)
+}
+
+func (c legacyOVNCursor) String() string {
+	return legacyOVNTables[c.table] + "/" + c.id.String()
+}
+
+// migrateLegacyOVNs returns the BatchFunc storing the legacy OVNs of the rows
+// without OVN if store, or clearing the stored OVNs which are legacy OVNs
+// otherwise. Each batch migrates rows of a single table.
+func migrateLegacyOVNs(store bool) datamigration.BatchFunc {
+	return func(ctx context.Context, q dsssql.Queryable, cursor string, limit int) (datamigration.Batch, error) {
+		c, err := parseLegacyOVNCursor(cursor)
+		if err != nil {
+			return datamigration.Batch{}, err // No need to Propagate this error as this stack layer does not add useful information
+		}
+		var (
+			table       = legacyOVNTables[c.table]
+			selectQuery = fmt.Sprintf(`
+				SELECT
+					id, updated_at, ovn
+				FROM
+					%s
+				WHERE
+					($1::UUID IS NULL OR id > $1)
+				AND
+					(ovn IS NULL) = $2
+				ORDER BY
+					id
+				LIMIT $3`, table)
+			updateQuery = fmt.Sprintf(`
+				UPDATE
+					%s
+				SET
+					ovn = $2
+				WHERE
+					id = $1`, table)
+		)
+
+		after, err := c.id.NullablePgUUID()
+		if err != nil {
+			return datamigration.Batch{}, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+		}
+		rows, err := q.Query(ctx, selectQuery, after, store, limit)
+		if err != nil {
+			return datamigration.Batch{}, stacktrace.Propagate(err, "Error in query: %s", selectQuery)
+		}
+		var (
+			ids     []dssmodels.ID
+			updates = map[dssmodels.ID]*string{}
+		)
+		for rows.Next() {
+			var (
+				id        dssmodels.ID
+				updatedAt time.Time
+				ovn       pgtype.Text
+			)
+			if err := rows.Scan(&id, &updatedAt, &ovn); err != nil {
+				rows.Close()
+				return datamigration.Batch{}, stacktrace.Propagate(err, "Error scanning %s row", table)
+			}
+			ids = append(ids, id)
+			legacy := scdmodels.NewOVNFromTime(updatedAt, id.String()).String()
+			if store {
+				updates[id] = &legacy
+			} else if ovn.String == legacy {
+				// OVNs generated when the rows were written are kept.
+				updates[id] = nil
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return datamigration.Batch{}, stacktrace.Propagate(err, "Error in rows query result")
+		}
+
+		for id, ovn := range updates {
+			uid, err := id.PgUUID()
+			if err != nil {
+				return datamigration.Batch{}, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+			}
+			if _, err := q.Exec(ctx, updateQuery, uid, ovn); err != nil {
+				return datamigration.Batch{}, stacktrace.Propagate(err, "Error in query: %s", updateQuery)
+			}
+		}
+
+		if len(ids) == limit {
+			c.id = ids[len(ids)-1]
+			return datamigration.Batch{Cursor: c.String(), Rows: int64(len(updates))}, nil
+		}
+		// The table is migrated, the next batch migrates the next one.
+		if c.table == len(legacyOVNTables)-1 {
+			if len(ids) > 0 {
+				c.id = ids[len(ids)-1]
+			}
+			return datamigration.Batch{Cursor: c.String(), Rows: int64(len(updates)), Done: true}, nil
+		}
+		next := legacyOVNCursor{table: c.table + 1}
+		return datamigration.Batch{Cursor: next.String(), Rows: int64(len(updates))}, nil
+	}
+}
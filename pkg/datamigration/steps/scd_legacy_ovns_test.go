@@ -0,0 +1,108 @@
+package steps
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/interuss/dss/pkg/datamigration/datamigrationtest"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	dsssql "github.com/interuss/dss/pkg/sql"
+	"github.com/jackc/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	legacyOVNsUpdatedAt = "2022-01-01 12:00:00+00"
+	generatedOVN        = "generated-ovn-of-a-row-written-from-v3.9.0"
+)
+
+var (
+	// legacyOVNRows are the ids of the rows of each table, the first one of
+	// each table having a generated OVN.
+	legacyOVNRows = map[string][]string{
+		"scd_constraints": {
+			"00000000-0000-4000-8000-000000000001",
+			"00000000-0000-4000-8000-000000000002",
+		},
+		"scd_operations": {
+			"00000000-0000-4000-8000-000000000003",
+			"00000000-0000-4000-8000-000000000004",
+			"00000000-0000-4000-8000-000000000005",
+		},
+		"scd_subscriptions": {
+			"00000000-0000-4000-8000-000000000006",
+			"00000000-0000-4000-8000-000000000007",
+		},
+	}
+)
+
+func setUpLegacyOVNs(ctx context.Context, q dsssql.Queryable) error {
+	for _, table := range legacyOVNTables {
+		if _, err := q.Exec(ctx, `CREATE TABLE `+table+` (id UUID PRIMARY KEY, updated_at TIMESTAMPTZ NOT NULL, ovn STRING)`); err != nil {
+			return err
+		}
+		for i, id := range legacyOVNRows[table] {
+			var ovn *string
+			if i == 0 {
+				ovn = new(string)
+				*ovn = generatedOVN
+			}
+			if _, err := q.Exec(ctx, `INSERT INTO `+table+` (id, updated_at, ovn) VALUES ($1, $2, $3)`, id, legacyOVNsUpdatedAt, ovn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkLegacyOVNs checks that the rows without generated OVN have their
+// legacy OVN stored if stored, or no OVN otherwise.
+func checkLegacyOVNs(stored bool) func(t *testing.T, ctx context.Context, q dsssql.Queryable) {
+	return func(t *testing.T, ctx context.Context, q dsssql.Queryable) {
+		for _, table := range legacyOVNTables {
+			for i, id := range legacyOVNRows[table] {
+				var (
+					updatedAt time.Time
+					ovn       pgtype.Text
+				)
+				require.NoError(t, q.QueryRow(ctx, `SELECT updated_at, ovn FROM `+table+` WHERE id = $1`, id).Scan(&updatedAt, &ovn))
+				switch {
+				case i == 0:
+					require.Equal(t, generatedOVN, ovn.String, "%s %s", table, id)
+				case stored:
+					require.Equal(t, scdmodels.NewOVNFromTime(updatedAt, id).String(), ovn.String, "%s %s", table, id)
+				default:
+					require.Equal(t, pgtype.Null, ovn.Status, "%s %s", table, id)
+				}
+			}
+		}
+	}
+}
+
+func TestStoreLegacyOVNs(t *testing.T) {
+	datamigrationtest.Run(t, datamigrationtest.Case{
+		Step:            StoreLegacyOVNs,
+		PreviousVersion: *semver.New("3.12.0"),
+		Setup:           setUpLegacyOVNs,
+		CheckUp:         checkLegacyOVNs(true),
+		CheckDown:       checkLegacyOVNs(false),
+	})
+}
+
+func TestParseLegacyOVNCursor(t *testing.T) {
+	c, err := parseLegacyOVNCursor("")
+	require.NoError(t, err)
+	require.Equal(t, "scd_constraints/", c.String())
+
+	c, err = parseLegacyOVNCursor("scd_operations/00000000-0000-4000-8000-000000000003")
+	require.NoError(t, err)
+	require.Equal(t, legacyOVNCursor{table: 1, id: "00000000-0000-4000-8000-000000000003"}, c)
+	require.Equal(t, "scd_operations/00000000-0000-4000-8000-000000000003", c.String())
+
+	_, err = parseLegacyOVNCursor("scd_uss_availability/")
+	require.Error(t, err)
+	_, err = parseLegacyOVNCursor("scd_operations")
+	require.Error(t, err)
+}
@@ -0,0 +1,4 @@
+// Package steps registers the data migration steps of the DSS databases. It
+// is imported for its side effects by the db-manager, for the steps to run
+// along with the SQL migration steps of their schemas.
+package steps
@@ -28,6 +28,15 @@ const (
 	// be returned rather than the standard error response.
 	MissingOVNs stacktrace.ErrorCode = stacktrace.ErrorCode(19)
 
+	// InvalidStateTransition is used when a user attempts to transition an
+	// operational intent to a state which may not follow its current state.
+	InvalidStateTransition stacktrace.ErrorCode = stacktrace.ErrorCode(20)
+
+	// StateOutsideTimeWindow is used when a user attempts to put an
+	// operational intent in a state outside of the time window in which this
+	// state is allowed, e.g. Activated before its start time.
+	StateOutsideTimeWindow stacktrace.ErrorCode = stacktrace.ErrorCode(21)
+
 	// AlreadyExists is used when attempting to create a resource that already
 	// exists.
 	AlreadyExists stacktrace.ErrorCode = stacktrace.ErrorCode(uint16(codes.AlreadyExists))
@@ -51,6 +60,10 @@ const (
 
 	// Unauthenticated is used when an OAuth token is invalid or not supplied.
 	Unauthenticated stacktrace.ErrorCode = stacktrace.ErrorCode(uint16(codes.Unauthenticated))
+
+	// NotImplemented is used when a feature is not supported by the current
+	// deployment, e.g. by the schema version of its database.
+	NotImplemented stacktrace.ErrorCode = stacktrace.ErrorCode(uint16(codes.Unimplemented))
 )
 
 func init() {
@@ -94,62 +107,77 @@ func MakeStatusProto(code codes.Code, message string, detail proto.Message) (*sp
 func Interceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		resp, err := handler(ctx, req)
-
 		if err == nil {
 			return resp, nil
 		}
+		return resp, toStatusError(logger, "unary", info.FullMethod, err)
+	}
+}
 
-		errID := MakeErrID()
-
-		// Separate the root cause and code from the stacktrace wrapping.
-		trace := err.Error()
-		rootErr := stacktrace.RootCause(err)
-		code := stacktrace.GetCode(err)
-
-		statusErr, ok := status.FromError(rootErr)
-		if ok {
-			// The root cause is a Status error; return it exactly as-is.
-			logger.Error(
-				fmt.Sprintf("Status error %s during unary server call", errID),
-				zap.String("method", info.FullMethod),
-				zap.String("stacktrace", trace),
-				zap.String("grpc_code", statusErr.Code().String()),
-				zap.Error(rootErr))
-			return resp, rootErr
+// StreamInterceptor is the equivalent of Interceptor for streaming RPCs.
+func StreamInterceptor(logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		if err == nil {
+			return nil
 		}
+		return toStatusError(logger, "stream", info.FullMethod, err)
+	}
+}
 
-		if code != stacktrace.NoCode {
-			logger.Error(
-				fmt.Sprintf("Error %s during unary server call", errID),
-				zap.String("method", info.FullMethod),
-				zap.String("stacktrace", trace),
-				zap.String("grpc_code", codes.Code(uint16(code)).String()),
-				zap.Int("code", int(code)),
-				zap.Error(rootErr))
-			p, constructionErr := MakeStatusProto(codes.Code(uint16(code)), rootErr.Error(), &auxpb.StandardErrorResponse{
-				Error:   rootErr.Error(),
-				Code:    int32(code),
-				Message: rootErr.Error(),
-				ErrorId: errID,
-			})
-			if constructionErr == nil {
-				err = status.ErrorProto(p)
-			} else {
-				constructionErrID := MakeErrID()
-				logger.Error(
-					fmt.Sprintf("Error %s constructing StandardErrorResponse from %s", constructionErrID, errID),
-					zap.Error(constructionErr))
-				err = status.Error(codes.Internal, fmt.Sprintf("Internal server error %s", constructionErrID))
-			}
+// toStatusError logs "err", returned by a "kind" server call to "method", and
+// converts it to the status error returned to the client.
+func toStatusError(logger *zap.Logger, kind string, method string, err error) error {
+	errID := MakeErrID()
+
+	// Separate the root cause and code from the stacktrace wrapping.
+	trace := err.Error()
+	rootErr := stacktrace.RootCause(err)
+	code := stacktrace.GetCode(err)
+
+	statusErr, ok := status.FromError(rootErr)
+	if ok {
+		// The root cause is a Status error; return it exactly as-is.
+		logger.Error(
+			fmt.Sprintf("Status error %s during %s server call", errID, kind),
+			zap.String("method", method),
+			zap.String("stacktrace", trace),
+			zap.String("grpc_code", statusErr.Code().String()),
+			zap.Error(rootErr))
+		return rootErr
+	}
+
+	if code != stacktrace.NoCode {
+		logger.Error(
+			fmt.Sprintf("Error %s during %s server call", errID, kind),
+			zap.String("method", method),
+			zap.String("stacktrace", trace),
+			zap.String("grpc_code", codes.Code(uint16(code)).String()),
+			zap.Int("code", int(code)),
+			zap.Error(rootErr))
+		p, constructionErr := MakeStatusProto(codes.Code(uint16(code)), rootErr.Error(), &auxpb.StandardErrorResponse{
+			Error:   rootErr.Error(),
+			Code:    int32(code),
+			Message: rootErr.Error(),
+			ErrorId: errID,
+		})
+		if constructionErr == nil {
+			err = status.ErrorProto(p)
 		} else {
+			constructionErrID := MakeErrID()
 			logger.Error(
-				fmt.Sprintf("Uncoded error %s during unary server call", errID),
-				zap.String("method", info.FullMethod),
-				zap.String("stacktrace", trace),
-				zap.Error(rootErr))
-			err = status.Error(codes.Internal, fmt.Sprintf("Internal server error %s", errID))
+				fmt.Sprintf("Error %s constructing StandardErrorResponse from %s", constructionErrID, errID),
+				zap.Error(constructionErr))
+			err = status.Error(codes.Internal, fmt.Sprintf("Internal server error %s", constructionErrID))
 		}
-
-		return resp, err
+	} else {
+		logger.Error(
+			fmt.Sprintf("Uncoded error %s during %s server call", errID, kind),
+			zap.String("method", method),
+			zap.String("stacktrace", trace),
+			zap.Error(rootErr))
+		err = status.Error(codes.Internal, fmt.Sprintf("Internal server error %s", errID))
 	}
+
+	return err
 }
@@ -0,0 +1,4 @@
+// Package healthcheck reports the health of the DSS gRPC services, based on
+// periodic checks of their dependencies, through the standard grpc.health.v1
+// service.
+package healthcheck
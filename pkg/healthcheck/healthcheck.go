@@ -0,0 +1,124 @@
+package healthcheck
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Names of the DSS gRPC services, as reported by the health service.
+const (
+	RIDV1Service   = "ridpbv1.DiscoveryAndSynchronizationService"
+	RIDV2Service   = "ridpbv2.StandardRemoteIDAPIInterfacesService"
+	SCDService     = "scdpb.UTMAPIUSSDSSAndUSSUSSService"
+	BulkService    = "bulkpb.DSSBulkService"
+	HistoryService = "historypb.DSSHistoryService"
+	AuxService     = "auxpb.DSSAuxService"
+	AdminService   = "adminpb.DSSAdminService"
+	WatchService   = "watchpb.DSSWatchService"
+
+	// OverallService is the name under which the aggregate status of all
+	// services is reported.
+	OverallService = ""
+)
+
+// Check returns an error if a dependency of a service is unhealthy.
+type Check func(ctx context.Context) error
+
+// Monitor periodically runs the Checks of each service and reports the
+// resulting status through a health Server. A service is serving only if all
+// of its Checks succeed, and OverallService is serving only if all services
+// are.
+type Monitor struct {
+	server   *health.Server
+	interval time.Duration
+	logger   *zap.Logger
+
+	mu       sync.Mutex
+	services []string
+	checks   map[string][]Check
+	statuses map[string]healthpb.HealthCheckResponse_ServingStatus
+}
+
+// NewMonitor returns a Monitor reporting to server, running checks every
+// interval. Each round of checks must complete within interval.
+func NewMonitor(server *health.Server, interval time.Duration, logger *zap.Logger) *Monitor {
+	server.SetServingStatus(OverallService, healthpb.HealthCheckResponse_NOT_SERVING)
+	return &Monitor{
+		server:   server,
+		interval: interval,
+		logger:   logger,
+		checks:   map[string][]Check{},
+		statuses: map[string]healthpb.HealthCheckResponse_ServingStatus{},
+	}
+}
+
+// Register adds service to the services monitored by m. The service is not
+// serving until its checks have run and succeeded.
+func (m *Monitor) Register(service string, checks ...Check) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.checks[service]; !ok {
+		m.services = append(m.services, service)
+	}
+	m.checks[service] = append(m.checks[service], checks...)
+	m.setStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+func (m *Monitor) setStatus(service string, status healthpb.HealthCheckResponse_ServingStatus) {
+	if previous, ok := m.statuses[service]; ok && previous != status {
+		m.logger.Info("health status changed",
+			zap.String("service", service), zap.Stringer("status", status))
+	}
+	m.statuses[service] = status
+	m.server.SetServingStatus(service, status)
+}
+
+// CheckOnce runs the checks of all services and updates their status.
+func (m *Monitor) CheckOnce(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, m.interval)
+	defer cancel()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	overall := healthpb.HealthCheckResponse_SERVING
+	for _, service := range m.services {
+		status := healthpb.HealthCheckResponse_SERVING
+		for _, check := range m.checks[service] {
+			if err := check(ctx); err != nil {
+				m.logger.Warn("health check failed", zap.String("service", service), zap.Error(err))
+				status = healthpb.HealthCheckResponse_NOT_SERVING
+				break
+			}
+		}
+		m.setStatus(service, status)
+		if status != healthpb.HealthCheckResponse_SERVING {
+			overall = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+	}
+	m.setStatus(OverallService, overall)
+}
+
+// Run checks all services immediately and then every interval, until ctx is
+// done, at which point all services are reported as not serving.
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	m.CheckOnce(ctx)
+	for {
+		select {
+		case <-ticker.C:
+			m.CheckOnce(ctx)
+		case <-ctx.Done():
+			m.server.Shutdown()
+			return
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package healthcheck
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/interuss/stacktrace"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func statusOf(t *testing.T, server *health.Server, service string) healthpb.HealthCheckResponse_ServingStatus {
+	res, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	require.NoError(t, err)
+	return res.Status
+}
+
+func TestMonitor(t *testing.T) {
+	var (
+		server   = health.NewServer()
+		m        = NewMonitor(server, time.Second, zap.NewNop())
+		ridErr   error
+		keysErr  error
+		ridCheck = func(context.Context) error { return ridErr }
+		keyCheck = func(context.Context) error { return keysErr }
+	)
+	m.Register(RIDV1Service, ridCheck, keyCheck)
+	m.Register(AuxService, keyCheck)
+
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, statusOf(t, server, RIDV1Service))
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, statusOf(t, server, OverallService))
+
+	m.CheckOnce(context.Background())
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, statusOf(t, server, RIDV1Service))
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, statusOf(t, server, AuxService))
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, statusOf(t, server, OverallService))
+
+	ridErr = stacktrace.NewError("Database unreachable")
+	m.CheckOnce(context.Background())
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, statusOf(t, server, RIDV1Service))
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, statusOf(t, server, AuxService))
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, statusOf(t, server, OverallService))
+
+	ridErr, keysErr = nil, stacktrace.NewError("Keys unavailable")
+	m.CheckOnce(context.Background())
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, statusOf(t, server, RIDV1Service))
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, statusOf(t, server, AuxService))
+
+	keysErr = nil
+	m.CheckOnce(context.Background())
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, statusOf(t, server, OverallService))
+}
+
+func TestMonitorRunShutsDown(t *testing.T) {
+	server := health.NewServer()
+	m := NewMonitor(server, time.Hour, zap.NewNop())
+	m.Register(AuxService)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		m.Run(ctx)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool {
+		return statusOf(t, server, OverallService) == healthpb.HealthCheckResponse_SERVING
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	<-done
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, statusOf(t, server, OverallService))
+}
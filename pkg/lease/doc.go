@@ -0,0 +1,3 @@
+// Package lease elects, among the DSS instances of a locality, the single
+// instance running each scheduled job, with leases stored in the database.
+package lease
@@ -0,0 +1,180 @@
+package lease
+
+import (
+	"context"
+	"expvar"
+	"sync"
+	"time"
+
+	"github.com/interuss/stacktrace"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// Metrics publishes, for each job, whether its lease is held (<job>.held)
+// and the number of runs (<job>.runs), of runs skipped because the lease is
+// held by another instance (<job>.skipped) and of failures to acquire or
+// renew the lease (<job>.errors).
+var Metrics = expvar.NewMap("dss_job_leases")
+
+// Store stores leases.
+type Store interface {
+	// AcquireLease acquires or renews the lease name in locality for
+	// holder, for ttl from now. It returns false if the lease is held by
+	// another holder and has not expired.
+	AcquireLease(ctx context.Context, name string, locality string, holder string, ttl time.Duration) (bool, error)
+
+	// ReleaseLease releases the lease name in locality if held by holder.
+	ReleaseLease(ctx context.Context, name string, locality string, holder string) error
+}
+
+// Elector runs scheduled jobs only while holding their lease, so that a
+// single Elector of a locality runs each job at a time.
+type Elector struct {
+	store    Store
+	locality string
+	holder   string
+	ttl      time.Duration
+	logger   *zap.Logger
+
+	mu   sync.Mutex
+	held map[string]bool
+}
+
+// NewElector returns an Elector acquiring the leases of locality in store as
+// holder, which must identify the Elector among all the Electors sharing
+// store, for ttl at a time.
+func NewElector(store Store, locality string, holder string, ttl time.Duration, logger *zap.Logger) *Elector {
+	return &Elector{
+		store:    store,
+		locality: locality,
+		holder:   holder,
+		ttl:      ttl,
+		logger:   logger.With(zap.String("lease_holder", holder), zap.String("locality", locality)),
+		held:     map[string]bool{},
+	}
+}
+
+// Func is a scheduled job. The context it is run with is canceled once the
+// lease of the job is lost.
+type Func func(ctx context.Context)
+
+// Job returns a cron.Job running job, named name, only if e acquires the
+// lease of name. The lease is renewed every third of its TTL while job runs,
+// and is kept once job completes so that e keeps running job as long as it is
+// scheduled more often than the TTL. If the lease cannot be renewed, the
+// context of job is canceled so that it stops before another instance starts
+// running it.
+func (e *Elector) Job(ctx context.Context, name string, job Func) cron.Job {
+	return cron.FuncJob(func() {
+		e.run(ctx, name, job)
+	})
+}
+
+func (e *Elector) run(ctx context.Context, name string, job Func) {
+	acquired, err := e.store.AcquireLease(ctx, name, e.locality, e.holder, e.ttl)
+	if err != nil {
+		e.logger.Warn("Failed to acquire job lease", zap.String("job", name), zap.Error(err))
+		Metrics.Add(name+".errors", 1)
+		e.setHeld(name, false)
+		return
+	}
+	e.setHeld(name, acquired)
+	if !acquired {
+		e.logger.Debug("Skipping job whose lease is held by another instance", zap.String("job", name))
+		Metrics.Add(name+".skipped", 1)
+		return
+	}
+
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	done := make(chan struct{})
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		ticker := time.NewTicker(e.ttl / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				acquired, err := e.store.AcquireLease(ctx, name, e.locality, e.holder, e.ttl)
+				if err != nil {
+					e.logger.Warn("Failed to renew job lease", zap.String("job", name), zap.Error(err))
+					Metrics.Add(name+".errors", 1)
+				}
+				if err != nil || !acquired {
+					// The lease may expire, or has already been taken over,
+					// before it is renewed again: stop the job now.
+					e.setHeld(name, false)
+					e.logger.Warn("Canceling job whose lease could not be renewed", zap.String("job", name))
+					cancel()
+					return
+				}
+				e.setHeld(name, true)
+			}
+		}
+	}()
+
+	job(jobCtx)
+	close(done)
+	<-renewed
+	Metrics.Add(name+".runs", 1)
+}
+
+func (e *Elector) setHeld(name string, held bool) {
+	e.mu.Lock()
+	wasHeld := e.held[name]
+	e.held[name] = held
+	e.mu.Unlock()
+
+	switch {
+	case held && !wasHeld:
+		e.logger.Info("Acquired job lease", zap.String("job", name))
+	case !held && wasHeld:
+		e.logger.Warn("Lost job lease", zap.String("job", name))
+	}
+	publishHeld(name, held)
+}
+
+func publishHeld(name string, held bool) {
+	v := new(expvar.Int)
+	if held {
+		v.Set(1)
+	}
+	Metrics.Set(name+".held", v)
+}
+
+// Held returns true if e holds the lease of the job named name.
+func (e *Elector) Held(name string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.held[name]
+}
+
+// Release releases the leases held by e, so that other instances may run the
+// jobs without waiting for the leases to expire. It must be called once the
+// jobs are stopped.
+func (e *Elector) Release(ctx context.Context) error {
+	e.mu.Lock()
+	var names []string
+	for name, held := range e.held {
+		if held {
+			names = append(names, name)
+		}
+	}
+	e.mu.Unlock()
+
+	for _, name := range names {
+		if err := e.store.ReleaseLease(ctx, name, e.locality, e.holder); err != nil {
+			return stacktrace.Propagate(err, "Failed to release the lease of job %s", name)
+		}
+		e.mu.Lock()
+		e.held[name] = false
+		e.mu.Unlock()
+		e.logger.Info("Released job lease", zap.String("job", name))
+		publishHeld(name, false)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package lease
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type leaseKey struct {
+	name     string
+	locality string
+}
+
+type heldLease struct {
+	holder    string
+	expiresAt time.Time
+}
+
+// memStore is an in-memory Store.
+type memStore struct {
+	mu     sync.Mutex
+	leases map[leaseKey]heldLease
+}
+
+func (s *memStore) AcquireLease(ctx context.Context, name string, locality string, holder string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := leaseKey{name: name, locality: locality}
+	if l, ok := s.leases[key]; ok && l.holder != holder && time.Now().Before(l.expiresAt) {
+		return false, nil
+	}
+	s.leases[key] = heldLease{holder: holder, expiresAt: time.Now().Add(ttl)}
+	return true, nil
+}
+
+func (s *memStore) ReleaseLease(ctx context.Context, name string, locality string, holder string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := leaseKey{name: name, locality: locality}
+	if l, ok := s.leases[key]; ok && l.holder == holder {
+		delete(s.leases, key)
+	}
+	return nil
+}
+
+func countingJob(n *int) Func {
+	return func(context.Context) { *n++ }
+}
+
+func TestElectorRunsJobOncePerLocality(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		store = &memStore{leases: map[leaseKey]heldLease{}}
+		a     = NewElector(store, "east", "a", time.Minute, zap.NewNop())
+		b     = NewElector(store, "east", "b", time.Minute, zap.NewNop())
+		c     = NewElector(store, "west", "c", time.Minute, zap.NewNop())
+		runs  = map[string]*int{"a": new(int), "b": new(int), "c": new(int)}
+	)
+
+	for i := 0; i < 3; i++ {
+		a.Job(ctx, "gc", countingJob(runs["a"])).Run()
+		b.Job(ctx, "gc", countingJob(runs["b"])).Run()
+		c.Job(ctx, "gc", countingJob(runs["c"])).Run()
+	}
+	require.Equal(t, 3, *runs["a"])
+	require.Equal(t, 0, *runs["b"])
+	require.Equal(t, 3, *runs["c"])
+	require.True(t, a.Held("gc"))
+	require.False(t, b.Held("gc"))
+
+	// Other jobs have their own leases.
+	b.Job(ctx, "purge", countingJob(runs["b"])).Run()
+	require.Equal(t, 1, *runs["b"])
+
+	// Releasing the lease hands the job over.
+	require.NoError(t, a.Release(ctx))
+	require.False(t, a.Held("gc"))
+	b.Job(ctx, "gc", countingJob(runs["b"])).Run()
+	a.Job(ctx, "gc", countingJob(runs["a"])).Run()
+	require.Equal(t, 2, *runs["b"])
+	require.Equal(t, 3, *runs["a"])
+}
+
+func TestElectorTakesOverExpiredLease(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		store = &memStore{leases: map[leaseKey]heldLease{}}
+		a     = NewElector(store, "east", "a", 30*time.Millisecond, zap.NewNop())
+		b     = NewElector(store, "east", "b", 30*time.Millisecond, zap.NewNop())
+		runs  int
+	)
+
+	a.Job(ctx, "gc", countingJob(&runs)).Run()
+	b.Job(ctx, "gc", countingJob(&runs)).Run()
+	require.Equal(t, 1, runs)
+
+	time.Sleep(50 * time.Millisecond)
+	b.Job(ctx, "gc", countingJob(&runs)).Run()
+	require.Equal(t, 2, runs)
+	require.True(t, b.Held("gc"))
+}
+
+func TestElectorRenewsLeaseWhileRunning(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		store = &memStore{leases: map[leaseKey]heldLease{}}
+		a     = NewElector(store, "east", "a", 90*time.Millisecond, zap.NewNop())
+		b     = NewElector(store, "east", "b", 90*time.Millisecond, zap.NewNop())
+		runs  int
+	)
+
+	a.Job(ctx, "gc", func(context.Context) {
+		// Outlive the TTL while b attempts to run the job.
+		for i := 0; i < 5; i++ {
+			time.Sleep(40 * time.Millisecond)
+			b.Job(ctx, "gc", countingJob(&runs)).Run()
+		}
+	}).Run()
+	require.Equal(t, 0, runs)
+}
+
+// renewalFailingStore fails to renew the leases it granted.
+type renewalFailingStore struct {
+	*memStore
+	granted map[string]bool
+}
+
+func (s *renewalFailingStore) AcquireLease(ctx context.Context, name string, locality string, holder string, ttl time.Duration) (bool, error) {
+	if s.granted[name] {
+		return false, errors.New("connection refused")
+	}
+	s.granted[name] = true
+	return s.memStore.AcquireLease(ctx, name, locality, holder, ttl)
+}
+
+func TestElectorCancelsJobOnLostLease(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		store = &memStore{leases: map[leaseKey]heldLease{}}
+		a     = NewElector(store, "east", "a", 90*time.Millisecond, zap.NewNop())
+		b     = NewElector(&renewalFailingStore{memStore: store, granted: map[string]bool{}}, "east", "b", 90*time.Millisecond, zap.NewNop())
+	)
+
+	for name, e := range map[string]*Elector{"taken over": a, "renewal failed": b} {
+		var canceled bool
+		e.Job(ctx, name, func(ctx context.Context) {
+			if e == a {
+				// Another instance takes over the lease once it expires.
+				store.mu.Lock()
+				store.leases[leaseKey{name: name, locality: "east"}] = heldLease{holder: "c", expiresAt: time.Now().Add(time.Minute)}
+				store.mu.Unlock()
+			}
+			select {
+			case <-ctx.Done():
+				canceled = true
+			case <-time.After(time.Second):
+			}
+		}).Run()
+		require.True(t, canceled, name)
+		require.False(t, e.Held(name), name)
+	}
+}
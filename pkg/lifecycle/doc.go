@@ -0,0 +1,3 @@
+// Package lifecycle coordinates the orderly shutdown of the components of a
+// service.
+package lifecycle
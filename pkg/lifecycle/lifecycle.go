@@ -0,0 +1,150 @@
+package lifecycle
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/interuss/stacktrace"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// StopFunc stops a component, returning an error if it could not be stopped
+// cleanly before ctx is done.
+type StopFunc func(ctx context.Context) error
+
+type component struct {
+	name string
+	stop StopFunc
+}
+
+// Manager owns the components of a service and stops them in the reverse
+// order of their registration, so that a component is stopped before the
+// components it depends on.
+type Manager struct {
+	logger *zap.Logger
+
+	mu         sync.Mutex
+	components []component
+
+	once sync.Once
+	done chan struct{}
+	err  error
+}
+
+// NewManager returns a Manager reporting shutdown progress to logger.
+func NewManager(logger *zap.Logger) *Manager {
+	return &Manager{
+		logger: logger,
+		done:   make(chan struct{}),
+	}
+}
+
+// Add registers a component to be stopped by stop on shutdown.
+func (m *Manager) Add(name string, stop StopFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.components = append(m.components, component{name: name, stop: stop})
+}
+
+// AddGRPCServer registers a gRPC server which stops accepting requests on
+// shutdown and drains in-flight requests until the shutdown deadline, after
+// which remaining requests are canceled.
+func (m *Manager) AddGRPCServer(name string, s *grpc.Server) {
+	m.Add(name, func(ctx context.Context) error {
+		drained := make(chan struct{})
+		go func() {
+			s.GracefulStop()
+			close(drained)
+		}()
+		select {
+		case <-drained:
+			return nil
+		case <-ctx.Done():
+			s.Stop()
+			return stacktrace.Propagate(ctx.Err(), "In-flight requests were canceled")
+		}
+	})
+}
+
+// AddCron registers a cron scheduler which stops scheduling jobs on shutdown
+// and waits for running jobs to complete until the shutdown deadline.
+func (m *Manager) AddCron(name string, c *cron.Cron) {
+	m.Add(name, func(ctx context.Context) error {
+		select {
+		case <-c.Stop().Done():
+			return nil
+		case <-ctx.Done():
+			return stacktrace.Propagate(ctx.Err(), "Running jobs did not complete")
+		}
+	})
+}
+
+// Go runs f in the background with a context which is canceled on shutdown,
+// after which the shutdown waits for f to return.
+func (m *Manager) Go(ctx context.Context, name string, f func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(ctx)
+	returned := make(chan struct{})
+	go func() {
+		defer close(returned)
+		f(ctx)
+	}()
+	m.Add(name, func(stopCtx context.Context) error {
+		cancel()
+		select {
+		case <-returned:
+			return nil
+		case <-stopCtx.Done():
+			return stacktrace.Propagate(stopCtx.Err(), "Did not return")
+		}
+	})
+}
+
+// Shutdown stops all components, most recently registered first, and returns
+// an error if any of them could not be stopped cleanly. Once ctx is done, the
+// remaining components are stopped without waiting. Shutdown may be called
+// several times and concurrently; all calls wait for the first one to
+// complete and return its result.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.once.Do(func() {
+		defer close(m.done)
+		m.err = m.shutdown(ctx)
+	})
+	<-m.done
+	return m.err
+}
+
+// Done returns a channel closed once the shutdown is complete.
+func (m *Manager) Done() <-chan struct{} {
+	return m.done
+}
+
+func (m *Manager) shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	components := m.components
+	m.components = nil
+	m.mu.Unlock()
+
+	m.logger.Info("shutting down", zap.Int("components", len(components)))
+	start := time.Now()
+	var failed []string
+	for i := len(components) - 1; i >= 0; i-- {
+		c := components[i]
+		m.logger.Info("stopping component", zap.String("component", c.name))
+		stopStart := time.Now()
+		if err := c.stop(ctx); err != nil {
+			m.logger.Warn("failed to stop component cleanly", zap.String("component", c.name), zap.Error(err))
+			failed = append(failed, c.name)
+			continue
+		}
+		m.logger.Info("stopped component", zap.String("component", c.name), zap.Duration("duration", time.Since(stopStart)))
+	}
+	m.logger.Info("shut down", zap.Duration("duration", time.Since(start)))
+
+	if len(failed) > 0 {
+		return stacktrace.NewError("Failed to stop %v cleanly", failed)
+	}
+	return nil
+}
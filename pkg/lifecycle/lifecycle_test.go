@@ -0,0 +1,114 @@
+package lifecycle
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestShutdownOrder(t *testing.T) {
+	m := NewManager(zap.NewNop())
+	var stopped []string
+	for _, name := range []string{"db", "cron", "server"} {
+		name := name
+		m.Add(name, func(context.Context) error {
+			stopped = append(stopped, name)
+			return nil
+		})
+	}
+
+	require.NoError(t, m.Shutdown(context.Background()))
+	require.Equal(t, []string{"server", "cron", "db"}, stopped)
+
+	// Subsequent calls do not stop components again.
+	require.NoError(t, m.Shutdown(context.Background()))
+	require.Len(t, stopped, 3)
+}
+
+func TestShutdownConcurrent(t *testing.T) {
+	m := NewManager(zap.NewNop())
+	release := make(chan struct{})
+	m.Add("slow", func(context.Context) error {
+		<-release
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, m.Shutdown(context.Background()))
+		}()
+	}
+	close(release)
+	wg.Wait()
+
+	select {
+	case <-m.Done():
+	default:
+		t.Fatal("Done not closed after shutdown")
+	}
+}
+
+func TestGo(t *testing.T) {
+	m := NewManager(zap.NewNop())
+	var finished bool
+	m.Go(context.Background(), "worker", func(ctx context.Context) {
+		<-ctx.Done()
+		finished = true
+	})
+
+	require.NoError(t, m.Shutdown(context.Background()))
+	require.True(t, finished)
+}
+
+func TestCronWaitsForRunningJobs(t *testing.T) {
+	m := NewManager(zap.NewNop())
+	c := cron.New(cron.WithSeconds())
+	started, finished := make(chan struct{}), make(chan struct{})
+	_, err := c.AddFunc("* * * * * *", func() {
+		select {
+		case <-started:
+			return
+		default:
+			close(started)
+		}
+		time.Sleep(50 * time.Millisecond)
+		close(finished)
+	})
+	require.NoError(t, err)
+	c.Start()
+	m.AddCron("cron", c)
+
+	<-started
+	require.NoError(t, m.Shutdown(context.Background()))
+	select {
+	case <-finished:
+	default:
+		t.Fatal("Shutdown did not wait for the running job")
+	}
+}
+
+func TestShutdownDeadline(t *testing.T) {
+	m := NewManager(zap.NewNop())
+	m.Add("stuck", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	var stopped bool
+	m.Add("quick", func(context.Context) error {
+		stopped = true
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.Error(t, m.Shutdown(ctx))
+	require.True(t, stopped)
+}
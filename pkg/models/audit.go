@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+)
+
+// AuditEntityType identifies the kind of entity an AuditRecord refers to.
+type AuditEntityType string
+
+// AuditOperation identifies the mutation an AuditRecord refers to.
+type AuditOperation string
+
+const (
+	// AuditEntityISA identifies remote ID Identification Service Areas.
+	AuditEntityISA AuditEntityType = "identification_service_area"
+	// AuditEntityRIDSubscription identifies remote ID Subscriptions.
+	AuditEntityRIDSubscription AuditEntityType = "rid_subscription"
+	// AuditEntityOperationalIntent identifies SCD Operational Intents.
+	AuditEntityOperationalIntent AuditEntityType = "operational_intent"
+	// AuditEntityConstraint identifies SCD Constraints.
+	AuditEntityConstraint AuditEntityType = "constraint"
+	// AuditEntitySCDSubscription identifies SCD Subscriptions.
+	AuditEntitySCDSubscription AuditEntityType = "scd_subscription"
+	// AuditEntityUssAvailability identifies SCD USS availabilities.
+	AuditEntityUssAvailability AuditEntityType = "uss_availability"
+
+	// AuditCreate is recorded when an entity is created.
+	AuditCreate AuditOperation = "Create"
+	// AuditUpdate is recorded when an existing entity is modified.
+	AuditUpdate AuditOperation = "Update"
+	// AuditDelete is recorded when an entity is deleted.
+	AuditDelete AuditOperation = "Delete"
+)
+
+// Valid returns true if t identifies a known type of entity.
+func (t AuditEntityType) Valid() bool {
+	switch t {
+	case AuditEntityISA, AuditEntityRIDSubscription, AuditEntityOperationalIntent,
+		AuditEntityConstraint, AuditEntitySCDSubscription, AuditEntityUssAvailability:
+		return true
+	}
+	return false
+}
+
+// IsRID returns true if t identifies an entity stored in the remote ID
+// database.
+func (t AuditEntityType) IsRID() bool {
+	return t == AuditEntityISA || t == AuditEntityRIDSubscription
+}
+
+// AuditRecord is an entry of the append-only trail of mutations of DSS
+// entities. The deletions of expired remote ID entities by the garbage
+// collector are recorded as well. Strategic conflict detection entities are
+// not garbage collected: they are only deleted on request of their managers.
+type AuditRecord struct {
+	EntityType AuditEntityType
+	EntityID   string
+	Operation  AuditOperation
+	// Owner is the owner (or manager) of the entity after the mutation.
+	Owner Owner
+
+	// OldVersion and NewVersion are the versions (or OVNs) of the entity
+	// before and after the mutation, empty when not applicable.
+	OldVersion string
+	NewVersion string
+
+	// Summary of the extents of the entity after the mutation (or before a
+	// deletion).
+	StartTime  *time.Time
+	EndTime    *time.Time
+	AltitudeLo *float32
+	AltitudeHi *float32
+
+	// RecordedAt is set by the store to the timestamp of the transaction in
+	// which the mutation occurred.
+	RecordedAt time.Time
+}
+
+// AuditFilter restricts the AuditRecords returned by a search. Zero-valued
+// fields are not used to filter.
+type AuditFilter struct {
+	EntityType AuditEntityType
+	EntityID   string
+	Owner      Owner
+	Earliest   *time.Time
+	Latest     *time.Time
+}
@@ -47,6 +47,18 @@ func (id *ID) PgUUID() (*pgtype.UUID, error) {
 	return &pgUUID, nil
 }
 
+// NullablePgUUID converts id to a PgUUID, which is NULL if id is empty.
+func (id ID) NullablePgUUID() (*pgtype.UUID, error) {
+	pgUUID := pgtype.UUID{Status: pgtype.Null}
+	if id.Empty() {
+		return &pgUUID, nil
+	}
+	if err := pgUUID.Set(id.String()); err != nil {
+		return nil, stacktrace.Propagate(err, "Error converting ID to PgUUID format")
+	}
+	return &pgUUID, nil
+}
+
 func (id ID) String() string {
 	return string(id)
 }
@@ -142,6 +154,23 @@ func (v *Version) String() string {
 	return v.s
 }
 
+// MarshalText implements encoding.TextMarshaler, encoding v as its string
+// representation.
+func (v *Version) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, decoding the string
+// representation of a version.
+func (v *Version) UnmarshalText(text []byte) error {
+	temp, err := VersionFromString(string(text))
+	if err != nil {
+		return err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	*v = *temp
+	return nil
+}
+
 // ToTimestamp converts the version back its commit timestamp.
 func (v *Version) ToTimestamp() *time.Time {
 	if v == nil {
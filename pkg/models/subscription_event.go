@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+// SubscriptionEvent is a change of an entity which incremented the
+// notification index of a subscription.
+type SubscriptionEvent struct {
+	SubscriptionID ID
+	// NotificationIndex is the notification index of the subscription
+	// resulting from the change.
+	NotificationIndex int
+	EntityType        AuditEntityType
+	EntityID          string
+	// Version is the version (or OVN) of the entity after the change, empty if
+	// the entity was deleted.
+	Version string
+	Deleted bool
+
+	// RecordedAt is set by the store to the timestamp of the transaction in
+	// which the change occurred.
+	RecordedAt time.Time
+}
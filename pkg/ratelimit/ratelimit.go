@@ -0,0 +1,281 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/interuss/dss/pkg/auth"
+	dsserr "github.com/interuss/dss/pkg/errors"
+	"github.com/interuss/dss/pkg/models"
+	"github.com/interuss/stacktrace"
+	"github.com/jonboulle/clockwork"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// RetryAfterHeader is the response metadata key carrying the number of
+	// seconds a client should wait before retrying a rate-limited call. As
+	// rejected calls have no response, it is sent in the trailer.
+	RetryAfterHeader = "retry-after"
+
+	// sweepInterval is the minimum duration between two evictions of the idle
+	// buckets of a Limiter.
+	sweepInterval = time.Minute
+)
+
+var (
+	// DefaultClock is what is used as the limiter's clock, exposed for testing.
+	DefaultClock = clockwork.NewRealClock()
+)
+
+// Class models a class of methods sharing a rate limit.
+type Class string
+
+const (
+	// Read is the class of methods retrieving a single resource.
+	Read Class = "read"
+	// Write is the class of methods mutating resources.
+	Write Class = "write"
+	// Search is the class of methods querying resources within an area.
+	Search Class = "search"
+)
+
+// Rate describes a token bucket: tokens are replenished at PerSecond tokens
+// per second up to a maximum of Burst tokens.
+type Rate struct {
+	PerSecond float64
+	Burst     int
+}
+
+// Unlimited returns true if r does not limit calls.
+func (r Rate) Unlimited() bool {
+	return r.PerSecond <= 0 || r.Burst <= 0
+}
+
+// ParseRate parses a rate expressed as "<tokens per second>/<burst>", e.g.
+// "5/20". An empty string results in an unlimited Rate.
+func ParseRate(s string) (Rate, error) {
+	if s == "" {
+		return Rate{}, nil
+	}
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 {
+		return Rate{}, stacktrace.NewError("Rate `%s` must be of the form <tokens per second>/<burst>", s)
+	}
+	perSecond, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil || perSecond < 0 {
+		return Rate{}, stacktrace.NewError("Invalid tokens per second `%s` in rate `%s`", parts[0], s)
+	}
+	burst, err := strconv.Atoi(parts[1])
+	if err != nil || burst < 0 {
+		return Rate{}, stacktrace.NewError("Invalid burst `%s` in rate `%s`", parts[1], s)
+	}
+	return Rate{PerSecond: perSecond, Burst: burst}, nil
+}
+
+// Classes maps the full name of each gRPC method of the DSS to the Class of
+// its rate limit. Methods missing from Classes are limited as Write methods.
+var Classes = map[auth.Operation]Class{
+	"/adminpb.DSSAdminService/ForceDeleteIdentificationServiceArea": Write,
+	"/adminpb.DSSAdminService/ForceDeleteOperationalIntent":         Write,
+	"/adminpb.DSSAdminService/GetSchemaVersions":                    Read,
+	"/adminpb.DSSAdminService/GetSubscriptionDetails":               Read,
+	"/adminpb.DSSAdminService/ListEntitiesByManager":                Search,
+	"/adminpb.DSSAdminService/QueryAuditLog":                        Search,
+	"/adminpb.DSSAdminService/SearchAirspaceAsOf":                   Search,
+
+	"/auxpb.DSSAuxService/GetVersion":    Read,
+	"/auxpb.DSSAuxService/ValidateOauth": Read,
+
+	"/bulkpb.DSSBulkService/BulkChangeConstraintReferences":        Write,
+	"/bulkpb.DSSBulkService/BulkChangeOperationalIntentReferences": Write,
+
+	"/historypb.DSSHistoryService/GetOperationalIntentStateHistory": Read,
+
+	"/ridpbv1.DiscoveryAndSynchronizationService/CreateIdentificationServiceArea":  Write,
+	"/ridpbv1.DiscoveryAndSynchronizationService/CreateSubscription":               Write,
+	"/ridpbv1.DiscoveryAndSynchronizationService/DeleteIdentificationServiceArea":  Write,
+	"/ridpbv1.DiscoveryAndSynchronizationService/DeleteSubscription":               Write,
+	"/ridpbv1.DiscoveryAndSynchronizationService/GetIdentificationServiceArea":     Read,
+	"/ridpbv1.DiscoveryAndSynchronizationService/GetSubscription":                  Read,
+	"/ridpbv1.DiscoveryAndSynchronizationService/SearchIdentificationServiceAreas": Search,
+	"/ridpbv1.DiscoveryAndSynchronizationService/SearchSubscriptions":              Search,
+	"/ridpbv1.DiscoveryAndSynchronizationService/UpdateIdentificationServiceArea":  Write,
+	"/ridpbv1.DiscoveryAndSynchronizationService/UpdateSubscription":               Write,
+
+	"/ridpbv2.StandardRemoteIDAPIInterfacesService/CreateIdentificationServiceArea":  Write,
+	"/ridpbv2.StandardRemoteIDAPIInterfacesService/CreateSubscription":               Write,
+	"/ridpbv2.StandardRemoteIDAPIInterfacesService/DeleteIdentificationServiceArea":  Write,
+	"/ridpbv2.StandardRemoteIDAPIInterfacesService/DeleteSubscription":               Write,
+	"/ridpbv2.StandardRemoteIDAPIInterfacesService/GetIdentificationServiceArea":     Read,
+	"/ridpbv2.StandardRemoteIDAPIInterfacesService/GetSubscription":                  Read,
+	"/ridpbv2.StandardRemoteIDAPIInterfacesService/SearchIdentificationServiceAreas": Search,
+	"/ridpbv2.StandardRemoteIDAPIInterfacesService/SearchSubscriptions":              Search,
+	"/ridpbv2.StandardRemoteIDAPIInterfacesService/UpdateIdentificationServiceArea":  Write,
+	"/ridpbv2.StandardRemoteIDAPIInterfacesService/UpdateSubscription":               Write,
+
+	"/scdpb.UTMAPIUSSDSSAndUSSUSSService/CreateConstraintReference":        Write,
+	"/scdpb.UTMAPIUSSDSSAndUSSUSSService/CreateOperationalIntentReference": Write,
+	"/scdpb.UTMAPIUSSDSSAndUSSUSSService/CreateSubscription":               Write,
+	"/scdpb.UTMAPIUSSDSSAndUSSUSSService/DeleteConstraintReference":        Write,
+	"/scdpb.UTMAPIUSSDSSAndUSSUSSService/DeleteOperationalIntentReference": Write,
+	"/scdpb.UTMAPIUSSDSSAndUSSUSSService/DeleteSubscription":               Write,
+	"/scdpb.UTMAPIUSSDSSAndUSSUSSService/GetConstraintReference":           Read,
+	"/scdpb.UTMAPIUSSDSSAndUSSUSSService/GetOperationalIntentReference":    Read,
+	"/scdpb.UTMAPIUSSDSSAndUSSUSSService/GetSubscription":                  Read,
+	"/scdpb.UTMAPIUSSDSSAndUSSUSSService/GetUssAvailability":               Read,
+	"/scdpb.UTMAPIUSSDSSAndUSSUSSService/MakeDssReport":                    Write,
+	"/scdpb.UTMAPIUSSDSSAndUSSUSSService/QueryConstraintReferences":        Search,
+	"/scdpb.UTMAPIUSSDSSAndUSSUSSService/QueryOperationalIntentReferences": Search,
+	"/scdpb.UTMAPIUSSDSSAndUSSUSSService/QuerySubscriptions":               Search,
+	"/scdpb.UTMAPIUSSDSSAndUSSUSSService/SetUssAvailability":               Write,
+	"/scdpb.UTMAPIUSSDSSAndUSSUSSService/UpdateConstraintReference":        Write,
+	"/scdpb.UTMAPIUSSDSSAndUSSUSSService/UpdateOperationalIntentReference": Write,
+	"/scdpb.UTMAPIUSSDSSAndUSSUSSService/UpdateSubscription":               Write,
+
+	"/watchpb.DSSWatchService/WatchSubscription": Read,
+}
+
+type bucketKey struct {
+	owner models.Owner
+	class Class
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// Limiter enforces token bucket rate limits per owner and per Class.
+type Limiter struct {
+	rates     map[Class]Rate
+	overrides map[auth.Operation]Class
+	clock     clockwork.Clock
+
+	mu      sync.Mutex
+	buckets map[bucketKey]*bucket
+	swept   time.Time
+}
+
+// NewLimiter returns a Limiter enforcing rates. Classes missing from rates are
+// not limited. overrides may be used to assign operations to a Class other
+// than the one in Classes.
+func NewLimiter(rates map[Class]Rate, overrides map[auth.Operation]Class, clock clockwork.Clock) *Limiter {
+	return &Limiter{
+		rates:     rates,
+		overrides: overrides,
+		clock:     clock,
+		buckets:   map[bucketKey]*bucket{},
+		swept:     clock.Now(),
+	}
+}
+
+// Allow consumes a token from the bucket of owner for class. If no token is
+// available, it returns false and the duration after which a token will be.
+func (l *Limiter) Allow(owner models.Owner, class Class) (bool, time.Duration) {
+	rate, ok := l.rates[class]
+	if !ok || rate.Unlimited() {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	if now.Sub(l.swept) >= sweepInterval {
+		l.evictIdle(now)
+		l.swept = now
+	}
+
+	key := bucketKey{owner: owner, class: class}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(rate.Burst), updated: now}
+		l.buckets[key] = b
+	}
+
+	if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.tokens = math.Min(float64(rate.Burst), b.tokens+elapsed.Seconds()*rate.PerSecond)
+		b.updated = now
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - b.tokens) / rate.PerSecond * float64(time.Second))
+	return false, wait
+}
+
+// evictIdle removes the buckets which are full at now, which are equivalent to
+// the buckets created on the next call of their owner, so that the buckets of
+// owners no longer calling do not accumulate. l.mu must be held.
+func (l *Limiter) evictIdle(now time.Time) {
+	for key, b := range l.buckets {
+		rate := l.rates[key.class]
+		if b.tokens+now.Sub(b.updated).Seconds()*rate.PerSecond >= float64(rate.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func (l *Limiter) classOf(op auth.Operation) Class {
+	if class, ok := l.overrides[op]; ok {
+		return class
+	}
+	if class, ok := Classes[op]; ok {
+		return class
+	}
+	return Write
+}
+
+// Interceptor is a grpc Interceptor rejecting calls from owners that exceeded
+// the rate of the class of the called method. It must be chained after the
+// authorization interceptor so that the owner is present in the context;
+// calls without an owner are not limited.
+func (l *Limiter) Interceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if trailer, err := l.limit(ctx, info.FullMethod); err != nil {
+		// The call fails without response, so the hint is sent in the trailer
+		// rather than in a header. Failing to set it only loses the hint; the
+		// call is rejected regardless.
+		_ = grpc.SetTrailer(ctx, trailer)
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamInterceptor is the grpc StreamInterceptor counterpart of Interceptor,
+// limiting the opening of streams.
+func (l *Limiter) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if trailer, err := l.limit(ss.Context(), info.FullMethod); err != nil {
+		ss.SetTrailer(trailer)
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// limit returns an error, along with the trailer hinting when to retry, if the
+// owner in ctx exceeded the rate of the class of method.
+func (l *Limiter) limit(ctx context.Context, method string) (metadata.MD, error) {
+	owner, ok := auth.OwnerFromContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	class := l.classOf(auth.Operation(method))
+	allowed, wait := l.Allow(owner, class)
+	if allowed {
+		return nil, nil
+	}
+	retryAfter := int(math.Ceil(wait.Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	return metadata.Pairs(RetryAfterHeader, strconv.Itoa(retryAfter)), stacktrace.NewErrorWithCode(dsserr.Exhausted,
+		"Rate limit exceeded for %s calls by %s; retry after %d seconds", class, owner, retryAfter)
+}
@@ -0,0 +1,228 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/interuss/dss/pkg/auth"
+	dsserr "github.com/interuss/dss/pkg/errors"
+	"github.com/interuss/dss/pkg/models"
+	"github.com/interuss/stacktrace"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	_ "github.com/interuss/dss/pkg/api/v1/adminpb"
+	_ "github.com/interuss/dss/pkg/api/v1/auxpb"
+	_ "github.com/interuss/dss/pkg/api/v1/bulkpb"
+	_ "github.com/interuss/dss/pkg/api/v1/historypb"
+	_ "github.com/interuss/dss/pkg/api/v1/ridpbv1"
+	_ "github.com/interuss/dss/pkg/api/v1/scdpb"
+	_ "github.com/interuss/dss/pkg/api/v1/watchpb"
+	_ "github.com/interuss/dss/pkg/api/v2/ridpbv2"
+)
+
+func TestParseRate(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		input   string
+		want    Rate
+		wantErr bool
+	}{
+		{name: "empty", input: "", want: Rate{}},
+		{name: "integer", input: "5/20", want: Rate{PerSecond: 5, Burst: 20}},
+		{name: "fractional", input: "0.5/3", want: Rate{PerSecond: 0.5, Burst: 3}},
+		{name: "missing burst", input: "5", wantErr: true},
+		{name: "negative", input: "-1/3", wantErr: true},
+		{name: "garbage", input: "a/b", wantErr: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseRate(test.input)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestClasses(t *testing.T) {
+	// Every method of the services of the DSS must be classified explicitly.
+	for _, service := range []protoreflect.FullName{
+		"adminpb.DSSAdminService",
+		"auxpb.DSSAuxService",
+		"bulkpb.DSSBulkService",
+		"historypb.DSSHistoryService",
+		"ridpbv1.DiscoveryAndSynchronizationService",
+		"ridpbv2.StandardRemoteIDAPIInterfacesService",
+		"scdpb.UTMAPIUSSDSSAndUSSUSSService",
+		"watchpb.DSSWatchService",
+	} {
+		d, err := protoregistry.GlobalFiles.FindDescriptorByName(service)
+		require.NoError(t, err)
+		methods := d.(protoreflect.ServiceDescriptor).Methods()
+		for i := 0; i < methods.Len(); i++ {
+			op := auth.Operation(fmt.Sprintf("/%s/%s", service, methods.Get(i).Name()))
+			require.Contains(t, Classes, op)
+		}
+	}
+
+	require.Equal(t, Search, Classes["/scdpb.UTMAPIUSSDSSAndUSSUSSService/QueryOperationalIntentReferences"])
+	require.Equal(t, Read, Classes["/scdpb.UTMAPIUSSDSSAndUSSUSSService/GetConstraintReference"])
+	require.Equal(t, Write, Classes["/scdpb.UTMAPIUSSDSSAndUSSUSSService/MakeDssReport"])
+	require.Equal(t, Write, Classes["/ridpbv1.DiscoveryAndSynchronizationService/DeleteSubscription"])
+}
+
+func TestLimiterAllow(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	l := NewLimiter(map[Class]Rate{Write: {PerSecond: 1, Burst: 2}}, nil, clock)
+
+	ok, _ := l.Allow("uss1", Write)
+	require.True(t, ok)
+	ok, _ = l.Allow("uss1", Write)
+	require.True(t, ok)
+	ok, wait := l.Allow("uss1", Write)
+	require.False(t, ok)
+	require.Equal(t, time.Second, wait)
+
+	// Buckets are independent per owner and per class.
+	ok, _ = l.Allow("uss2", Write)
+	require.True(t, ok)
+	for i := 0; i < 10; i++ {
+		ok, _ = l.Allow("uss1", Read)
+		require.True(t, ok)
+	}
+
+	clock.Advance(time.Second)
+	ok, _ = l.Allow("uss1", Write)
+	require.True(t, ok)
+	ok, _ = l.Allow("uss1", Write)
+	require.False(t, ok)
+}
+
+func TestLimiterEvictsIdleBuckets(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	l := NewLimiter(map[Class]Rate{Write: {PerSecond: 0.02, Burst: 2}}, nil, clock)
+
+	ok, _ := l.Allow("uss1", Write)
+	require.True(t, ok)
+	ok, _ = l.Allow("uss1", Write)
+	require.True(t, ok)
+	ok, _ = l.Allow("uss2", Write)
+	require.True(t, ok)
+	require.Len(t, l.buckets, 2)
+
+	// After a minute, the bucket of uss2 is full again while the bucket of
+	// uss1 is not: only the former is evicted.
+	clock.Advance(sweepInterval)
+	ok, _ = l.Allow("uss3", Write)
+	require.True(t, ok)
+	require.Len(t, l.buckets, 2)
+	require.Contains(t, l.buckets, bucketKey{owner: "uss1", class: Write})
+	require.Contains(t, l.buckets, bucketKey{owner: "uss3", class: Write})
+
+	// The bucket of uss1 was kept with the single token it regained.
+	ok, _ = l.Allow("uss1", Write)
+	require.True(t, ok)
+	ok, _ = l.Allow("uss1", Write)
+	require.False(t, ok)
+
+	// Once full, all the idle buckets are evicted.
+	clock.Advance(5 * time.Minute)
+	ok, _ = l.Allow("uss4", Write)
+	require.True(t, ok)
+	require.Len(t, l.buckets, 1)
+}
+
+// trailerStream is a grpc.ServerTransportStream recording the metadata set
+// by the handlers.
+type trailerStream struct {
+	grpc.ServerTransportStream
+	header  metadata.MD
+	trailer metadata.MD
+}
+
+func (s *trailerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *trailerStream) SetTrailer(md metadata.MD) error {
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+func TestInterceptor(t *testing.T) {
+	l := NewLimiter(
+		map[Class]Rate{Search: {PerSecond: 1, Burst: 1}},
+		map[auth.Operation]Class{"/svc/GetHugeArea": Search},
+		clockwork.NewFakeClock(),
+	)
+	ctx := auth.ContextWithOwner(context.Background(), models.Owner("uss1"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/svc/GetHugeArea"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	res, err := l.Interceptor(ctx, nil, info, handler)
+	require.NoError(t, err)
+	require.Equal(t, "ok", res)
+
+	stream := &trailerStream{}
+	_, err = l.Interceptor(grpc.NewContextWithServerTransportStream(ctx, stream), nil, info, handler)
+	require.Error(t, err)
+	require.Equal(t, dsserr.Exhausted, stacktrace.GetCode(err))
+	require.Equal(t, []string{"1"}, stream.trailer.Get(RetryAfterHeader))
+	require.Empty(t, stream.header)
+
+	// Calls without an owner are not limited.
+	_, err = l.Interceptor(context.Background(), nil, info, handler)
+	require.NoError(t, err)
+}
+
+// serverStream is a grpc.ServerStream with a context, recording its trailer.
+type serverStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	trailer metadata.MD
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStream) SetTrailer(md metadata.MD) {
+	s.trailer = metadata.Join(s.trailer, md)
+}
+
+func TestStreamInterceptor(t *testing.T) {
+	const watch = "/watchpb.DSSWatchService/WatchSubscription"
+	l := NewLimiter(map[Class]Rate{Read: {PerSecond: 1, Burst: 1}}, nil, clockwork.NewFakeClock())
+	info := &grpc.StreamServerInfo{FullMethod: watch}
+	handled := 0
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		handled++
+		return nil
+	}
+
+	ss := &serverStream{ctx: auth.ContextWithOwner(context.Background(), models.Owner("uss1"))}
+	require.NoError(t, l.StreamInterceptor(nil, ss, info, handler))
+	require.Equal(t, 1, handled)
+
+	err := l.StreamInterceptor(nil, ss, info, handler)
+	require.Error(t, err)
+	require.Equal(t, dsserr.Exhausted, stacktrace.GetCode(err))
+	require.Equal(t, []string{"1"}, ss.trailer.Get(RetryAfterHeader))
+	require.Equal(t, 1, handled)
+
+	// Streams without an owner are not limited.
+	require.NoError(t, l.StreamInterceptor(nil, &serverStream{ctx: context.Background()}, info, handler))
+	require.Equal(t, 2, handled)
+}
@@ -28,6 +28,8 @@ var (
 type mockRepo struct {
 	*isaStore
 	*subscriptionStore
+	*auditStore
+	*eventStore
 	dssql.Queryable
 }
 
@@ -39,6 +41,10 @@ func (s *mockRepo) Transact(ctx context.Context, f func(repo repos.Repository) e
 	return f(s)
 }
 
+func (s *mockRepo) TransactReadOnly(ctx context.Context, f func(repo repos.Repository) error) error {
+	return f(s)
+}
+
 func (s *mockRepo) Close() error {
 	return nil
 }
@@ -56,6 +62,8 @@ func setUpStore(ctx context.Context, t *testing.T, logger *zap.Logger) (store.St
 			subscriptionStore: &subscriptionStore{
 				subs: make(map[dssmodels.ID]*ridmodels.Subscription),
 			},
+			auditStore: &auditStore{},
+			eventStore: &eventStore{},
 		}, func() {}
 	}
 	if !(connectParameters.DBName == "rid" || connectParameters.DBName == "scd") {
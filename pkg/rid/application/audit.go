@@ -0,0 +1,58 @@
+package application
+
+import (
+	dssmodels "github.com/interuss/dss/pkg/models"
+	ridmodels "github.com/interuss/dss/pkg/rid/models"
+)
+
+// isaAuditRecord returns the AuditRecord for the mutation "op" of an ISA from
+// "old" to "isa", either of which may be nil.
+func isaAuditRecord(op dssmodels.AuditOperation, old, isa *ridmodels.IdentificationServiceArea) *dssmodels.AuditRecord {
+	r := &dssmodels.AuditRecord{
+		EntityType: dssmodels.AuditEntityISA,
+		Operation:  op,
+	}
+	if old != nil {
+		r.OldVersion = old.Version.String()
+	}
+	if isa != nil {
+		r.NewVersion = isa.Version.String()
+	} else {
+		isa = old
+	}
+	if isa != nil {
+		r.EntityID = isa.ID.String()
+		r.Owner = isa.Owner
+		r.StartTime = isa.StartTime
+		r.EndTime = isa.EndTime
+		r.AltitudeLo = isa.AltitudeLo
+		r.AltitudeHi = isa.AltitudeHi
+	}
+	return r
+}
+
+// subscriptionAuditRecord returns the AuditRecord for the mutation "op" of a
+// Subscription from "old" to "sub", either of which may be nil.
+func subscriptionAuditRecord(op dssmodels.AuditOperation, old, sub *ridmodels.Subscription) *dssmodels.AuditRecord {
+	r := &dssmodels.AuditRecord{
+		EntityType: dssmodels.AuditEntityRIDSubscription,
+		Operation:  op,
+	}
+	if old != nil {
+		r.OldVersion = old.Version.String()
+	}
+	if sub != nil {
+		r.NewVersion = sub.Version.String()
+	} else {
+		sub = old
+	}
+	if sub != nil {
+		r.EntityID = sub.ID.String()
+		r.Owner = sub.Owner
+		r.StartTime = sub.StartTime
+		r.EndTime = sub.EndTime
+		r.AltitudeLo = sub.AltitudeLo
+		r.AltitudeHi = sub.AltitudeHi
+	}
+	return r
+}
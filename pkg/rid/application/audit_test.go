@@ -0,0 +1,82 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/geo/s2"
+	"github.com/google/uuid"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	ridmodels "github.com/interuss/dss/pkg/rid/models"
+	"github.com/stretchr/testify/require"
+)
+
+type auditStore struct {
+	records []*dssmodels.AuditRecord
+}
+
+func (store *auditStore) InsertAuditRecord(ctx context.Context, r *dssmodels.AuditRecord) error {
+	recorded := *r
+	recorded.RecordedAt = fakeClock.Now()
+	store.records = append(store.records, &recorded)
+	return nil
+}
+
+func (store *auditStore) SearchAuditRecords(ctx context.Context, f *dssmodels.AuditFilter) ([]*dssmodels.AuditRecord, error) {
+	var records []*dssmodels.AuditRecord
+	for i := len(store.records) - 1; i >= 0; i-- {
+		r := store.records[i]
+		switch {
+		case f.EntityType != "" && f.EntityType != r.EntityType,
+			f.EntityID != "" && f.EntityID != r.EntityID,
+			f.Owner != "" && f.Owner != r.Owner,
+			f.Earliest != nil && r.RecordedAt.Before(*f.Earliest),
+			f.Latest != nil && r.RecordedAt.After(*f.Latest):
+			continue
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+func TestISAMutationsAreAudited(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		app, cleanup = setUpISAApp(ctx, t)
+	)
+	defer cleanup()
+
+	serviceArea := &ridmodels.IdentificationServiceArea{
+		ID:        dssmodels.ID(uuid.New().String()),
+		Owner:     dssmodels.Owner(uuid.New().String()),
+		URL:       "https://no/place/like/home/for/flights",
+		StartTime: &startTime,
+		EndTime:   &endTime,
+		Cells: s2.CellUnion{
+			s2.CellID(12494535935418957824),
+		},
+	}
+
+	isa, _, err := app.InsertISA(ctx, serviceArea)
+	require.NoError(t, err)
+	_, _, err = app.DeleteISA(ctx, isa.ID, isa.Owner, isa.Version)
+	require.NoError(t, err)
+
+	repo, err := app.Store.Interact(ctx)
+	require.NoError(t, err)
+	records, err := repo.SearchAuditRecords(ctx, &dssmodels.AuditFilter{
+		EntityType: dssmodels.AuditEntityISA,
+		EntityID:   isa.ID.String(),
+	})
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	require.Equal(t, dssmodels.AuditDelete, records[0].Operation)
+	require.Equal(t, isa.Version.String(), records[0].OldVersion)
+	require.Empty(t, records[0].NewVersion)
+
+	require.Equal(t, dssmodels.AuditCreate, records[1].Operation)
+	require.Empty(t, records[1].OldVersion)
+	require.Equal(t, isa.Version.String(), records[1].NewVersion)
+	require.Equal(t, isa.Owner, records[1].Owner)
+}
@@ -0,0 +1,26 @@
+package application
+
+import (
+	dssmodels "github.com/interuss/dss/pkg/models"
+	ridmodels "github.com/interuss/dss/pkg/rid/models"
+)
+
+// isaSubscriptionEvents returns the SubscriptionEvents of the change of "isa"
+// to each of "subs", whose notification indices were incremented by the
+// change. "deleted" indicates whether the change deleted "isa".
+func isaSubscriptionEvents(isa *ridmodels.IdentificationServiceArea, deleted bool, subs []*ridmodels.Subscription) []*dssmodels.SubscriptionEvent {
+	events := make([]*dssmodels.SubscriptionEvent, len(subs))
+	for i, sub := range subs {
+		events[i] = &dssmodels.SubscriptionEvent{
+			SubscriptionID:    sub.ID,
+			NotificationIndex: sub.NotificationIndex,
+			EntityType:        dssmodels.AuditEntityISA,
+			EntityID:          isa.ID.String(),
+			Deleted:           deleted,
+		}
+		if !deleted {
+			events[i].Version = isa.Version.String()
+		}
+	}
+	return events
+}
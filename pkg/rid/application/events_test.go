@@ -0,0 +1,111 @@
+package application
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/golang/geo/s2"
+	"github.com/google/uuid"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	ridmodels "github.com/interuss/dss/pkg/rid/models"
+	"github.com/stretchr/testify/require"
+)
+
+type eventStore struct {
+	events []*dssmodels.SubscriptionEvent
+}
+
+func (store *eventStore) InsertSubscriptionEvents(ctx context.Context, events []*dssmodels.SubscriptionEvent) error {
+	for _, e := range events {
+		copy := *e
+		copy.RecordedAt = fakeClock.Now()
+		store.events = append(store.events, &copy)
+	}
+	return nil
+}
+
+func (store *eventStore) ListSubscriptionEvents(ctx context.Context, id dssmodels.ID, afterIndex int, limit int) ([]*dssmodels.SubscriptionEvent, error) {
+	var events []*dssmodels.SubscriptionEvent
+	for _, e := range store.events {
+		if e.SubscriptionID == id && e.NotificationIndex > afterIndex {
+			events = append(events, e)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].NotificationIndex < events[j].NotificationIndex })
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+func (store *eventStore) DeleteSubscriptionEventsBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	var (
+		kept    []*dssmodels.SubscriptionEvent
+		deleted int64
+	)
+	for _, e := range store.events {
+		if e.RecordedAt.Before(cutoff) {
+			deleted++
+			continue
+		}
+		kept = append(kept, e)
+	}
+	store.events = kept
+	return deleted, nil
+}
+
+func TestISAMutationsRecordSubscriptionEvents(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		app, cleanup = setUpISAApp(ctx, t)
+	)
+	defer cleanup()
+
+	cells := s2.CellUnion{s2.CellID(12494535935418957824)}
+	sub, err := app.InsertSubscription(ctx, &ridmodels.Subscription{
+		ID:        dssmodels.ID(uuid.New().String()),
+		Owner:     "owner",
+		URL:       "https://example.com/uss",
+		StartTime: &startTime,
+		EndTime:   &endTime,
+		Cells:     cells,
+	})
+	require.NoError(t, err)
+
+	isa, _, err := app.InsertISA(ctx, &ridmodels.IdentificationServiceArea{
+		ID:        dssmodels.ID(uuid.New().String()),
+		Owner:     dssmodels.Owner(uuid.New().String()),
+		URL:       "https://no/place/like/home/for/flights",
+		StartTime: &startTime,
+		EndTime:   &endTime,
+		Cells:     cells,
+	})
+	require.NoError(t, err)
+	_, _, err = app.DeleteISA(ctx, isa.ID, isa.Owner, isa.Version)
+	require.NoError(t, err)
+
+	repo, err := app.Store.Interact(ctx)
+	require.NoError(t, err)
+	events, err := repo.ListSubscriptionEvents(ctx, sub.ID, sub.NotificationIndex, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	require.Equal(t, sub.NotificationIndex+1, events[0].NotificationIndex)
+	require.Equal(t, dssmodels.AuditEntityISA, events[0].EntityType)
+	require.Equal(t, isa.ID.String(), events[0].EntityID)
+	require.Equal(t, isa.Version.String(), events[0].Version)
+	require.False(t, events[0].Deleted)
+
+	require.Equal(t, sub.NotificationIndex+2, events[1].NotificationIndex)
+	require.Equal(t, isa.ID.String(), events[1].EntityID)
+	require.Empty(t, events[1].Version)
+	require.True(t, events[1].Deleted)
+
+	// Resuming after the first event only returns the second one.
+	events, err = repo.ListSubscriptionEvents(ctx, sub.ID, sub.NotificationIndex+1, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.True(t, events[0].Deleted)
+}
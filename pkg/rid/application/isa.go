@@ -23,6 +23,11 @@ type ISAApp interface {
 	// Returns the delete IdentificationServiceArea and all Subscriptions affected by the delete.
 	DeleteISA(ctx context.Context, id dssmodels.ID, owner dssmodels.Owner, version *dssmodels.Version) (*ridmodels.IdentificationServiceArea, []*ridmodels.Subscription, error)
 
+	// ForceDeleteISA deletes the IdentificationServiceArea identified by "id"
+	// regardless of its owner and version, for use by DSS operators.
+	// Returns the deleted IdentificationServiceArea and all Subscriptions affected by the delete.
+	ForceDeleteISA(ctx context.Context, id dssmodels.ID) (*ridmodels.IdentificationServiceArea, []*ridmodels.Subscription, error)
+
 	// InsertISA inserts or updates an ISA.
 	InsertISA(ctx context.Context, isa *ridmodels.IdentificationServiceArea) (*ridmodels.IdentificationServiceArea, []*ridmodels.Subscription, error)
 
@@ -34,11 +39,12 @@ type ISAApp interface {
 }
 
 func (a *app) GetISA(ctx context.Context, id dssmodels.ID) (*ridmodels.IdentificationServiceArea, error) {
-	repo, err := a.Store.Interact(ctx)
-	if err != nil {
-		return nil, stacktrace.Propagate(err, "Unable to interact with store")
-	}
-	return repo.GetISA(ctx, id)
+	var isa *ridmodels.IdentificationServiceArea
+	err := a.Store.TransactReadOnly(ctx, func(repo repos.Repository) (err error) {
+		isa, err = repo.GetISA(ctx, id)
+		return err
+	})
+	return isa, err // No need to Propagate this error as this stack layer does not add useful information
 }
 
 // SearchISAs for ISA within the volume bounds.
@@ -48,16 +54,38 @@ func (a *app) SearchISAs(ctx context.Context, cells s2.CellUnion, earliest *time
 		earliest = &now
 	}
 
-	repo, err := a.Store.Interact(ctx)
-	if err != nil {
-		return nil, stacktrace.Propagate(err, "Unable to interact with store")
-	}
-
-	return repo.SearchISAs(ctx, cells, earliest, latest)
+	var isas []*ridmodels.IdentificationServiceArea
+	err := a.Store.TransactReadOnly(ctx, func(repo repos.Repository) (err error) {
+		isas, err = repo.SearchISAs(ctx, cells, earliest, latest)
+		return err
+	})
+	return isas, err // No need to Propagate this error as this stack layer does not add useful information
 }
 
 // DeleteISA the given ISA
 func (a *app) DeleteISA(ctx context.Context, id dssmodels.ID, owner dssmodels.Owner, version *dssmodels.Version) (*ridmodels.IdentificationServiceArea, []*ridmodels.Subscription, error) {
+	return a.deleteISA(ctx, id, func(old *ridmodels.IdentificationServiceArea) error {
+		switch {
+		case !version.Matches(old.Version):
+			return stacktrace.NewErrorWithCode(dsserr.VersionMismatch,
+				"ISA currently at version %s but client specified %s", old.Version, version)
+		case old.Owner != owner:
+			return stacktrace.NewErrorWithCode(dsserr.PermissionDenied,
+				"ISA owned by %s, but %s attempted to delete", old.Owner, owner)
+		}
+		return nil
+	})
+}
+
+// ForceDeleteISA deletes the given ISA regardless of its owner and version.
+func (a *app) ForceDeleteISA(ctx context.Context, id dssmodels.ID) (*ridmodels.IdentificationServiceArea, []*ridmodels.Subscription, error) {
+	return a.deleteISA(ctx, id, func(*ridmodels.IdentificationServiceArea) error { return nil })
+}
+
+// deleteISA deletes the ISA identified by id if check accepts its current
+// state, and increments the notification indices of the subscriptions in its
+// cells.
+func (a *app) deleteISA(ctx context.Context, id dssmodels.ID, check func(old *ridmodels.IdentificationServiceArea) error) (*ridmodels.IdentificationServiceArea, []*ridmodels.Subscription, error) {
 	var (
 		ret  *ridmodels.IdentificationServiceArea
 		subs []*ridmodels.Subscription
@@ -70,23 +98,26 @@ func (a *app) DeleteISA(ctx context.Context, id dssmodels.ID, owner dssmodels.Ow
 			return stacktrace.Propagate(err, "Error getting ISA")
 		case old == nil:
 			return stacktrace.NewErrorWithCode(dsserr.NotFound, "ISA %s not found", id.String())
-		case !version.Matches(old.Version):
-			return stacktrace.NewErrorWithCode(dsserr.VersionMismatch,
-				"ISA currently at version %s but client specified %s", old.Version, version)
-		case old.Owner != owner:
-			return stacktrace.NewErrorWithCode(dsserr.PermissionDenied,
-				"ISA owned by %s, but %s attempted to delete", old.Owner, owner)
+		}
+		if err := check(old); err != nil {
+			return err
 		}
 
 		ret, err = repo.DeleteISA(ctx, old)
 		if err != nil {
 			return stacktrace.Propagate(err, "Error deleting ISA")
 		}
+		if err := repo.InsertAuditRecord(ctx, isaAuditRecord(dssmodels.AuditDelete, old, nil)); err != nil {
+			return stacktrace.Propagate(err, "Error recording ISA deletion in audit log")
+		}
 
 		subs, err = repo.UpdateNotificationIdxsInCells(ctx, old.Cells)
 		if err != nil {
 			return stacktrace.Propagate(err, "Error updating notification indices")
 		}
+		if err := repo.InsertSubscriptionEvents(ctx, isaSubscriptionEvents(old, true, subs)); err != nil {
+			return stacktrace.Propagate(err, "Error recording subscription events")
+		}
 		return nil
 	})
 	return ret, subs, err // No need to Propagate this error as this stack layer does not add useful information
@@ -125,6 +156,12 @@ func (a *app) InsertISA(ctx context.Context, isa *ridmodels.IdentificationServic
 		if err != nil {
 			return stacktrace.Propagate(err, "Error inserting ISA")
 		}
+		if err := repo.InsertAuditRecord(ctx, isaAuditRecord(dssmodels.AuditCreate, nil, ret)); err != nil {
+			return stacktrace.Propagate(err, "Error recording ISA creation in audit log")
+		}
+		if err := repo.InsertSubscriptionEvents(ctx, isaSubscriptionEvents(ret, false, subs)); err != nil {
+			return stacktrace.Propagate(err, "Error recording subscription events")
+		}
 		return nil
 	})
 	return ret, subs, err // No need to Propagate this error as this stack layer does not add useful information
@@ -163,6 +200,9 @@ func (a *app) UpdateISA(ctx context.Context, isa *ridmodels.IdentificationServic
 		if err != nil {
 			return stacktrace.Propagate(err, "Error updating ISA")
 		}
+		if err := repo.InsertAuditRecord(ctx, isaAuditRecord(dssmodels.AuditUpdate, old, ret)); err != nil {
+			return stacktrace.Propagate(err, "Error recording ISA update in audit log")
+		}
 
 		// TODO steeling, we should change this to a Custom type, to obfuscate
 		// some of these metrics and prevent us from doing the wrong thing.
@@ -175,6 +215,9 @@ func (a *app) UpdateISA(ctx context.Context, isa *ridmodels.IdentificationServic
 		if err != nil {
 			return stacktrace.Propagate(err, "Error updating notification indices")
 		}
+		if err := repo.InsertSubscriptionEvents(ctx, isaSubscriptionEvents(ret, false, subs)); err != nil {
+			return stacktrace.Propagate(err, "Error recording subscription events")
+		}
 		return nil
 	})
 
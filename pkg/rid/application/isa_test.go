@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"sort"
 	"testing"
 	"time"
 
@@ -84,11 +85,44 @@ func (store *isaStore) SearchISAs(ctx context.Context, cells s2.CellUnion, earli
 	return isas, nil
 }
 
+// Implements repos.ISA.ListISAsByOwner
+func (store *isaStore) ListISAsByOwner(ctx context.Context, owner dssmodels.Owner) ([]*ridmodels.IdentificationServiceArea, error) {
+	var isas []*ridmodels.IdentificationServiceArea
+	for _, isa := range store.isas {
+		if isa.Owner == owner {
+			isas = append(isas, isa)
+		}
+	}
+	return isas, nil
+}
+
 // Implements repos.ISA.ListExpiredISAs
 func (store *isaStore) ListExpiredISAs(ctx context.Context, writer string) ([]*ridmodels.IdentificationServiceArea, error) {
 	return make([]*ridmodels.IdentificationServiceArea, 0), nil
 }
 
+// Implements repos.ISA.ListISAs
+func (store *isaStore) ListISAs(ctx context.Context, after dssmodels.ID, limit int) ([]*ridmodels.IdentificationServiceArea, error) {
+	var isas []*ridmodels.IdentificationServiceArea
+	for id, isa := range store.isas {
+		if id > after {
+			isas = append(isas, isa)
+		}
+	}
+	sort.Slice(isas, func(i, j int) bool { return isas[i].ID < isas[j].ID })
+	if len(isas) > limit {
+		isas = isas[:limit]
+	}
+	return isas, nil
+}
+
+// Implements repos.ISA.RestoreISA
+func (store *isaStore) RestoreISA(ctx context.Context, isa *ridmodels.IdentificationServiceArea) error {
+	storedCopy := *isa
+	store.isas[isa.ID] = &storedCopy
+	return nil
+}
+
 func TestISAUpdateIdxCells(t *testing.T) {
 	ctx := context.Background()
 	app, cleanup := setUpISAApp(ctx, t)
@@ -378,3 +412,44 @@ func TestAppDeleteISAs(t *testing.T) {
 		require.Equal(t, 44, subscriptionsOut[i].NotificationIndex)
 	}
 }
+
+func TestAppForceDeleteISA(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		app, cleanup = setUpISAApp(ctx, t)
+	)
+	defer cleanup()
+
+	for _, r := range subscriptionsPool {
+		copy := *r.input
+		_, err := app.InsertSubscription(ctx, &copy)
+		require.NoError(t, err)
+	}
+	isa, _, err := app.InsertISA(ctx, &ridmodels.IdentificationServiceArea{
+		ID:        dssmodels.ID(uuid.New().String()),
+		Owner:     dssmodels.Owner(uuid.New().String()),
+		URL:       "https://no/place/like/home/for/flights",
+		StartTime: &startTime,
+		EndTime:   &endTime,
+		Cells: s2.CellUnion{
+			s2.CellID(12494535935418957824),
+		},
+	})
+	require.NoError(t, err)
+
+	// Force deletion ignores the owner and version of the ISA.
+	serviceAreaOut, subscriptionsOut, err := app.ForceDeleteISA(ctx, isa.ID)
+	require.NoError(t, err)
+	require.Equal(t, isa.ID, serviceAreaOut.ID)
+	require.Len(t, subscriptionsOut, len(subscriptionsPool))
+	for _, s := range subscriptionsOut {
+		require.Equal(t, 44, s.NotificationIndex)
+	}
+
+	isa, err = app.GetISA(ctx, isa.ID)
+	require.NoError(t, err)
+	require.Nil(t, isa)
+
+	_, _, err = app.ForceDeleteISA(ctx, dssmodels.ID(uuid.New().String()))
+	require.Equal(t, dsserr.NotFound, stacktrace.GetCode(err))
+}
@@ -39,19 +39,21 @@ type SubscriptionApp interface {
 }
 
 func (a *app) GetSubscription(ctx context.Context, id dssmodels.ID) (*ridmodels.Subscription, error) {
-	repo, err := a.Store.Interact(ctx)
-	if err != nil {
-		return nil, stacktrace.Propagate(err, "Unable to interact with store")
-	}
-	return repo.GetSubscription(ctx, id)
+	var sub *ridmodels.Subscription
+	err := a.Store.TransactReadOnly(ctx, func(repo repos.Repository) (err error) {
+		sub, err = repo.GetSubscription(ctx, id)
+		return err
+	})
+	return sub, err // No need to Propagate this error as this stack layer does not add useful information
 }
 
 func (a *app) SearchSubscriptionsByOwner(ctx context.Context, cells s2.CellUnion, owner dssmodels.Owner) ([]*ridmodels.Subscription, error) {
-	repo, err := a.Store.Interact(ctx)
-	if err != nil {
-		return nil, stacktrace.Propagate(err, "Unable to interact with store")
-	}
-	return repo.SearchSubscriptionsByOwner(ctx, cells, owner)
+	var subs []*ridmodels.Subscription
+	err := a.Store.TransactReadOnly(ctx, func(repo repos.Repository) (err error) {
+		subs, err = repo.SearchSubscriptionsByOwner(ctx, cells, owner)
+		return err
+	})
+	return subs, err // No need to Propagate this error as this stack layer does not add useful information
 }
 
 func (a *app) InsertSubscription(ctx context.Context, s *ridmodels.Subscription) (*ridmodels.Subscription, error) {
@@ -88,6 +90,9 @@ func (a *app) InsertSubscription(ctx context.Context, s *ridmodels.Subscription)
 		if err != nil {
 			return stacktrace.Propagate(err, "Error inserting Subscription into repo")
 		}
+		if err := repo.InsertAuditRecord(ctx, subscriptionAuditRecord(dssmodels.AuditCreate, nil, sub)); err != nil {
+			return stacktrace.Propagate(err, "Error recording Subscription creation in audit log")
+		}
 
 		return nil
 	})
@@ -137,6 +142,9 @@ func (a *app) UpdateSubscription(ctx context.Context, s *ridmodels.Subscription)
 		if err != nil {
 			return stacktrace.Propagate(err, "Error updating Subscription in repo")
 		}
+		if err := repo.InsertAuditRecord(ctx, subscriptionAuditRecord(dssmodels.AuditUpdate, old, sub)); err != nil {
+			return stacktrace.Propagate(err, "Error recording Subscription update in audit log")
+		}
 		return nil
 	})
 	return sub, err
@@ -167,6 +175,9 @@ func (a *app) DeleteSubscription(ctx context.Context, id dssmodels.ID, owner dss
 		if err != nil {
 			return stacktrace.Propagate(err, "Error deleting Subscription from repo")
 		}
+		if err := repo.InsertAuditRecord(ctx, subscriptionAuditRecord(dssmodels.AuditDelete, old, nil)); err != nil {
+			return stacktrace.Propagate(err, "Error recording Subscription deletion in audit log")
+		}
 		return nil
 	})
 	return ret, err
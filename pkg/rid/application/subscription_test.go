@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"sort"
 	"testing"
 	"time"
 
@@ -158,10 +159,40 @@ func (store *subscriptionStore) SearchSubscriptions(ctx context.Context, cells s
 	return subs, nil
 }
 
+func (store *subscriptionStore) ListSubscriptionsByOwner(ctx context.Context, owner dssmodels.Owner) ([]*ridmodels.Subscription, error) {
+	var subs []*ridmodels.Subscription
+	for _, s := range store.subs {
+		if s.Owner == owner {
+			subs = append(subs, s)
+		}
+	}
+	return subs, nil
+}
+
 func (store *subscriptionStore) ListExpiredSubscriptions(ctx context.Context, writer string) ([]*ridmodels.Subscription, error) {
 	return make([]*ridmodels.Subscription, 0), nil
 }
 
+func (store *subscriptionStore) ListSubscriptions(ctx context.Context, after dssmodels.ID, limit int) ([]*ridmodels.Subscription, error) {
+	var subs []*ridmodels.Subscription
+	for id, s := range store.subs {
+		if id > after {
+			subs = append(subs, s)
+		}
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].ID < subs[j].ID })
+	if len(subs) > limit {
+		subs = subs[:limit]
+	}
+	return subs, nil
+}
+
+func (store *subscriptionStore) RestoreSubscription(ctx context.Context, s *ridmodels.Subscription) error {
+	storedCopy := *s
+	store.subs[s.ID] = &storedCopy
+	return nil
+}
+
 func TestBadOwner(t *testing.T) {
 	ctx := context.Background()
 	app, cleanup := setUpSubApp(ctx, t)
@@ -0,0 +1,18 @@
+package repos
+
+import (
+	"context"
+
+	dssmodels "github.com/interuss/dss/pkg/models"
+)
+
+// AuditLog is an interface to the append-only storage of mutations of remote
+// ID entities.
+type AuditLog interface {
+	// InsertAuditRecord appends "record" to the audit log.
+	InsertAuditRecord(ctx context.Context, record *dssmodels.AuditRecord) error
+
+	// SearchAuditRecords returns the most recent audit records matching
+	// "filter".
+	SearchAuditRecords(ctx context.Context, filter *dssmodels.AuditFilter) ([]*dssmodels.AuditRecord, error)
+}
@@ -0,0 +1,24 @@
+package repos
+
+import (
+	"context"
+	"time"
+
+	dssmodels "github.com/interuss/dss/pkg/models"
+)
+
+// SubscriptionEvents is an interface to the storage of the changes which
+// incremented the notification indices of remote ID Subscriptions.
+type SubscriptionEvents interface {
+	// InsertSubscriptionEvents stores "events".
+	InsertSubscriptionEvents(ctx context.Context, events []*dssmodels.SubscriptionEvent) error
+
+	// ListSubscriptionEvents returns at most "limit" events of the
+	// Subscription identified by "id" with a notification index greater than
+	// "afterIndex", in order of notification index.
+	ListSubscriptionEvents(ctx context.Context, id dssmodels.ID, afterIndex int, limit int) ([]*dssmodels.SubscriptionEvent, error)
+
+	// DeleteSubscriptionEventsBefore deletes the events recorded before
+	// "cutoff" and returns how many were deleted.
+	DeleteSubscriptionEventsBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
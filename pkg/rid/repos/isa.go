@@ -29,6 +29,17 @@ type ISA interface {
 	// SearchISAs returns all subscriptions ownded by "owner" in "cells".
 	SearchISAs(ctx context.Context, cells s2.CellUnion, earliest *time.Time, latest *time.Time) ([]*ridmodels.IdentificationServiceArea, error)
 
+	// ListISAsByOwner returns all ISAs owned by "owner".
+	ListISAsByOwner(ctx context.Context, owner dssmodels.Owner) ([]*ridmodels.IdentificationServiceArea, error)
+
 	// ListExpiredISAs lists all expired ISAs based on writer
 	ListExpiredISAs(ctx context.Context, writer string) ([]*ridmodels.IdentificationServiceArea, error)
+
+	// ListISAs returns at most "limit" ISAs with an ID greater than "after",
+	// or the first ISAs if "after" is empty, in order of ID.
+	ListISAs(ctx context.Context, after dssmodels.ID, limit int) ([]*ridmodels.IdentificationServiceArea, error)
+
+	// RestoreISA inserts "isa" as is, keeping its version, e.g. to restore a
+	// backup.
+	RestoreISA(ctx context.Context, isa *ridmodels.IdentificationServiceArea) error
 }
@@ -38,6 +38,18 @@ type Subscription interface {
 	// belonging to the given owner, and returns that number.
 	MaxSubscriptionCountInCellsByOwner(ctx context.Context, cells s2.CellUnion, owner dssmodels.Owner) (int, error)
 
+	// ListSubscriptionsByOwner returns all subscriptions owned by "owner".
+	ListSubscriptionsByOwner(ctx context.Context, owner dssmodels.Owner) ([]*ridmodels.Subscription, error)
+
 	// ListExpiredSubscriptions lists all expired Subscriptions based on writer.
 	ListExpiredSubscriptions(ctx context.Context, writer string) ([]*ridmodels.Subscription, error)
+
+	// ListSubscriptions returns at most "limit" subscriptions with an ID
+	// greater than "after", or the first subscriptions if "after" is empty, in
+	// order of ID.
+	ListSubscriptions(ctx context.Context, after dssmodels.ID, limit int) ([]*ridmodels.Subscription, error)
+
+	// RestoreSubscription inserts "sub" as is, keeping its version and
+	// notification index, e.g. to restore a backup.
+	RestoreSubscription(ctx context.Context, sub *ridmodels.Subscription) error
 }
@@ -94,6 +94,11 @@ func (ma *mockApp) DeleteISA(ctx context.Context, id dssmodels.ID, owner dssmode
 	return args.Get(0).(*ridmodels.IdentificationServiceArea), args.Get(1).([]*ridmodels.Subscription), args.Error(2)
 }
 
+func (ma *mockApp) ForceDeleteISA(ctx context.Context, id dssmodels.ID) (*ridmodels.IdentificationServiceArea, []*ridmodels.Subscription, error) {
+	args := ma.Called(ctx, id)
+	return args.Get(0).(*ridmodels.IdentificationServiceArea), args.Get(1).([]*ridmodels.Subscription), args.Error(2)
+}
+
 func (ma *mockApp) InsertISA(ctx context.Context, isa *ridmodels.IdentificationServiceArea) (*ridmodels.IdentificationServiceArea, []*ridmodels.Subscription, error) {
 	args := ma.Called(ctx, isa)
 	return args.Get(0).(*ridmodels.IdentificationServiceArea), args.Get(1).([]*ridmodels.Subscription), args.Error(2)
@@ -3,6 +3,7 @@ package cockroach
 import (
 	"context"
 
+	dssmodels "github.com/interuss/dss/pkg/models"
 	"github.com/interuss/dss/pkg/rid/repos"
 	"github.com/interuss/stacktrace"
 )
@@ -44,6 +45,20 @@ func (gc *GarbageCollector) DeleteExpiredISAs(ctx context.Context) error {
 	for _, isa := range expiredISAs {
 		isaOut, err := gc.repos.DeleteISA(ctx, isa)
 		if isaOut != nil {
+			if err := gc.repos.InsertAuditRecord(ctx, &dssmodels.AuditRecord{
+				EntityType: dssmodels.AuditEntityISA,
+				EntityID:   isaOut.ID.String(),
+				Operation:  dssmodels.AuditDelete,
+				Owner:      isaOut.Owner,
+				OldVersion: isaOut.Version.String(),
+				StartTime:  isaOut.StartTime,
+				EndTime:    isaOut.EndTime,
+				AltitudeLo: isaOut.AltitudeLo,
+				AltitudeHi: isaOut.AltitudeHi,
+			}); err != nil {
+				return stacktrace.Propagate(err,
+					"Failed to record ISA deletion in audit log")
+			}
 			return stacktrace.Propagate(err,
 				"Deleted ISA")
 		}
@@ -66,6 +81,20 @@ func (gc *GarbageCollector) DeleteExpiredSubscriptions(ctx context.Context) erro
 	for _, sub := range expiredSubscriptions {
 		subOut, err := gc.repos.DeleteSubscription(ctx, sub)
 		if subOut != nil {
+			if err := gc.repos.InsertAuditRecord(ctx, &dssmodels.AuditRecord{
+				EntityType: dssmodels.AuditEntityRIDSubscription,
+				EntityID:   subOut.ID.String(),
+				Operation:  dssmodels.AuditDelete,
+				Owner:      subOut.Owner,
+				OldVersion: subOut.Version.String(),
+				StartTime:  subOut.StartTime,
+				EndTime:    subOut.EndTime,
+				AltitudeLo: subOut.AltitudeLo,
+				AltitudeHi: subOut.AltitudeHi,
+			}); err != nil {
+				return stacktrace.Propagate(err,
+					"Failed to record Subscription deletion in audit log")
+			}
 			return stacktrace.Propagate(err,
 				"Deleted Subscription")
 		}
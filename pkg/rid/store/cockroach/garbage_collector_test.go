@@ -53,6 +53,12 @@ func TestDeleteExpiredISAs(t *testing.T) {
 	ret, err = repo.GetISA(ctx, serviceArea.ID)
 	require.NoError(t, err)
 	require.Nil(t, ret)
+
+	records, err := repo.SearchAuditRecords(ctx, &dssmodels.AuditFilter{EntityID: serviceArea.ID.String()})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, dssmodels.AuditDelete, records[0].Operation)
+	require.Equal(t, serviceArea.Owner, records[0].Owner)
 }
 
 func TestDeleteExpiredSubscriptions(t *testing.T) {
@@ -97,4 +103,10 @@ func TestDeleteExpiredSubscriptions(t *testing.T) {
 	ret, err = repo.GetSubscription(ctx, subscription.ID)
 	require.NoError(t, err)
 	require.Nil(t, ret)
+
+	records, err := repo.SearchAuditRecords(ctx, &dssmodels.AuditFilter{EntityID: subscription.ID.String()})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, dssmodels.AuditDelete, records[0].Operation)
+	require.Equal(t, subscription.Owner, records[0].Owner)
 }
@@ -25,11 +25,12 @@ const (
 	updateISAFields = "id, url, cells, starts_at, ends_at, writer, updated_at"
 )
 
-func NewISARepo(ctx context.Context, db dssql.Queryable, dbVersion semver.Version, logger *zap.Logger) repos.ISA {
+func NewISARepo(ctx context.Context, db dssql.Queryable, dbVersion semver.Version, logger *zap.Logger, multiRegion bool) repos.ISA {
 	if dbVersion.Compare(v400) >= 0 {
 		return &isaRepo{
-			Queryable: db,
-			logger:    logger,
+			Queryable:   db,
+			logger:      logger,
+			multiRegion: multiRegion,
 		}
 	}
 	return &isaRepoV3{
@@ -43,6 +44,9 @@ type isaRepo struct {
 	dssql.Queryable
 
 	logger *zap.Logger
+	// multiRegion is set if the database is multi-region, in which case
+	// expired ISAs are listed by the region they are homed in.
+	multiRegion bool
 }
 
 func (c *isaRepo) process(ctx context.Context, query string, args ...interface{}) ([]*ridmodels.IdentificationServiceArea, error) {
@@ -261,13 +265,34 @@ func (c *isaRepo) SearchISAs(ctx context.Context, cells s2.CellUnion, earliest *
 	return c.process(ctx, isasInCellsQuery, earliest, latest, pgCids, dssmodels.MaxResultLimit)
 }
 
+// ListISAsByOwner returns all IdentificationServiceAreas owned by "owner".
+func (c *isaRepo) ListISAsByOwner(ctx context.Context, owner dssmodels.Owner) ([]*ridmodels.IdentificationServiceArea, error) {
+	var (
+		query = fmt.Sprintf(`
+			SELECT
+				%s
+			FROM
+				identification_service_areas
+			WHERE
+				owner = $1
+			LIMIT $2`, isaFields)
+	)
+
+	return c.process(ctx, query, owner, dssmodels.MaxResultLimit)
+}
+
 // ListExpiredISAs lists all expired ISAs based on writer.
 // Records expire if current time is <expiredDurationInMin> minutes more than records' endTime.
 // The function queries both empty writer and null writer when passing empty string as a writer.
+// In multi-region databases, writer is the region of the DSS instance and
+// the ISAs homed in that region are listed, whatever their writer.
 func (c *isaRepo) ListExpiredISAs(ctx context.Context, writer string) ([]*ridmodels.IdentificationServiceArea, error) {
-	writerQuery := "'" + writer + "'"
+	writerQuery := "writer = $2"
 	if len(writer) == 0 {
-		writerQuery = "'' OR writer = NULL"
+		writerQuery = "writer = $2 OR writer = NULL"
+	}
+	if c.multiRegion {
+		writerQuery = "crdb_region::STRING = $2"
 	}
 
 	var (
@@ -279,9 +304,65 @@ func (c *isaRepo) ListExpiredISAs(ctx context.Context, writer string) ([]*ridmod
 	WHERE
 		ends_at + INTERVAL '%d' MINUTE <= CURRENT_TIMESTAMP
 	AND
-		(writer = %s)
+		(%s)
 	LIMIT $1`, isaFields, expiredDurationInMin, writerQuery)
 	)
 
-	return c.process(ctx, isasInCellsQuery, dssmodels.MaxResultLimit)
+	return c.process(ctx, isasInCellsQuery, dssmodels.MaxResultLimit, writer)
+}
+
+// ListISAs returns at most "limit" IdentificationServiceAreas with an ID
+// greater than "after", or the first ones if "after" is empty, in order of ID.
+func (c *isaRepo) ListISAs(ctx context.Context, after dssmodels.ID, limit int) ([]*ridmodels.IdentificationServiceArea, error) {
+	var (
+		query = fmt.Sprintf(`
+			SELECT
+				%s
+			FROM
+				identification_service_areas
+			WHERE
+				($1::UUID IS NULL OR id > $1)
+			ORDER BY
+				id
+			LIMIT $2`, isaFields)
+	)
+
+	afterID, err := after.NullablePgUUID()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	return c.process(ctx, query, afterID, limit)
+}
+
+// RestoreISA inserts the IdentificationServiceArea "isa" as is, with the
+// update time of its version.
+func (c *isaRepo) RestoreISA(ctx context.Context, isa *ridmodels.IdentificationServiceArea) error {
+	var (
+		restoreQuery = fmt.Sprintf(`
+			INSERT INTO
+				identification_service_areas
+				(%s)
+			VALUES
+				($1, $2, $3, $4, $5, $6, $7, $8)`, isaFields)
+	)
+
+	cids := make([]int64, len(isa.Cells))
+	for i, cell := range isa.Cells {
+		if err := geo.ValidateCell(cell); err != nil {
+			return stacktrace.Propagate(err, "Error validating cell")
+		}
+		cids[i] = int64(cell)
+	}
+	var pgCids pgtype.Int8Array
+	if err := pgCids.Set(cids); err != nil {
+		return stacktrace.Propagate(err, "Failed to convert array to jackc/pgtype")
+	}
+	id, err := isa.ID.PgUUID()
+	if err != nil {
+		return stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	if _, err := c.Exec(ctx, restoreQuery, id, isa.Owner, isa.URL, pgCids, isa.StartTime, isa.EndTime, isa.Writer, isa.Version.ToTimestamp()); err != nil {
+		return stacktrace.Propagate(err, "Error in query: %s", restoreQuery)
+	}
+	return nil
 }
@@ -230,6 +230,30 @@ func TestStoreDeleteISAs(t *testing.T) {
 	require.Equal(t, isa, serviceAreaOut)
 }
 
+func TestListISAsByOwner(t *testing.T) {
+	var (
+		ctx                  = context.Background()
+		store, tearDownStore = setUpStore(ctx, t)
+	)
+	defer tearDownStore()
+
+	repo, err := store.Interact(ctx)
+	require.NoError(t, err)
+
+	copy := *serviceArea
+	_, err = repo.InsertISA(ctx, &copy)
+	require.NoError(t, err)
+
+	isas, err := repo.ListISAsByOwner(ctx, serviceArea.Owner)
+	require.NoError(t, err)
+	require.Len(t, isas, 1)
+	require.Equal(t, serviceArea.ID, isas[0].ID)
+
+	isas, err = repo.ListISAsByOwner(ctx, dssmodels.Owner(uuid.New().String()))
+	require.NoError(t, err)
+	require.Empty(t, isas)
+}
+
 func TestStoreISAWithNoGeoData(t *testing.T) {
 	ctx := context.Background()
 	store, tearDownStore := setUpStore(ctx, t)
@@ -246,7 +246,79 @@ func (c *isaRepoV3) SearchISAs(ctx context.Context, cells s2.CellUnion, earliest
 	return c.process(ctx, isasInCellsQuery, earliest, latest, pgCids, dssmodels.MaxResultLimit)
 }
 
+// ListISAsByOwner returns all IdentificationServiceAreas owned by "owner".
+func (c *isaRepoV3) ListISAsByOwner(ctx context.Context, owner dssmodels.Owner) ([]*ridmodels.IdentificationServiceArea, error) {
+	var (
+		query = fmt.Sprintf(`
+			SELECT
+				%s
+			FROM
+				identification_service_areas
+			WHERE
+				owner = $1
+			LIMIT $2`, isaFieldsV3)
+	)
+
+	return c.process(ctx, query, owner, dssmodels.MaxResultLimit)
+}
+
 // ListExpiredISAs returns empty. We don't support thi function in store v3.0 because db doesn't have 'writer' field.
 func (c *isaRepoV3) ListExpiredISAs(ctx context.Context, writer string) ([]*ridmodels.IdentificationServiceArea, error) {
 	return make([]*ridmodels.IdentificationServiceArea, 0), nil
 }
+
+// ListISAs returns at most "limit" IdentificationServiceAreas with an ID
+// greater than "after", or the first ones if "after" is empty, in order of ID.
+func (c *isaRepoV3) ListISAs(ctx context.Context, after dssmodels.ID, limit int) ([]*ridmodels.IdentificationServiceArea, error) {
+	var (
+		query = fmt.Sprintf(`
+			SELECT
+				%s
+			FROM
+				identification_service_areas
+			WHERE
+				($1::UUID IS NULL OR id > $1)
+			ORDER BY
+				id
+			LIMIT $2`, isaFieldsV3)
+	)
+
+	afterID, err := after.NullablePgUUID()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	return c.process(ctx, query, afterID, limit)
+}
+
+// RestoreISA inserts the IdentificationServiceArea "isa" as is, with the
+// update time of its version.
+func (c *isaRepoV3) RestoreISA(ctx context.Context, isa *ridmodels.IdentificationServiceArea) error {
+	var (
+		restoreQuery = fmt.Sprintf(`
+			INSERT INTO
+				identification_service_areas
+				(%s)
+			VALUES
+				($1, $2, $3, $4, $5, $6, $7)`, isaFieldsV3)
+	)
+
+	cids := make([]int64, len(isa.Cells))
+	for i, cell := range isa.Cells {
+		if err := geo.ValidateCell(cell); err != nil {
+			return stacktrace.Propagate(err, "Error validating cell")
+		}
+		cids[i] = int64(cell)
+	}
+	var pgCids pgtype.Int8Array
+	if err := pgCids.Set(cids); err != nil {
+		return stacktrace.Propagate(err, "Failed to convert array to jackc/pgtype")
+	}
+	uid, err := isa.ID.PgUUID()
+	if err != nil {
+		return stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	if _, err := c.Exec(ctx, restoreQuery, uid, isa.Owner, isa.URL, pgCids, isa.StartTime, isa.EndTime, isa.Version.ToTimestamp()); err != nil {
+		return stacktrace.Propagate(err, "Error in query: %s", restoreQuery)
+	}
+	return nil
+}
@@ -37,11 +37,28 @@ var (
 	DefaultTimeout = 10 * time.Second
 
 	v400 = *semver.New("4.0.0")
+	v410 = *semver.New("4.1.0")
+	v420 = *semver.New("4.2.0")
+	v430 = *semver.New("4.3.0")
+	v440 = *semver.New("4.4.0")
+)
+
+const (
+	// auditLogTable is the name of the table storing the remote ID audit log.
+	auditLogTable = "audit_log"
+	// subscriptionEventsTable is the name of the table storing the events of
+	// remote ID subscriptions.
+	subscriptionEventsTable = "subscription_events"
+	// leasesTable is the name of the table storing the leases of the remote
+	// ID scheduled jobs.
+	leasesTable = "leases"
 )
 
 type repo struct {
 	repos.ISA
 	repos.Subscription
+	repos.AuditLog
+	repos.SubscriptionEvents
 }
 
 // Store is an implementation of store.Store using Cockroach DB as its backend
@@ -54,6 +71,8 @@ type Store struct {
 	logger  *zap.Logger
 	clock   clockwork.Clock
 	version *semver.Version
+	// multiRegion is set if the database is multi-region.
+	multiRegion bool
 
 	// DatabaseName is the name of database storing remote ID data.
 	DatabaseName string
@@ -78,6 +97,15 @@ func NewStore(ctx context.Context, db *cockroach.DB, dbName string, logger *zap.
 		return nil, stacktrace.Propagate(err, "Remote ID schema version check failed")
 	}
 
+	// Rows are only homed in regions once the table localities are set.
+	if vs.Compare(v430) >= 0 {
+		regions, err := db.DatabaseRegions(ctx, dbName)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Failed to get the regions of remote ID db : %s", dbName)
+		}
+		store.multiRegion = len(regions) > 0
+	}
+
 	return store, nil
 }
 
@@ -107,8 +135,10 @@ func (s *Store) Interact(ctx context.Context) (repos.Repository, error) {
 	}
 
 	return &repo{
-		ISA:          NewISARepo(ctx, s.db.Pool, *storeVersion, logger),
-		Subscription: NewISASubscriptionRepo(ctx, s.db.Pool, *storeVersion, logger, s.clock),
+		ISA:                NewISARepo(ctx, s.db.Pool, *storeVersion, logger, s.multiRegion),
+		Subscription:       NewISASubscriptionRepo(ctx, s.db.Pool, *storeVersion, logger, s.clock, s.multiRegion),
+		AuditLog:           cockroach.NewAuditLog(s.db.Pool, auditLogTable, storeVersion.Compare(v410) >= 0),
+		SubscriptionEvents: cockroach.NewSubscriptionEventLog(s.db.Pool, subscriptionEventsTable, storeVersion.Compare(v420) >= 0, s.multiRegion),
 	}, nil
 }
 
@@ -133,12 +163,42 @@ func (s *Store) Transact(ctx context.Context, f func(repo repos.Repository) erro
 		// Is this recover still necessary?
 		defer recoverRollbackRepanic(ctx, tx)
 		return f(&repo{
-			ISA:          NewISARepo(ctx, tx, *storeVersion, logger),
-			Subscription: NewISASubscriptionRepo(ctx, tx, *storeVersion, logger, s.clock),
+			ISA:                NewISARepo(ctx, tx, *storeVersion, logger, s.multiRegion),
+			Subscription:       NewISASubscriptionRepo(ctx, tx, *storeVersion, logger, s.clock, s.multiRegion),
+			AuditLog:           cockroach.NewAuditLog(tx, auditLogTable, storeVersion.Compare(v410) >= 0),
+			SubscriptionEvents: cockroach.NewSubscriptionEventLog(tx, subscriptionEventsTable, storeVersion.Compare(v420) >= 0, s.multiRegion),
+		})
+	})
+}
+
+// TransactReadOnly supplies a new repo, that will perform all of the DB
+// accesses in a read-only Txn, reading as stale data as configured in ctx.
+func (s *Store) TransactReadOnly(ctx context.Context, f func(repo repos.Repository) error) error {
+	logger := logging.WithValuesFromContext(ctx, s.logger)
+	ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+
+	ctx = crdb.WithMaxRetries(ctx, flags.ConnectParameters().MaxRetries)
+
+	storeVersion, err := s.GetVersion(ctx)
+	if err != nil {
+		return stacktrace.Propagate(err, "Error determining database RID schema version")
+	}
+	return cockroach.ExecuteReadOnlyTx(ctx, s.db.Pool, func(tx pgx.Tx) error {
+		return f(&repo{
+			ISA:                NewISARepo(ctx, tx, *storeVersion, logger, s.multiRegion),
+			Subscription:       NewISASubscriptionRepo(ctx, tx, *storeVersion, logger, s.clock, s.multiRegion),
+			AuditLog:           cockroach.NewAuditLog(tx, auditLogTable, storeVersion.Compare(v410) >= 0),
+			SubscriptionEvents: cockroach.NewSubscriptionEventLog(tx, subscriptionEventsTable, storeVersion.Compare(v420) >= 0, s.multiRegion),
 		})
 	})
 }
 
+// Leases returns the table of the leases of the remote ID scheduled jobs.
+func (s *Store) Leases() *cockroach.LeaseTable {
+	return cockroach.NewLeaseTable(s.db.Pool, leasesTable, s.version != nil && s.version.Compare(v440) >= 0)
+}
+
 // Close closes the underlying DB connection.
 func (s *Store) Close() error {
 	s.db.Pool.Close()
@@ -342,7 +342,88 @@ func (c *subscriptionRepoV3) SearchSubscriptionsByOwner(ctx context.Context, cel
 	return c.process(ctx, query, pgCids, owner, c.clock.Now(), dssmodels.MaxResultLimit)
 }
 
+// ListSubscriptionsByOwner returns all subscriptions owned by "owner".
+func (c *subscriptionRepoV3) ListSubscriptionsByOwner(ctx context.Context, owner dssmodels.Owner) ([]*ridmodels.Subscription, error) {
+	var (
+		query = fmt.Sprintf(`
+			SELECT
+				%s
+			FROM
+				subscriptions
+			WHERE
+				owner = $1
+			LIMIT $2`, subscriptionFieldsV3)
+	)
+
+	return c.process(ctx, query, owner, dssmodels.MaxResultLimit)
+}
+
 // ListExpiredSubscriptions returns empty. We don't support this function in store v3.0 because db doesn't have 'writer' field.
 func (c *subscriptionRepoV3) ListExpiredSubscriptions(ctx context.Context, writer string) ([]*ridmodels.Subscription, error) {
 	return make([]*ridmodels.Subscription, 0), nil
 }
+
+// ListSubscriptions returns at most "limit" subscriptions with an ID greater
+// than "after", or the first ones if "after" is empty, in order of ID.
+func (c *subscriptionRepoV3) ListSubscriptions(ctx context.Context, after dssmodels.ID, limit int) ([]*ridmodels.Subscription, error) {
+	var (
+		query = fmt.Sprintf(`
+			SELECT
+				%s
+			FROM
+				subscriptions
+			WHERE
+				($1::UUID IS NULL OR id > $1)
+			ORDER BY
+				id
+			LIMIT $2`, subscriptionFieldsV3)
+	)
+
+	afterID, err := after.NullablePgUUID()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	return c.process(ctx, query, afterID, limit)
+}
+
+// RestoreSubscription inserts subscription "s" as is, with its notification
+// index and the update time of its version.
+func (c *subscriptionRepoV3) RestoreSubscription(ctx context.Context, s *ridmodels.Subscription) error {
+	var (
+		restoreQuery = fmt.Sprintf(`
+		INSERT INTO
+		  subscriptions
+		  (%s)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8)`, subscriptionFieldsV3)
+	)
+
+	cids := make([]int64, len(s.Cells))
+	for i, cell := range s.Cells {
+		if err := geo.ValidateCell(cell); err != nil {
+			return stacktrace.Propagate(err, "Error validating cell")
+		}
+		cids[i] = int64(cell)
+	}
+	var pgCids pgtype.Int8Array
+	if err := pgCids.Set(cids); err != nil {
+		return stacktrace.Propagate(err, "Failed to convert array to jackc/pgtype")
+	}
+	uid, err := s.ID.PgUUID()
+	if err != nil {
+		return stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	_, err = c.Exec(ctx, restoreQuery,
+		uid,
+		s.Owner,
+		s.URL,
+		s.NotificationIndex,
+		pgCids,
+		s.StartTime,
+		s.EndTime,
+		s.Version.ToTimestamp())
+	if err != nil {
+		return stacktrace.Propagate(err, "Error in query: %s", restoreQuery)
+	}
+	return nil
+}
@@ -25,12 +25,13 @@ const (
 	updateSubscriptionFields = "id, url, notification_index, cells, starts_at, ends_at, writer, updated_at"
 )
 
-func NewISASubscriptionRepo(ctx context.Context, db dssql.Queryable, dbVersion semver.Version, logger *zap.Logger, clock clockwork.Clock) repos.Subscription {
+func NewISASubscriptionRepo(ctx context.Context, db dssql.Queryable, dbVersion semver.Version, logger *zap.Logger, clock clockwork.Clock, multiRegion bool) repos.Subscription {
 	if dbVersion.Compare(v400) >= 0 {
 		return &subscriptionRepo{
-			Queryable: db,
-			logger:    logger,
-			clock:     clock,
+			Queryable:   db,
+			logger:      logger,
+			clock:       clock,
+			multiRegion: multiRegion,
 		}
 	}
 	return &subscriptionRepoV3{
@@ -46,6 +47,9 @@ type subscriptionRepo struct {
 
 	clock  clockwork.Clock
 	logger *zap.Logger
+	// multiRegion is set if the database is multi-region, in which case
+	// expired Subscriptions are listed by the region they are homed in.
+	multiRegion bool
 }
 
 // process a query that should return one or many subscriptions.
@@ -364,13 +368,34 @@ func (c *subscriptionRepo) SearchSubscriptionsByOwner(ctx context.Context, cells
 	return c.process(ctx, query, pgCids, owner, c.clock.Now(), dssmodels.MaxResultLimit)
 }
 
+// ListSubscriptionsByOwner returns all subscriptions owned by "owner".
+func (c *subscriptionRepo) ListSubscriptionsByOwner(ctx context.Context, owner dssmodels.Owner) ([]*ridmodels.Subscription, error) {
+	var (
+		query = fmt.Sprintf(`
+			SELECT
+				%s
+			FROM
+				subscriptions
+			WHERE
+				owner = $1
+			LIMIT $2`, subscriptionFields)
+	)
+
+	return c.process(ctx, query, owner, dssmodels.MaxResultLimit)
+}
+
 // ListExpiredSubscriptions lists all expired Subscriptions based on writer.
 // Records expire if current time is <expiredDurationInMin> minutes more than records' endTime.
 // The function queries both empty writer and null writer when passing empty string as a writer.
+// In multi-region databases, writer is the region of the DSS instance and
+// the Subscriptions homed in that region are listed, whatever their writer.
 func (c *subscriptionRepo) ListExpiredSubscriptions(ctx context.Context, writer string) ([]*ridmodels.Subscription, error) {
-	writerQuery := "'" + writer + "'"
+	writerQuery := "writer = $1"
 	if len(writer) == 0 {
-		writerQuery = "'' OR writer = NULL"
+		writerQuery = "writer = $1 OR writer = NULL"
+	}
+	if c.multiRegion {
+		writerQuery = "crdb_region::STRING = $1"
 	}
 
 	var (
@@ -382,8 +407,74 @@ func (c *subscriptionRepo) ListExpiredSubscriptions(ctx context.Context, writer
 	WHERE
 		ends_at + INTERVAL '%d' MINUTE <= CURRENT_TIMESTAMP
 	AND
-		(writer = %s)`, subscriptionFields, expiredDurationInMin, writerQuery)
+		(%s)`, subscriptionFields, expiredDurationInMin, writerQuery)
+	)
+
+	return c.process(ctx, query, writer)
+}
+
+// ListSubscriptions returns at most "limit" subscriptions with an ID greater
+// than "after", or the first ones if "after" is empty, in order of ID.
+func (c *subscriptionRepo) ListSubscriptions(ctx context.Context, after dssmodels.ID, limit int) ([]*ridmodels.Subscription, error) {
+	var (
+		query = fmt.Sprintf(`
+			SELECT
+				%s
+			FROM
+				subscriptions
+			WHERE
+				($1::UUID IS NULL OR id > $1)
+			ORDER BY
+				id
+			LIMIT $2`, subscriptionFields)
+	)
+
+	afterID, err := after.NullablePgUUID()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	return c.process(ctx, query, afterID, limit)
+}
+
+// RestoreSubscription inserts subscription "s" as is, with its notification
+// index and the update time of its version.
+func (c *subscriptionRepo) RestoreSubscription(ctx context.Context, s *ridmodels.Subscription) error {
+	var (
+		restoreQuery = fmt.Sprintf(`
+		INSERT INTO
+		  subscriptions
+		  (%s)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9)`, subscriptionFields)
 	)
 
-	return c.process(ctx, query)
+	cids := make([]int64, len(s.Cells))
+	for i, cell := range s.Cells {
+		if err := geo.ValidateCell(cell); err != nil {
+			return stacktrace.Propagate(err, "Error validating cell")
+		}
+		cids[i] = int64(cell)
+	}
+	var pgCids pgtype.Int8Array
+	if err := pgCids.Set(cids); err != nil {
+		return stacktrace.Propagate(err, "Failed to convert array to jackc/pgtype")
+	}
+	id, err := s.ID.PgUUID()
+	if err != nil {
+		return stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	_, err = c.Exec(ctx, restoreQuery,
+		id,
+		s.Owner,
+		s.URL,
+		s.NotificationIndex,
+		pgCids,
+		s.StartTime,
+		s.EndTime,
+		s.Writer,
+		s.Version.ToTimestamp())
+	if err != nil {
+		return stacktrace.Propagate(err, "Error in query: %s", restoreQuery)
+	}
+	return nil
 }
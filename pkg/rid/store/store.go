@@ -14,6 +14,7 @@ type Store interface {
 	io.Closer
 	Interactor
 	Transactor
+	ReadOnlyTransactor
 
 	// Get store version
 	GetVersion(ctx context.Context) (*semver.Version, error)
@@ -33,3 +34,12 @@ type Transactor interface {
 	// isolation/atomicity.
 	Transact(ctx context.Context, f func(repos.Repository) error) error
 }
+
+// ReadOnlyTransactor provides means to get hold of a repos.Repository instance
+// in the context of a read-only transaction, which may read stale data if so
+// configured in the context.
+type ReadOnlyTransactor interface {
+	// TransactReadOnly executes f and provides a repos.Repository instance that
+	// guarantees isolation but may only be read from.
+	TransactReadOnly(ctx context.Context, f func(repos.Repository) error) error
+}
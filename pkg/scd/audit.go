@@ -0,0 +1,96 @@
+package scd
+
+import (
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+)
+
+// operationalIntentAuditRecord returns the AuditRecord for the mutation "op" of
+// an OperationalIntent from "old" to "intent", either of which may be nil.
+func operationalIntentAuditRecord(op dssmodels.AuditOperation, old, intent *scdmodels.OperationalIntent) *dssmodels.AuditRecord {
+	r := &dssmodels.AuditRecord{
+		EntityType: dssmodels.AuditEntityOperationalIntent,
+		Operation:  op,
+	}
+	if old != nil {
+		r.OldVersion = old.OVN.String()
+	}
+	if intent != nil {
+		r.NewVersion = intent.OVN.String()
+	} else {
+		intent = old
+	}
+	if intent != nil {
+		r.EntityID = intent.ID.String()
+		r.Owner = dssmodels.Owner(intent.Manager)
+		r.StartTime = intent.StartTime
+		r.EndTime = intent.EndTime
+		r.AltitudeLo = intent.AltitudeLower
+		r.AltitudeHi = intent.AltitudeUpper
+	}
+	return r
+}
+
+// constraintAuditRecord returns the AuditRecord for the mutation "op" of a
+// Constraint from "old" to "constraint", either of which may be nil.
+func constraintAuditRecord(op dssmodels.AuditOperation, old, constraint *scdmodels.Constraint) *dssmodels.AuditRecord {
+	r := &dssmodels.AuditRecord{
+		EntityType: dssmodels.AuditEntityConstraint,
+		Operation:  op,
+	}
+	if old != nil {
+		r.OldVersion = old.OVN.String()
+	}
+	if constraint != nil {
+		r.NewVersion = constraint.OVN.String()
+	} else {
+		constraint = old
+	}
+	if constraint != nil {
+		r.EntityID = constraint.ID.String()
+		r.Owner = dssmodels.Owner(constraint.Manager)
+		r.StartTime = constraint.StartTime
+		r.EndTime = constraint.EndTime
+		r.AltitudeLo = constraint.AltitudeLower
+		r.AltitudeHi = constraint.AltitudeUpper
+	}
+	return r
+}
+
+// subscriptionAuditRecord returns the AuditRecord for the mutation "op" of a
+// Subscription from "old" to "sub", either of which may be nil.
+func subscriptionAuditRecord(op dssmodels.AuditOperation, old, sub *scdmodels.Subscription) *dssmodels.AuditRecord {
+	r := &dssmodels.AuditRecord{
+		EntityType: dssmodels.AuditEntitySCDSubscription,
+		Operation:  op,
+	}
+	if old != nil {
+		r.OldVersion = old.Version.String()
+	}
+	if sub != nil {
+		r.NewVersion = sub.Version.String()
+	} else {
+		sub = old
+	}
+	if sub != nil {
+		r.EntityID = sub.ID.String()
+		r.Owner = dssmodels.Owner(sub.Manager)
+		r.StartTime = sub.StartTime
+		r.EndTime = sub.EndTime
+		r.AltitudeLo = sub.AltitudeLo
+		r.AltitudeHi = sub.AltitudeHi
+	}
+	return r
+}
+
+// ussAvailabilityAuditRecord returns the AuditRecord for a change of the
+// availability of a USS to "ussa" requested by "manager".
+func ussAvailabilityAuditRecord(manager dssmodels.Manager, ussa *scdmodels.UssAvailabilityStatus) *dssmodels.AuditRecord {
+	return &dssmodels.AuditRecord{
+		EntityType: dssmodels.AuditEntityUssAvailability,
+		EntityID:   ussa.Uss.String(),
+		Operation:  dssmodels.AuditUpdate,
+		Owner:      dssmodels.Owner(manager),
+		NewVersion: ussa.Version.String(),
+	}
+}
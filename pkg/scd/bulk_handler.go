@@ -0,0 +1,297 @@
+package scd
+
+import (
+	"context"
+
+	"github.com/interuss/dss/pkg/api/v1/bulkpb"
+	"github.com/interuss/dss/pkg/api/v1/scdpb"
+	"github.com/interuss/dss/pkg/auth"
+	dsserr "github.com/interuss/dss/pkg/errors"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scderr "github.com/interuss/dss/pkg/scd/errors"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/dss/pkg/scd/repos"
+	"github.com/interuss/stacktrace"
+	"google.golang.org/grpc/status"
+)
+
+// validateBulkSize returns an error if n changes may not be applied by a
+// single bulk request.
+func (a *Server) validateBulkSize(n int) error {
+	if n == 0 {
+		return stacktrace.NewErrorWithCode(dsserr.BadRequest, "Missing changes")
+	}
+	if a.Limits.MaxBulkChanges > 0 && n > a.Limits.MaxBulkChanges {
+		return stacktrace.NewErrorWithCode(dsserr.BadRequest, "Too many changes: %d (maximum %d)", n, a.Limits.MaxBulkChanges)
+	}
+	return nil
+}
+
+// mergeSubscribersToNotify returns the SubscribersToNotify of the union of
+// subscriptions, in which a Subscription notified of several changes appears
+// once with its final notification index.
+func mergeSubscribersToNotify(subscriptions []*scdmodels.Subscription) []*scdpb.SubscriberToNotify {
+	var (
+		merged []*scdmodels.Subscription
+		byID   = map[dssmodels.ID]*scdmodels.Subscription{}
+	)
+	for _, sub := range subscriptions {
+		if prev, ok := byID[sub.ID]; ok {
+			if sub.NotificationIndex > prev.NotificationIndex {
+				prev.NotificationIndex = sub.NotificationIndex
+			}
+			continue
+		}
+		s := *sub
+		byID[sub.ID] = &s
+		merged = append(merged, &s)
+	}
+	return makeSubscribersToNotify(merged)
+}
+
+// bulkKey returns the OVNs against which the keys of the changes of a bulk
+// request are validated: the OVNs of key, the union of the keys of the
+// changes, and the new OVNs of the OperationalIntents upserted by the request,
+// ops[i] resulting from upserts[i]. The client may not know the OVNs
+// resulting from its changes before sending them, but it is aware of the
+// OperationalIntents it changes, so that a change does not need the OVNs
+// resulting from the other changes of the request in its key.
+func bulkKey(key map[scdmodels.OVN]bool, ops []*scdmodels.OperationalIntent, upserts []*operationalIntentUpsert) map[scdmodels.OVN]bool {
+	known := map[scdmodels.OVN]bool{}
+	for ovn := range key {
+		known[ovn] = true
+	}
+	for i, u := range upserts {
+		if u != nil {
+			known[ops[i].OVN] = true
+		}
+	}
+	return known
+}
+
+// BulkChangeOperationalIntentReferences creates, updates and deletes several
+// OperationalIntents in a single transaction.
+func (a *Server) BulkChangeOperationalIntentReferences(ctx context.Context, req *bulkpb.BulkChangeOperationalIntentReferencesRequest) (*bulkpb.BulkChangeOperationalIntentReferencesResponse, error) {
+	// Retrieve ID of client making call
+	manager, ok := auth.ManagerFromContext(ctx)
+	if !ok {
+		return nil, stacktrace.NewErrorWithCode(dsserr.PermissionDenied, "Missing manager from context")
+	}
+
+	changes := req.GetChanges()
+	if err := a.validateBulkSize(len(changes)); err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+
+	// Validate all changes before touching the repo. A nil upsert denotes a
+	// deletion.
+	var (
+		ids     = make([]dssmodels.ID, len(changes))
+		upserts = make([]*operationalIntentUpsert, len(changes))
+		seen    = map[dssmodels.ID]bool{}
+		key     = map[scdmodels.OVN]bool{}
+	)
+	for i, change := range changes {
+		switch {
+		case change.GetDelete() && change.GetParams() != nil:
+			return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Change %d may not both delete and specify params", i)
+		case change.GetDelete():
+			id, err := dssmodels.IDFromString(change.GetEntityid())
+			if err != nil {
+				return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Invalid ID format in change %d: `%s`", i, change.GetEntityid())
+			}
+			ids[i] = id
+		case change.GetParams() == nil:
+			return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Missing params in change %d", i)
+		default:
+			u, err := a.validateOperationalIntentUpsert(change.GetEntityid(), change.GetOvn(), change.GetParams())
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "Invalid change %d", i)
+			}
+			if err := validateStateScope(ctx, u.state); err != nil {
+				return nil, stacktrace.Propagate(err, "Unauthorized change %d", i)
+			}
+			ids[i] = u.id
+			upserts[i] = u
+			for _, ovn := range change.GetParams().GetKey() {
+				key[scdmodels.OVN(ovn)] = true
+			}
+		}
+		if seen[ids[i]] {
+			return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "OperationalIntent %s changed more than once", ids[i])
+		}
+		seen[ids[i]] = true
+	}
+
+	var response *bulkpb.BulkChangeOperationalIntentReferencesResponse
+	action := func(ctx context.Context, r repos.Repository) (err error) {
+		var (
+			ops     = make([]*scdmodels.OperationalIntent, len(changes))
+			subs    = make([]*scdmodels.Subscription, len(changes))
+			notify  []*scdmodels.Subscription
+			changed repos.Subscriptions
+		)
+		for i := range changes {
+			if upserts[i] == nil {
+				ops[i], changed, err = deleteOperationalIntent(ctx, r, ids[i], func(old *scdmodels.OperationalIntent) error {
+					if old.Manager != manager {
+						return stacktrace.NewErrorWithCode(dsserr.PermissionDenied,
+							"OperationalIntent owned by %s, but %s attempted to delete", old.Manager, manager)
+					}
+					return nil
+				})
+			} else {
+				ops[i], subs[i], changed, err = a.upsertOperationalIntent(ctx, r, manager, upserts[i], false)
+			}
+			if err != nil {
+				return stacktrace.Propagate(err, "Failed to apply change %d", i)
+			}
+			notify = append(notify, changed...)
+		}
+
+		// Validate the keys once all changes are applied, so that each change
+		// is validated against the resulting state of the airspace.
+		var (
+			known              = bulkKey(key, ops, upserts)
+			missingOps         []*scdmodels.OperationalIntent
+			missingConstraints []*scdmodels.Constraint
+			missing            = map[dssmodels.ID]bool{}
+		)
+		for i, u := range upserts {
+			if u == nil || !u.state.RequiresKey() {
+				continue
+			}
+			unknownOps, unknownConstraints, err := missingFromKey(ctx, r, manager, u.extent, a.minKeyPriority(u.params.GetPriority()), subs[i].NotifyForConstraints, known)
+			if err != nil {
+				return err // No need to Propagate this error as this stack layer does not add useful information
+			}
+			for _, op := range unknownOps {
+				if !missing[op.ID] {
+					missing[op.ID] = true
+					missingOps = append(missingOps, op)
+				}
+			}
+			for _, constraint := range unknownConstraints {
+				if !missing[constraint.ID] {
+					missing[constraint.ID] = true
+					missingConstraints = append(missingConstraints, constraint)
+				}
+			}
+		}
+		if len(missingOps) > 0 || len(missingConstraints) > 0 {
+			p, err := scderr.MissingOVNsErrorResponse(missingOps, missingConstraints)
+			if err != nil {
+				return stacktrace.Propagate(err, "Failed to construct missing OVNs error message")
+			}
+			return stacktrace.Propagate(status.ErrorProto(p), "Missing OVNs")
+		}
+
+		response = &bulkpb.BulkChangeOperationalIntentReferencesResponse{
+			Subscribers: mergeSubscribersToNotify(notify),
+		}
+		for _, op := range ops {
+			p, err := op.ToProto()
+			if err != nil {
+				return stacktrace.Propagate(err, "Could not convert OperationalIntent to proto")
+			}
+			response.OperationalIntentReferences = append(response.OperationalIntentReferences, p)
+		}
+
+		return nil
+	}
+
+	err := a.Store.Transact(ctx, action)
+	if err != nil {
+		return nil, err // No need to Propagate this error as this is not a useful stacktrace line
+	}
+
+	return response, nil
+}
+
+// BulkChangeConstraintReferences creates, updates and deletes several
+// Constraints in a single transaction.
+func (a *Server) BulkChangeConstraintReferences(ctx context.Context, req *bulkpb.BulkChangeConstraintReferencesRequest) (*bulkpb.BulkChangeConstraintReferencesResponse, error) {
+	// Retrieve ID of client making call
+	manager, ok := auth.ManagerFromContext(ctx)
+	if !ok {
+		return nil, stacktrace.NewErrorWithCode(dsserr.PermissionDenied, "Missing manager from context")
+	}
+
+	changes := req.GetChanges()
+	if err := a.validateBulkSize(len(changes)); err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+
+	// Validate all changes before touching the repo. A nil upsert denotes a
+	// deletion.
+	var (
+		ids     = make([]dssmodels.ID, len(changes))
+		upserts = make([]*constraintUpsert, len(changes))
+		seen    = map[dssmodels.ID]bool{}
+	)
+	for i, change := range changes {
+		switch {
+		case change.GetDelete() && change.GetParams() != nil:
+			return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Change %d may not both delete and specify params", i)
+		case change.GetDelete():
+			id, err := dssmodels.IDFromString(change.GetEntityid())
+			if err != nil {
+				return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Invalid ID format in change %d: `%s`", i, change.GetEntityid())
+			}
+			ids[i] = id
+		case change.GetParams() == nil:
+			return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Missing params in change %d", i)
+		default:
+			u, err := a.validateConstraintUpsert(change.GetEntityid(), change.GetOvn(), change.GetParams())
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "Invalid change %d", i)
+			}
+			ids[i] = u.id
+			upserts[i] = u
+		}
+		if seen[ids[i]] {
+			return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Constraint %s changed more than once", ids[i])
+		}
+		seen[ids[i]] = true
+	}
+
+	var response *bulkpb.BulkChangeConstraintReferencesResponse
+	action := func(ctx context.Context, r repos.Repository) (err error) {
+		var (
+			constraints = make([]*scdmodels.Constraint, len(changes))
+			notify      []*scdmodels.Subscription
+			changed     repos.Subscriptions
+		)
+		for i := range changes {
+			if upserts[i] == nil {
+				constraints[i], changed, err = deleteConstraint(ctx, r, manager, ids[i])
+			} else {
+				constraints[i], changed, err = upsertConstraint(ctx, r, manager, upserts[i])
+			}
+			if err != nil {
+				return stacktrace.Propagate(err, "Failed to apply change %d", i)
+			}
+			notify = append(notify, changed...)
+		}
+
+		response = &bulkpb.BulkChangeConstraintReferencesResponse{
+			Subscribers: mergeSubscribersToNotify(notify),
+		}
+		for _, constraint := range constraints {
+			p, err := constraint.ToProto()
+			if err != nil {
+				return stacktrace.Propagate(err, "Could not convert Constraint to proto")
+			}
+			response.ConstraintReferences = append(response.ConstraintReferences, p)
+		}
+
+		return nil
+	}
+
+	err := a.Store.Transact(ctx, action)
+	if err != nil {
+		return nil, err // No need to Propagate this error as this is not a useful stacktrace line
+	}
+
+	return response, nil
+}
@@ -0,0 +1,210 @@
+package scd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/interuss/dss/pkg/api/v1/bulkpb"
+	"github.com/interuss/dss/pkg/api/v1/scdpb"
+	"github.com/interuss/dss/pkg/auth"
+	dsserr "github.com/interuss/dss/pkg/errors"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/stacktrace"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// strategicCoordinationContext returns the context of a request of manager
+// with the strategic coordination scope.
+func strategicCoordinationContext(manager dssmodels.Owner) context.Context {
+	ctx := auth.ContextWithOwner(context.Background(), manager)
+	return auth.ContextWithScopes(ctx, auth.ScopeSet{strategicCoordinationScope: {}})
+}
+
+// testOperationalIntentParams returns the parameters of an
+// OperationalIntent in state, with an implicit Subscription, starting in an
+// hour for an hour, and whose key is key.
+func testOperationalIntentParams(t *testing.T, state scdmodels.OperationalIntentState, key ...scdmodels.OVN) *scdpb.PutOperationalIntentReferenceParameters {
+	var (
+		start    = time.Now().Add(time.Hour)
+		end      = start.Add(time.Hour)
+		altLower = float32(0)
+		altUpper = float32(100)
+	)
+	extent, err := (&dssmodels.Volume4D{
+		StartTime: &start,
+		EndTime:   &end,
+		SpatialVolume: &dssmodels.Volume3D{
+			AltitudeLo: &altLower,
+			AltitudeHi: &altUpper,
+			Footprint: &dssmodels.GeoCircle{
+				Center:      dssmodels.LatLngPoint{Lat: 37.4, Lng: -122.1},
+				RadiusMeter: 100,
+			},
+		},
+	}).ToSCDProto()
+	require.NoError(t, err)
+
+	params := &scdpb.PutOperationalIntentReferenceParameters{
+		Extents:         []*scdpb.Volume4D{extent},
+		State:           state.String(),
+		UssBaseUrl:      "https://uss1.example.com",
+		NewSubscription: &scdpb.ImplicitSubscriptionParameters{UssBaseUrl: "https://uss1.example.com"},
+	}
+	for _, ovn := range key {
+		params.Key = append(params.Key, ovn.String())
+	}
+	return params
+}
+
+func TestMergeSubscribersToNotify(t *testing.T) {
+	subscribers := mergeSubscribersToNotify([]*scdmodels.Subscription{
+		{ID: "sub1", USSBaseURL: "https://uss1.example.com", NotificationIndex: 3},
+		{ID: "sub2", USSBaseURL: "https://uss1.example.com", NotificationIndex: 7},
+		{ID: "sub1", USSBaseURL: "https://uss1.example.com", NotificationIndex: 4},
+		{ID: "sub3", USSBaseURL: "https://uss2.example.com", NotificationIndex: 1},
+	})
+
+	indices := map[string]map[string]int32{}
+	for _, subscriber := range subscribers {
+		indices[subscriber.UssBaseUrl] = map[string]int32{}
+		for _, state := range subscriber.Subscriptions {
+			indices[subscriber.UssBaseUrl][state.SubscriptionId] = state.NotificationIndex
+		}
+	}
+	require.Equal(t, map[string]map[string]int32{
+		"https://uss1.example.com": {"sub1": 4, "sub2": 7},
+		"https://uss2.example.com": {"sub3": 1},
+	}, indices)
+}
+
+func TestBulkChangeOperationalIntentReferencesValidation(t *testing.T) {
+	const id = "00000000-0000-4000-8000-000000000001"
+	// Invalid requests are rejected before the Store is used.
+	a := &Server{Limits: Limits{MaxBulkChanges: 2}}
+	ctx := auth.ContextWithOwner(context.Background(), "uss1")
+
+	for _, tc := range []struct {
+		name    string
+		changes []*bulkpb.OperationalIntentReferenceChange
+	}{
+		{name: "empty"},
+		{name: "too many", changes: []*bulkpb.OperationalIntentReferenceChange{
+			{Entityid: id, Delete: true},
+			{Entityid: id, Delete: true},
+			{Entityid: id, Delete: true},
+		}},
+		{name: "missing params", changes: []*bulkpb.OperationalIntentReferenceChange{
+			{Entityid: id},
+		}},
+		{name: "invalid ID", changes: []*bulkpb.OperationalIntentReferenceChange{
+			{Entityid: "not-a-uuid", Delete: true},
+		}},
+		{name: "duplicate ID", changes: []*bulkpb.OperationalIntentReferenceChange{
+			{Entityid: id, Delete: true},
+			{Entityid: id, Delete: true},
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := a.BulkChangeOperationalIntentReferences(ctx, &bulkpb.BulkChangeOperationalIntentReferencesRequest{Changes: tc.changes})
+			require.Error(t, err)
+			require.Equal(t, dsserr.BadRequest, stacktrace.GetCode(err))
+		})
+	}
+}
+
+func TestBulkKey(t *testing.T) {
+	upserts := []*operationalIntentUpsert{{}, nil, {}}
+	ops := []*scdmodels.OperationalIntent{
+		{ID: "created", OVN: "ovn-created"},
+		{ID: "deleted", OVN: "ovn-deleted"},
+		{ID: "updated", OVN: "ovn-updated"},
+	}
+	known := bulkKey(map[scdmodels.OVN]bool{"ovn-other": true}, ops, upserts)
+	require.Equal(t, map[scdmodels.OVN]bool{
+		"ovn-other":   true,
+		"ovn-created": true,
+		"ovn-updated": true,
+	}, known)
+}
+
+func TestBulkChangeOperationalIntentReferencesRollsBack(t *testing.T) {
+	var (
+		store = newMemoryStore()
+		a     = &Server{Store: store}
+		ctx   = strategicCoordinationContext("uss1")
+	)
+
+	// The third change updates an OperationalIntent which does not exist, so
+	// the creations of the first two are rolled back along with it.
+	_, err := a.BulkChangeOperationalIntentReferences(ctx, &bulkpb.BulkChangeOperationalIntentReferencesRequest{
+		Changes: []*bulkpb.OperationalIntentReferenceChange{
+			{Entityid: "00000000-0000-4000-8000-000000000001", Params: testOperationalIntentParams(t, scdmodels.OperationalIntentStateAccepted)},
+			{Entityid: "00000000-0000-4000-8000-000000000002", Params: testOperationalIntentParams(t, scdmodels.OperationalIntentStateAccepted)},
+			{Entityid: "00000000-0000-4000-8000-000000000003", Ovn: "unknown-ovn", Params: testOperationalIntentParams(t, scdmodels.OperationalIntentStateAccepted)},
+		},
+	})
+	require.Error(t, err)
+	require.Equal(t, dsserr.NotFound, stacktrace.GetCode(err))
+	require.Equal(t, 1, store.transactions)
+	require.Empty(t, store.state.ops)
+	require.Empty(t, store.state.subs)
+	require.Empty(t, store.state.transitions)
+}
+
+func TestBulkChangeOperationalIntentReferencesKnowsBatchOVNs(t *testing.T) {
+	const (
+		id1 = "00000000-0000-4000-8000-000000000001"
+		id2 = "00000000-0000-4000-8000-000000000002"
+		id3 = "00000000-0000-4000-8000-000000000003"
+	)
+	var (
+		store = newMemoryStore()
+		a     = &Server{Store: store}
+		ctx   = strategicCoordinationContext("uss1")
+	)
+
+	// The OperationalIntents created by a request intersect each other, but
+	// their keys do not need the OVNs they are created with.
+	response, err := a.BulkChangeOperationalIntentReferences(ctx, &bulkpb.BulkChangeOperationalIntentReferencesRequest{
+		Changes: []*bulkpb.OperationalIntentReferenceChange{
+			{Entityid: id1, Params: testOperationalIntentParams(t, scdmodels.OperationalIntentStateAccepted)},
+			{Entityid: id2, Params: testOperationalIntentParams(t, scdmodels.OperationalIntentStateAccepted)},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, response.OperationalIntentReferences, 2)
+	var (
+		ovn1 = scdmodels.OVN(response.OperationalIntentReferences[0].Ovn)
+		ovn2 = scdmodels.OVN(response.OperationalIntentReferences[1].Ovn)
+	)
+
+	// Each update only has the prior OVN of the other OperationalIntent in
+	// its key, the OVNs resulting from the request being known.
+	response, err = a.BulkChangeOperationalIntentReferences(ctx, &bulkpb.BulkChangeOperationalIntentReferencesRequest{
+		Changes: []*bulkpb.OperationalIntentReferenceChange{
+			{Entityid: id1, Ovn: ovn1.String(), Params: testOperationalIntentParams(t, scdmodels.OperationalIntentStateAccepted, ovn2)},
+			{Entityid: id2, Ovn: ovn2.String(), Params: testOperationalIntentParams(t, scdmodels.OperationalIntentStateAccepted, ovn1)},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, response.OperationalIntentReferences, 2)
+	require.NotEqual(t, ovn1.String(), response.OperationalIntentReferences[0].Ovn)
+	require.NotEqual(t, ovn2.String(), response.OperationalIntentReferences[1].Ovn)
+	require.Equal(t, store.state.ops[id1].OVN.String(), response.OperationalIntentReferences[0].Ovn)
+	require.Equal(t, store.state.ops[id2].OVN.String(), response.OperationalIntentReferences[1].Ovn)
+
+	// The OVNs of the OperationalIntents the request does not change must
+	// still be in the key.
+	_, err = a.BulkChangeOperationalIntentReferences(ctx, &bulkpb.BulkChangeOperationalIntentReferencesRequest{
+		Changes: []*bulkpb.OperationalIntentReferenceChange{
+			{Entityid: id3, Params: testOperationalIntentParams(t, scdmodels.OperationalIntentStateAccepted, store.state.ops[id1].OVN)},
+		},
+	})
+	require.Error(t, err)
+	require.Equal(t, codes.Code(uint16(dsserr.MissingOVNs)), status.Code(stacktrace.RootCause(err)))
+	require.NotContains(t, store.state.ops, dssmodels.ID(id3))
+}
@@ -31,51 +31,9 @@ func (a *Server) DeleteConstraintReference(ctx context.Context, req *scdpb.Delet
 
 	var response *scdpb.ChangeConstraintReferenceResponse
 	action := func(ctx context.Context, r repos.Repository) (err error) {
-		// Make sure deletion request is valid
-		old, err := r.GetConstraint(ctx, id)
-		switch {
-		case err == pgx.ErrNoRows:
-			return stacktrace.NewErrorWithCode(dsserr.NotFound, "Constraint %s not found", id.String())
-		case err != nil:
-			return stacktrace.Propagate(err, "Unable to get Constraint from repo")
-		case old.Manager != manager:
-			return stacktrace.NewErrorWithCode(dsserr.PermissionDenied,
-				"Constraint owned by %s, but %s attempted to delete", old.Manager, manager)
-		}
-
-		// Find Subscriptions that may overlap the Constraint's Volume4D
-		allsubs, err := r.SearchSubscriptions(ctx, &dssmodels.Volume4D{
-			StartTime: old.StartTime,
-			EndTime:   old.EndTime,
-			SpatialVolume: &dssmodels.Volume3D{
-				AltitudeHi: old.AltitudeUpper,
-				AltitudeLo: old.AltitudeLower,
-				Footprint: dssmodels.GeometryFunc(func() (s2.CellUnion, error) {
-					return old.Cells, nil
-				}),
-			}})
-		if err != nil {
-			return stacktrace.Propagate(err, "Unable to search Subscriptions in repo")
-		}
-
-		// Limit Subscription notifications to only those interested in Constraints
-		var subs repos.Subscriptions
-		for _, sub := range allsubs {
-			if sub.NotifyForConstraints {
-				subs = append(subs, sub)
-			}
-		}
-
-		// Delete Constraint in repo
-		err = r.DeleteConstraint(ctx, id)
-		if err != nil {
-			return stacktrace.Propagate(err, "Unable to delete Constraint from repo")
-		}
-
-		// Increment notification indices for relevant Subscriptions
-		err = subs.IncrementNotificationIndices(ctx, r)
+		old, subs, err := deleteConstraint(ctx, r, manager, id)
 		if err != nil {
-			return stacktrace.Propagate(err, "Unable to increment notification indices")
+			return err // No need to Propagate this error as this stack layer does not add useful information
 		}
 
 		// Convert deleted Constraint to proto
@@ -101,6 +59,67 @@ func (a *Server) DeleteConstraintReference(ctx context.Context, req *scdpb.Delet
 	return response, nil
 }
 
+// deleteConstraint deletes the Constraint identified by id on behalf of
+// manager and increments the notification indices of the Subscriptions to
+// notify. It returns the deleted Constraint and these Subscriptions.
+func deleteConstraint(ctx context.Context, r repos.Repository, manager dssmodels.Manager, id dssmodels.ID) (*scdmodels.Constraint, repos.Subscriptions, error) {
+	// Make sure deletion request is valid
+	old, err := r.GetConstraint(ctx, id)
+	switch {
+	case err == pgx.ErrNoRows:
+		return nil, nil, stacktrace.NewErrorWithCode(dsserr.NotFound, "Constraint %s not found", id.String())
+	case err != nil:
+		return nil, nil, stacktrace.Propagate(err, "Unable to get Constraint from repo")
+	case old.Manager != manager:
+		return nil, nil, stacktrace.NewErrorWithCode(dsserr.PermissionDenied,
+			"Constraint owned by %s, but %s attempted to delete", old.Manager, manager)
+	}
+
+	// Find Subscriptions that may overlap the Constraint's Volume4D
+	allsubs, err := r.SearchSubscriptions(ctx, &dssmodels.Volume4D{
+		StartTime: old.StartTime,
+		EndTime:   old.EndTime,
+		SpatialVolume: &dssmodels.Volume3D{
+			AltitudeHi: old.AltitudeUpper,
+			AltitudeLo: old.AltitudeLower,
+			Footprint: dssmodels.GeometryFunc(func() (s2.CellUnion, error) {
+				return old.Cells, nil
+			}),
+		}})
+	if err != nil {
+		return nil, nil, stacktrace.Propagate(err, "Unable to search Subscriptions in repo")
+	}
+
+	// Limit Subscription notifications to only those interested in Constraints
+	// of its category
+	var subs repos.Subscriptions
+	for _, sub := range allsubs {
+		if sub.NotifiesForConstraint(old) {
+			subs = append(subs, sub)
+		}
+	}
+
+	// Delete Constraint in repo
+	err = r.DeleteConstraint(ctx, id)
+	if err != nil {
+		return nil, nil, stacktrace.Propagate(err, "Unable to delete Constraint from repo")
+	}
+	if err := r.InsertAuditRecord(ctx, constraintAuditRecord(dssmodels.AuditDelete, old, nil)); err != nil {
+		return nil, nil, stacktrace.Propagate(err, "Unable to record Constraint deletion in audit log")
+	}
+
+	// Increment notification indices for relevant Subscriptions
+	err = subs.IncrementNotificationIndices(ctx, r)
+	if err != nil {
+		return nil, nil, stacktrace.Propagate(err, "Unable to increment notification indices")
+	}
+	if err := r.InsertSubscriptionEvents(ctx, subscriptionEvents(subs, dssmodels.AuditEntityConstraint, old.ID, "")); err != nil {
+		return nil, nil, stacktrace.Propagate(err, "Unable to record subscription events")
+	}
+
+	return old, subs, nil
+}
+
 // GetConstraintReference returns a single constraint ref for the given ID.
 func (a *Server) GetConstraintReference(ctx context.Context, req *scdpb.GetConstraintReferenceRequest) (*scdpb.GetConstraintReferenceResponse, error) {
 	id, err := dssmodels.IDFromString(req.GetEntityid())
@@ -141,7 +160,7 @@ func (a *Server) GetConstraintReference(ctx context.Context, req *scdpb.GetConst
 		return nil
 	}
 
-	err = a.Store.Transact(ctx, action)
+	err = a.Store.TransactReadOnly(ctx, action)
 	if err != nil {
 		return nil, err // No need to Propagate this error as this is not a useful stacktrace line
 	}
@@ -160,18 +179,67 @@ func (a *Server) UpdateConstraintReference(ctx context.Context, req *scdpb.Updat
 // PutConstraintReference inserts or updates a Constraint.
 // If the ovn argument is empty (""), it will attempt to create a new Constraint.
 func (a *Server) PutConstraintReference(ctx context.Context, entityid string, ovn string, params *scdpb.PutConstraintReferenceParameters) (*scdpb.ChangeConstraintReferenceResponse, error) {
-	id, err := dssmodels.IDFromString(entityid)
-
-	if err != nil {
-		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Invalid ID format: `%s`", entityid)
-	}
-
 	// Retrieve ID of client making call
 	manager, ok := auth.ManagerFromContext(ctx)
 	if !ok {
 		return nil, stacktrace.NewErrorWithCode(dsserr.PermissionDenied, "Missing manager from context")
 	}
 
+	u, err := a.validateConstraintUpsert(entityid, ovn, params)
+	if err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+
+	var response *scdpb.ChangeConstraintReferenceResponse
+	action := func(ctx context.Context, r repos.Repository) (err error) {
+		constraint, subs, err := upsertConstraint(ctx, r, manager, u)
+		if err != nil {
+			return err // No need to Propagate this error as this stack layer does not add useful information
+		}
+
+		// Convert upserted Constraint to proto
+		p, err := constraint.ToProto()
+		if err != nil {
+			return err
+		}
+
+		// Return response to client
+		response = &scdpb.ChangeConstraintReferenceResponse{
+			ConstraintReference: p,
+			Subscribers:         makeSubscribersToNotify(subs),
+		}
+
+		return nil
+	}
+
+	err = a.Store.Transact(ctx, action)
+	if err != nil {
+		return nil, err // No need to Propagate this error as this is not a useful stacktrace line
+	}
+
+	return response, nil
+}
+
+// constraintUpsert is a validated request to create or update a Constraint.
+type constraintUpsert struct {
+	id       dssmodels.ID
+	ovn      scdmodels.OVN
+	params   *scdpb.PutConstraintReferenceParameters
+	extent   *dssmodels.Volume4D
+	cells    s2.CellUnion
+	category scdmodels.ConstraintCategory
+}
+
+// validateConstraintUpsert validates the parts of a request to create (if ovn
+// is empty) or update a Constraint which do not depend on the content of the
+// repo.
+func (a *Server) validateConstraintUpsert(entityid string, ovn string, params *scdpb.PutConstraintReferenceParameters) (*constraintUpsert, error) {
+	id, err := dssmodels.IDFromString(entityid)
+
+	if err != nil {
+		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Invalid ID format: `%s`", entityid)
+	}
+
 	var extents = make([]*dssmodels.Volume4D, len(params.GetExtents()))
 
 	if len(params.UssBaseUrl) == 0 {
@@ -185,6 +253,11 @@ func (a *Server) PutConstraintReference(ctx context.Context, entityid string, ov
 		}
 	}
 
+	category, err := scdmodels.ConstraintCategoryFromString(params.GetCategory())
+	if err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+
 	// TODO: factor out logic below into common multi-vol4d parser and reuse with PutOperationReference
 	for idx, extent := range params.GetExtents() {
 		cExtent, err := dssmodels.Volume4DFromSCDProto(extent)
@@ -210,114 +283,124 @@ func (a *Server) PutConstraintReference(ctx context.Context, entityid string, ov
 		return nil, stacktrace.PropagateWithCode(err, dsserr.BadRequest, "Invalid area")
 	}
 
-	var response *scdpb.ChangeConstraintReferenceResponse
-	action := func(ctx context.Context, r repos.Repository) (err error) {
-		var version int32 // Version of the Constraint (0 means creation requested).
+	return &constraintUpsert{
+		id:       id,
+		ovn:      scdmodels.OVN(ovn),
+		params:   params,
+		extent:   uExtent,
+		cells:    cells,
+		category: category,
+	}, nil
+}
 
-		// Get existing Constraint, if any, and validate request
-		old, err := r.GetConstraint(ctx, id)
-		switch {
-		case err == pgx.ErrNoRows:
-			// No existing Constraint; verify that creation was requested
-			if ovn != "" {
-				return stacktrace.NewErrorWithCode(dsserr.VersionMismatch, "Old version %s does not exist", ovn)
-			}
-			version = 0
-		case err != nil:
-			return stacktrace.Propagate(err, "Could not get Constraint from repo")
+// upsertConstraint creates or updates the Constraint requested by manager in
+// u and increments the notification indices of the Subscriptions to notify.
+// It returns the upserted Constraint and these Subscriptions.
+func upsertConstraint(ctx context.Context, r repos.Repository, manager dssmodels.Manager, u *constraintUpsert) (*scdmodels.Constraint, repos.Subscriptions, error) {
+	var (
+		version int32 // Version of the Constraint (0 means creation requested).
+		uExtent = u.extent
+	)
+
+	// Get existing Constraint, if any, and validate request
+	old, err := r.GetConstraint(ctx, u.id)
+	switch {
+	case err == pgx.ErrNoRows:
+		// No existing Constraint; verify that creation was requested
+		if u.ovn != "" {
+			return nil, nil, stacktrace.NewErrorWithCode(dsserr.VersionMismatch, "Old version %s does not exist", u.ovn)
 		}
-		if old != nil {
-			if old.Manager != manager {
-				return stacktrace.NewErrorWithCode(dsserr.PermissionDenied,
-					"Constraint owned by %s, but %s attempted to modify", old.Manager, manager)
-			}
-			if old.OVN != scdmodels.OVN(ovn) {
-				return stacktrace.NewErrorWithCode(dsserr.VersionMismatch,
-					"Current version is %s but client specified version %s", old.OVN, ovn)
-			}
-			version = int32(old.Version)
+		version = 0
+	case err != nil:
+		return nil, nil, stacktrace.Propagate(err, "Could not get Constraint from repo")
+	}
+	if old != nil {
+		if old.Manager != manager {
+			return nil, nil, stacktrace.NewErrorWithCode(dsserr.PermissionDenied,
+				"Constraint owned by %s, but %s attempted to modify", old.Manager, manager)
 		}
-
-		// Compute total affected Volume4D for notification purposes
-		var notifyVol4 *dssmodels.Volume4D
-		if old == nil {
-			notifyVol4 = uExtent
-		} else {
-			oldVol4 := &dssmodels.Volume4D{
-				StartTime: old.StartTime,
-				EndTime:   old.EndTime,
-				SpatialVolume: &dssmodels.Volume3D{
-					AltitudeHi: old.AltitudeUpper,
-					AltitudeLo: old.AltitudeLower,
-					Footprint: dssmodels.GeometryFunc(func() (s2.CellUnion, error) {
-						return old.Cells, nil
-					}),
-				}}
-			notifyVol4, err = dssmodels.UnionVolumes4D(uExtent, oldVol4)
-			if err != nil {
-				return stacktrace.Propagate(err, "Error constructing 4D volumes union")
-			}
+		if old.OVN != u.ovn {
+			return nil, nil, stacktrace.NewErrorWithCode(dsserr.VersionMismatch,
+				"Current version is %s but client specified version %s", old.OVN, u.ovn)
 		}
+		version = int32(old.Version)
+	}
 
-		// Upsert the Constraint
-		constraint, err := r.UpsertConstraint(ctx, &scdmodels.Constraint{
-			ID:      id,
-			Manager: manager,
-			Version: scdmodels.VersionNumber(version + 1),
-
-			StartTime:     uExtent.StartTime,
-			EndTime:       uExtent.EndTime,
-			AltitudeLower: uExtent.SpatialVolume.AltitudeLo,
-			AltitudeUpper: uExtent.SpatialVolume.AltitudeHi,
-
-			USSBaseURL: params.UssBaseUrl,
-			Cells:      cells,
-		})
+	// Compute total affected Volume4D for notification purposes
+	var notifyVol4 *dssmodels.Volume4D
+	if old == nil {
+		notifyVol4 = uExtent
+	} else {
+		oldVol4 := &dssmodels.Volume4D{
+			StartTime: old.StartTime,
+			EndTime:   old.EndTime,
+			SpatialVolume: &dssmodels.Volume3D{
+				AltitudeHi: old.AltitudeUpper,
+				AltitudeLo: old.AltitudeLower,
+				Footprint: dssmodels.GeometryFunc(func() (s2.CellUnion, error) {
+					return old.Cells, nil
+				}),
+			}}
+		notifyVol4, err = dssmodels.UnionVolumes4D(uExtent, oldVol4)
 		if err != nil {
-			return err
+			return nil, nil, stacktrace.Propagate(err, "Error constructing 4D volumes union")
 		}
+	}
 
-		// Find Subscriptions that may need to be notified
-		allsubs, err := r.SearchSubscriptions(ctx, notifyVol4)
-		if err != nil {
-			return err
-		}
+	// Upsert the Constraint
+	constraint, err := r.UpsertConstraint(ctx, &scdmodels.Constraint{
+		ID:      u.id,
+		Manager: manager,
+		Version: scdmodels.VersionNumber(version + 1),
 
-		// Limit Subscription notifications to only those interested in Constraints
-		var subs repos.Subscriptions
-		for _, sub := range allsubs {
-			if sub.NotifyForConstraints {
-				subs = append(subs, sub)
-			}
-		}
+		StartTime:     uExtent.StartTime,
+		EndTime:       uExtent.EndTime,
+		AltitudeLower: uExtent.SpatialVolume.AltitudeLo,
+		AltitudeUpper: uExtent.SpatialVolume.AltitudeHi,
 
-		// Increment notification indices for relevant Subscriptions
-		err = subs.IncrementNotificationIndices(ctx, r)
-		if err != nil {
-			return err
-		}
+		USSBaseURL: u.params.UssBaseUrl,
+		Cells:      u.cells,
 
-		// Convert upserted Constraint to proto
-		p, err := constraint.ToProto()
-		if err != nil {
-			return err
-		}
+		Category:  u.category,
+		Authority: u.params.GetAuthority(),
+		Reason:    u.params.GetReason(),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	auditOp := dssmodels.AuditCreate
+	if old != nil {
+		auditOp = dssmodels.AuditUpdate
+	}
+	if err := r.InsertAuditRecord(ctx, constraintAuditRecord(auditOp, old, constraint)); err != nil {
+		return nil, nil, stacktrace.Propagate(err, "Failed to record Constraint change in audit log")
+	}
 
-		// Return response to client
-		response = &scdpb.ChangeConstraintReferenceResponse{
-			ConstraintReference: p,
-			Subscribers:         makeSubscribersToNotify(subs),
-		}
+	// Find Subscriptions that may need to be notified
+	allsubs, err := r.SearchSubscriptions(ctx, notifyVol4)
+	if err != nil {
+		return nil, nil, err
+	}
 
-		return nil
+	// Limit Subscription notifications to only those interested in Constraints
+	// of its previous or new category
+	var subs repos.Subscriptions
+	for _, sub := range allsubs {
+		if sub.NotifiesForConstraint(constraint) || (old != nil && sub.NotifiesForConstraint(old)) {
+			subs = append(subs, sub)
+		}
 	}
 
-	err = a.Store.Transact(ctx, action)
+	// Increment notification indices for relevant Subscriptions
+	err = subs.IncrementNotificationIndices(ctx, r)
 	if err != nil {
-		return nil, err // No need to Propagate this error as this is not a useful stacktrace line
+		return nil, nil, err
+	}
+	if err := r.InsertSubscriptionEvents(ctx, subscriptionEvents(subs, dssmodels.AuditEntityConstraint, constraint.ID, constraint.OVN)); err != nil {
+		return nil, nil, stacktrace.Propagate(err, "Failed to record subscription events")
 	}
 
-	return response, nil
+	return constraint, subs, nil
 }
 
 // QueryConstraintReferences queries existing contraint refs in the given
@@ -335,6 +418,11 @@ func (a *Server) QueryConstraintReferences(ctx context.Context, req *scdpb.Query
 		return nil, err
 	}
 
+	categories, err := scdmodels.ConstraintCategoriesFromStrings(req.GetParams().GetCategories())
+	if err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+
 	// Retrieve ID of client making call
 	manager, ok := auth.ManagerFromContext(ctx)
 	if !ok {
@@ -344,7 +432,7 @@ func (a *Server) QueryConstraintReferences(ctx context.Context, req *scdpb.Query
 	var response *scdpb.QueryConstraintReferencesResponse
 	action := func(ctx context.Context, r repos.Repository) (err error) {
 		// Perform search query on Store
-		constraints, err := r.SearchConstraints(ctx, vol4)
+		constraints, err := r.SearchConstraints(ctx, vol4, categories)
 		if err != nil {
 			return err
 		}
@@ -365,7 +453,7 @@ func (a *Server) QueryConstraintReferences(ctx context.Context, req *scdpb.Query
 		return nil
 	}
 
-	err = a.Store.Transact(ctx, action)
+	err = a.Store.TransactReadOnly(ctx, action)
 	if err != nil {
 		return nil, err // No need to Propagate this error as this is not a useful stacktrace line
 	}
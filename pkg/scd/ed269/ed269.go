@@ -0,0 +1,249 @@
+// Package ed269 converts UAS geographical zones in the ED-269 JSON format to
+// strategic conflict detection Constraints.
+package ed269
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	dssmodels "github.com/interuss/dss/pkg/models"
+	"github.com/interuss/stacktrace"
+)
+
+const (
+	// Values of the ED-269 enumerations interpreted by the conversion.
+	yes = "YES"
+
+	uomMeters = "M"
+	uomFeet   = "FT"
+
+	referenceAGL   = "AGL"
+	referenceAMSL  = "AMSL"
+	referenceWGS84 = "WGS84"
+
+	projectionPolygon = "Polygon"
+	projectionCircle  = "Circle"
+
+	metersPerFoot = 0.3048
+)
+
+// ZoneCollection is the content of an ED-269 UAS zones file.
+type ZoneCollection struct {
+	Title       string            `json:"title,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Features    []*UASZoneVersion `json:"features"`
+}
+
+// UASZoneVersion is a UAS geographical zone. Only the fields relevant to
+// strategic conflict detection are decoded.
+type UASZoneVersion struct {
+	Identifier    string            `json:"identifier"`
+	Country       string            `json:"country"`
+	Name          string            `json:"name,omitempty"`
+	Restriction   string            `json:"restriction,omitempty"`
+	Message       string            `json:"message,omitempty"`
+	Applicability []*TimePeriod     `json:"applicability"`
+	Geometry      []*AirspaceVolume `json:"geometry"`
+}
+
+// TimePeriod is a period during which a zone applies.
+type TimePeriod struct {
+	// Permanent is "YES" if the zone always applies, in which case the start
+	// and end are ignored.
+	Permanent     string         `json:"permanent"`
+	StartDateTime *time.Time     `json:"startDateTime,omitempty"`
+	EndDateTime   *time.Time     `json:"endDateTime,omitempty"`
+	Schedule      []*DailyPeriod `json:"schedule,omitempty"`
+}
+
+// DailyPeriod restricts a TimePeriod to some times of some days.
+type DailyPeriod struct {
+	Day       []string `json:"day"`
+	StartTime string   `json:"startTime"`
+	EndTime   string   `json:"endTime"`
+}
+
+// AirspaceVolume is a volume of a zone.
+type AirspaceVolume struct {
+	// UomDimensions is the unit of the limits, "M" or "FT".
+	UomDimensions          string                `json:"uomDimensions"`
+	LowerLimit             *float64              `json:"lowerLimit,omitempty"`
+	LowerVerticalReference string                `json:"lowerVerticalReference,omitempty"`
+	UpperLimit             *float64              `json:"upperLimit,omitempty"`
+	UpperVerticalReference string                `json:"upperVerticalReference,omitempty"`
+	HorizontalProjection   *HorizontalProjection `json:"horizontalProjection"`
+}
+
+// HorizontalProjection is the footprint of an AirspaceVolume: either a GeoJSON
+// Polygon, or a circle. Positions are [longitude, latitude] pairs.
+type HorizontalProjection struct {
+	Type        string         `json:"type"`
+	Coordinates [][][2]float64 `json:"coordinates,omitempty"`
+	Center      *[2]float64    `json:"center,omitempty"`
+	// Radius of a circle in meters.
+	Radius float64 `json:"radius,omitempty"`
+}
+
+// Parse reads an ED-269 UAS zones file from r.
+func Parse(r io.Reader) (*ZoneCollection, error) {
+	zones := &ZoneCollection{}
+	if err := json.NewDecoder(r).Decode(zones); err != nil {
+		return nil, stacktrace.Propagate(err, "Error decoding UAS zones")
+	}
+	seen := map[string]bool{}
+	for i, z := range zones.Features {
+		if z == nil || z.Identifier == "" {
+			return nil, stacktrace.NewError("Missing identifier of zone %d", i)
+		}
+		if seen[z.Key()] {
+			return nil, stacktrace.NewError("Duplicate zone %s", z.Key())
+		}
+		seen[z.Key()] = true
+	}
+	return zones, nil
+}
+
+// Key identifies the zone among the zones of all countries.
+func (z *UASZoneVersion) Key() string {
+	return z.Country + "/" + z.Identifier
+}
+
+// Options configures the conversion of zones to Constraint extents.
+type Options struct {
+	// Now is the time of the conversion. Periods which ended before Now are
+	// ignored, and periods without start start at Now.
+	Now time.Time
+	// Horizon is the duration after Now at which periods without end end.
+	Horizon time.Duration
+	// MaxGroundElevation is the highest elevation of the ground, in meters
+	// above the WGS84 ellipsoid, in the zones. Limits relative to the ground
+	// cannot be converted exactly: upper limits are raised by
+	// MaxGroundElevation and lower limits are dropped.
+	MaxGroundElevation float64
+}
+
+// Extents returns the extents of the Constraint corresponding to z, one per
+// volume and current time period, or none if z no longer applies. Whether
+// the start or end of an extent was bounded by opts is also returned.
+//
+// Daily schedules are not represented: each extent covers the whole time
+// period.
+func (z *UASZoneVersion) Extents(opts Options) (extents []*dssmodels.Volume4D, openStart bool, openEnd bool, err error) {
+	if len(z.Geometry) == 0 {
+		return nil, false, false, stacktrace.NewError("Missing geometry of zone %s", z.Key())
+	}
+	var volumes []*dssmodels.Volume3D
+	for i, v := range z.Geometry {
+		vol3, err := v.toVolume3D(opts)
+		if err != nil {
+			return nil, false, false, stacktrace.Propagate(err, "Invalid volume %d of zone %s", i, z.Key())
+		}
+		volumes = append(volumes, vol3)
+	}
+
+	periods := z.Applicability
+	if len(periods) == 0 {
+		// Zones without applicability always apply.
+		periods = []*TimePeriod{{Permanent: yes}}
+	}
+	for i, p := range periods {
+		start, end := p.StartDateTime, p.EndDateTime
+		if p.Permanent == yes {
+			start, end = nil, nil
+		} else if start != nil && end != nil && end.Before(*start) {
+			return nil, false, false, stacktrace.NewError("Period %d of zone %s ends before it starts", i, z.Key())
+		}
+		if end != nil && !end.After(opts.Now) {
+			continue
+		}
+		if start == nil || start.Before(opts.Now) {
+			// Constraints only need to cover the future.
+			start = &opts.Now
+			openStart = true
+		}
+		if end == nil {
+			e := opts.Now.Add(opts.Horizon)
+			end = &e
+			openEnd = true
+		}
+		for _, vol3 := range volumes {
+			s, e := *start, *end
+			extents = append(extents, &dssmodels.Volume4D{
+				StartTime:     &s,
+				EndTime:       &e,
+				SpatialVolume: vol3,
+			})
+		}
+	}
+	return extents, openStart, openEnd, nil
+}
+
+func (v *AirspaceVolume) toVolume3D(opts Options) (*dssmodels.Volume3D, error) {
+	var factor float64
+	switch v.UomDimensions {
+	case uomMeters:
+		factor = 1
+	case uomFeet:
+		factor = metersPerFoot
+	default:
+		return nil, stacktrace.NewError("Unsupported uomDimensions `%s`", v.UomDimensions)
+	}
+
+	vol3 := &dssmodels.Volume3D{}
+	if v.LowerLimit != nil {
+		switch v.LowerVerticalReference {
+		case referenceAMSL, referenceWGS84:
+			lo := float32(*v.LowerLimit * factor)
+			vol3.AltitudeLo = &lo
+		case referenceAGL:
+			// The ground may be as low as the ellipsoid.
+		default:
+			return nil, stacktrace.NewError("Unsupported lowerVerticalReference `%s`", v.LowerVerticalReference)
+		}
+	}
+	if v.UpperLimit != nil {
+		switch v.UpperVerticalReference {
+		case referenceAMSL, referenceWGS84:
+			hi := float32(*v.UpperLimit * factor)
+			vol3.AltitudeHi = &hi
+		case referenceAGL:
+			hi := float32(*v.UpperLimit*factor + opts.MaxGroundElevation)
+			vol3.AltitudeHi = &hi
+		default:
+			return nil, stacktrace.NewError("Unsupported upperVerticalReference `%s`", v.UpperVerticalReference)
+		}
+	}
+
+	p := v.HorizontalProjection
+	switch {
+	case p == nil:
+		return nil, stacktrace.NewError("Missing horizontalProjection")
+	case p.Type == projectionPolygon:
+		if len(p.Coordinates) == 0 {
+			return nil, stacktrace.NewError("Missing polygon coordinates")
+		}
+		// Holes are ignored, as the Constraint covers them anyway.
+		ring := p.Coordinates[0]
+		if len(ring) > 1 && ring[0] == ring[len(ring)-1] {
+			// GeoJSON rings are closed.
+			ring = ring[:len(ring)-1]
+		}
+		polygon := &dssmodels.GeoPolygon{}
+		for _, pos := range ring {
+			polygon.Vertices = append(polygon.Vertices, &dssmodels.LatLngPoint{Lat: pos[1], Lng: pos[0]})
+		}
+		vol3.Footprint = polygon
+	case p.Type == projectionCircle:
+		if p.Center == nil {
+			return nil, stacktrace.NewError("Missing circle center")
+		}
+		vol3.Footprint = &dssmodels.GeoCircle{
+			Center:      dssmodels.LatLngPoint{Lat: p.Center[1], Lng: p.Center[0]},
+			RadiusMeter: float32(p.Radius),
+		}
+	default:
+		return nil, stacktrace.NewError("Unsupported horizontalProjection type `%s`", p.Type)
+	}
+	return vol3, nil
+}
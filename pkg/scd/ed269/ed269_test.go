@@ -0,0 +1,151 @@
+package ed269
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	dssmodels "github.com/interuss/dss/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+const zonesJSON = `{
+  "title": "Test zones",
+  "features": [
+    {
+      "identifier": "ZONE1",
+      "country": "CHE",
+      "name": "Permanent polygon",
+      "restriction": "PROHIBITED",
+      "applicability": [{"permanent": "YES"}],
+      "geometry": [{
+        "uomDimensions": "FT",
+        "lowerLimit": 0,
+        "lowerVerticalReference": "AGL",
+        "upperLimit": 500,
+        "upperVerticalReference": "AGL",
+        "horizontalProjection": {
+          "type": "Polygon",
+          "coordinates": [[[7.47, 46.95], [7.48, 46.95], [7.48, 46.96], [7.47, 46.96], [7.47, 46.95]]]
+        }
+      }]
+    },
+    {
+      "identifier": "ZONE2",
+      "country": "CHE",
+      "name": "Temporary circle",
+      "applicability": [{
+        "permanent": "NO",
+        "startDateTime": "2030-01-01T08:00:00Z",
+        "endDateTime": "2030-01-02T18:00:00Z",
+        "schedule": [{"day": ["MON"], "startTime": "08:00:00", "endTime": "18:00:00"}]
+      }],
+      "geometry": [{
+        "uomDimensions": "M",
+        "lowerLimit": 400,
+        "lowerVerticalReference": "AMSL",
+        "upperLimit": 600,
+        "upperVerticalReference": "AMSL",
+        "horizontalProjection": {"type": "Circle", "center": [7.45, 46.94], "radius": 250}
+      }]
+    },
+    {
+      "identifier": "ZONE3",
+      "country": "CHE",
+      "name": "Expired",
+      "applicability": [{
+        "permanent": "NO",
+        "startDateTime": "2020-01-01T00:00:00Z",
+        "endDateTime": "2020-01-02T00:00:00Z"
+      }],
+      "geometry": [{
+        "uomDimensions": "M",
+        "horizontalProjection": {"type": "Circle", "center": [7.45, 46.94], "radius": 250}
+      }]
+    }
+  ]
+}`
+
+var testOptions = Options{
+	Now:                time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	Horizon:            24 * time.Hour,
+	MaxGroundElevation: 1000,
+}
+
+func parseTestZones(t *testing.T) *ZoneCollection {
+	zones, err := Parse(strings.NewReader(zonesJSON))
+	require.NoError(t, err)
+	require.Len(t, zones.Features, 3)
+	return zones
+}
+
+func TestParseRejectsDuplicates(t *testing.T) {
+	_, err := Parse(strings.NewReader(`{"features": [
+		{"identifier": "A", "country": "CHE"},
+		{"identifier": "A", "country": "CHE"}
+	]}`))
+	require.Error(t, err)
+
+	_, err = Parse(strings.NewReader(`{"features": [{"country": "CHE"}]}`))
+	require.Error(t, err)
+}
+
+func TestPermanentPolygonExtents(t *testing.T) {
+	zone := parseTestZones(t).Features[0]
+	extents, openStart, openEnd, err := zone.Extents(testOptions)
+	require.NoError(t, err)
+	require.True(t, openStart)
+	require.True(t, openEnd)
+	require.Len(t, extents, 1)
+
+	e := extents[0]
+	require.Equal(t, testOptions.Now, *e.StartTime)
+	require.Equal(t, testOptions.Now.Add(testOptions.Horizon), *e.EndTime)
+	// The lower limit relative to the ground is dropped, and the upper one is
+	// raised by the maximum ground elevation.
+	require.Nil(t, e.SpatialVolume.AltitudeLo)
+	require.InDelta(t, 500*0.3048+1000, *e.SpatialVolume.AltitudeHi, 0.01)
+
+	polygon, ok := e.SpatialVolume.Footprint.(*dssmodels.GeoPolygon)
+	require.True(t, ok)
+	require.Len(t, polygon.Vertices, 4)
+	require.Equal(t, &dssmodels.LatLngPoint{Lat: 46.95, Lng: 7.47}, polygon.Vertices[0])
+}
+
+func TestTemporaryCircleExtents(t *testing.T) {
+	zone := parseTestZones(t).Features[1]
+	extents, openStart, openEnd, err := zone.Extents(testOptions)
+	require.NoError(t, err)
+	require.False(t, openStart)
+	require.False(t, openEnd)
+	require.Len(t, extents, 1)
+
+	e := extents[0]
+	require.Equal(t, time.Date(2030, 1, 1, 8, 0, 0, 0, time.UTC), *e.StartTime)
+	require.Equal(t, time.Date(2030, 1, 2, 18, 0, 0, 0, time.UTC), *e.EndTime)
+	require.Equal(t, float32(400), *e.SpatialVolume.AltitudeLo)
+	require.Equal(t, float32(600), *e.SpatialVolume.AltitudeHi)
+	require.Equal(t, &dssmodels.GeoCircle{
+		Center:      dssmodels.LatLngPoint{Lat: 46.94, Lng: 7.45},
+		RadiusMeter: 250,
+	}, e.SpatialVolume.Footprint)
+}
+
+func TestExpiredZoneHasNoExtents(t *testing.T) {
+	zone := parseTestZones(t).Features[2]
+	extents, _, _, err := zone.Extents(testOptions)
+	require.NoError(t, err)
+	require.Empty(t, extents)
+}
+
+func TestInvalidVolume(t *testing.T) {
+	zone := &UASZoneVersion{
+		Identifier: "BAD",
+		Geometry: []*AirspaceVolume{{
+			UomDimensions:        "NM",
+			HorizontalProjection: &HorizontalProjection{Type: projectionCircle, Center: &[2]float64{0, 0}, Radius: 1},
+		}},
+	}
+	_, _, _, err := zone.Extents(testOptions)
+	require.Error(t, err)
+}
@@ -0,0 +1,249 @@
+package ed269
+
+import (
+	"context"
+	"sort"
+
+	"github.com/golang/geo/s2"
+	"github.com/google/uuid"
+	"github.com/interuss/dss/pkg/api/v1/bulkpb"
+	"github.com/interuss/dss/pkg/api/v1/scdpb"
+	"github.com/interuss/dss/pkg/auth"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	"github.com/interuss/dss/pkg/scd"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/stacktrace"
+	"go.uber.org/zap"
+)
+
+var (
+	// idNamespace is the namespace of the name-based UUIDs identifying the
+	// Constraints corresponding to zones.
+	idNamespace = uuid.MustParse("4f2dc1f6-9b0c-4b43-9d07-3cf1e0d3b8a7")
+)
+
+// ConstraintID returns the ID of the Constraint corresponding to the zone
+// identified by "key" when imported by "manager". The ID is the same on every
+// import, so that re-importing a zone updates its Constraint.
+func ConstraintID(manager dssmodels.Manager, key string) dssmodels.ID {
+	return dssmodels.ID(uuid.NewSHA1(idNamespace, []byte(manager.String()+"/"+key)).String())
+}
+
+// Importer synchronizes the Constraints managed by Manager with a set of
+// zones. Manager must be dedicated to the import: its Constraints which do not
+// correspond to an imported zone are deleted.
+type Importer struct {
+	Server  *scd.Server
+	Manager dssmodels.Manager
+	// USSBaseURL is the base URL of the USS serving the details of the
+	// Constraints.
+	USSBaseURL string
+	Options    Options
+	Logger     *zap.Logger
+}
+
+// Result counts the Constraints changed by an import.
+type Result struct {
+	Created   int
+	Updated   int
+	Unchanged int
+	Deleted   int
+}
+
+// upsert is the creation (if ovn is empty) or update of a Constraint.
+type upsert struct {
+	key    string
+	id     dssmodels.ID
+	ovn    scdmodels.OVN
+	params *scdpb.PutConstraintReferenceParameters
+}
+
+// plan lists the changes needed to synchronize the Constraints with the
+// zones.
+type plan struct {
+	upserts   []*upsert
+	deletes   []*scdmodels.Constraint
+	unchanged int
+}
+
+// Import synchronizes the Constraints of the importer's manager with zones:
+// Constraints are created or updated for the zones which still apply, and
+// deleted otherwise. All zones are converted before any change is made, and
+// all changes are applied in a single transaction, so that an invalid zone or
+// a failed change leaves the Constraints untouched.
+func (im *Importer) Import(ctx context.Context, zones []*UASZoneVersion) (*Result, error) {
+	ctx = auth.ContextWithOwner(ctx, dssmodels.Owner(im.Manager))
+
+	repo, err := im.Server.Store.Interact(ctx)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Unable to interact with store")
+	}
+	existing, err := repo.ListConstraintsByManager(ctx, im.Manager)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Unable to list Constraints of %s", im.Manager)
+	}
+	p, err := im.plan(zones, existing)
+	if err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+
+	result := &Result{Unchanged: p.unchanged}
+	if len(p.upserts) == 0 && len(p.deletes) == 0 {
+		return result, nil
+	}
+
+	// An updated Constraint which changed since it was listed fails the check
+	// of its OVN, which aborts the whole import.
+	req := &bulkpb.BulkChangeConstraintReferencesRequest{}
+	for _, u := range p.upserts {
+		req.Changes = append(req.Changes, &bulkpb.ConstraintReferenceChange{
+			Entityid: u.id.String(),
+			Ovn:      u.ovn.String(),
+			Params:   u.params,
+		})
+	}
+	for _, c := range p.deletes {
+		req.Changes = append(req.Changes, &bulkpb.ConstraintReferenceChange{
+			Entityid: c.ID.String(),
+			Ovn:      c.OVN.String(),
+			Delete:   true,
+		})
+	}
+	if _, err := im.Server.BulkChangeConstraintReferences(ctx, req); err != nil {
+		return result, stacktrace.Propagate(err, "Unable to change the Constraints of %s", im.Manager)
+	}
+
+	for _, u := range p.upserts {
+		if u.ovn == "" {
+			result.Created++
+			im.Logger.Info("created constraint", zap.String("zone", u.key), zap.String("id", u.id.String()))
+		} else {
+			result.Updated++
+			im.Logger.Info("updated constraint", zap.String("zone", u.key), zap.String("id", u.id.String()))
+		}
+	}
+	for _, c := range p.deletes {
+		result.Deleted++
+		im.Logger.Info("deleted constraint", zap.String("id", c.ID.String()))
+	}
+	return result, nil
+}
+
+func (im *Importer) plan(zones []*UASZoneVersion, existing []*scdmodels.Constraint) (*plan, error) {
+	var (
+		p       = &plan{}
+		current = map[dssmodels.ID]*scdmodels.Constraint{}
+		kept    = map[dssmodels.ID]bool{}
+	)
+	for _, c := range existing {
+		current[c.ID] = c
+	}
+
+	for _, z := range zones {
+		extents, openStart, openEnd, err := z.Extents(im.Options)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Unable to convert zone %s", z.Key())
+		}
+		if len(extents) == 0 {
+			// The zone no longer applies.
+			continue
+		}
+		id := ConstraintID(im.Manager, z.Key())
+		kept[id] = true
+
+		old := current[id]
+		if old != nil {
+			upToDate, err := im.upToDate(old, extents, openStart, openEnd)
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "Unable to compare zone %s", z.Key())
+			}
+			if upToDate {
+				p.unchanged++
+				continue
+			}
+		}
+
+		params := &scdpb.PutConstraintReferenceParameters{UssBaseUrl: im.USSBaseURL}
+		for _, e := range extents {
+			vol4, err := e.ToSCDProto()
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "Unable to convert extent of zone %s", z.Key())
+			}
+			params.Extents = append(params.Extents, vol4)
+		}
+		u := &upsert{key: z.Key(), id: id, params: params}
+		if old != nil {
+			u.ovn = old.OVN
+		}
+		p.upserts = append(p.upserts, u)
+	}
+
+	for _, c := range existing {
+		if !kept[c.ID] {
+			p.deletes = append(p.deletes, c)
+		}
+	}
+	return p, nil
+}
+
+// upToDate returns whether Constraint "old" already covers "extents". When
+// the start of the extents is open, an earlier start is accepted. When their
+// end is open, an end at least half the horizon away is accepted, so that the
+// Constraint is only extended by an import every so often.
+func (im *Importer) upToDate(old *scdmodels.Constraint, extents []*dssmodels.Volume4D, openStart bool, openEnd bool) (bool, error) {
+	union, err := dssmodels.UnionVolumes4D(extents...)
+	if err != nil {
+		return false, stacktrace.Propagate(err, "Error unioning extents")
+	}
+	cells, err := union.CalculateSpatialCovering()
+	if err != nil {
+		return false, stacktrace.Propagate(err, "Error calculating covering")
+	}
+
+	switch {
+	case old.USSBaseURL != im.USSBaseURL,
+		!equalAltitudes(old.AltitudeLower, union.SpatialVolume.AltitudeLo),
+		!equalAltitudes(old.AltitudeUpper, union.SpatialVolume.AltitudeHi),
+		!equalCells(old.Cells, cells),
+		old.StartTime == nil || old.EndTime == nil:
+		return false, nil
+	}
+	if openStart {
+		if old.StartTime.After(*union.StartTime) {
+			return false, nil
+		}
+	} else if !old.StartTime.Equal(*union.StartTime) {
+		return false, nil
+	}
+	if openEnd {
+		if old.EndTime.Before(im.Options.Now.Add(im.Options.Horizon / 2)) {
+			return false, nil
+		}
+	} else if !old.EndTime.Equal(*union.EndTime) {
+		return false, nil
+	}
+	return true, nil
+}
+
+func equalAltitudes(a, b *float32) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func equalCells(a, b s2.CellUnion) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append(s2.CellUnion(nil), a...)
+	b = append(s2.CellUnion(nil), b...)
+	sort.Slice(a, func(i, j int) bool { return a[i] < a[j] })
+	sort.Slice(b, func(i, j int) bool { return b[i] < b[j] })
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
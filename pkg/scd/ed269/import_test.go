@@ -0,0 +1,260 @@
+package ed269
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/coreos/go-semver/semver"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	"github.com/interuss/dss/pkg/scd"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/dss/pkg/scd/repos"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// constraintStore is an in-memory scdstore.Store holding only Constraints.
+// Transact applies the changes of f only if f succeeds.
+type constraintStore struct {
+	constraints  map[dssmodels.ID]*scdmodels.Constraint
+	transactions int
+	// failDelete, if set, fails the deletion of any Constraint.
+	failDelete bool
+}
+
+func (s *constraintStore) Interact(ctx context.Context) (repos.Repository, error) {
+	return &constraintRepo{store: s, constraints: s.constraints}, nil
+}
+
+func (s *constraintStore) Transact(ctx context.Context, f func(context.Context, repos.Repository) error) error {
+	s.transactions++
+	constraints := map[dssmodels.ID]*scdmodels.Constraint{}
+	for id, c := range s.constraints {
+		constraints[id] = c
+	}
+	if err := f(ctx, &constraintRepo{store: s, constraints: constraints}); err != nil {
+		return err
+	}
+	s.constraints = constraints
+	return nil
+}
+
+func (s *constraintStore) TransactReadOnly(ctx context.Context, f func(context.Context, repos.Repository) error) error {
+	return f(ctx, &constraintRepo{store: s, constraints: s.constraints})
+}
+
+func (s *constraintStore) Close() error {
+	return nil
+}
+
+func (s *constraintStore) GetVersion(ctx context.Context) (*semver.Version, error) {
+	return semver.New("3.13.0"), nil
+}
+
+// constraintRepo is the repos.Repository of a constraintStore. The methods it
+// does not implement panic.
+type constraintRepo struct {
+	repos.Repository
+	store       *constraintStore
+	constraints map[dssmodels.ID]*scdmodels.Constraint
+}
+
+func (r *constraintRepo) ListConstraintsByManager(ctx context.Context, manager dssmodels.Manager) ([]*scdmodels.Constraint, error) {
+	var result []*scdmodels.Constraint
+	for _, c := range r.constraints {
+		if c.Manager == manager {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+func (r *constraintRepo) GetConstraint(ctx context.Context, id dssmodels.ID) (*scdmodels.Constraint, error) {
+	c, ok := r.constraints[id]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+	return c, nil
+}
+
+func (r *constraintRepo) UpsertConstraint(ctx context.Context, c *scdmodels.Constraint) (*scdmodels.Constraint, error) {
+	upserted := *c
+	upserted.OVN = scdmodels.OVN(fmt.Sprintf("%s-%d", c.ID, c.Version))
+	r.constraints[c.ID] = &upserted
+	return &upserted, nil
+}
+
+func (r *constraintRepo) DeleteConstraint(ctx context.Context, id dssmodels.ID) error {
+	if r.store.failDelete {
+		return errors.New("deletion failed")
+	}
+	delete(r.constraints, id)
+	return nil
+}
+
+func (r *constraintRepo) SearchSubscriptions(ctx context.Context, v4d *dssmodels.Volume4D) ([]*scdmodels.Subscription, error) {
+	return nil, nil
+}
+
+func (r *constraintRepo) IncrementNotificationIndices(ctx context.Context, ids []dssmodels.ID) ([]int, error) {
+	return make([]int, len(ids)), nil
+}
+
+func (r *constraintRepo) InsertAuditRecord(ctx context.Context, record *dssmodels.AuditRecord) error {
+	return nil
+}
+
+func (r *constraintRepo) InsertSubscriptionEvents(ctx context.Context, events []*dssmodels.SubscriptionEvent) error {
+	return nil
+}
+
+// constraintFromUpsert returns the Constraint resulting from u.
+func constraintFromUpsert(t *testing.T, u *upsert, manager dssmodels.Manager) *scdmodels.Constraint {
+	var extents []*dssmodels.Volume4D
+	for _, e := range u.params.Extents {
+		vol4, err := dssmodels.Volume4DFromSCDProto(e)
+		require.NoError(t, err)
+		extents = append(extents, vol4)
+	}
+	union, err := dssmodels.UnionVolumes4D(extents...)
+	require.NoError(t, err)
+	cells, err := union.CalculateSpatialCovering()
+	require.NoError(t, err)
+	return &scdmodels.Constraint{
+		ID:            u.id,
+		Manager:       manager,
+		OVN:           "ovn",
+		StartTime:     union.StartTime,
+		EndTime:       union.EndTime,
+		USSBaseURL:    u.params.UssBaseUrl,
+		AltitudeLower: union.SpatialVolume.AltitudeLo,
+		AltitudeUpper: union.SpatialVolume.AltitudeHi,
+		Cells:         cells,
+	}
+}
+
+func TestConstraintIDIsStable(t *testing.T) {
+	require.Equal(t, ConstraintID("manager", "CHE/ZONE1"), ConstraintID("manager", "CHE/ZONE1"))
+	require.NotEqual(t, ConstraintID("manager", "CHE/ZONE1"), ConstraintID("manager", "CHE/ZONE2"))
+	require.NotEqual(t, ConstraintID("manager", "CHE/ZONE1"), ConstraintID("other", "CHE/ZONE1"))
+	_, err := dssmodels.IDFromString(ConstraintID("manager", "CHE/ZONE1").String())
+	require.NoError(t, err)
+}
+
+func TestPlan(t *testing.T) {
+	zones := parseTestZones(t).Features
+	im := &Importer{
+		Manager:    "manager",
+		USSBaseURL: "https://uss.example.com",
+		Options:    testOptions,
+	}
+
+	// Initial import: the expired zone is skipped.
+	p, err := im.plan(zones, nil)
+	require.NoError(t, err)
+	require.Len(t, p.upserts, 2)
+	require.Empty(t, p.deletes)
+	for _, u := range p.upserts {
+		require.Empty(t, u.ovn)
+	}
+
+	// Re-import of the same zones: nothing changes.
+	var existing []*scdmodels.Constraint
+	for _, u := range p.upserts {
+		existing = append(existing, constraintFromUpsert(t, u, im.Manager))
+	}
+	p, err = im.plan(zones, existing)
+	require.NoError(t, err)
+	require.Empty(t, p.upserts)
+	require.Empty(t, p.deletes)
+	require.Equal(t, 2, p.unchanged)
+
+	// Later re-import: open-ended zones are extended once half the horizon
+	// elapsed.
+	later := *im
+	later.Options.Now = testOptions.Now.Add(testOptions.Horizon * 3 / 4)
+	p, err = later.plan(zones, existing)
+	require.NoError(t, err)
+	require.Len(t, p.upserts, 1)
+	require.Equal(t, ConstraintID(im.Manager, "CHE/ZONE1"), p.upserts[0].id)
+	require.Equal(t, scdmodels.OVN("ovn"), p.upserts[0].ovn)
+
+	// Changed zone and removed zone.
+	zones[1].Geometry[0].HorizontalProjection.Radius = 500
+	p, err = im.plan(zones[1:], existing)
+	require.NoError(t, err)
+	require.Len(t, p.upserts, 1)
+	require.Equal(t, ConstraintID(im.Manager, "CHE/ZONE2"), p.upserts[0].id)
+	require.Len(t, p.deletes, 1)
+	require.Equal(t, ConstraintID(im.Manager, "CHE/ZONE1"), p.deletes[0].ID)
+}
+
+func TestPlanRejectsInvalidZone(t *testing.T) {
+	im := &Importer{Manager: "manager", Options: testOptions}
+	_, err := im.plan([]*UASZoneVersion{{Identifier: "EMPTY", Country: "CHE"}}, nil)
+	require.Error(t, err)
+}
+
+func TestImportAppliesChangesInSingleTransaction(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		zones = parseTestZones(t).Features
+		stale = &scdmodels.Constraint{
+			ID:      ConstraintID("manager", "CHE/REMOVED"),
+			Manager: "manager",
+			OVN:     "stale",
+		}
+		other = &scdmodels.Constraint{
+			ID:      ConstraintID("other", "CHE/ZONE1"),
+			Manager: "other",
+			OVN:     "other",
+		}
+	)
+	newImporter := func(store *constraintStore) *Importer {
+		return &Importer{
+			Server:     &scd.Server{Store: store},
+			Manager:    "manager",
+			USSBaseURL: "https://uss.example.com",
+			Options:    testOptions,
+			Logger:     zap.NewNop(),
+		}
+	}
+	newStore := func() *constraintStore {
+		return &constraintStore{constraints: map[dssmodels.ID]*scdmodels.Constraint{
+			stale.ID: stale,
+			other.ID: other,
+		}}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		store := newStore()
+		result, err := newImporter(store).Import(ctx, zones)
+		require.NoError(t, err)
+		require.Equal(t, &Result{Created: 2, Deleted: 1}, result)
+		require.Equal(t, 1, store.transactions)
+		require.Len(t, store.constraints, 3)
+		require.NotContains(t, store.constraints, stale.ID)
+		require.Contains(t, store.constraints, other.ID)
+
+		// Re-import of the same zones: no transaction is needed.
+		result, err = newImporter(store).Import(ctx, zones)
+		require.NoError(t, err)
+		require.Equal(t, &Result{Unchanged: 2}, result)
+		require.Equal(t, 1, store.transactions)
+	})
+
+	t.Run("failed change", func(t *testing.T) {
+		store := newStore()
+		store.failDelete = true
+		_, err := newImporter(store).Import(ctx, zones)
+		require.Error(t, err)
+		require.Equal(t, 1, store.transactions)
+		// The Constraints created before the failed deletion are rolled back.
+		require.Len(t, store.constraints, 2)
+		require.Contains(t, store.constraints, stale.ID)
+		require.Contains(t, store.constraints, other.ID)
+	})
+}
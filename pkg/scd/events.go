@@ -0,0 +1,26 @@
+package scd
+
+import (
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/dss/pkg/scd/repos"
+)
+
+// subscriptionEvents returns the SubscriptionEvents of the change of the
+// entity of type "entityType" identified by "id" to each of "subs", whose
+// notification indices were incremented by the change. "ovn" is the OVN of
+// the entity after the change, or empty if the change deleted the entity.
+func subscriptionEvents(subs repos.Subscriptions, entityType dssmodels.AuditEntityType, id dssmodels.ID, ovn scdmodels.OVN) []*dssmodels.SubscriptionEvent {
+	events := make([]*dssmodels.SubscriptionEvent, len(subs))
+	for i, sub := range subs {
+		events[i] = &dssmodels.SubscriptionEvent{
+			SubscriptionID:    sub.ID,
+			NotificationIndex: sub.NotificationIndex,
+			EntityType:        entityType,
+			EntityID:          id.String(),
+			Version:           ovn.String(),
+			Deleted:           ovn == "",
+		}
+	}
+	return events
+}
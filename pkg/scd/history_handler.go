@@ -0,0 +1,61 @@
+package scd
+
+import (
+	"context"
+
+	"github.com/interuss/dss/pkg/api/v1/historypb"
+	"github.com/interuss/dss/pkg/auth"
+	dsserr "github.com/interuss/dss/pkg/errors"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	"github.com/interuss/dss/pkg/scd/repos"
+	"github.com/interuss/stacktrace"
+	tspb "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetOperationalIntentStateHistory returns the state transitions of an
+// OperationalIntent of the caller, which remain available after its deletion.
+func (a *Server) GetOperationalIntentStateHistory(ctx context.Context, req *historypb.GetOperationalIntentStateHistoryRequest) (*historypb.GetOperationalIntentStateHistoryResponse, error) {
+	id, err := dssmodels.IDFromString(req.GetEntityid())
+	if err != nil {
+		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Invalid ID format: `%s`", req.GetEntityid())
+	}
+
+	manager, ok := auth.ManagerFromContext(ctx)
+	if !ok {
+		return nil, stacktrace.NewErrorWithCode(dsserr.PermissionDenied, "Missing manager from context")
+	}
+
+	var response *historypb.GetOperationalIntentStateHistoryResponse
+	action := func(ctx context.Context, r repos.Repository) (err error) {
+		transitions, err := r.ListStateTransitions(ctx, id)
+		if err != nil {
+			return stacktrace.Propagate(err, "Unable to list OperationalIntent state transitions from repo")
+		}
+		if len(transitions) == 0 {
+			return stacktrace.NewErrorWithCode(dsserr.NotFound, "No state history for OperationalIntent %s", id)
+		}
+
+		response = &historypb.GetOperationalIntentStateHistoryResponse{}
+		for _, t := range transitions {
+			if t.Manager != manager {
+				return stacktrace.NewErrorWithCode(dsserr.PermissionDenied,
+					"OperationalIntent owned by %s, but %s attempted to view its state history", t.Manager, manager)
+			}
+			response.StateTransitions = append(response.StateTransitions, &historypb.StateTransition{
+				FromState:  t.From.String(),
+				ToState:    t.To.String(),
+				Version:    int32(t.Version),
+				RecordedAt: tspb.New(t.RecordedAt),
+			})
+		}
+
+		return nil
+	}
+
+	err = a.Store.TransactReadOnly(ctx, action)
+	if err != nil {
+		return nil, err // No need to Propagate this error as this is not a useful stacktrace line
+	}
+
+	return response, nil
+}
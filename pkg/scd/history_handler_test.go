@@ -0,0 +1,54 @@
+package scd
+
+import (
+	"testing"
+
+	"github.com/interuss/dss/pkg/api/v1/historypb"
+	dsserr "github.com/interuss/dss/pkg/errors"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/stacktrace"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOperationalIntentStateHistory(t *testing.T) {
+	const id = "00000000-0000-4000-8000-000000000001"
+	var (
+		store = newMemoryStore()
+		a     = &Server{Store: store}
+		ctx   = strategicCoordinationContext("uss1")
+	)
+
+	// Create an OperationalIntent, then declare it Nonconforming.
+	response, err := a.PutOperationalIntentReference(ctx, id, "", testOperationalIntentParams(t, scdmodels.OperationalIntentStateAccepted))
+	require.NoError(t, err)
+	_, err = a.PutOperationalIntentReference(ctx, id, response.OperationalIntentReference.Ovn, testOperationalIntentParams(t, scdmodels.OperationalIntentStateNonconforming))
+	require.NoError(t, err)
+
+	history, err := a.GetOperationalIntentStateHistory(ctx, &historypb.GetOperationalIntentStateHistoryRequest{Entityid: id})
+	require.NoError(t, err)
+	require.Len(t, history.StateTransitions, 2)
+	require.Equal(t, "", history.StateTransitions[0].FromState)
+	require.Equal(t, "Accepted", history.StateTransitions[0].ToState)
+	require.Equal(t, int32(1), history.StateTransitions[0].Version)
+	require.Equal(t, "Accepted", history.StateTransitions[1].FromState)
+	require.Equal(t, "Nonconforming", history.StateTransitions[1].ToState)
+	require.Equal(t, int32(2), history.StateTransitions[1].Version)
+
+	for _, tc := range []struct {
+		name     string
+		entityid string
+		manager  dssmodels.Owner
+		code     stacktrace.ErrorCode
+	}{
+		{name: "other manager", entityid: id, manager: "uss2", code: dsserr.PermissionDenied},
+		{name: "unknown OperationalIntent", entityid: "00000000-0000-4000-8000-000000000002", manager: "uss1", code: dsserr.NotFound},
+		{name: "invalid ID", entityid: "not-an-id", manager: "uss1", code: dsserr.BadRequest},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := a.GetOperationalIntentStateHistory(strategicCoordinationContext(tc.manager), &historypb.GetOperationalIntentStateHistoryRequest{Entityid: tc.entityid})
+			require.Error(t, err)
+			require.Equal(t, tc.code, stacktrace.GetCode(err))
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	dssmodels "github.com/interuss/dss/pkg/models"
 	"github.com/interuss/stacktrace"
 	"strings"
+	"time"
 )
 
 // Aggregates constants for uss availability.
@@ -21,6 +22,10 @@ type UssAvailabilityStatus struct {
 	Uss          dssmodels.Manager
 	Availability UssAvailabilityState
 	Version      OVN
+
+	// UpdatedAt is the time the status was written, from which Version is
+	// derived.
+	UpdatedAt time.Time
 }
 
 func (u UssAvailabilityState) String() string {
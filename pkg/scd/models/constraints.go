@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"time"
 
 	"github.com/golang/geo/s2"
@@ -11,6 +12,61 @@ import (
 	tspb "google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// Aggregates constants for constraint categories.
+const (
+	ConstraintCategoryRestricted                 ConstraintCategory = "Restricted"
+	ConstraintCategoryAdvisory                   ConstraintCategory = "Advisory"
+	ConstraintCategoryTemporaryFlightRestriction ConstraintCategory = "TemporaryFlightRestriction"
+)
+
+// ConstraintCategory models the category of a constraint.
+type ConstraintCategory string
+
+func (c ConstraintCategory) String() string {
+	return string(c)
+}
+
+// RequiresAcknowledgement indicates whether the OVNs of the constraints of
+// this category must be in the keys of operational intents intersecting them.
+func (c ConstraintCategory) RequiresAcknowledgement() bool {
+	return c != ConstraintCategoryAdvisory
+}
+
+// ConstraintCategoryFromString parses a constraint category, case
+// insensitively. An empty string denotes ConstraintCategoryRestricted.
+func ConstraintCategoryFromString(s string) (ConstraintCategory, error) {
+	switch strings.ToLower(s) {
+	case "", "restricted":
+		return ConstraintCategoryRestricted, nil
+	case "advisory":
+		return ConstraintCategoryAdvisory, nil
+	case "temporaryflightrestriction":
+		return ConstraintCategoryTemporaryFlightRestriction, nil
+	default:
+		return ConstraintCategoryRestricted, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Invalid constraint category `%s`", s)
+	}
+}
+
+// ConstraintCategoriesFromStrings parses each of ss with
+// ConstraintCategoryFromString, dropping duplicates.
+func ConstraintCategoriesFromStrings(ss []string) ([]ConstraintCategory, error) {
+	var (
+		categories []ConstraintCategory
+		seen       = map[ConstraintCategory]bool{}
+	)
+	for _, s := range ss {
+		category, err := ConstraintCategoryFromString(s)
+		if err != nil {
+			return nil, err // No need to Propagate this error as this stack layer does not add useful information
+		}
+		if !seen[category] {
+			seen[category] = true
+			categories = append(categories, category)
+		}
+	}
+	return categories, nil
+}
+
 // Constraint models a constraint, as known by the DSS
 type Constraint struct {
 	ID              dssmodels.ID
@@ -24,6 +80,13 @@ type Constraint struct {
 	AltitudeLower   *float32
 	AltitudeUpper   *float32
 	Cells           s2.CellUnion
+	Category        ConstraintCategory
+	Authority       string
+	Reason          string
+
+	// UpdatedAt is the time the Constraint was written, from which its OVN is
+	// derived if OVNs are not stored.
+	UpdatedAt time.Time
 }
 
 // ToProto converts the Constraint to its proto API format
@@ -35,6 +98,9 @@ func (c *Constraint) ToProto() (*scdpb.ConstraintReference, error) {
 		Version:         int32(c.Version),
 		UssBaseUrl:      c.USSBaseURL,
 		UssAvailability: UssAvailabilityStateUnknown.String(),
+		Category:        c.Category.String(),
+		Authority:       c.Authority,
+		Reason:          c.Reason,
 	}
 
 	if c.StartTime != nil {
@@ -56,6 +122,20 @@ func (c *Constraint) ToProto() (*scdpb.ConstraintReference, error) {
 	return result, nil
 }
 
+// HasCategory indicates whether the category of c is one of categories, any
+// category matching if categories is empty.
+func (c *Constraint) HasCategory(categories []ConstraintCategory) bool {
+	if len(categories) == 0 {
+		return true
+	}
+	for _, category := range categories {
+		if c.Category == category {
+			return true
+		}
+	}
+	return false
+}
+
 // ValidateTimeRange validates the time range of c.
 func (c *Constraint) ValidateTimeRange() error {
 	if c.StartTime == nil {
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"testing"
+
+	dsserr "github.com/interuss/dss/pkg/errors"
+	"github.com/interuss/stacktrace"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstraintCategoriesFromStrings(t *testing.T) {
+	categories, err := ConstraintCategoriesFromStrings([]string{"", "advisory", "TemporaryFlightRestriction", "Restricted"})
+	require.NoError(t, err)
+	require.Equal(t, []ConstraintCategory{
+		ConstraintCategoryRestricted,
+		ConstraintCategoryAdvisory,
+		ConstraintCategoryTemporaryFlightRestriction,
+	}, categories)
+
+	_, err = ConstraintCategoriesFromStrings([]string{"Prohibited"})
+	require.Equal(t, dsserr.BadRequest, stacktrace.GetCode(err))
+}
+
+func TestNotifiesForConstraint(t *testing.T) {
+	var (
+		restricted = &Constraint{Category: ConstraintCategoryRestricted}
+		advisory   = &Constraint{Category: ConstraintCategoryAdvisory}
+	)
+
+	sub := &Subscription{NotifyForConstraints: true}
+	require.True(t, sub.NotifiesForConstraint(restricted))
+	require.True(t, sub.NotifiesForConstraint(advisory))
+
+	sub.ConstraintCategories = []ConstraintCategory{ConstraintCategoryAdvisory}
+	require.False(t, sub.NotifiesForConstraint(restricted))
+	require.True(t, sub.NotifiesForConstraint(advisory))
+
+	sub.NotifyForConstraints = false
+	require.False(t, sub.NotifiesForConstraint(advisory))
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"crypto/hmac"
+	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
 	"net/url"
@@ -25,10 +27,39 @@ type (
 )
 
 // NewOVNFromTime encodes t as an OVN.
+//
+// Since t is only encoded to the second and the OVN may be recomputed by
+// anyone knowing salt and t, NewOVN should be preferred for new versions. This
+// remains the OVN of the versions stored without one.
 func NewOVNFromTime(t time.Time, salt string) OVN {
 	sum := sha256.Sum256([]byte(salt + t.Format(time.RFC3339)))
+	return encodeOVN(sum[:])
+}
+
+// NewOVN returns a new OVN for the version of the entity identified by salt
+// committed at t, at full precision. The OVN is the HMAC of salt and t under
+// key, or the hash of salt, t and random bytes if key is empty, so that it
+// may not be guessed.
+func NewOVN(key []byte, salt string, t time.Time) (OVN, error) {
+	message := []byte(salt + t.UTC().Format(time.RFC3339Nano))
+	if len(key) > 0 {
+		mac := hmac.New(sha256.New, key)
+		mac.Write(message)
+		return encodeOVN(mac.Sum(nil)), nil
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", stacktrace.Propagate(err, "Error generating random OVN component")
+	}
+	sum := sha256.Sum256(append(nonce, message...))
+	return encodeOVN(sum[:]), nil
+}
+
+// encodeOVN encodes sum as an OVN.
+func encodeOVN(sum []byte) OVN {
 	ovn := base64.StdEncoding.EncodeToString(
-		sum[:],
+		sum,
 	)
 	ovn = strings.Replace(ovn, "+", "-", -1)
 	ovn = strings.Replace(ovn, "/", ".", -1)
@@ -11,3 +11,32 @@ import (
 func TestOVNFromTimeIsValid(t *testing.T) {
 	require.True(t, NewOVNFromTime(time.Now(), uuid.New().String()).Valid())
 }
+
+func TestNewOVN(t *testing.T) {
+	var (
+		id  = uuid.New().String()
+		now = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		key = []byte("secret")
+	)
+
+	ovn, err := NewOVN(key, id, now)
+	require.NoError(t, err)
+	require.True(t, ovn.Valid())
+
+	// OVNs are reproducible with the key, and differ within a second.
+	same, err := NewOVN(key, id, now)
+	require.NoError(t, err)
+	require.Equal(t, ovn, same)
+	later, err := NewOVN(key, id, now.Add(time.Millisecond))
+	require.NoError(t, err)
+	require.NotEqual(t, ovn, later)
+	require.NotEqual(t, NewOVNFromTime(now, id), ovn)
+
+	// Without a key, OVNs are random.
+	random1, err := NewOVN(nil, id, now)
+	require.NoError(t, err)
+	random2, err := NewOVN(nil, id, now)
+	require.NoError(t, err)
+	require.True(t, random1.Valid())
+	require.NotEqual(t, random1, random2)
+}
@@ -18,8 +18,31 @@ const (
 	OperationalIntentStateActivated     OperationalIntentState = "Activated"
 	OperationalIntentStateNonconforming OperationalIntentState = "Nonconforming"
 	OperationalIntentStateContingent    OperationalIntentState = "Contingent"
+
+	// OperationalIntentStateEnded is the state of a deleted OperationalIntent.
+	// It is only used in the state history of OperationalIntents.
+	OperationalIntentStateEnded OperationalIntentState = "Ended"
 )
 
+// allowedTransitions maps each OperationalIntentState to the states an
+// OperationalIntent in that state may be transitioned to via a DSS PUT,
+// following the operational intent state diagram of ASTM F3548-21: an
+// Accepted OperationalIntent may become Activated or off-nominal, an
+// Activated one may become off-nominal, a Nonconforming one may become
+// Activated again or Contingent, and a Contingent one may only end. An
+// OperationalIntent may also be updated without changing its state.
+// OperationalIntentStateUnknown denotes the creation of an OperationalIntent.
+// Since Activated requires a key, a Nonconforming OperationalIntent may only
+// return to a nominal state with a key acknowledging the current state of the
+// airspace.
+var allowedTransitions = map[OperationalIntentState][]OperationalIntentState{
+	OperationalIntentStateUnknown:       {OperationalIntentStateAccepted},
+	OperationalIntentStateAccepted:      {OperationalIntentStateAccepted, OperationalIntentStateActivated, OperationalIntentStateNonconforming, OperationalIntentStateContingent},
+	OperationalIntentStateActivated:     {OperationalIntentStateActivated, OperationalIntentStateNonconforming, OperationalIntentStateContingent},
+	OperationalIntentStateNonconforming: {OperationalIntentStateNonconforming, OperationalIntentStateActivated, OperationalIntentStateContingent},
+	OperationalIntentStateContingent:    {OperationalIntentStateContingent},
+}
+
 // OperationState models the state of an operation.
 type OperationalIntentState string
 
@@ -35,6 +58,12 @@ func (s OperationalIntentState) RequiresKey() bool {
 	return true
 }
 
+// IsOffNominal indicates whether an OperationalIntent in this
+// OperationalIntentState may have an OffNominalVolume.
+func (s OperationalIntentState) IsOffNominal() bool {
+	return s == OperationalIntentStateNonconforming || s == OperationalIntentStateContingent
+}
+
 // IsValid indicates whether an OperationalIntent may be transitioned to the specified
 // state via a DSS PUT.
 func (s OperationalIntentState) IsValidInDSS() bool {
@@ -51,6 +80,21 @@ func (s OperationalIntentState) IsValidInDSS() bool {
 	return false
 }
 
+// ValidateTransition returns an error with code dsserr.InvalidStateTransition
+// if an OperationalIntent in state from (OperationalIntentStateUnknown for a
+// creation) may not be transitioned to state to.
+func ValidateTransition(from, to OperationalIntentState) error {
+	for _, allowed := range allowedTransitions[from] {
+		if to == allowed {
+			return nil
+		}
+	}
+	if from == OperationalIntentStateUnknown {
+		return stacktrace.NewErrorWithCode(dsserr.InvalidStateTransition, "OperationalIntents may not be created in state %s", to)
+	}
+	return stacktrace.NewErrorWithCode(dsserr.InvalidStateTransition, "OperationalIntents may not transition from state %s to state %s", from, to)
+}
+
 // OperationalIntent models an operational intent.
 type OperationalIntent struct {
 	// Reference
@@ -66,6 +110,62 @@ type OperationalIntent struct {
 	AltitudeLower  *float32
 	AltitudeUpper  *float32
 	Cells          s2.CellUnion
+	Priority       int32
+
+	// UpdatedAt is the time the OperationalIntent was written, from which its
+	// OVN is derived if OVNs are not stored.
+	UpdatedAt time.Time
+
+	// OffNominalVolume is the union of the off-nominal volumes of a
+	// Nonconforming or Contingent OperationalIntent, if any.
+	OffNominalVolume *OffNominalVolume
+}
+
+// OffNominalVolume is the union of the volumes containing the anticipated area
+// of non-conformance of an OperationalIntent.
+type OffNominalVolume struct {
+	StartTime     *time.Time
+	EndTime       *time.Time
+	AltitudeLower *float32
+	AltitudeUpper *float32
+	Cells         s2.CellUnion
+}
+
+// ToVolume4D returns the Volume4D covered by v.
+func (v *OffNominalVolume) ToVolume4D() *dssmodels.Volume4D {
+	return &dssmodels.Volume4D{
+		StartTime: v.StartTime,
+		EndTime:   v.EndTime,
+		SpatialVolume: &dssmodels.Volume3D{
+			AltitudeHi: v.AltitudeUpper,
+			AltitudeLo: v.AltitudeLower,
+			Footprint: dssmodels.GeometryFunc(func() (s2.CellUnion, error) {
+				return v.Cells, nil
+			}),
+		}}
+}
+
+// AffectedVolume returns the union of the nominal extent of o and of its
+// OffNominalVolume, if any.
+func (o *OperationalIntent) AffectedVolume() (*dssmodels.Volume4D, error) {
+	volumes := []*dssmodels.Volume4D{{
+		StartTime: o.StartTime,
+		EndTime:   o.EndTime,
+		SpatialVolume: &dssmodels.Volume3D{
+			AltitudeHi: o.AltitudeUpper,
+			AltitudeLo: o.AltitudeLower,
+			Footprint: dssmodels.GeometryFunc(func() (s2.CellUnion, error) {
+				return o.Cells, nil
+			}),
+		}}}
+	if o.OffNominalVolume != nil {
+		volumes = append(volumes, o.OffNominalVolume.ToVolume4D())
+	}
+	result, err := dssmodels.UnionVolumes4D(volumes...)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error constructing 4D volumes union")
+	}
+	return result, nil
 }
 
 func (s OperationalIntentState) String() string {
@@ -83,6 +183,7 @@ func (o *OperationalIntent) ToProto() (*scdpb.OperationalIntentReference, error)
 		SubscriptionId:  o.SubscriptionID.String(),
 		State:           o.State.String(),
 		UssAvailability: UssAvailabilityStateUnknown.String(),
+		Priority:        o.Priority,
 	}
 
 	if o.StartTime != nil {
@@ -123,6 +224,35 @@ func (o *OperationalIntent) ValidateTimeRange() error {
 	return nil
 }
 
+// ValidateStateTime returns an error with code dsserr.StateOutsideTimeWindow
+// if o may not be in its state at time now. An OperationalIntent may only be
+// Activated between its start and end times.
+func (o *OperationalIntent) ValidateStateTime(now time.Time) error {
+	if o.State != OperationalIntentStateActivated {
+		return nil
+	}
+	if o.StartTime != nil && now.Before(*o.StartTime) {
+		return stacktrace.NewErrorWithCode(dsserr.StateOutsideTimeWindow, "OperationalIntent may not be Activated before its start time %s", o.StartTime.Format(time.RFC3339))
+	}
+	if o.EndTime != nil && now.After(*o.EndTime) {
+		return stacktrace.NewErrorWithCode(dsserr.StateOutsideTimeWindow, "OperationalIntent may not be Activated after its end time %s", o.EndTime.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// StateTransition records a change of the state of an OperationalIntent.
+type StateTransition struct {
+	OperationalIntentID dssmodels.ID
+	Manager             dssmodels.Manager
+	// Version of the OperationalIntent resulting from the transition, or the
+	// deleted version if To is OperationalIntentStateEnded.
+	Version VersionNumber
+	// From is OperationalIntentStateUnknown on creation.
+	From       OperationalIntentState
+	To         OperationalIntentState
+	RecordedAt time.Time
+}
+
 // SetCells is a convenience function that accepts an int64 array and converts
 // to s2.CellUnion.
 // TODO: wrap s2.CellUnion in a custom type that embeds the struct such that
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/geo/s2"
+	dsserr "github.com/interuss/dss/pkg/errors"
+	"github.com/interuss/stacktrace"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTransition(t *testing.T) {
+	for _, tc := range []struct {
+		from, to OperationalIntentState
+		allowed  bool
+	}{
+		{OperationalIntentStateUnknown, OperationalIntentStateAccepted, true},
+		{OperationalIntentStateUnknown, OperationalIntentStateActivated, false},
+		{OperationalIntentStateAccepted, OperationalIntentStateActivated, true},
+		{OperationalIntentStateAccepted, OperationalIntentStateContingent, true},
+		{OperationalIntentStateAccepted, OperationalIntentStateNonconforming, true},
+		{OperationalIntentStateActivated, OperationalIntentStateAccepted, false},
+		{OperationalIntentStateActivated, OperationalIntentStateContingent, true},
+		{OperationalIntentStateNonconforming, OperationalIntentStateActivated, true},
+		{OperationalIntentStateNonconforming, OperationalIntentStateContingent, true},
+		{OperationalIntentStateNonconforming, OperationalIntentStateAccepted, false},
+		{OperationalIntentStateContingent, OperationalIntentStateContingent, true},
+		{OperationalIntentStateContingent, OperationalIntentStateActivated, false},
+		{OperationalIntentStateContingent, OperationalIntentStateAccepted, false},
+		{OperationalIntentStateContingent, OperationalIntentStateNonconforming, false},
+	} {
+		err := ValidateTransition(tc.from, tc.to)
+		if tc.allowed {
+			require.NoError(t, err, "%s -> %s", tc.from, tc.to)
+		} else {
+			require.Error(t, err, "%s -> %s", tc.from, tc.to)
+			require.Equal(t, dsserr.InvalidStateTransition, stacktrace.GetCode(err))
+		}
+	}
+}
+
+func TestValidateStateTime(t *testing.T) {
+	var (
+		start = time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+		end   = start.Add(time.Hour)
+		op    = &OperationalIntent{StartTime: &start, EndTime: &end, State: OperationalIntentStateActivated}
+	)
+
+	require.NoError(t, op.ValidateStateTime(start.Add(time.Minute)))
+	err := op.ValidateStateTime(start.Add(-time.Minute))
+	require.Error(t, err)
+	require.Equal(t, dsserr.StateOutsideTimeWindow, stacktrace.GetCode(err))
+	require.Error(t, op.ValidateStateTime(end.Add(time.Minute)))
+
+	op.State = OperationalIntentStateAccepted
+	require.NoError(t, op.ValidateStateTime(start.Add(-time.Minute)))
+}
+
+func TestAffectedVolume(t *testing.T) {
+	var (
+		start      = time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+		end        = start.Add(time.Hour)
+		offEnd     = end.Add(time.Hour)
+		lower      = float32(10)
+		upper      = float32(100)
+		offUpper   = float32(200)
+		nominal    = s2.CellID(0x89c25a3000000000)
+		offNominal = s2.CellID(0x89c25a5000000000)
+	)
+	op := &OperationalIntent{
+		StartTime:     &start,
+		EndTime:       &end,
+		AltitudeLower: &lower,
+		AltitudeUpper: &upper,
+		Cells:         s2.CellUnion{nominal},
+	}
+
+	v, err := op.AffectedVolume()
+	require.NoError(t, err)
+	require.Equal(t, end, *v.EndTime)
+	require.Equal(t, upper, *v.SpatialVolume.AltitudeHi)
+
+	op.OffNominalVolume = &OffNominalVolume{
+		StartTime:     &start,
+		EndTime:       &offEnd,
+		AltitudeLower: &lower,
+		AltitudeUpper: &offUpper,
+		Cells:         s2.CellUnion{offNominal},
+	}
+	v, err = op.AffectedVolume()
+	require.NoError(t, err)
+	require.Equal(t, start, *v.StartTime)
+	require.Equal(t, offEnd, *v.EndTime)
+	require.Equal(t, lower, *v.SpatialVolume.AltitudeLo)
+	require.Equal(t, offUpper, *v.SpatialVolume.AltitudeHi)
+	cells, err := v.CalculateSpatialCovering()
+	require.NoError(t, err)
+	require.ElementsMatch(t, s2.CellUnion{nominal, offNominal}, cells)
+}
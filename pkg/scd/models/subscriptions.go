@@ -41,6 +41,14 @@ type Subscription struct {
 	NotifyForConstraints        bool
 	ImplicitSubscription        bool
 	Cells                       s2.CellUnion
+
+	// ConstraintCategories restricts the constraints whose changes notify the
+	// Subscription to those of these categories, if not empty.
+	ConstraintCategories []ConstraintCategory
+
+	// UpdatedAt is the time the Subscription was written, from which its
+	// Version is derived if OVNs are not stored.
+	UpdatedAt time.Time
 }
 
 // ToProto converts the Subscription to its proto API format
@@ -55,6 +63,10 @@ func (s *Subscription) ToProto(dependentOperationalIntents []dssmodels.ID) (*scd
 		ImplicitSubscription:        s.ImplicitSubscription,
 	}
 
+	for _, category := range s.ConstraintCategories {
+		result.ConstraintCategories = append(result.ConstraintCategories, category.String())
+	}
+
 	if s.StartTime != nil {
 		ts := tspb.New(*s.StartTime)
 		result.TimeStart = &scdpb.Time{
@@ -78,6 +90,12 @@ func (s *Subscription) ToProto(dependentOperationalIntents []dssmodels.ID) (*scd
 	return result, nil
 }
 
+// NotifiesForConstraint indicates whether changes in constraint trigger
+// notifications to s.
+func (s *Subscription) NotifiesForConstraint(constraint *Constraint) bool {
+	return s.NotifyForConstraints && constraint.HasCategory(s.ConstraintCategories)
+}
+
 // AdjustTimeRange adjusts the time range to the max allowed ranges on a
 // subscription.
 func (s *Subscription) AdjustTimeRange(now time.Time, old *Subscription) error {
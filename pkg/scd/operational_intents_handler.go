@@ -13,6 +13,7 @@ import (
 	scderr "github.com/interuss/dss/pkg/scd/errors"
 	scdmodels "github.com/interuss/dss/pkg/scd/models"
 	"github.com/interuss/dss/pkg/scd/repos"
+	scdstore "github.com/interuss/dss/pkg/scd/store"
 	"github.com/interuss/stacktrace"
 	"google.golang.org/grpc/status"
 )
@@ -34,83 +35,16 @@ func (a *Server) DeleteOperationalIntentReference(ctx context.Context, req *scdp
 
 	var response *scdpb.ChangeOperationalIntentReferenceResponse
 	action := func(ctx context.Context, r repos.Repository) (err error) {
-		// Get OperationalIntent to delete
-		old, err := r.GetOperationalIntent(ctx, id)
-		if err != nil {
-			return stacktrace.Propagate(err, "Unable to get OperationIntent from repo")
-		}
-		if old == nil {
-			return stacktrace.NewErrorWithCode(dsserr.NotFound, "OperationalIntent %s not found", id)
-		}
-
-		// Validate deletion request
-		if old.Manager != manager {
-			return stacktrace.NewErrorWithCode(dsserr.PermissionDenied,
-				"OperationalIntent owned by %s, but %s attempted to delete", old.Manager, manager)
-		}
-
-		// Get the Subscription supporting the OperationalIntent
-		sub, err := r.GetSubscription(ctx, old.SubscriptionID)
-		if err != nil {
-			return stacktrace.Propagate(err, "Unable to get OperationalIntent's Subscription from repo")
-		}
-		if sub == nil {
-			return stacktrace.NewError("OperationalIntent's Subscription missing from repo")
-		}
-
-		removeImplicitSubscription := false
-		if sub.ImplicitSubscription {
-			// Get the Subscription's dependent OperationalIntents
-			dependentOps, err := r.GetDependentOperationalIntents(ctx, sub.ID)
-			if err != nil {
-				return stacktrace.Propagate(err, "Could not find dependent OperationalIntents")
-			}
-			if len(dependentOps) == 0 {
-				return stacktrace.NewError("An implicit Subscription had no dependent OperationalIntents")
-			} else if len(dependentOps) == 1 {
-				removeImplicitSubscription = true
+		old, subs, err := deleteOperationalIntent(ctx, r, id, func(old *scdmodels.OperationalIntent) error {
+			// Validate deletion request
+			if old.Manager != manager {
+				return stacktrace.NewErrorWithCode(dsserr.PermissionDenied,
+					"OperationalIntent owned by %s, but %s attempted to delete", old.Manager, manager)
 			}
-		}
-
-		// Find Subscriptions that may overlap the OperationalIntent's Volume4D
-		allsubs, err := r.SearchSubscriptions(ctx, &dssmodels.Volume4D{
-			StartTime: old.StartTime,
-			EndTime:   old.EndTime,
-			SpatialVolume: &dssmodels.Volume3D{
-				AltitudeHi: old.AltitudeUpper,
-				AltitudeLo: old.AltitudeLower,
-				Footprint: dssmodels.GeometryFunc(func() (s2.CellUnion, error) {
-					return old.Cells, nil
-				}),
-			}})
+			return nil
+		})
 		if err != nil {
-			return stacktrace.Propagate(err, "Unable to search Subscriptions in repo")
-		}
-
-		// Limit Subscription notifications to only those interested in OperationalIntents
-		var subs repos.Subscriptions
-		for _, s := range allsubs {
-			if s.NotifyForOperationalIntents {
-				subs = append(subs, s)
-			}
-		}
-
-		// Increment notification indices for Subscriptions to be notified
-		if err := subs.IncrementNotificationIndices(ctx, r); err != nil {
-			return stacktrace.Propagate(err, "Unable to increment notification indices")
-		}
-
-		// Delete OperationalIntent from repo
-		if err := r.DeleteOperationalIntent(ctx, id); err != nil {
-			return stacktrace.Propagate(err, "Unable to delete OperationalIntent from repo")
-		}
-
-		if removeImplicitSubscription {
-			// Automatically remove a now-unused implicit Subscription
-			err = r.DeleteSubscription(ctx, sub.ID)
-			if err != nil {
-				return stacktrace.Propagate(err, "Unable to delete associated implicit Subscription")
-			}
+			return err // No need to Propagate this error as this is not a useful stacktrace line
 		}
 
 		// Convert deleted OperationalIntent to proto
@@ -136,6 +70,122 @@ func (a *Server) DeleteOperationalIntentReference(ctx context.Context, req *scdp
 	return response, nil
 }
 
+// ForceDeleteOperationalIntent deletes the OperationalIntent identified by id
+// regardless of its manager, for use by DSS operators. It returns the deleted
+// OperationalIntent and the Subscriptions to notify of the deletion.
+func ForceDeleteOperationalIntent(ctx context.Context, store scdstore.Store, id dssmodels.ID) (*scdmodels.OperationalIntent, []*scdmodels.Subscription, error) {
+	var (
+		old  *scdmodels.OperationalIntent
+		subs repos.Subscriptions
+	)
+	err := store.Transact(ctx, func(ctx context.Context, r repos.Repository) (err error) {
+		old, subs, err = deleteOperationalIntent(ctx, r, id, func(*scdmodels.OperationalIntent) error { return nil })
+		return err
+	})
+	if err != nil {
+		return nil, nil, err // No need to Propagate this error as this is not a useful stacktrace line
+	}
+	return old, subs, nil
+}
+
+// deleteOperationalIntent deletes the OperationalIntent identified by id if
+// check accepts it, along with its implicit Subscription when no longer
+// needed, and increments the notification indices of the Subscriptions to
+// notify. It returns the deleted OperationalIntent and these Subscriptions.
+func deleteOperationalIntent(ctx context.Context, r repos.Repository, id dssmodels.ID, check func(old *scdmodels.OperationalIntent) error) (*scdmodels.OperationalIntent, repos.Subscriptions, error) {
+	// Get OperationalIntent to delete
+	old, err := r.GetOperationalIntent(ctx, id)
+	if err != nil {
+		return nil, nil, stacktrace.Propagate(err, "Unable to get OperationIntent from repo")
+	}
+	if old == nil {
+		return nil, nil, stacktrace.NewErrorWithCode(dsserr.NotFound, "OperationalIntent %s not found", id)
+	}
+	if err := check(old); err != nil {
+		return nil, nil, err
+	}
+
+	// Get the Subscription supporting the OperationalIntent
+	sub, err := r.GetSubscription(ctx, old.SubscriptionID)
+	if err != nil {
+		return nil, nil, stacktrace.Propagate(err, "Unable to get OperationalIntent's Subscription from repo")
+	}
+	if sub == nil {
+		return nil, nil, stacktrace.NewError("OperationalIntent's Subscription missing from repo")
+	}
+
+	removeImplicitSubscription := false
+	if sub.ImplicitSubscription {
+		// Get the Subscription's dependent OperationalIntents
+		dependentOps, err := r.GetDependentOperationalIntents(ctx, sub.ID)
+		if err != nil {
+			return nil, nil, stacktrace.Propagate(err, "Could not find dependent OperationalIntents")
+		}
+		if len(dependentOps) == 0 {
+			return nil, nil, stacktrace.NewError("An implicit Subscription had no dependent OperationalIntents")
+		} else if len(dependentOps) == 1 {
+			removeImplicitSubscription = true
+		}
+	}
+
+	// Find Subscriptions that may overlap the OperationalIntent's Volume4D,
+	// including its off-nominal volumes
+	oldVol4, err := old.AffectedVolume()
+	if err != nil {
+		return nil, nil, stacktrace.Propagate(err, "Error computing OperationalIntent volume")
+	}
+	allsubs, err := r.SearchSubscriptions(ctx, oldVol4)
+	if err != nil {
+		return nil, nil, stacktrace.Propagate(err, "Unable to search Subscriptions in repo")
+	}
+
+	// Limit Subscription notifications to only those interested in OperationalIntents
+	var subs repos.Subscriptions
+	for _, s := range allsubs {
+		if s.NotifyForOperationalIntents {
+			subs = append(subs, s)
+		}
+	}
+
+	// Increment notification indices for Subscriptions to be notified
+	if err := subs.IncrementNotificationIndices(ctx, r); err != nil {
+		return nil, nil, stacktrace.Propagate(err, "Unable to increment notification indices")
+	}
+	if err := r.InsertSubscriptionEvents(ctx, subscriptionEvents(subs, dssmodels.AuditEntityOperationalIntent, old.ID, "")); err != nil {
+		return nil, nil, stacktrace.Propagate(err, "Unable to record subscription events")
+	}
+
+	// Delete OperationalIntent from repo
+	if err := r.DeleteOperationalIntent(ctx, id); err != nil {
+		return nil, nil, stacktrace.Propagate(err, "Unable to delete OperationalIntent from repo")
+	}
+	if err := r.InsertAuditRecord(ctx, operationalIntentAuditRecord(dssmodels.AuditDelete, old, nil)); err != nil {
+		return nil, nil, stacktrace.Propagate(err, "Unable to record OperationalIntent deletion in audit log")
+	}
+	if err := r.InsertStateTransition(ctx, &scdmodels.StateTransition{
+		OperationalIntentID: old.ID,
+		Manager:             old.Manager,
+		Version:             old.Version,
+		From:                old.State,
+		To:                  scdmodels.OperationalIntentStateEnded,
+	}); err != nil {
+		return nil, nil, stacktrace.Propagate(err, "Unable to record OperationalIntent state transition")
+	}
+
+	if removeImplicitSubscription {
+		// Automatically remove a now-unused implicit Subscription
+		err = r.DeleteSubscription(ctx, sub.ID)
+		if err != nil {
+			return nil, nil, stacktrace.Propagate(err, "Unable to delete associated implicit Subscription")
+		}
+		if err := r.InsertAuditRecord(ctx, subscriptionAuditRecord(dssmodels.AuditDelete, sub, nil)); err != nil {
+			return nil, nil, stacktrace.Propagate(err, "Unable to record implicit Subscription deletion in audit log")
+		}
+	}
+
+	return old, subs, nil
+}
+
 // GetOperationalIntentReference returns a single operation intent ref for the given ID.
 func (a *Server) GetOperationalIntentReference(ctx context.Context, req *scdpb.GetOperationalIntentReferenceRequest) (*scdpb.GetOperationalIntentReferenceResponse, error) {
 	id, err := dssmodels.IDFromString(req.GetEntityid())
@@ -174,7 +224,7 @@ func (a *Server) GetOperationalIntentReference(ctx context.Context, req *scdpb.G
 		return nil
 	}
 
-	err = a.Store.Transact(ctx, action)
+	err = a.Store.TransactReadOnly(ctx, action)
 	if err != nil {
 		return nil, err // No need to Propagate this error as this is not a useful stacktrace line
 	}
@@ -227,7 +277,7 @@ func (a *Server) QueryOperationalIntentReferences(ctx context.Context, req *scdp
 		return nil
 	}
 
-	err = a.Store.Transact(ctx, action)
+	err = a.Store.TransactReadOnly(ctx, action)
 	if err != nil {
 		return nil, err // No need to Propagate this error as this is not a useful stacktrace line
 	}
@@ -246,17 +296,72 @@ func (a *Server) UpdateOperationalIntentReference(ctx context.Context, req *scdp
 // PutOperationalIntentReference inserts or updates an Operational Intent.
 // If the ovn argument is empty (""), it will attempt to create a new Operational Intent.
 func (a *Server) PutOperationalIntentReference(ctx context.Context, entityid string, ovn string, params *scdpb.PutOperationalIntentReferenceParameters) (*scdpb.ChangeOperationalIntentReferenceResponse, error) {
-	id, err := dssmodels.IDFromString(entityid)
-	if err != nil {
-		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Invalid ID format: `%s`", entityid)
-	}
-
 	// Retrieve ID of client making call
 	manager, ok := auth.ManagerFromContext(ctx)
 	if !ok {
 		return nil, stacktrace.NewErrorWithCode(dsserr.PermissionDenied, "Missing manager from context")
 	}
 
+	u, err := a.validateOperationalIntentUpsert(entityid, ovn, params)
+	if err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	if err := validateStateScope(ctx, u.state); err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+
+	var response *scdpb.ChangeOperationalIntentReferenceResponse
+	action := func(ctx context.Context, r repos.Repository) (err error) {
+		op, _, subs, err := a.upsertOperationalIntent(ctx, r, manager, u, true)
+		if err != nil {
+			return err // No need to Propagate this error as this stack layer does not add useful information
+		}
+
+		// Convert upserted OperationalIntent to proto
+		p, err := op.ToProto()
+		if err != nil {
+			return stacktrace.Propagate(err, "Could not convert OperationalIntent to proto")
+		}
+
+		// Return response to client
+		response = &scdpb.ChangeOperationalIntentReferenceResponse{
+			OperationalIntentReference: p,
+			Subscribers:                makeSubscribersToNotify(subs),
+		}
+
+		return nil
+	}
+
+	err = a.Store.Transact(ctx, action)
+	if err != nil {
+		return nil, err // No need to Propagate this error as this is not a useful stacktrace line
+	}
+
+	return response, nil
+}
+
+// operationalIntentUpsert is a validated request to create or update an
+// OperationalIntent.
+type operationalIntentUpsert struct {
+	id             dssmodels.ID
+	ovn            scdmodels.OVN
+	params         *scdpb.PutOperationalIntentReferenceParameters
+	state          scdmodels.OperationalIntentState
+	extent         *dssmodels.Volume4D
+	cells          s2.CellUnion
+	subscriptionID dssmodels.ID
+	offNominal     *scdmodels.OffNominalVolume
+}
+
+// validateOperationalIntentUpsert validates the parts of a request to create
+// (if ovn is empty) or update an OperationalIntent which do not depend on the
+// content of the repo.
+func (a *Server) validateOperationalIntentUpsert(entityid string, ovn string, params *scdpb.PutOperationalIntentReferenceParameters) (*operationalIntentUpsert, error) {
+	id, err := dssmodels.IDFromString(entityid)
+	if err != nil {
+		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Invalid ID format: `%s`", entityid)
+	}
+
 	var (
 		extents = make([]*dssmodels.Volume4D, len(params.GetExtents()))
 	)
@@ -309,8 +414,19 @@ func (a *Server) PutOperationalIntentReference(ctx context.Context, entityid str
 		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "End time is past the start time")
 	}
 
-	if ovn == "" && params.State != "Accepted" {
-		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Invalid state for initial version: `%s`", params.State)
+	offNominal, err := offNominalVolumeFromProto(state, params.GetOffNominalVolumes())
+	if err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+
+	if params.GetPriority() < 0 {
+		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Invalid priority: %d", params.GetPriority())
+	}
+
+	if ovn == "" {
+		if err := scdmodels.ValidateTransition(scdmodels.OperationalIntentStateUnknown, state); err != nil {
+			return nil, err // No need to Propagate this error as this stack layer does not add useful information
+		}
 	}
 
 	subscriptionID, err := dssmodels.IDFromOptionalString(params.GetSubscriptionId())
@@ -318,248 +434,402 @@ func (a *Server) PutOperationalIntentReference(ctx context.Context, entityid str
 		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Invalid ID format for Subscription ID: `%s`", params.GetSubscriptionId())
 	}
 
-	var response *scdpb.ChangeOperationalIntentReferenceResponse
-	action := func(ctx context.Context, r repos.Repository) (err error) {
-		var version int32 // Version of the Operational Intent (0 means creation requested).
+	return &operationalIntentUpsert{
+		id:             id,
+		ovn:            scdmodels.OVN(ovn),
+		params:         params,
+		state:          state,
+		extent:         uExtent,
+		cells:          cells,
+		subscriptionID: subscriptionID,
+		offNominal:     offNominal,
+	}, nil
+}
+
+// offNominalVolumeFromProto returns the OffNominalVolume of an OperationalIntent
+// in state from its off-nominal volumes, or nil if there are none.
+func offNominalVolumeFromProto(state scdmodels.OperationalIntentState, volumes []*scdpb.Volume4D) (*scdmodels.OffNominalVolume, error) {
+	if len(volumes) == 0 {
+		return nil, nil
+	}
+	if !state.IsOffNominal() {
+		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Off-nominal volumes may not be specified for %s OperationalIntents", state)
+	}
 
-		// Get existing OperationalIntent, if any, and validate request
-		old, err := r.GetOperationalIntent(ctx, id)
+	vol4s := make([]*dssmodels.Volume4D, len(volumes))
+	for idx, volume := range volumes {
+		vol4, err := dssmodels.Volume4DFromSCDProto(volume)
 		if err != nil {
-			return stacktrace.Propagate(err, "Could not get OperationalIntent from repo")
+			return nil, stacktrace.PropagateWithCode(err, dsserr.BadRequest, "Failed to parse off-nominal volume %d", idx)
 		}
-		if old != nil {
-			if old.Manager != manager {
-				return stacktrace.NewErrorWithCode(dsserr.PermissionDenied,
-					"OperationalIntent owned by %s, but %s attempted to modify", old.Manager, manager)
-			}
-			if old.OVN != scdmodels.OVN(ovn) {
-				return stacktrace.NewErrorWithCode(dsserr.VersionMismatch,
-					"Current version is %s but client specified version %s", old.OVN, ovn)
-			}
+		vol4s[idx] = vol4
+	}
+	union, err := dssmodels.UnionVolumes4D(vol4s...)
+	if err != nil {
+		return nil, stacktrace.PropagateWithCode(err, dsserr.BadRequest, "Failed to union off-nominal volumes")
+	}
 
-			version = int32(old.Version)
-		} else {
-			if ovn != "" {
-				return stacktrace.NewErrorWithCode(dsserr.NotFound, "OperationalIntent does not exist and therefore is not version %s", ovn)
-			}
+	if union.StartTime == nil {
+		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Missing time_start from off-nominal volumes")
+	}
+	if union.EndTime == nil {
+		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Missing time_end from off-nominal volumes")
+	}
+	if union.EndTime.Before(*union.StartTime) {
+		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Off-nominal volumes end before they start")
+	}
+	cells, err := union.CalculateSpatialCovering()
+	if err != nil {
+		return nil, stacktrace.PropagateWithCode(err, dsserr.BadRequest, "Invalid off-nominal area")
+	}
+
+	return &scdmodels.OffNominalVolume{
+		StartTime:     union.StartTime,
+		EndTime:       union.EndTime,
+		AltitudeLower: union.SpatialVolume.AltitudeLo,
+		AltitudeUpper: union.SpatialVolume.AltitudeHi,
+		Cells:         cells,
+	}, nil
+}
 
-			version = 0
+// validateStateScope returns an error if the scopes of the caller in ctx do
+// not allow putting an OperationalIntent in state. Off-nominal states may be
+// declared under either utm.strategic_coordination or
+// utm.conformance_monitoring_sa, and nominal states only under
+// utm.strategic_coordination.
+func validateStateScope(ctx context.Context, state scdmodels.OperationalIntentState) error {
+	scopes, ok := auth.ScopesFromContext(ctx)
+	if !ok {
+		return stacktrace.NewErrorWithCode(dsserr.PermissionDenied, "Missing scopes from context")
+	}
+	if _, ok := scopes[strategicCoordinationScope]; ok {
+		return nil
+	}
+	if _, ok := scopes[conformanceMonitoringSAScope]; ok && state.IsOffNominal() {
+		return nil
+	}
+	return stacktrace.NewErrorWithCode(dsserr.PermissionDenied, "Scope %s is required to put an OperationalIntent in state %s", strategicCoordinationScope, state)
+}
+
+// upsertOperationalIntent creates or updates the OperationalIntent requested
+// by manager in u, along with its Subscription, and increments the
+// notification indices of the Subscriptions to notify. If checkKey is false,
+// the key of the request is not validated, which is then the responsibility of
+// the caller. It returns the upserted OperationalIntent, its Subscription and
+// the Subscriptions to notify.
+func (a *Server) upsertOperationalIntent(ctx context.Context, r repos.Repository, manager dssmodels.Manager, u *operationalIntentUpsert, checkKey bool) (*scdmodels.OperationalIntent, *scdmodels.Subscription, repos.Subscriptions, error) {
+	var (
+		version int32 // Version of the Operational Intent (0 means creation requested).
+		params  = u.params
+		uExtent = u.extent
+	)
+
+	// Get existing OperationalIntent, if any, and validate request
+	old, err := r.GetOperationalIntent(ctx, u.id)
+	if err != nil {
+		return nil, nil, nil, stacktrace.Propagate(err, "Could not get OperationalIntent from repo")
+	}
+	if old != nil {
+		if old.Manager != manager {
+			return nil, nil, nil, stacktrace.NewErrorWithCode(dsserr.PermissionDenied,
+				"OperationalIntent owned by %s, but %s attempted to modify", old.Manager, manager)
+		}
+		if old.OVN != u.ovn {
+			return nil, nil, nil, stacktrace.NewErrorWithCode(dsserr.VersionMismatch,
+				"Current version is %s but client specified version %s", old.OVN, u.ovn)
+		}
+		if err := scdmodels.ValidateTransition(old.State, u.state); err != nil {
+			return nil, nil, nil, err // No need to Propagate this error as this stack layer does not add useful information
 		}
 
-		var sub *scdmodels.Subscription
-		if subscriptionID.Empty() {
-			// Create implicit Subscription
-			subBaseURL := params.GetNewSubscription().GetUssBaseUrl()
-			if subBaseURL == "" {
-				return stacktrace.NewErrorWithCode(dsserr.BadRequest, "Missing uss_base_url in new_subscription")
+		version = int32(old.Version)
+	} else {
+		if u.ovn != "" {
+			return nil, nil, nil, stacktrace.NewErrorWithCode(dsserr.NotFound, "OperationalIntent does not exist and therefore is not version %s", u.ovn)
+		}
+
+		if a.Limits.MaxActiveOperationalIntents > 0 {
+			count, err := r.CountActiveOperationalIntentsByManager(ctx, manager)
+			if err != nil {
+				return nil, nil, nil, stacktrace.Propagate(err, "Failed to fetch OperationalIntent count, rejecting request")
 			}
-			if !a.EnableHTTP {
-				err := scdmodels.ValidateUSSBaseURL(subBaseURL)
-				if err != nil {
-					return stacktrace.PropagateWithCode(err, dsserr.BadRequest, "Failed to validate USS base URL")
-				}
+			if count >= a.Limits.MaxActiveOperationalIntents {
+				return nil, nil, nil, stacktrace.Propagate(
+					stacktrace.NewErrorWithCode(dsserr.Exhausted, "Too many active OperationalIntents already"),
+					"%s had %d active OperationalIntents", manager, count)
 			}
+		}
 
-			sub, err = r.UpsertSubscription(ctx, &scdmodels.Subscription{
-				ID:                          dssmodels.ID(uuid.New().String()),
-				Manager:                     manager,
-				StartTime:                   uExtent.StartTime,
-				EndTime:                     uExtent.EndTime,
-				AltitudeLo:                  uExtent.SpatialVolume.AltitudeLo,
-				AltitudeHi:                  uExtent.SpatialVolume.AltitudeHi,
-				Cells:                       cells,
-				USSBaseURL:                  subBaseURL,
-				NotifyForOperationalIntents: true,
-				NotifyForConstraints:        params.GetNewSubscription().GetNotifyForConstraints(),
-				ImplicitSubscription:        true,
-			})
+		version = 0
+	}
+
+	var sub *scdmodels.Subscription
+	if u.subscriptionID.Empty() {
+		// Create implicit Subscription
+		subBaseURL := params.GetNewSubscription().GetUssBaseUrl()
+		if subBaseURL == "" {
+			return nil, nil, nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Missing uss_base_url in new_subscription")
+		}
+		if !a.EnableHTTP {
+			err := scdmodels.ValidateUSSBaseURL(subBaseURL)
 			if err != nil {
-				return stacktrace.Propagate(err, "Failed to create implicit subscription")
+				return nil, nil, nil, stacktrace.PropagateWithCode(err, dsserr.BadRequest, "Failed to validate USS base URL")
 			}
-		} else {
-			// Use existing Subscription
-			sub, err = r.GetSubscription(ctx, subscriptionID)
+		}
+
+		if a.Limits.MaxImplicitSubscriptions > 0 {
+			count, err := r.CountImplicitSubscriptionsByManager(ctx, manager)
 			if err != nil {
-				return stacktrace.Propagate(err, "Unable to get Subscription")
+				return nil, nil, nil, stacktrace.Propagate(err, "Failed to fetch implicit Subscription count, rejecting request")
 			}
-			if sub == nil {
-				return stacktrace.NewErrorWithCode(dsserr.BadRequest, "Specified Subscription %s does not exist", subscriptionID)
+			if count >= a.Limits.MaxImplicitSubscriptions {
+				return nil, nil, nil, stacktrace.Propagate(
+					stacktrace.NewErrorWithCode(dsserr.Exhausted, "Too many active implicit Subscriptions already"),
+					"%s had %d active implicit Subscriptions", manager, count)
 			}
-			if sub.Manager != manager {
-				return stacktrace.Propagate(
-					stacktrace.NewErrorWithCode(dsserr.PermissionDenied, "Specificed Subscription is owned by different client"),
-					"Subscription %s owned by %s, but %s attempted to use it for an OperationalIntent", subscriptionID, sub.Manager, manager)
+		}
+
+		sub, err = r.UpsertSubscription(ctx, &scdmodels.Subscription{
+			ID:                          dssmodels.ID(uuid.New().String()),
+			Manager:                     manager,
+			StartTime:                   uExtent.StartTime,
+			EndTime:                     uExtent.EndTime,
+			AltitudeLo:                  uExtent.SpatialVolume.AltitudeLo,
+			AltitudeHi:                  uExtent.SpatialVolume.AltitudeHi,
+			Cells:                       u.cells,
+			USSBaseURL:                  subBaseURL,
+			NotifyForOperationalIntents: true,
+			NotifyForConstraints:        params.GetNewSubscription().GetNotifyForConstraints(),
+			ImplicitSubscription:        true,
+		})
+		if err != nil {
+			return nil, nil, nil, stacktrace.Propagate(err, "Failed to create implicit subscription")
+		}
+		if err := r.InsertAuditRecord(ctx, subscriptionAuditRecord(dssmodels.AuditCreate, nil, sub)); err != nil {
+			return nil, nil, nil, stacktrace.Propagate(err, "Failed to record implicit subscription creation in audit log")
+		}
+	} else {
+		// Use existing Subscription
+		sub, err = r.GetSubscription(ctx, u.subscriptionID)
+		if err != nil {
+			return nil, nil, nil, stacktrace.Propagate(err, "Unable to get Subscription")
+		}
+		if sub == nil {
+			return nil, nil, nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Specified Subscription %s does not exist", u.subscriptionID)
+		}
+		if sub.Manager != manager {
+			return nil, nil, nil, stacktrace.Propagate(
+				stacktrace.NewErrorWithCode(dsserr.PermissionDenied, "Specificed Subscription is owned by different client"),
+				"Subscription %s owned by %s, but %s attempted to use it for an OperationalIntent", u.subscriptionID, sub.Manager, manager)
+		}
+		oldSub := *sub
+		updateSub := false
+		if sub.StartTime != nil && sub.StartTime.After(*uExtent.StartTime) {
+			if sub.ImplicitSubscription {
+				sub.StartTime = uExtent.StartTime
+				updateSub = true
+			} else {
+				return nil, nil, nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Subscription does not begin until after the OperationalIntent starts")
 			}
-			updateSub := false
-			if sub.StartTime != nil && sub.StartTime.After(*uExtent.StartTime) {
-				if sub.ImplicitSubscription {
-					sub.StartTime = uExtent.StartTime
-					updateSub = true
-				} else {
-					return stacktrace.NewErrorWithCode(dsserr.BadRequest, "Subscription does not begin until after the OperationalIntent starts")
-				}
+		}
+		if sub.EndTime != nil && sub.EndTime.Before(*uExtent.EndTime) {
+			if sub.ImplicitSubscription {
+				sub.EndTime = uExtent.EndTime
+				updateSub = true
+			} else {
+				return nil, nil, nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Subscription ends before the OperationalIntent ends")
 			}
-			if sub.EndTime != nil && sub.EndTime.Before(*uExtent.EndTime) {
-				if sub.ImplicitSubscription {
-					sub.EndTime = uExtent.EndTime
-					updateSub = true
-				} else {
-					return stacktrace.NewErrorWithCode(dsserr.BadRequest, "Subscription ends before the OperationalIntent ends")
-				}
+		}
+		if !sub.Cells.Contains(u.cells) {
+			if sub.ImplicitSubscription {
+				sub.Cells = s2.CellUnionFromUnion(sub.Cells, u.cells)
+				updateSub = true
+			} else {
+				return nil, nil, nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Subscription does not cover entire spatial area of the OperationalIntent")
 			}
-			if !sub.Cells.Contains(cells) {
-				if sub.ImplicitSubscription {
-					sub.Cells = s2.CellUnionFromUnion(sub.Cells, cells)
-					updateSub = true
-				} else {
-					return stacktrace.NewErrorWithCode(dsserr.BadRequest, "Subscription does not cover entire spatial area of the OperationalIntent")
-				}
+		}
+		if updateSub {
+			sub, err = r.UpsertSubscription(ctx, sub)
+			if err != nil {
+				return nil, nil, nil, stacktrace.Propagate(err, "Failed to update existing Subscription")
 			}
-			if updateSub {
-				sub, err = r.UpsertSubscription(ctx, sub)
-				if err != nil {
-					return stacktrace.Propagate(err, "Failed to update existing Subscription")
-				}
+			if err := r.InsertAuditRecord(ctx, subscriptionAuditRecord(dssmodels.AuditUpdate, &oldSub, sub)); err != nil {
+				return nil, nil, nil, stacktrace.Propagate(err, "Failed to record Subscription update in audit log")
 			}
 		}
+	}
 
-		if state.RequiresKey() {
-			// Construct a hash set of OVNs as the key
-			key := map[scdmodels.OVN]bool{}
-			for _, ovn := range params.GetKey() {
-				key[scdmodels.OVN(ovn)] = true
-			}
+	if checkKey && u.state.RequiresKey() {
+		// Construct a hash set of OVNs as the key
+		key := map[scdmodels.OVN]bool{}
+		for _, ovn := range params.GetKey() {
+			key[scdmodels.OVN(ovn)] = true
+		}
 
-			// Identify OperationalIntents missing from the key
-			var missingOps []*scdmodels.OperationalIntent
-			relevantOps, err := r.SearchOperationalIntents(ctx, uExtent)
+		// If the client is missing some OVNs, provide the pointers to the
+		// information they need
+		missingOps, missingConstraints, err := missingFromKey(ctx, r, manager, uExtent, a.minKeyPriority(params.GetPriority()), sub.NotifyForConstraints, key)
+		if err != nil {
+			return nil, nil, nil, err // No need to Propagate this error as this stack layer does not add useful information
+		}
+		if len(missingOps) > 0 || len(missingConstraints) > 0 {
+			p, err := scderr.MissingOVNsErrorResponse(missingOps, missingConstraints)
 			if err != nil {
-				return stacktrace.Propagate(err, "Unable to SearchOperations")
-			}
-			for _, relevantOp := range relevantOps {
-				if _, ok := key[relevantOp.OVN]; !ok {
-					if relevantOp.Manager != manager {
-						relevantOp.OVN = scdmodels.NoOvnPhrase
-					}
-					missingOps = append(missingOps, relevantOp)
-				}
+				return nil, nil, nil, stacktrace.Propagate(err, "Failed to construct missing OVNs error message")
 			}
+			return nil, nil, nil, stacktrace.Propagate(status.ErrorProto(p), "Missing OVNs")
+		}
+	}
 
-			// Identify Constraints missing from the key
-			var missingConstraints []*scdmodels.Constraint
-			if sub.NotifyForConstraints {
-				constraints, err := r.SearchConstraints(ctx, uExtent)
-				if err != nil {
-					return stacktrace.Propagate(err, "Unable to SearchConstraints")
-				}
-				for _, relevantConstraint := range constraints {
-					if _, ok := key[relevantConstraint.OVN]; !ok {
-						if relevantConstraint.Manager != manager {
-							relevantConstraint.OVN = scdmodels.NoOvnPhrase
-						}
-						missingConstraints = append(missingConstraints, relevantConstraint)
-					}
-				}
-			}
+	// Construct the new OperationalIntent
+	op := &scdmodels.OperationalIntent{
+		ID:      u.id,
+		Manager: manager,
+		Version: scdmodels.VersionNumber(version + 1),
 
-			// If the client is missing some OVNs, provide the pointers to the
-			// information they need
-			if len(missingOps) > 0 || len(missingConstraints) > 0 {
-				p, err := scderr.MissingOVNsErrorResponse(missingOps, missingConstraints)
-				if err != nil {
-					return stacktrace.Propagate(err, "Failed to construct missing OVNs error message")
-				}
-				return stacktrace.Propagate(status.ErrorProto(p), "Missing OVNs")
-			}
-		}
+		StartTime:     uExtent.StartTime,
+		EndTime:       uExtent.EndTime,
+		AltitudeLower: uExtent.SpatialVolume.AltitudeLo,
+		AltitudeUpper: uExtent.SpatialVolume.AltitudeHi,
+		Cells:         u.cells,
 
-		// Construct the new OperationalIntent
-		op := &scdmodels.OperationalIntent{
-			ID:      id,
-			Manager: manager,
-			Version: scdmodels.VersionNumber(version + 1),
+		USSBaseURL:     params.UssBaseUrl,
+		SubscriptionID: sub.ID,
+		State:          u.state,
+		Priority:       params.GetPriority(),
 
-			StartTime:     uExtent.StartTime,
-			EndTime:       uExtent.EndTime,
-			AltitudeLower: uExtent.SpatialVolume.AltitudeLo,
-			AltitudeUpper: uExtent.SpatialVolume.AltitudeHi,
-			Cells:         cells,
+		OffNominalVolume: u.offNominal,
+	}
+	err = op.ValidateTimeRange()
+	if err != nil {
+		return nil, nil, nil, stacktrace.Propagate(err, "Error validating time range")
+	}
+	if err := op.ValidateStateTime(time.Now()); err != nil {
+		return nil, nil, nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
 
-			USSBaseURL:     params.UssBaseUrl,
-			SubscriptionID: sub.ID,
-			State:          state,
-		}
-		err = op.ValidateTimeRange()
+	// Compute total affected Volume4D for notification purposes, including the
+	// off-nominal volumes
+	notifyVol4, err := op.AffectedVolume()
+	if err != nil {
+		return nil, nil, nil, stacktrace.Propagate(err, "Error computing OperationalIntent volume")
+	}
+	if old != nil {
+		oldVol4, err := old.AffectedVolume()
 		if err != nil {
-			return stacktrace.Propagate(err, "Error validating time range")
-		}
-
-		// Compute total affected Volume4D for notification purposes
-		var notifyVol4 *dssmodels.Volume4D
-		if old == nil {
-			notifyVol4 = uExtent
-		} else {
-			oldVol4 := &dssmodels.Volume4D{
-				StartTime: old.StartTime,
-				EndTime:   old.EndTime,
-				SpatialVolume: &dssmodels.Volume3D{
-					AltitudeHi: old.AltitudeUpper,
-					AltitudeLo: old.AltitudeLower,
-					Footprint: dssmodels.GeometryFunc(func() (s2.CellUnion, error) {
-						return old.Cells, nil
-					}),
-				}}
-			notifyVol4, err = dssmodels.UnionVolumes4D(uExtent, oldVol4)
-			if err != nil {
-				return stacktrace.Propagate(err, "Error constructing 4D volumes union")
-			}
+			return nil, nil, nil, stacktrace.Propagate(err, "Error computing previous OperationalIntent volume")
 		}
-
-		// Upsert the OperationalIntent
-		op, err = r.UpsertOperationalIntent(ctx, op)
+		notifyVol4, err = dssmodels.UnionVolumes4D(notifyVol4, oldVol4)
 		if err != nil {
-			return stacktrace.Propagate(err, "Failed to upsert OperationalIntent in repo")
+			return nil, nil, nil, stacktrace.Propagate(err, "Error constructing 4D volumes union")
 		}
+	}
 
-		// Find Subscriptions that may need to be notified
-		allsubs, err := r.SearchSubscriptions(ctx, notifyVol4)
-		if err != nil {
-			return err
+	// Upsert the OperationalIntent
+	op, err = r.UpsertOperationalIntent(ctx, op)
+	if err != nil {
+		return nil, nil, nil, stacktrace.Propagate(err, "Failed to upsert OperationalIntent in repo")
+	}
+	auditOp := dssmodels.AuditCreate
+	if old != nil {
+		auditOp = dssmodels.AuditUpdate
+	}
+	if err := r.InsertAuditRecord(ctx, operationalIntentAuditRecord(auditOp, old, op)); err != nil {
+		return nil, nil, nil, stacktrace.Propagate(err, "Failed to record OperationalIntent change in audit log")
+	}
+	if old == nil || old.State != op.State {
+		from := scdmodels.OperationalIntentStateUnknown
+		if old != nil {
+			from = old.State
 		}
-
-		// Limit Subscription notifications to only those interested in OperationalIntents
-		var subs repos.Subscriptions
-		for _, sub := range allsubs {
-			if sub.NotifyForOperationalIntents {
-				subs = append(subs, sub)
-			}
+		if err := r.InsertStateTransition(ctx, &scdmodels.StateTransition{
+			OperationalIntentID: op.ID,
+			Manager:             op.Manager,
+			Version:             op.Version,
+			From:                from,
+			To:                  op.State,
+		}); err != nil {
+			return nil, nil, nil, stacktrace.Propagate(err, "Failed to record OperationalIntent state transition")
 		}
+	}
 
-		// Increment notification indices for relevant Subscriptions
-		err = subs.IncrementNotificationIndices(ctx, r)
-		if err != nil {
-			return err
-		}
+	// Find Subscriptions that may need to be notified
+	allsubs, err := r.SearchSubscriptions(ctx, notifyVol4)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
-		// Convert upserted OperationalIntent to proto
-		p, err := op.ToProto()
-		if err != nil {
-			return stacktrace.Propagate(err, "Could not convert OperationalIntent to proto")
+	// Limit Subscription notifications to only those interested in OperationalIntents
+	var subs repos.Subscriptions
+	for _, sub := range allsubs {
+		if sub.NotifyForOperationalIntents {
+			subs = append(subs, sub)
 		}
+	}
 
-		// Return response to client
-		response = &scdpb.ChangeOperationalIntentReferenceResponse{
-			OperationalIntentReference: p,
-			Subscribers:                makeSubscribersToNotify(subs),
-		}
+	// Increment notification indices for relevant Subscriptions
+	err = subs.IncrementNotificationIndices(ctx, r)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := r.InsertSubscriptionEvents(ctx, subscriptionEvents(subs, dssmodels.AuditEntityOperationalIntent, op.ID, op.OVN)); err != nil {
+		return nil, nil, nil, stacktrace.Propagate(err, "Failed to record subscription events")
+	}
 
-		return nil
+	return op, sub, subs, nil
+}
+
+// minKeyPriority returns the lowest priority of the OperationalIntents whose
+// OVNs must be in the key of an OperationalIntent of the given priority.
+func (a *Server) minKeyPriority(priority int32) int32 {
+	if !a.PriorityAwareKeys {
+		return 0
 	}
+	return priority
+}
 
-	err = a.Store.Transact(ctx, action)
+// missingFromKey returns the OperationalIntents of at least minPriority, and
+// the Constraints requiring acknowledgement if withConstraints is true,
+// intersecting extent whose OVN is not in key. The OVNs of the entities not
+// managed by manager are redacted.
+func missingFromKey(ctx context.Context, r repos.Repository, manager dssmodels.Manager, extent *dssmodels.Volume4D, minPriority int32, withConstraints bool, key map[scdmodels.OVN]bool) ([]*scdmodels.OperationalIntent, []*scdmodels.Constraint, error) {
+	// Identify OperationalIntents missing from the key
+	var missingOps []*scdmodels.OperationalIntent
+	relevantOps, err := r.SearchOperationalIntents(ctx, extent)
 	if err != nil {
-		return nil, err // No need to Propagate this error as this is not a useful stacktrace line
+		return nil, nil, stacktrace.Propagate(err, "Unable to SearchOperations")
+	}
+	for _, relevantOp := range relevantOps {
+		if relevantOp.Priority < minPriority {
+			continue
+		}
+		if _, ok := key[relevantOp.OVN]; !ok {
+			if relevantOp.Manager != manager {
+				relevantOp.OVN = scdmodels.NoOvnPhrase
+			}
+			missingOps = append(missingOps, relevantOp)
+		}
 	}
 
-	return response, nil
+	// Identify Constraints missing from the key
+	var missingConstraints []*scdmodels.Constraint
+	if withConstraints {
+		constraints, err := r.SearchConstraints(ctx, extent, nil)
+		if err != nil {
+			return nil, nil, stacktrace.Propagate(err, "Unable to SearchConstraints")
+		}
+		for _, relevantConstraint := range constraints {
+			if !relevantConstraint.Category.RequiresAcknowledgement() {
+				continue
+			}
+			if _, ok := key[relevantConstraint.OVN]; !ok {
+				if relevantConstraint.Manager != manager {
+					relevantConstraint.OVN = scdmodels.NoOvnPhrase
+				}
+				missingConstraints = append(missingConstraints, relevantConstraint)
+			}
+		}
+	}
+
+	return missingOps, missingConstraints, nil
 }
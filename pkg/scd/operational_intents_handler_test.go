@@ -0,0 +1,190 @@
+package scd
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/interuss/dss/pkg/api/v1/scdpb"
+	"github.com/interuss/dss/pkg/auth"
+	dsserr "github.com/interuss/dss/pkg/errors"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/dss/pkg/scd/repos"
+	"github.com/interuss/stacktrace"
+	"github.com/stretchr/testify/require"
+)
+
+// searchOnlyRepo is a repos.Repository which only supports searching
+// OperationalIntents and Constraints.
+type searchOnlyRepo struct {
+	repos.Repository
+	ops         []*scdmodels.OperationalIntent
+	constraints []*scdmodels.Constraint
+}
+
+func (r *searchOnlyRepo) SearchOperationalIntents(ctx context.Context, v4d *dssmodels.Volume4D) ([]*scdmodels.OperationalIntent, error) {
+	var result []*scdmodels.OperationalIntent
+	for _, op := range r.ops {
+		copied := *op
+		result = append(result, &copied)
+	}
+	return result, nil
+}
+
+func (r *searchOnlyRepo) SearchConstraints(ctx context.Context, v4d *dssmodels.Volume4D, categories []scdmodels.ConstraintCategory) ([]*scdmodels.Constraint, error) {
+	var result []*scdmodels.Constraint
+	for _, constraint := range r.constraints {
+		if !constraint.HasCategory(categories) {
+			continue
+		}
+		copied := *constraint
+		result = append(result, &copied)
+	}
+	return result, nil
+}
+
+func TestMissingFromKeyPriorities(t *testing.T) {
+	r := &searchOnlyRepo{ops: []*scdmodels.OperationalIntent{
+		{ID: "low", Manager: "uss2", OVN: "ovn-low", Priority: 0},
+		{ID: "equal", Manager: "uss2", OVN: "ovn-equal", Priority: 5},
+		{ID: "high", Manager: "uss2", OVN: "ovn-high", Priority: 10},
+		{ID: "known", Manager: "uss2", OVN: "ovn-known", Priority: 10},
+	}}
+	key := map[scdmodels.OVN]bool{"ovn-known": true}
+
+	for _, tc := range []struct {
+		name              string
+		priorityAwareKeys bool
+		missing           []dssmodels.ID
+	}{
+		{name: "priorities ignored", missing: []dssmodels.ID{"low", "equal", "high"}},
+		{name: "priority aware", priorityAwareKeys: true, missing: []dssmodels.ID{"equal", "high"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			a := &Server{PriorityAwareKeys: tc.priorityAwareKeys}
+			ops, constraints, err := missingFromKey(context.Background(), r, "uss1", &dssmodels.Volume4D{}, a.minKeyPriority(5), false, key)
+			require.NoError(t, err)
+			require.Empty(t, constraints)
+
+			var missing []dssmodels.ID
+			for _, op := range ops {
+				require.Equal(t, scdmodels.OVN(scdmodels.NoOvnPhrase), op.OVN)
+				missing = append(missing, op.ID)
+			}
+			require.Equal(t, tc.missing, missing)
+		})
+	}
+}
+
+func TestMissingFromKeyConstraintCategories(t *testing.T) {
+	r := &searchOnlyRepo{constraints: []*scdmodels.Constraint{
+		{ID: "restricted", Manager: "uss2", OVN: "ovn-restricted", Category: scdmodels.ConstraintCategoryRestricted},
+		{ID: "advisory", Manager: "uss2", OVN: "ovn-advisory", Category: scdmodels.ConstraintCategoryAdvisory},
+		{ID: "tfr", Manager: "uss1", OVN: "ovn-tfr", Category: scdmodels.ConstraintCategoryTemporaryFlightRestriction},
+		{ID: "known", Manager: "uss2", OVN: "ovn-known", Category: scdmodels.ConstraintCategoryRestricted},
+	}}
+	key := map[scdmodels.OVN]bool{"ovn-known": true}
+
+	ops, constraints, err := missingFromKey(context.Background(), r, "uss1", &dssmodels.Volume4D{}, 0, true, key)
+	require.NoError(t, err)
+	require.Empty(t, ops)
+	require.Len(t, constraints, 2)
+	require.Equal(t, dssmodels.ID("restricted"), constraints[0].ID)
+	require.Equal(t, scdmodels.OVN(scdmodels.NoOvnPhrase), constraints[0].OVN)
+	require.Equal(t, dssmodels.ID("tfr"), constraints[1].ID)
+	require.Equal(t, scdmodels.OVN("ovn-tfr"), constraints[1].OVN)
+
+	_, constraints, err = missingFromKey(context.Background(), r, "uss1", &dssmodels.Volume4D{}, 0, false, key)
+	require.NoError(t, err)
+	require.Empty(t, constraints)
+}
+
+func TestValidateStateScope(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		scopes  auth.ScopeSet
+		state   scdmodels.OperationalIntentState
+		allowed bool
+	}{
+		{name: "strategic coordination nominal", scopes: auth.ScopeSet{strategicCoordinationScope: {}}, state: scdmodels.OperationalIntentStateActivated, allowed: true},
+		{name: "strategic coordination off-nominal", scopes: auth.ScopeSet{strategicCoordinationScope: {}}, state: scdmodels.OperationalIntentStateContingent, allowed: true},
+		{name: "conformance monitoring nominal", scopes: auth.ScopeSet{conformanceMonitoringSAScope: {}}, state: scdmodels.OperationalIntentStateAccepted},
+		{name: "conformance monitoring off-nominal", scopes: auth.ScopeSet{conformanceMonitoringSAScope: {}}, state: scdmodels.OperationalIntentStateNonconforming, allowed: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateStateScope(auth.ContextWithScopes(context.Background(), tc.scopes), tc.state)
+			if tc.allowed {
+				require.NoError(t, err)
+			} else {
+				require.Equal(t, dsserr.PermissionDenied, stacktrace.GetCode(err))
+			}
+		})
+	}
+
+	err := validateStateScope(context.Background(), scdmodels.OperationalIntentStateContingent)
+	require.Equal(t, dsserr.PermissionDenied, stacktrace.GetCode(err))
+}
+
+func TestOffNominalVolumeFromProto(t *testing.T) {
+	v, err := offNominalVolumeFromProto(scdmodels.OperationalIntentStateAccepted, nil)
+	require.NoError(t, err)
+	require.Nil(t, v)
+
+	_, err = offNominalVolumeFromProto(scdmodels.OperationalIntentStateAccepted, []*scdpb.Volume4D{{}})
+	require.Equal(t, dsserr.BadRequest, stacktrace.GetCode(err))
+
+	_, err = offNominalVolumeFromProto(scdmodels.OperationalIntentStateContingent, []*scdpb.Volume4D{{}})
+	require.Equal(t, dsserr.BadRequest, stacktrace.GetCode(err))
+}
+
+func TestPutOperationalIntentReferenceLimits(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		limits   Limits
+		existing int
+		manager  dssmodels.Owner
+		code     stacktrace.ErrorCode
+	}{
+		{name: "below active OperationalIntents limit", limits: Limits{MaxActiveOperationalIntents: 3}, existing: 2, manager: "uss1"},
+		{name: "at active OperationalIntents limit", limits: Limits{MaxActiveOperationalIntents: 2}, existing: 2, manager: "uss1", code: dsserr.Exhausted},
+		{name: "over active OperationalIntents limit", limits: Limits{MaxActiveOperationalIntents: 1}, existing: 2, manager: "uss1", code: dsserr.Exhausted},
+		{name: "active OperationalIntents of another manager", limits: Limits{MaxActiveOperationalIntents: 1}, existing: 2, manager: "uss2"},
+		{name: "below implicit Subscriptions limit", limits: Limits{MaxImplicitSubscriptions: 3}, existing: 2, manager: "uss1"},
+		{name: "at implicit Subscriptions limit", limits: Limits{MaxImplicitSubscriptions: 2}, existing: 2, manager: "uss1", code: dsserr.Exhausted},
+		{name: "over implicit Subscriptions limit", limits: Limits{MaxImplicitSubscriptions: 1}, existing: 2, manager: "uss1", code: dsserr.Exhausted},
+		{name: "implicit Subscriptions of another manager", limits: Limits{MaxImplicitSubscriptions: 1}, existing: 2, manager: "uss2"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				store = newMemoryStore()
+				a     = &Server{Store: store}
+				key   []scdmodels.OVN
+			)
+
+			// The existing OperationalIntents of uss1, each with an implicit
+			// Subscription, are created without limits.
+			for i := 1; i <= tc.existing; i++ {
+				response, err := a.PutOperationalIntentReference(strategicCoordinationContext("uss1"),
+					fmt.Sprintf("00000000-0000-4000-8000-%012d", i), "",
+					testOperationalIntentParams(t, scdmodels.OperationalIntentStateAccepted, key...))
+				require.NoError(t, err)
+				key = append(key, scdmodels.OVN(response.OperationalIntentReference.Ovn))
+			}
+
+			a.Limits = tc.limits
+			_, err := a.PutOperationalIntentReference(strategicCoordinationContext(tc.manager),
+				"00000000-0000-4000-8000-100000000000", "",
+				testOperationalIntentParams(t, scdmodels.OperationalIntentStateAccepted, key...))
+			if tc.code == 0 {
+				require.NoError(t, err)
+				require.Contains(t, store.state.ops, dssmodels.ID("00000000-0000-4000-8000-100000000000"))
+				return
+			}
+			require.Error(t, err)
+			require.Equal(t, tc.code, stacktrace.GetCode(err))
+			require.Len(t, store.state.ops, tc.existing)
+			require.Len(t, store.state.subs, tc.existing)
+		})
+	}
+}
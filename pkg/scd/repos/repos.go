@@ -2,7 +2,9 @@ package repos
 
 import (
 	"context"
+	"time"
 
+	"github.com/golang/geo/s2"
 	dssmodels "github.com/interuss/dss/pkg/models"
 	scdmodels "github.com/interuss/dss/pkg/scd/models"
 )
@@ -27,6 +29,23 @@ type OperationalIntent interface {
 	// GetDependentOperationalIntents returns IDs of all operations dependent on
 	// subscription identified by "subscriptionID".
 	GetDependentOperationalIntents(ctx context.Context, subscriptionID dssmodels.ID) ([]dssmodels.ID, error)
+
+	// CountActiveOperationalIntentsByManager returns the number of operations
+	// managed by "manager" which have not yet ended.
+	CountActiveOperationalIntentsByManager(ctx context.Context, manager dssmodels.Manager) (int, error)
+
+	// ListOperationalIntentsByManager returns all operations managed by
+	// "manager".
+	ListOperationalIntentsByManager(ctx context.Context, manager dssmodels.Manager) ([]*scdmodels.OperationalIntent, error)
+
+	// ListOperationalIntents returns at most "limit" operations with an ID
+	// greater than "after", or the first operations if "after" is empty, in
+	// order of ID.
+	ListOperationalIntents(ctx context.Context, after dssmodels.ID, limit int) ([]*scdmodels.OperationalIntent, error)
+
+	// RestoreOperationalIntent inserts "operation" as is, keeping its version
+	// and, if the schema stores OVNs, its OVN, e.g. to restore a backup.
+	RestoreOperationalIntent(ctx context.Context, operation *scdmodels.OperationalIntent) error
 }
 
 // Subscription abstracts subscription-specific interactions with the backing repository.
@@ -51,18 +70,50 @@ type Subscription interface {
 	// specified Subscription and returns the resulting corresponding
 	// notification indices.
 	IncrementNotificationIndices(ctx context.Context, subscriptionIds []dssmodels.ID) ([]int, error)
+
+	// MaxSubscriptionCountInCellsByManager counts how many active explicit
+	// Subscriptions, other than the one identified by "exclude", "manager" has
+	// in each one of "cells", and returns the highest of these counts.
+	MaxSubscriptionCountInCellsByManager(ctx context.Context, cells s2.CellUnion, manager dssmodels.Manager, exclude dssmodels.ID) (int, error)
+
+	// CountImplicitSubscriptionsByManager returns the number of active implicit
+	// Subscriptions managed by "manager".
+	CountImplicitSubscriptionsByManager(ctx context.Context, manager dssmodels.Manager) (int, error)
+
+	// ListSubscriptionsByManager returns all Subscriptions managed by
+	// "manager".
+	ListSubscriptionsByManager(ctx context.Context, manager dssmodels.Manager) ([]*scdmodels.Subscription, error)
+
+	// ListSubscriptions returns at most "limit" Subscriptions with an ID
+	// greater than "after", or the first Subscriptions if "after" is empty, in
+	// order of ID.
+	ListSubscriptions(ctx context.Context, after dssmodels.ID, limit int) ([]*scdmodels.Subscription, error)
+
+	// RestoreSubscription inserts "sub" as is, keeping its version and
+	// notification index, e.g. to restore a backup.
+	RestoreSubscription(ctx context.Context, sub *scdmodels.Subscription) error
 }
 
 type UssAvailability interface {
 	GetUssAvailability(ctx context.Context, id dssmodels.Manager) (*scdmodels.UssAvailabilityStatus, error)
 
 	UpsertUssAvailability(ctx context.Context, ussa *scdmodels.UssAvailabilityStatus) (*scdmodels.UssAvailabilityStatus, error)
+
+	// ListUssAvailabilities returns at most "limit" availabilities of USSs
+	// greater than "after", or the first availabilities if "after" is empty,
+	// in order of USS.
+	ListUssAvailabilities(ctx context.Context, after dssmodels.Manager, limit int) ([]*scdmodels.UssAvailabilityStatus, error)
+
+	// RestoreUssAvailability inserts "ussa" as is, keeping the time it was
+	// written and thus its version, e.g. to restore a backup.
+	RestoreUssAvailability(ctx context.Context, ussa *scdmodels.UssAvailabilityStatus) error
 }
 
 // repos.Constraint abstracts constraint-specific interactions with the backing store.
 type Constraint interface {
-	// SearchConstraints returns all Constraints in "v4d".
-	SearchConstraints(ctx context.Context, v4d *dssmodels.Volume4D) ([]*scdmodels.Constraint, error)
+	// SearchConstraints returns all Constraints in "v4d" whose category is one
+	// of "categories", whatever their category if "categories" is empty.
+	SearchConstraints(ctx context.Context, v4d *dssmodels.Volume4D, categories []scdmodels.ConstraintCategory) ([]*scdmodels.Constraint, error)
 
 	// GetConstraint returns the Constraint referenced by id, or
 	// (nil, sql.ErrNoRows) if the Constraint doesn't exist
@@ -75,6 +126,66 @@ type Constraint interface {
 	// deleted subscription.  Returns nil and an error if the Constraint does
 	// not exist.
 	DeleteConstraint(ctx context.Context, id dssmodels.ID) error
+
+	// ListConstraintsByManager returns all Constraints managed by "manager".
+	ListConstraintsByManager(ctx context.Context, manager dssmodels.Manager) ([]*scdmodels.Constraint, error)
+
+	// ListConstraints returns at most "limit" Constraints with an ID greater
+	// than "after", or the first Constraints if "after" is empty, in order of
+	// ID.
+	ListConstraints(ctx context.Context, after dssmodels.ID, limit int) ([]*scdmodels.Constraint, error)
+
+	// RestoreConstraint inserts "constraint" as is, keeping its version and,
+	// if the schema stores OVNs, its OVN, e.g. to restore a backup.
+	RestoreConstraint(ctx context.Context, constraint *scdmodels.Constraint) error
+}
+
+// AuditLog abstracts the append-only storage of mutations of SCD entities.
+type AuditLog interface {
+	// InsertAuditRecord appends "record" to the audit log.
+	InsertAuditRecord(ctx context.Context, record *dssmodels.AuditRecord) error
+
+	// SearchAuditRecords returns the most recent audit records matching
+	// "filter".
+	SearchAuditRecords(ctx context.Context, filter *dssmodels.AuditFilter) ([]*dssmodels.AuditRecord, error)
+}
+
+// History abstracts access to the prior versions of SCD entities.
+type History interface {
+	// SearchOperationalIntentsAsOf returns all operations intersecting "v4d"
+	// in the version that was current at time "asOf".
+	SearchOperationalIntentsAsOf(ctx context.Context, v4d *dssmodels.Volume4D, asOf time.Time) ([]*scdmodels.OperationalIntent, error)
+
+	// SearchConstraintsAsOf returns all Constraints in "v4d" in the version
+	// that was current at time "asOf".
+	SearchConstraintsAsOf(ctx context.Context, v4d *dssmodels.Volume4D, asOf time.Time) ([]*scdmodels.Constraint, error)
+}
+
+// StateTransitions abstracts the storage of the changes of the state of
+// OperationalIntents.
+type StateTransitions interface {
+	// InsertStateTransition records "transition".
+	InsertStateTransition(ctx context.Context, transition *scdmodels.StateTransition) error
+
+	// ListStateTransitions returns the recorded state transitions of the
+	// OperationalIntent identified by "id", in chronological order.
+	ListStateTransitions(ctx context.Context, id dssmodels.ID) ([]*scdmodels.StateTransition, error)
+}
+
+// SubscriptionEvents abstracts the storage of the changes which incremented
+// the notification indices of SCD Subscriptions.
+type SubscriptionEvents interface {
+	// InsertSubscriptionEvents stores "events".
+	InsertSubscriptionEvents(ctx context.Context, events []*dssmodels.SubscriptionEvent) error
+
+	// ListSubscriptionEvents returns at most "limit" events of the
+	// Subscription identified by "id" with a notification index greater than
+	// "afterIndex", in order of notification index.
+	ListSubscriptionEvents(ctx context.Context, id dssmodels.ID, afterIndex int, limit int) ([]*dssmodels.SubscriptionEvent, error)
+
+	// DeleteSubscriptionEventsBefore deletes the events recorded before
+	// "cutoff" and returns how many were deleted.
+	DeleteSubscriptionEventsBefore(ctx context.Context, cutoff time.Time) (int64, error)
 }
 
 // Repository aggregates all SCD-specific repo interfaces.
@@ -83,6 +194,10 @@ type Repository interface {
 	Subscription
 	Constraint
 	UssAvailability
+	AuditLog
+	History
+	SubscriptionEvents
+	StateTransitions
 }
 
 // IncrementNotificationIndices is a utility function that extracts the IDs from
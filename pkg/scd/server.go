@@ -41,16 +41,46 @@ func makeSubscribersToNotify(subscriptions []*scdmodels.Subscription) []*scdpb.S
 	return result
 }
 
-// Server implements scdpb.DiscoveryAndSynchronizationService.
+// Limits bounds the number of entities a single manager may hold in the DSS.
+// A zero value disables the corresponding limit.
+type Limits struct {
+	// MaxSubscriptionsPerArea is the maximum number of active explicit
+	// Subscriptions a manager may have in any single cell.
+	MaxSubscriptionsPerArea int
+
+	// MaxActiveOperationalIntents is the maximum number of OperationalIntents a
+	// manager may have which have not yet ended.
+	MaxActiveOperationalIntents int
+
+	// MaxImplicitSubscriptions is the maximum number of active implicit
+	// Subscriptions a manager may have.
+	MaxImplicitSubscriptions int
+
+	// MaxBulkChanges is the maximum number of changes a single bulk request
+	// may apply.
+	MaxBulkChanges int
+}
+
+// Server implements scdpb.DiscoveryAndSynchronizationService,
+// bulkpb.DSSBulkService and historypb.DSSHistoryService.
 type Server struct {
 	Store      scdstore.Store
 	Timeout    time.Duration
 	EnableHTTP bool
+	Limits     Limits
+
+	// PriorityAwareKeys, if true, only requires the OVNs of the
+	// OperationalIntents of equal or higher priority in the key of an
+	// OperationalIntent.
+	PriorityAwareKeys bool
 }
 
 // AuthScopes returns a map of endpoint to required Oauth scope.
 func (a *Server) AuthScopes() map[auth.Operation]auth.KeyClaimedScopesValidator {
 	return map[auth.Operation]auth.KeyClaimedScopesValidator{
+		"/bulkpb.DSSBulkService/BulkChangeConstraintReferences":                auth.RequireAnyScope(constraintManagementScope),
+		"/bulkpb.DSSBulkService/BulkChangeOperationalIntentReferences":         auth.RequireAnyScope(strategicCoordinationScope, conformanceMonitoringSAScope),
+		"/historypb.DSSHistoryService/GetOperationalIntentStateHistory":        auth.RequireAnyScope(strategicCoordinationScope, conformanceMonitoringSAScope),
 		"/scdpb.UTMAPIUSSDSSAndUSSUSSService/CreateConstraintReference":        auth.RequireAnyScope(constraintManagementScope),
 		"/scdpb.UTMAPIUSSDSSAndUSSUSSService/CreateOperationalIntentReference": auth.RequireAnyScope(strategicCoordinationScope, conformanceMonitoringSAScope),
 		"/scdpb.UTMAPIUSSDSSAndUSSUSSService/CreateSubscription":               auth.RequireAnyScope(strategicCoordinationScope, constraintProcessingScope),
@@ -9,7 +9,6 @@ import (
 	"github.com/interuss/stacktrace"
 	"github.com/jackc/pgx/v4"
 	"strings"
-	"time"
 )
 
 var (
@@ -60,6 +59,23 @@ func (u *repo) UpsertUssAvailability(ctx context.Context, s *scdmodels.UssAvaila
 	return s, nil
 }
 
+// RestoreUssAvailability implements repos.UssAvailability.RestoreUssAvailability.
+func (u *repo) RestoreUssAvailability(ctx context.Context, s *scdmodels.UssAvailabilityStatus) error {
+	var (
+		upsertQuery = fmt.Sprintf(`
+		UPSERT INTO
+		scd_uss_availability
+		  (%s)
+		VALUES
+			($1, $2, COALESCE($3, transaction_timestamp()))`, availabilityFieldsWithoutPrefix)
+	)
+
+	if _, err := u.q.Exec(ctx, upsertQuery, s.Uss, s.Availability, restoredUpdatedAt(s.UpdatedAt)); err != nil {
+		return stacktrace.Propagate(err, "Error in query: %s", upsertQuery)
+	}
+	return nil
+}
+
 func (u *repo) fetchAvailabilities(ctx context.Context, q dsssql.Queryable, query string, args ...interface{}) ([]*scdmodels.UssAvailabilityStatus, error) {
 	rows, err := q.Query(ctx, query, args...)
 	if err != nil {
@@ -69,19 +85,16 @@ func (u *repo) fetchAvailabilities(ctx context.Context, q dsssql.Queryable, quer
 
 	var payload []*scdmodels.UssAvailabilityStatus
 	for rows.Next() {
-		var (
-			u         = new(scdmodels.UssAvailabilityStatus)
-			updatedAt time.Time
-		)
+		u := new(scdmodels.UssAvailabilityStatus)
 		err := rows.Scan(
 			&u.Uss,
 			&u.Availability,
-			&updatedAt,
+			&u.UpdatedAt,
 		)
 		if err != nil {
 			return nil, stacktrace.Propagate(err, "Error scanning UssAvailability row")
 		}
-		u.Version = scdmodels.NewOVNFromTime(updatedAt, u.Uss.String())
+		u.Version = scdmodels.NewOVNFromTime(u.UpdatedAt, u.Uss.String())
 		payload = append(payload, u)
 	}
 	if err := rows.Err(); err != nil {
@@ -121,3 +134,19 @@ func (u *repo) GetUssAvailability(ctx context.Context, ussID dssmodels.Manager)
 	}
 	return ussa, nil
 }
+
+// ListUssAvailabilities returns at most "limit" Availability statuses of USSs
+// greater than "after", in order of USS.
+func (u *repo) ListUssAvailabilities(ctx context.Context, after dssmodels.Manager, limit int) ([]*scdmodels.UssAvailabilityStatus, error) {
+	var query = fmt.Sprintf(`
+      SELECT %s
+      FROM
+        scd_uss_availability
+      WHERE
+        id > $1
+      ORDER BY
+        id
+      LIMIT $2`, availabilityFieldsWithoutPrefix)
+
+	return u.fetchAvailabilities(ctx, u.q, query, after, limit)
+}
@@ -0,0 +1,105 @@
+package cockroach
+
+import (
+	"context"
+
+	dsserr "github.com/interuss/dss/pkg/errors"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/stacktrace"
+	"github.com/jackc/pgtype"
+)
+
+// upsertConstraintAttributes stores the category, authority and reason of
+// constraint. Restricted constraints without authority nor reason are stored
+// without a row.
+func (c *repo) upsertConstraintAttributes(ctx context.Context, constraint *scdmodels.Constraint) error {
+	var (
+		category  = constraint.Category
+		isDefault = (category == "" || category == scdmodels.ConstraintCategoryRestricted) &&
+			constraint.Authority == "" && constraint.Reason == ""
+	)
+	if !c.storeConstraintCategories {
+		if !isDefault {
+			return stacktrace.NewErrorWithCode(dsserr.BadRequest, "Constraint categories are not supported by the current schema version of the database")
+		}
+		return nil
+	}
+
+	var (
+		upsertQuery = `
+			UPSERT INTO
+				scd_constraint_attributes
+				(constraint_id, category, authority, reason)
+			VALUES
+				($1, $2, NULLIF($3, ''), NULLIF($4, ''))`
+		deleteQuery = `
+			DELETE FROM
+				scd_constraint_attributes
+			WHERE
+				constraint_id = $1`
+	)
+
+	uid, err := constraint.ID.PgUUID()
+	if err != nil {
+		return stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	if isDefault {
+		if _, err := c.q.Exec(ctx, deleteQuery, uid); err != nil {
+			return stacktrace.Propagate(err, "Error in query: %s", deleteQuery)
+		}
+		return nil
+	}
+	if _, err := c.q.Exec(ctx, upsertQuery, uid, category.String(), constraint.Authority, constraint.Reason); err != nil {
+		return stacktrace.Propagate(err, "Error in query: %s", upsertQuery)
+	}
+	return nil
+}
+
+// upsertSubscriptionConstraintCategories stores the ConstraintCategories of
+// subscription, or deletes the stored ones if it has none.
+func (c *repo) upsertSubscriptionConstraintCategories(ctx context.Context, subscription *scdmodels.Subscription) error {
+	if !c.storeConstraintCategories {
+		if len(subscription.ConstraintCategories) > 0 {
+			return stacktrace.NewErrorWithCode(dsserr.BadRequest, "Constraint categories are not supported by the current schema version of the database")
+		}
+		return nil
+	}
+
+	var (
+		upsertQuery = `
+			UPSERT INTO
+				scd_subscription_constraint_categories
+				(subscription_id, categories)
+			VALUES
+				($1, $2)`
+		deleteQuery = `
+			DELETE FROM
+				scd_subscription_constraint_categories
+			WHERE
+				subscription_id = $1`
+	)
+
+	uid, err := subscription.ID.PgUUID()
+	if err != nil {
+		return stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	if len(subscription.ConstraintCategories) == 0 {
+		if _, err := c.q.Exec(ctx, deleteQuery, uid); err != nil {
+			return stacktrace.Propagate(err, "Error in query: %s", deleteQuery)
+		}
+		return nil
+	}
+
+	categories := make([]string, len(subscription.ConstraintCategories))
+	for i, category := range subscription.ConstraintCategories {
+		categories[i] = category.String()
+	}
+	var pgCategories pgtype.TextArray
+	if err := pgCategories.Set(categories); err != nil {
+		return stacktrace.Propagate(err, "Failed to convert array to jackc/pgtype")
+	}
+	if _, err := c.q.Exec(ctx, upsertQuery, uid, pgCategories); err != nil {
+		return stacktrace.Propagate(err, "Error in query: %s", upsertQuery)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package cockroach
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/geo/s2"
+	"github.com/google/uuid"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/dss/pkg/scd/repos"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchConstraintsByCategory(t *testing.T) {
+	var (
+		ctx                  = context.Background()
+		store, tearDownStore = setUpStore(ctx, t)
+		manager              = dssmodels.Manager(fmt.Sprintf("categories-%s", uuid.New()))
+		cells                = s2.CellUnion{s2.CellIDFromLatLng(s2.LatLngFromDegrees(37.4, -122.1)).Parent(13)}
+		start                = time.Now().Add(time.Hour)
+		end                  = start.Add(time.Hour)
+		altLower             = float32(0)
+		altUpper             = float32(100)
+	)
+	defer tearDownStore()
+
+	newConstraint := func(category scdmodels.ConstraintCategory) *scdmodels.Constraint {
+		return &scdmodels.Constraint{
+			ID:            dssmodels.ID(uuid.New().String()),
+			Manager:       manager,
+			Version:       1,
+			Category:      category,
+			StartTime:     &start,
+			EndTime:       &end,
+			USSBaseURL:    "https://example.com/uss",
+			AltitudeLower: &altLower,
+			AltitudeUpper: &altUpper,
+			Cells:         cells,
+		}
+	}
+	var (
+		advisory    = newConstraint(scdmodels.ConstraintCategoryAdvisory)
+		restricted  = newConstraint(scdmodels.ConstraintCategoryRestricted)
+		constraints = []*scdmodels.Constraint{advisory, restricted}
+	)
+	require.NoError(t, store.Transact(ctx, func(ctx context.Context, r repos.Repository) error {
+		for _, constraint := range constraints {
+			if _, err := r.UpsertConstraint(ctx, constraint); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	defer func() {
+		require.NoError(t, store.Transact(ctx, func(ctx context.Context, r repos.Repository) error {
+			for _, constraint := range constraints {
+				if err := r.DeleteConstraint(ctx, constraint.ID); err != nil {
+					return err
+				}
+			}
+			return nil
+		}))
+	}()
+
+	repo, err := store.Interact(ctx)
+	require.NoError(t, err)
+	volume := &dssmodels.Volume4D{
+		StartTime: &start,
+		EndTime:   &end,
+		SpatialVolume: &dssmodels.Volume3D{
+			Footprint: dssmodels.GeometryFunc(func() (s2.CellUnion, error) {
+				return cells, nil
+			}),
+		},
+	}
+
+	for _, tc := range []struct {
+		name       string
+		categories []scdmodels.ConstraintCategory
+		want       []dssmodels.ID
+	}{
+		{name: "any category", want: []dssmodels.ID{advisory.ID, restricted.ID}},
+		{name: "advisory", categories: []scdmodels.ConstraintCategory{scdmodels.ConstraintCategoryAdvisory}, want: []dssmodels.ID{advisory.ID}},
+		{name: "restricted", categories: []scdmodels.ConstraintCategory{scdmodels.ConstraintCategoryRestricted}, want: []dssmodels.ID{restricted.ID}},
+		{name: "both", categories: []scdmodels.ConstraintCategory{scdmodels.ConstraintCategoryAdvisory, scdmodels.ConstraintCategoryRestricted}, want: []dssmodels.ID{advisory.ID, restricted.ID}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			found, err := repo.SearchConstraints(ctx, volume, tc.categories)
+			require.NoError(t, err)
+			var ids []dssmodels.ID
+			for _, constraint := range found {
+				if constraint.Manager == manager {
+					ids = append(ids, constraint.ID)
+				}
+			}
+			require.ElementsMatch(t, tc.want, ids)
+		})
+	}
+}
@@ -53,7 +53,64 @@ func init() {
 	)
 }
 
+// constraintAttributes returns the attributes of constraints selected along
+// with constraintFieldsWithIndices by constraintSource, and the joins of
+// scd_constraints with the tables storing them with the current schema.
+func (c *repo) constraintAttributes() ([]attribute, string) {
+	var (
+		attributes []attribute
+		joins      string
+	)
+	if c.storeOVNs {
+		attributes = append(attributes, attribute{"ovn", "scd_constraints.ovn"})
+	}
+	if c.storeConstraintCategories {
+		attributes = append(attributes,
+			attribute{"category", "constraint_attributes.category"},
+			attribute{"authority", "COALESCE(constraint_attributes.authority, '')"},
+			attribute{"reason", "COALESCE(constraint_attributes.reason, '')"},
+		)
+		joins += `
+			LEFT JOIN
+				scd_constraint_attributes AS constraint_attributes
+			ON
+				constraint_attributes.constraint_id = scd_constraints.id`
+	}
+	return attributes, joins
+}
+
+// constraintSource returns the source of the constraints read by c, exposing
+// under the scd_constraints name the fields of constraintFields, so that
+// constraints are read along with the attributes stored outside of their
+// rows with a single query.
+func (c *repo) constraintSource() string {
+	attributes, joins := c.constraintAttributes()
+	if len(attributes) == 0 {
+		return "scd_constraints"
+	}
+	return fmt.Sprintf(`(
+			SELECT
+				%s,%s
+			FROM
+				scd_constraints%s
+		) AS scd_constraints`, constraintFieldsWithPrefix, selectAttributes(attributes), joins)
+}
+
+// constraintFields returns the fields of constraintSource read by
+// scanConstraints.
+func (c *repo) constraintFields() string {
+	attributes, _ := c.constraintAttributes()
+	return constraintFieldsWithPrefix + attributeFields("scd_constraints", attributes)
+}
+
 func (c *repo) fetchConstraints(ctx context.Context, q dsssql.Queryable, query string, args ...interface{}) ([]*scdmodels.Constraint, error) {
+	return c.scanConstraints(ctx, q, true, query, args...)
+}
+
+// scanConstraints returns the Constraints selected by query, which selects
+// constraintFieldsWithIndices followed, if attributes, by the attributes of
+// constraintFields.
+func (c *repo) scanConstraints(ctx context.Context, q dsssql.Queryable, attributes bool, query string, args ...interface{}) ([]*scdmodels.Constraint, error) {
 	rows, err := q.Query(ctx, query, args...)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Error in query: %s", query)
@@ -64,22 +121,29 @@ func (c *repo) fetchConstraints(ctx context.Context, q dsssql.Queryable, query s
 	pgCids := pgtype.Int8Array{}
 	for rows.Next() {
 		var (
-			c         = new(scdmodels.Constraint)
-			updatedAt time.Time
+			constraint = new(scdmodels.Constraint)
+			ovn        = pgtype.Text{}
+			category   = pgtype.Text{}
 		)
-		err := rows.Scan(
-			&c.ID,
-			&c.Manager,
-			&c.Version,
-			&c.USSBaseURL,
-			&c.AltitudeLower,
-			&c.AltitudeUpper,
-			&c.StartTime,
-			&c.EndTime,
+		dest := []interface{}{
+			&constraint.ID,
+			&constraint.Manager,
+			&constraint.Version,
+			&constraint.USSBaseURL,
+			&constraint.AltitudeLower,
+			&constraint.AltitudeUpper,
+			&constraint.StartTime,
+			&constraint.EndTime,
 			&pgCids,
-			&updatedAt,
-		)
-		if err != nil {
+			&constraint.UpdatedAt,
+		}
+		if attributes && c.storeOVNs {
+			dest = append(dest, &ovn)
+		}
+		if attributes && c.storeConstraintCategories {
+			dest = append(dest, &category, &constraint.Authority, &constraint.Reason)
+		}
+		if err := rows.Scan(dest...); err != nil {
 			return nil, stacktrace.Propagate(err, "Error scanning Constraint row")
 		}
 		var cids []int64
@@ -87,9 +151,15 @@ func (c *repo) fetchConstraints(ctx context.Context, q dsssql.Queryable, query s
 		if err := pgCids.AssignTo(&cids); err != nil {
 			return nil, stacktrace.Propagate(err, "Error converting jacks/pgtype to array")
 		}
-		c.Cells = geo.CellUnionFromInt64(cids)
-		c.OVN = scdmodels.NewOVNFromTime(updatedAt, c.ID.String())
-		payload = append(payload, c)
+		constraint.Cells = geo.CellUnionFromInt64(cids)
+		constraint.OVN = ovnOrLegacy(ovn, constraint.UpdatedAt, constraint.ID)
+		// Restricted constraints without authority nor reason have no attributes
+		// row.
+		constraint.Category = scdmodels.ConstraintCategoryRestricted
+		if category.Status == pgtype.Present {
+			constraint.Category = scdmodels.ConstraintCategory(category.String)
+		}
+		payload = append(payload, constraint)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, stacktrace.Propagate(err, "Error in rows query result")
@@ -118,9 +188,9 @@ func (c *repo) GetConstraint(ctx context.Context, id dssmodels.ID) (*scdmodels.C
 			SELECT
 				%s
 			FROM
-				scd_constraints
+				%s
 			WHERE
-				id = $1`, constraintFieldsWithoutPrefix)
+				id = $1`, c.constraintFields(), c.constraintSource())
 	)
 	uid, err := id.PgUUID()
 	if err != nil {
@@ -131,13 +201,43 @@ func (c *repo) GetConstraint(ctx context.Context, id dssmodels.ID) (*scdmodels.C
 
 // Implements scd.repos.Constraint.UpsertConstraint
 func (c *repo) UpsertConstraint(ctx context.Context, s *scdmodels.Constraint) (*scdmodels.Constraint, error) {
+	id, err := s.ID.PgUUID()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	if err := c.archiveConstraint(ctx, id); err != nil {
+		return nil, stacktrace.Propagate(err, "Error archiving Constraint")
+	}
+	attributes := *s
+	s, err = c.writeConstraint(ctx, s, nil)
+	if err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	s.OVN, err = c.storeOVN(ctx, "scd_constraints", s.ID, s.OVN)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error storing Constraint OVN")
+	}
+	if err := c.upsertConstraintAttributes(ctx, &attributes); err != nil {
+		return nil, stacktrace.Propagate(err, "Error storing attributes of Constraint")
+	}
+	s.Category = attributes.Category
+	s.Authority = attributes.Authority
+	s.Reason = attributes.Reason
+
+	return s, nil
+}
+
+// writeConstraint writes the row of s, without its OVN nor the attributes
+// stored outside of the row, and returns the written constraint. The row is
+// written as updated at updatedAt, or at the time of the transaction if nil.
+func (c *repo) writeConstraint(ctx context.Context, s *scdmodels.Constraint, updatedAt *time.Time) (*scdmodels.Constraint, error) {
 	var (
 		upsertQuery = fmt.Sprintf(`
 		UPSERT INTO
 		  scd_constraints
 		  (%s)
 		VALUES
-			($1, $2, $3, $4, $5, $6, $7, $8, $9, transaction_timestamp())
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, COALESCE($10, transaction_timestamp()))
 		RETURNING
 			%s`, constraintFieldsWithoutPrefix, constraintFieldsWithPrefix)
 	)
@@ -160,7 +260,7 @@ func (c *repo) UpsertConstraint(ctx context.Context, s *scdmodels.Constraint) (*
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
 	}
-	s, err = c.fetchConstraint(ctx, c.q, upsertQuery,
+	constraints, err := c.scanConstraints(ctx, c.q, false, upsertQuery,
 		id,
 		s.Manager,
 		s.Version,
@@ -169,12 +269,15 @@ func (c *repo) UpsertConstraint(ctx context.Context, s *scdmodels.Constraint) (*
 		s.AltitudeUpper,
 		s.StartTime,
 		s.EndTime,
-		pgCids)
+		pgCids,
+		updatedAt)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Error fetching Constraint")
 	}
-
-	return s, nil
+	if len(constraints) != 1 {
+		return nil, stacktrace.NewError("Upsert returned %d Constraints when only 1 was expected", len(constraints))
+	}
+	return constraints[0], nil
 }
 
 // Implements scd.repos.Constraint.DeleteConstraint
@@ -191,6 +294,9 @@ func (c *repo) DeleteConstraint(ctx context.Context, id dssmodels.ID) error {
 	if err != nil {
 		return stacktrace.Propagate(err, "Failed to convert id to PgUUID")
 	}
+	if err := c.archiveConstraint(ctx, uid); err != nil {
+		return stacktrace.Propagate(err, "Error archiving Constraint")
+	}
 	res, err := c.q.Exec(ctx, query, uid)
 	if err != nil {
 		return stacktrace.Propagate(err, "Error in query: %s", query)
@@ -203,23 +309,89 @@ func (c *repo) DeleteConstraint(ctx context.Context, id dssmodels.ID) error {
 	return nil
 }
 
-// Implements scd.repos.Constraint.SearchConstraints
-func (c *repo) SearchConstraints(ctx context.Context, v4d *dssmodels.Volume4D) ([]*scdmodels.Constraint, error) {
+// Implements scd.repos.Constraint.ListConstraintsByManager
+func (c *repo) ListConstraintsByManager(ctx context.Context, manager dssmodels.Manager) ([]*scdmodels.Constraint, error) {
+	var (
+		query = fmt.Sprintf(`
+			SELECT
+				%s
+			FROM
+				%s
+			WHERE
+				owner = $1
+			LIMIT $2`, c.constraintFields(), c.constraintSource())
+	)
+
+	constraints, err := c.fetchConstraints(ctx, c.q, query, manager, dssmodels.MaxResultLimit)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error fetching Constraints")
+	}
+	return constraints, nil
+}
+
+// Implements scd.repos.Constraint.ListConstraints
+func (c *repo) ListConstraints(ctx context.Context, after dssmodels.ID, limit int) ([]*scdmodels.Constraint, error) {
 	var (
 		query = fmt.Sprintf(`
 			SELECT
 				%s
 			FROM
-				scd_constraints
+				%s
+			WHERE
+				($1::UUID IS NULL OR id > $1)
+			ORDER BY
+				id
+			LIMIT $2`, c.constraintFields(), c.constraintSource())
+	)
+
+	afterID, err := after.NullablePgUUID()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	constraints, err := c.fetchConstraints(ctx, c.q, query, afterID, limit)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error fetching Constraints")
+	}
+	return constraints, nil
+}
+
+// Implements scd.repos.Constraint.RestoreConstraint. The constraint is
+// written without archiving a prior version of it, as updated at the time it
+// was backed up with, so that its OVN is kept if OVNs are not stored.
+func (c *repo) RestoreConstraint(ctx context.Context, constraint *scdmodels.Constraint) error {
+	if _, err := c.writeConstraint(ctx, constraint, restoredUpdatedAt(constraint.UpdatedAt)); err != nil {
+		return err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	if err := c.restoreOVN(ctx, "scd_constraints", constraint.ID, constraint.OVN); err != nil {
+		return stacktrace.Propagate(err, "Error restoring Constraint OVN")
+	}
+	if err := c.upsertConstraintAttributes(ctx, constraint); err != nil {
+		return stacktrace.Propagate(err, "Error restoring attributes of Constraint")
+	}
+	return nil
+}
+
+// constraintsIntersectingVolumeQuery returns a query selecting fields of the
+// constraints of source intersecting the volume described by the arguments
+// returned by constraintsIntersectingVolumeArgs.
+func constraintsIntersectingVolumeQuery(fields string, source string) string {
+	return fmt.Sprintf(`
+			SELECT
+				%s
+			FROM
+				%s
 			WHERE
 			  cells && $1
 			AND
 				COALESCE(starts_at <= $3, true)
 			AND
 				COALESCE(ends_at >= $2, true)
-			LIMIT $4`, constraintFieldsWithoutPrefix)
-	)
+			LIMIT $4`, fields, source)
+}
 
+// constraintsIntersectingVolumeArgs returns nil and no error if v4d does not
+// cover any cell.
+func constraintsIntersectingVolumeArgs(v4d *dssmodels.Volume4D) ([]interface{}, error) {
 	// TODO: Lazily calculate & cache spatial covering so that it is only ever
 	// computed once on a particular Volume4D
 	cells, err := v4d.CalculateSpatialCovering()
@@ -228,7 +400,7 @@ func (c *repo) SearchConstraints(ctx context.Context, v4d *dssmodels.Volume4D) (
 	}
 
 	if len(cells) == 0 {
-		return []*scdmodels.Constraint{}, nil
+		return nil, nil
 	}
 
 	cids := make([]int64, len(cells))
@@ -241,8 +413,46 @@ func (c *repo) SearchConstraints(ctx context.Context, v4d *dssmodels.Volume4D) (
 		return nil, stacktrace.Propagate(err, "Failed to convert array to jackc/pgtype")
 	}
 
-	constraints, err := c.fetchConstraints(
-		ctx, c.q, query, pgCids, v4d.StartTime, v4d.EndTime, dssmodels.MaxResultLimit)
+	return []interface{}{pgCids, v4d.StartTime, v4d.EndTime, dssmodels.MaxResultLimit}, nil
+}
+
+// categoriesSource returns the constraints of source whose category is one of
+// the categories of parameter param.
+func categoriesSource(source string, param int) string {
+	return fmt.Sprintf(`(
+				SELECT * FROM %s WHERE category = ANY($%d)
+			) AS scd_constraints`, source, param)
+}
+
+// Implements scd.repos.Constraint.SearchConstraints
+func (c *repo) SearchConstraints(ctx context.Context, v4d *dssmodels.Volume4D, categories []scdmodels.ConstraintCategory) ([]*scdmodels.Constraint, error) {
+	args, err := constraintsIntersectingVolumeArgs(v4d)
+	if err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	if args == nil {
+		return []*scdmodels.Constraint{}, nil
+	}
+
+	source := c.constraintSource()
+	if len(categories) > 0 {
+		if !c.storeConstraintCategories {
+			// Constraints have no category with the current schema.
+			return []*scdmodels.Constraint{}, nil
+		}
+		cats := make([]string, len(categories))
+		for i, category := range categories {
+			cats[i] = category.String()
+		}
+		var pgCategories pgtype.TextArray
+		if err := pgCategories.Set(cats); err != nil {
+			return nil, stacktrace.Propagate(err, "Failed to convert array to jackc/pgtype")
+		}
+		args = append(args, pgCategories)
+		source = categoriesSource(source, len(args))
+	}
+
+	constraints, err := c.fetchConstraints(ctx, c.q, constraintsIntersectingVolumeQuery(c.constraintFields(), source), args...)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Error fetching Constraints")
 	}
@@ -0,0 +1,174 @@
+package cockroach
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/stacktrace"
+	"github.com/jackc/pgtype"
+)
+
+// operationalIntentHistoryAttributes returns the attributes of operations
+// retained along with their prior versions with the current schema.
+func (s *repo) operationalIntentHistoryAttributes() []attribute {
+	if !s.historyAttributes {
+		return nil
+	}
+	attributes, _ := s.operationalIntentAttributes()
+	return attributes
+}
+
+// constraintHistoryAttributes returns the attributes of constraints retained
+// along with their prior versions with the current schema.
+func (c *repo) constraintHistoryAttributes() []attribute {
+	if !c.historyAttributes {
+		return nil
+	}
+	attributes, _ := c.constraintAttributes()
+	return attributes
+}
+
+// archiveOperationalIntent copies the current version of the operation
+// identified by id, if any, to the history table before it is modified or
+// deleted.
+func (s *repo) archiveOperationalIntent(ctx context.Context, id *pgtype.UUID) error {
+	if !s.retainHistory {
+		return nil
+	}
+	var (
+		attributes = s.operationalIntentHistoryAttributes()
+		source     = "scd_operations"
+	)
+	if len(attributes) > 0 {
+		source = s.operationalIntentSource()
+	}
+	var query = fmt.Sprintf(`
+		UPSERT INTO
+			scd_operations_history
+			(%s%s, superseded_at)
+		SELECT
+			%s%s, transaction_timestamp()
+		FROM
+			%s
+		WHERE
+			id = $1`,
+		operationFieldsWithoutPrefix, attributeNames(attributes),
+		operationFieldsWithPrefix, attributeFields("scd_operations", attributes),
+		source)
+
+	if _, err := s.q.Exec(ctx, query, id); err != nil {
+		return stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	return nil
+}
+
+// archiveConstraint copies the current version of the constraint identified
+// by id, if any, to the history table before it is modified or deleted.
+func (c *repo) archiveConstraint(ctx context.Context, id *pgtype.UUID) error {
+	if !c.retainHistory {
+		return nil
+	}
+	var (
+		attributes = c.constraintHistoryAttributes()
+		source     = "scd_constraints"
+	)
+	if len(attributes) > 0 {
+		source = c.constraintSource()
+	}
+	var query = fmt.Sprintf(`
+		UPSERT INTO
+			scd_constraints_history
+			(%s%s, superseded_at)
+		SELECT
+			%s%s, transaction_timestamp()
+		FROM
+			%s
+		WHERE
+			id = $1`,
+		constraintFieldsWithoutPrefix, attributeNames(attributes),
+		constraintFieldsWithPrefix, attributeFields("scd_constraints", attributes),
+		source)
+
+	if _, err := c.q.Exec(ctx, query, id); err != nil {
+		return stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	return nil
+}
+
+// asOfSource returns a subquery, aliased as table, selecting fields of the
+// versions of the entities of source, exposing the current versions under
+// the table name, and of the history table of table that were current at the
+// time passed as the param-th query argument.
+func asOfSource(table string, source string, fields string, param int) string {
+	return fmt.Sprintf(`(
+				SELECT %s FROM %s WHERE updated_at <= $%d
+				UNION ALL
+				SELECT %s FROM %s_history WHERE updated_at <= $%d AND superseded_at > $%d
+			) AS %s`, fields, source, param, fields, table, param, param, table)
+}
+
+// SearchOperationalIntentsAsOf implements
+// repos.History.SearchOperationalIntentsAsOf.
+func (s *repo) SearchOperationalIntentsAsOf(ctx context.Context, v4d *dssmodels.Volume4D, asOf time.Time) ([]*scdmodels.OperationalIntent, error) {
+	if !s.retainHistory {
+		return nil, stacktrace.NewError("Entity history is not supported by the current schema version of the database")
+	}
+
+	args, err := operationsIntersectingVolumeArgs(v4d)
+	if err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	args = append(args, asOf)
+
+	// Cells and attributes are read from the selected versions rather than the
+	// current ones. Versions retained without their attributes are read
+	// without them.
+	var (
+		attributes = s.operationalIntentHistoryAttributes()
+		current    = "scd_operations"
+	)
+	if len(attributes) > 0 {
+		current = s.operationalIntentSource()
+	}
+	source := asOfSource("scd_operations", current, operationFieldsWithoutPrefix+attributeNames(attributes), len(args))
+	result, err := s.searchOperationalIntentsIn(ctx, s.q, source, len(attributes) > 0, args)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error fetching Operations")
+	}
+	return result, nil
+}
+
+// SearchConstraintsAsOf implements repos.History.SearchConstraintsAsOf.
+func (c *repo) SearchConstraintsAsOf(ctx context.Context, v4d *dssmodels.Volume4D, asOf time.Time) ([]*scdmodels.Constraint, error) {
+	if !c.retainHistory {
+		return nil, stacktrace.NewError("Entity history is not supported by the current schema version of the database")
+	}
+
+	args, err := constraintsIntersectingVolumeArgs(v4d)
+	if err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	if args == nil {
+		return []*scdmodels.Constraint{}, nil
+	}
+	args = append(args, asOf)
+
+	var (
+		attributes = c.constraintHistoryAttributes()
+		current    = "scd_constraints"
+	)
+	if len(attributes) > 0 {
+		current = c.constraintSource()
+	}
+	source := asOfSource("scd_constraints", current, constraintFieldsWithoutPrefix+attributeNames(attributes), len(args))
+	query := constraintsIntersectingVolumeQuery(constraintFieldsWithPrefix+attributeFields("scd_constraints", attributes), source)
+
+	constraints, err := c.scanConstraints(ctx, c.q, len(attributes) > 0, query, args...)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error fetching Constraints")
+	}
+	return constraints, nil
+}
@@ -0,0 +1,283 @@
+package cockroach
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/geo/s2"
+	"github.com/google/uuid"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/dss/pkg/scd/repos"
+	"github.com/stretchr/testify/require"
+)
+
+// setUpHistoryStore returns a Store as setUpStore, skipping t if the schema
+// of the database does not retain the attributes of prior versions.
+func setUpHistoryStore(ctx context.Context, t *testing.T) (*Store, func()) {
+	store, tearDownStore := setUpStore(ctx, t)
+	if !store.newRepo(store.db.Pool).historyAttributes {
+		tearDownStore()
+		t.Skipf("Schema version %s does not retain the attributes of prior versions", store.version)
+	}
+	return store, tearDownStore
+}
+
+// historyVolume returns the Volume4D covering cells at all times and
+// altitudes.
+func historyVolume(cells s2.CellUnion) *dssmodels.Volume4D {
+	return &dssmodels.Volume4D{
+		SpatialVolume: &dssmodels.Volume3D{
+			Footprint: dssmodels.GeometryFunc(func() (s2.CellUnion, error) {
+				return cells, nil
+			}),
+		},
+	}
+}
+
+func requireEqualTimes(t *testing.T, expected *time.Time, actual *time.Time) {
+	if expected == nil {
+		require.Nil(t, actual)
+		return
+	}
+	require.NotNil(t, actual)
+	require.True(t, expected.Equal(*actual), "expected %s, got %s", expected, actual)
+}
+
+func requireEqualOperationalIntents(t *testing.T, expected *scdmodels.OperationalIntent, actual *scdmodels.OperationalIntent) {
+	require.Equal(t, expected.ID, actual.ID)
+	require.Equal(t, expected.Manager, actual.Manager)
+	require.Equal(t, expected.Version, actual.Version)
+	require.Equal(t, expected.OVN, actual.OVN)
+	require.Equal(t, expected.State, actual.State)
+	require.Equal(t, expected.Priority, actual.Priority)
+	require.Equal(t, expected.USSBaseURL, actual.USSBaseURL)
+	require.Equal(t, expected.SubscriptionID, actual.SubscriptionID)
+	require.Equal(t, expected.AltitudeLower, actual.AltitudeLower)
+	require.Equal(t, expected.AltitudeUpper, actual.AltitudeUpper)
+	requireEqualTimes(t, expected.StartTime, actual.StartTime)
+	requireEqualTimes(t, expected.EndTime, actual.EndTime)
+	require.Equal(t, expected.Cells, actual.Cells)
+	if expected.OffNominalVolume == nil {
+		require.Nil(t, actual.OffNominalVolume)
+		return
+	}
+	require.NotNil(t, actual.OffNominalVolume)
+	require.Equal(t, expected.OffNominalVolume.AltitudeLower, actual.OffNominalVolume.AltitudeLower)
+	require.Equal(t, expected.OffNominalVolume.AltitudeUpper, actual.OffNominalVolume.AltitudeUpper)
+	requireEqualTimes(t, expected.OffNominalVolume.StartTime, actual.OffNominalVolume.StartTime)
+	requireEqualTimes(t, expected.OffNominalVolume.EndTime, actual.OffNominalVolume.EndTime)
+	require.Equal(t, expected.OffNominalVolume.Cells, actual.OffNominalVolume.Cells)
+}
+
+func requireEqualConstraints(t *testing.T, expected *scdmodels.Constraint, actual *scdmodels.Constraint) {
+	require.Equal(t, expected.ID, actual.ID)
+	require.Equal(t, expected.Manager, actual.Manager)
+	require.Equal(t, expected.Version, actual.Version)
+	require.Equal(t, expected.OVN, actual.OVN)
+	require.Equal(t, expected.Category, actual.Category)
+	require.Equal(t, expected.Authority, actual.Authority)
+	require.Equal(t, expected.Reason, actual.Reason)
+	require.Equal(t, expected.USSBaseURL, actual.USSBaseURL)
+	require.Equal(t, expected.AltitudeLower, actual.AltitudeLower)
+	require.Equal(t, expected.AltitudeUpper, actual.AltitudeUpper)
+	requireEqualTimes(t, expected.StartTime, actual.StartTime)
+	requireEqualTimes(t, expected.EndTime, actual.EndTime)
+	require.Equal(t, expected.Cells, actual.Cells)
+}
+
+func TestSearchOperationalIntentsAsOf(t *testing.T) {
+	var (
+		ctx                  = context.Background()
+		store, tearDownStore = setUpHistoryStore(ctx, t)
+		manager              = dssmodels.Manager(fmt.Sprintf("history-%s", uuid.New()))
+		cells                = s2.CellUnion{s2.CellIDFromLatLng(s2.LatLngFromDegrees(37.4, -122.1)).Parent(13)}
+		offNominalCells      = s2.CellUnion{s2.CellIDFromLatLng(s2.LatLngFromDegrees(37.5, -122.1)).Parent(13)}
+		start                = time.Now().Add(time.Hour).Truncate(time.Microsecond)
+		end                  = start.Add(time.Hour)
+		altLower             = float32(0)
+		altUpper             = float32(100)
+		altUpper2            = float32(200)
+	)
+	defer tearDownStore()
+
+	var sub *scdmodels.Subscription
+	require.NoError(t, store.Transact(ctx, func(ctx context.Context, r repos.Repository) error {
+		var err error
+		sub, err = r.UpsertSubscription(ctx, &scdmodels.Subscription{
+			ID:                          dssmodels.ID(uuid.New().String()),
+			Manager:                     manager,
+			StartTime:                   &start,
+			EndTime:                     &end,
+			USSBaseURL:                  "https://example.com/uss",
+			NotifyForOperationalIntents: true,
+			ImplicitSubscription:        true,
+			Cells:                       cells,
+		})
+		return err
+	}))
+	defer func() {
+		// Operations are deleted along with the Subscription they depend on.
+		require.NoError(t, store.Transact(ctx, func(ctx context.Context, r repos.Repository) error {
+			return r.DeleteSubscription(ctx, sub.ID)
+		}))
+	}()
+
+	write := func(op *scdmodels.OperationalIntent) *scdmodels.OperationalIntent {
+		var written *scdmodels.OperationalIntent
+		require.NoError(t, store.Transact(ctx, func(ctx context.Context, r repos.Repository) error {
+			var err error
+			written, err = r.UpsertOperationalIntent(ctx, op)
+			return err
+		}))
+		return written
+	}
+	id := dssmodels.ID(uuid.New().String())
+	v1 := write(&scdmodels.OperationalIntent{
+		ID:             id,
+		Manager:        manager,
+		Version:        1,
+		State:          scdmodels.OperationalIntentStateNonconforming,
+		Priority:       2,
+		StartTime:      &start,
+		EndTime:        &end,
+		USSBaseURL:     "https://example.com/uss",
+		SubscriptionID: sub.ID,
+		AltitudeLower:  &altLower,
+		AltitudeUpper:  &altUpper,
+		Cells:          cells,
+		OffNominalVolume: &scdmodels.OffNominalVolume{
+			StartTime:     &start,
+			EndTime:       &end,
+			AltitudeLower: &altLower,
+			AltitudeUpper: &altUpper2,
+			Cells:         offNominalCells,
+		},
+	})
+	asOf := time.Now()
+	time.Sleep(10 * time.Millisecond)
+	v2 := write(&scdmodels.OperationalIntent{
+		ID:             id,
+		Manager:        manager,
+		Version:        2,
+		State:          scdmodels.OperationalIntentStateActivated,
+		StartTime:      &start,
+		EndTime:        &end,
+		USSBaseURL:     "https://example.com/uss/v2",
+		SubscriptionID: sub.ID,
+		AltitudeLower:  &altLower,
+		AltitudeUpper:  &altUpper2,
+		Cells:          cells,
+	})
+	require.NotEqual(t, v1.OVN, v2.OVN)
+
+	find := func(v4d *dssmodels.Volume4D, at time.Time) *scdmodels.OperationalIntent {
+		repo, err := store.Interact(ctx)
+		require.NoError(t, err)
+		ops, err := repo.SearchOperationalIntentsAsOf(ctx, v4d, at)
+		require.NoError(t, err)
+		for _, op := range ops {
+			if op.ID == id {
+				return op
+			}
+		}
+		return nil
+	}
+
+	found := find(historyVolume(cells), asOf)
+	require.NotNil(t, found)
+	requireEqualOperationalIntents(t, v1, found)
+
+	// The prior version is found through its off-nominal volume, which the
+	// current version no longer has.
+	found = find(historyVolume(offNominalCells), asOf)
+	require.NotNil(t, found)
+	requireEqualOperationalIntents(t, v1, found)
+	require.Nil(t, find(historyVolume(offNominalCells), time.Now()))
+
+	found = find(historyVolume(cells), time.Now())
+	require.NotNil(t, found)
+	requireEqualOperationalIntents(t, v2, found)
+}
+
+func TestSearchConstraintsAsOf(t *testing.T) {
+	var (
+		ctx                  = context.Background()
+		store, tearDownStore = setUpHistoryStore(ctx, t)
+		manager              = dssmodels.Manager(fmt.Sprintf("history-%s", uuid.New()))
+		cells                = s2.CellUnion{s2.CellIDFromLatLng(s2.LatLngFromDegrees(37.4, -122.1)).Parent(13)}
+		start                = time.Now().Add(time.Hour).Truncate(time.Microsecond)
+		end                  = start.Add(time.Hour)
+		altLower             = float32(0)
+		altUpper             = float32(100)
+		id                   = dssmodels.ID(uuid.New().String())
+	)
+	defer tearDownStore()
+
+	write := func(constraint *scdmodels.Constraint) *scdmodels.Constraint {
+		var written *scdmodels.Constraint
+		require.NoError(t, store.Transact(ctx, func(ctx context.Context, r repos.Repository) error {
+			var err error
+			written, err = r.UpsertConstraint(ctx, constraint)
+			return err
+		}))
+		return written
+	}
+	v1 := write(&scdmodels.Constraint{
+		ID:            id,
+		Manager:       manager,
+		Version:       1,
+		Category:      scdmodels.ConstraintCategoryAdvisory,
+		Authority:     "Authority",
+		Reason:        "Reason",
+		StartTime:     &start,
+		EndTime:       &end,
+		USSBaseURL:    "https://example.com/uss",
+		AltitudeLower: &altLower,
+		AltitudeUpper: &altUpper,
+		Cells:         cells,
+	})
+	defer func() {
+		require.NoError(t, store.Transact(ctx, func(ctx context.Context, r repos.Repository) error {
+			return r.DeleteConstraint(ctx, id)
+		}))
+	}()
+	asOf := time.Now()
+	time.Sleep(10 * time.Millisecond)
+	v2 := write(&scdmodels.Constraint{
+		ID:            id,
+		Manager:       manager,
+		Version:       2,
+		Category:      scdmodels.ConstraintCategoryRestricted,
+		StartTime:     &start,
+		EndTime:       &end,
+		USSBaseURL:    "https://example.com/uss/v2",
+		AltitudeLower: &altLower,
+		AltitudeUpper: &altUpper,
+		Cells:         cells,
+	})
+	require.NotEqual(t, v1.OVN, v2.OVN)
+
+	find := func(at time.Time) *scdmodels.Constraint {
+		repo, err := store.Interact(ctx)
+		require.NoError(t, err)
+		constraints, err := repo.SearchConstraintsAsOf(ctx, historyVolume(cells), at)
+		require.NoError(t, err)
+		for _, constraint := range constraints {
+			if constraint.ID == id {
+				return constraint
+			}
+		}
+		return nil
+	}
+
+	found := find(asOf)
+	require.NotNil(t, found)
+	requireEqualConstraints(t, v1, found)
+
+	found = find(time.Now())
+	require.NotNil(t, found)
+	requireEqualConstraints(t, v2, found)
+}
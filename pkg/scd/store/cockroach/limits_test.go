@@ -0,0 +1,149 @@
+package cockroach
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/geo/s2"
+	"github.com/google/uuid"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/dss/pkg/scd/repos"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountsByManager(t *testing.T) {
+	var (
+		ctx                  = context.Background()
+		store, tearDownStore = setUpStore(ctx, t)
+		manager              = dssmodels.Manager(fmt.Sprintf("limits-%s", uuid.New()))
+		otherManager         = dssmodels.Manager(fmt.Sprintf("limits-%s", uuid.New()))
+		cell1                = s2.CellIDFromLatLng(s2.LatLngFromDegrees(37.4, -122.1)).Parent(13)
+		cell2                = s2.CellIDFromLatLng(s2.LatLngFromDegrees(37.5, -122.1)).Parent(13)
+		start                = time.Now().Add(time.Hour)
+		end                  = start.Add(time.Hour)
+		pastStart            = time.Now().Add(-2 * time.Hour)
+		pastEnd              = pastStart.Add(time.Hour)
+		altLower             = float32(0)
+		altUpper             = float32(100)
+	)
+	defer tearDownStore()
+
+	newSubscription := func(manager dssmodels.Manager, implicit bool, end *time.Time, cells ...s2.CellID) *scdmodels.Subscription {
+		return &scdmodels.Subscription{
+			ID:                          dssmodels.ID(uuid.New().String()),
+			Manager:                     manager,
+			StartTime:                   &pastStart,
+			EndTime:                     end,
+			USSBaseURL:                  "https://example.com/uss",
+			NotifyForOperationalIntents: true,
+			ImplicitSubscription:        implicit,
+			Cells:                       cells,
+		}
+	}
+	var (
+		explicit1     = newSubscription(manager, false, &end, cell1)
+		explicit2     = newSubscription(manager, false, &end, cell1, cell2)
+		endedExplicit = newSubscription(manager, false, &pastEnd, cell1)
+		implicit      = newSubscription(manager, true, &end, cell1)
+		endedImplicit = newSubscription(manager, true, &pastEnd, cell1)
+		otherExplicit = newSubscription(otherManager, false, &end, cell1)
+		subs          = []*scdmodels.Subscription{explicit1, explicit2, endedExplicit, implicit, endedImplicit, otherExplicit}
+	)
+	newOperationalIntent := func(manager dssmodels.Manager, start *time.Time, end *time.Time) *scdmodels.OperationalIntent {
+		return &scdmodels.OperationalIntent{
+			ID:             dssmodels.ID(uuid.New().String()),
+			Manager:        manager,
+			Version:        1,
+			State:          scdmodels.OperationalIntentStateAccepted,
+			StartTime:      start,
+			EndTime:        end,
+			USSBaseURL:     "https://example.com/uss",
+			SubscriptionID: implicit.ID,
+			AltitudeLower:  &altLower,
+			AltitudeUpper:  &altUpper,
+			Cells:          s2.CellUnion{cell1},
+		}
+	}
+	ops := []*scdmodels.OperationalIntent{
+		newOperationalIntent(manager, &start, &end),
+		newOperationalIntent(manager, &pastStart, &pastEnd),
+		newOperationalIntent(otherManager, &start, &end),
+	}
+
+	require.NoError(t, store.Transact(ctx, func(ctx context.Context, r repos.Repository) error {
+		for _, sub := range subs {
+			if _, err := r.UpsertSubscription(ctx, sub); err != nil {
+				return err
+			}
+		}
+		for _, op := range ops {
+			if _, err := r.UpsertOperationalIntent(ctx, op); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	defer func() {
+		require.NoError(t, store.Transact(ctx, func(ctx context.Context, r repos.Repository) error {
+			for _, op := range ops {
+				if err := r.DeleteOperationalIntent(ctx, op.ID); err != nil {
+					return err
+				}
+			}
+			for _, sub := range subs {
+				if err := r.DeleteSubscription(ctx, sub.ID); err != nil {
+					return err
+				}
+			}
+			return nil
+		}))
+	}()
+
+	repo, err := store.Interact(ctx)
+	require.NoError(t, err)
+
+	t.Run("active OperationalIntents", func(t *testing.T) {
+		count, err := repo.CountActiveOperationalIntentsByManager(ctx, manager)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		count, err = repo.CountActiveOperationalIntentsByManager(ctx, otherManager)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+	})
+
+	t.Run("Subscriptions in cells", func(t *testing.T) {
+		none := dssmodels.ID(uuid.New().String())
+		for _, tc := range []struct {
+			name    string
+			cells   s2.CellUnion
+			exclude dssmodels.ID
+			want    int
+		}{
+			{name: "shared cell", cells: s2.CellUnion{cell1}, exclude: none, want: 2},
+			{name: "shared cell excluding one", cells: s2.CellUnion{cell1}, exclude: explicit1.ID, want: 1},
+			{name: "single cell", cells: s2.CellUnion{cell2}, exclude: none, want: 1},
+			{name: "both cells", cells: s2.CellUnion{cell1, cell2}, exclude: none, want: 2},
+			{name: "other cell", cells: s2.CellUnion{cell2.Next()}, exclude: none, want: 0},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				count, err := repo.MaxSubscriptionCountInCellsByManager(ctx, tc.cells, manager, tc.exclude)
+				require.NoError(t, err)
+				require.Equal(t, tc.want, count)
+			})
+		}
+	})
+
+	t.Run("implicit Subscriptions", func(t *testing.T) {
+		count, err := repo.CountImplicitSubscriptionsByManager(ctx, manager)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		count, err = repo.CountImplicitSubscriptionsByManager(ctx, otherManager)
+		require.NoError(t, err)
+		require.Equal(t, 0, count)
+	})
+}
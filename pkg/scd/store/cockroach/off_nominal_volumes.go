@@ -0,0 +1,89 @@
+package cockroach
+
+import (
+	"context"
+	"fmt"
+
+	dsserr "github.com/interuss/dss/pkg/errors"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/stacktrace"
+	"github.com/jackc/pgtype"
+)
+
+const (
+	offNominalVolumeFields = "operational_intent_id, altitude_lower, altitude_upper, starts_at, ends_at, cells"
+)
+
+// upsertOffNominalVolume stores the OffNominalVolume of operation, or deletes
+// the stored one if operation has none.
+func (s *repo) upsertOffNominalVolume(ctx context.Context, operation *scdmodels.OperationalIntent) error {
+	if !s.storeOffNominalVolumes {
+		if operation.OffNominalVolume != nil {
+			return stacktrace.NewErrorWithCode(dsserr.BadRequest, "Off-nominal volumes are not supported by the current schema version of the database")
+		}
+		return nil
+	}
+
+	var (
+		upsertQuery = fmt.Sprintf(`
+			UPSERT INTO
+				scd_operational_intent_off_nominal_volumes
+				(%s)
+			VALUES
+				($1, $2, $3, $4, $5, $6)`, offNominalVolumeFields)
+		deleteQuery = `
+			DELETE FROM
+				scd_operational_intent_off_nominal_volumes
+			WHERE
+				operational_intent_id = $1`
+	)
+
+	uid, err := operation.ID.PgUUID()
+	if err != nil {
+		return stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	v := operation.OffNominalVolume
+	if v == nil {
+		if _, err := s.q.Exec(ctx, deleteQuery, uid); err != nil {
+			return stacktrace.Propagate(err, "Error in query: %s", deleteQuery)
+		}
+		return nil
+	}
+
+	cids := make([]int64, len(v.Cells))
+	for i, cell := range v.Cells {
+		cids[i] = int64(cell)
+	}
+	var pgCids pgtype.Int8Array
+	if err := pgCids.Set(cids); err != nil {
+		return stacktrace.Propagate(err, "Failed to convert array to jackc/pgtype")
+	}
+	if _, err := s.q.Exec(ctx, upsertQuery, uid, v.AltitudeLower, v.AltitudeUpper, v.StartTime, v.EndTime, pgCids); err != nil {
+		return stacktrace.Propagate(err, "Error in query: %s", upsertQuery)
+	}
+	return nil
+}
+
+// operationsIntersectingOffNominalVolumeQuery returns a query selecting fields
+// of the operations of source whose OffNominalVolume intersects the volume
+// described by the arguments returned by operationsIntersectingVolumeArgs.
+// source must expose the operation fields along with their off-nominal
+// attributes under the scd_operations name.
+func operationsIntersectingOffNominalVolumeQuery(fields string, source string) string {
+	return fmt.Sprintf(`
+			SELECT
+				%s
+			FROM
+				%s
+			WHERE
+				scd_operations.off_nominal_cells && $1
+			AND
+				COALESCE(scd_operations.off_nominal_altitude_upper >= $2, true)
+			AND
+				COALESCE(scd_operations.off_nominal_altitude_lower <= $3, true)
+			AND
+				COALESCE(scd_operations.off_nominal_ends_at >= $4, true)
+			AND
+				COALESCE(scd_operations.off_nominal_starts_at <= $5, true)
+			LIMIT $6`, fields, source)
+}
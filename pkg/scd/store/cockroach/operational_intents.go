@@ -6,8 +6,8 @@ import (
 	"strings"
 	"time"
 
-	"github.com/golang/geo/s2"
 	dsserr "github.com/interuss/dss/pkg/errors"
+	"github.com/interuss/dss/pkg/geo"
 	dssmodels "github.com/interuss/dss/pkg/models"
 	scdmodels "github.com/interuss/dss/pkg/scd/models"
 	dsssql "github.com/interuss/dss/pkg/sql"
@@ -50,7 +50,75 @@ func init() {
 	)
 }
 
+// operationalIntentAttributes returns the attributes of operations selected
+// along with operationFieldsWithIndices by operationalIntentSource, and the
+// joins of scd_operations with the tables storing them with the current
+// schema.
+func (s *repo) operationalIntentAttributes() ([]attribute, string) {
+	var (
+		attributes []attribute
+		joins      string
+	)
+	if s.storeOVNs {
+		attributes = append(attributes, attribute{"ovn", "scd_operations.ovn"})
+	}
+	if s.storePriorities {
+		attributes = append(attributes, attribute{"priority", "COALESCE(priorities.priority, 0)"})
+		joins += `
+			LEFT JOIN
+				scd_operational_intent_priorities AS priorities
+			ON
+				priorities.operational_intent_id = scd_operations.id`
+	}
+	if s.storeOffNominalVolumes {
+		attributes = append(attributes,
+			attribute{"off_nominal_altitude_lower", "off_nominal.altitude_lower"},
+			attribute{"off_nominal_altitude_upper", "off_nominal.altitude_upper"},
+			attribute{"off_nominal_starts_at", "off_nominal.starts_at"},
+			attribute{"off_nominal_ends_at", "off_nominal.ends_at"},
+			attribute{"off_nominal_cells", "off_nominal.cells"},
+		)
+		joins += `
+			LEFT JOIN
+				scd_operational_intent_off_nominal_volumes AS off_nominal
+			ON
+				off_nominal.operational_intent_id = scd_operations.id`
+	}
+	return attributes, joins
+}
+
+// operationalIntentSource returns the source of the operations read by s,
+// exposing under the scd_operations name the fields of
+// operationalIntentFields, so that operations are read along with the
+// attributes stored outside of their rows with a single query.
+func (s *repo) operationalIntentSource() string {
+	attributes, joins := s.operationalIntentAttributes()
+	if len(attributes) == 0 {
+		return "scd_operations"
+	}
+	return fmt.Sprintf(`(
+			SELECT
+				%s,%s
+			FROM
+				scd_operations%s
+		) AS scd_operations`, operationFieldsWithPrefix, selectAttributes(attributes), joins)
+}
+
+// operationalIntentFields returns the fields of operationalIntentSource read
+// by scanOperationalIntents.
+func (s *repo) operationalIntentFields() string {
+	attributes, _ := s.operationalIntentAttributes()
+	return operationFieldsWithPrefix + attributeFields("scd_operations", attributes)
+}
+
 func (s *repo) fetchOperationalIntents(ctx context.Context, q dsssql.Queryable, query string, args ...interface{}) ([]*scdmodels.OperationalIntent, error) {
+	return s.scanOperationalIntents(ctx, q, true, query, args...)
+}
+
+// scanOperationalIntents returns the operations selected by query, using the
+// cells stored in the selected rows. query selects operationFieldsWithIndices
+// followed, if attributes, by the attributes of operationalIntentFields.
+func (s *repo) scanOperationalIntents(ctx context.Context, q dsssql.Queryable, attributes bool, query string, args ...interface{}) ([]*scdmodels.OperationalIntent, error) {
 	rows, err := q.Query(ctx, query, args...)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Error in query: %s", query)
@@ -61,10 +129,12 @@ func (s *repo) fetchOperationalIntents(ctx context.Context, q dsssql.Queryable,
 	pgCids := pgtype.Int8Array{}
 	for rows.Next() {
 		var (
-			o         = &scdmodels.OperationalIntent{}
-			updatedAt time.Time
+			o          = &scdmodels.OperationalIntent{}
+			ovn        = pgtype.Text{}
+			offNominal = &scdmodels.OffNominalVolume{}
+			pgOffCids  = pgtype.Int8Array{}
 		)
-		err := rows.Scan(
+		dest := []interface{}{
 			&o.ID,
 			&o.Manager,
 			&o.Version,
@@ -74,31 +144,48 @@ func (s *repo) fetchOperationalIntents(ctx context.Context, q dsssql.Queryable,
 			&o.StartTime,
 			&o.EndTime,
 			&o.SubscriptionID,
-			&updatedAt,
+			&o.UpdatedAt,
 			&o.State,
 			&pgCids,
-		)
-		if err != nil {
+		}
+		if attributes && s.storeOVNs {
+			dest = append(dest, &ovn)
+		}
+		if attributes && s.storePriorities {
+			dest = append(dest, &o.Priority)
+		}
+		if attributes && s.storeOffNominalVolumes {
+			dest = append(dest,
+				&offNominal.AltitudeLower,
+				&offNominal.AltitudeUpper,
+				&offNominal.StartTime,
+				&offNominal.EndTime,
+				&pgOffCids,
+			)
+		}
+		if err := rows.Scan(dest...); err != nil {
 			return nil, stacktrace.Propagate(err, "Error scanning Operation row")
 		}
 		var cids []int64
 		if err := pgCids.AssignTo(&cids); err != nil {
 			return nil, stacktrace.Propagate(err, "Error Converting jackc/pgtype to array")
 		}
-		o.OVN = scdmodels.NewOVNFromTime(updatedAt, o.ID.String())
+		o.OVN = ovnOrLegacy(ovn, o.UpdatedAt, o.ID)
 		o.SetCells(cids)
+		// Operations without off-nominal volume have no cells in the joined row.
+		if pgOffCids.Status == pgtype.Present {
+			var offCids []int64
+			if err := pgOffCids.AssignTo(&offCids); err != nil {
+				return nil, stacktrace.Propagate(err, "Error Converting jackc/pgtype to array")
+			}
+			offNominal.Cells = geo.CellUnionFromInt64(offCids)
+			o.OffNominalVolume = offNominal
+		}
 		payload = append(payload, o)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, stacktrace.Propagate(err, "Error in rows query result")
 	}
-
-	for _, op := range payload {
-		if err := s.populateOperationalIntentCells(ctx, q, op); err != nil {
-			return nil, stacktrace.Propagate(err, "Error populating cells for Operation %s", op.ID)
-		}
-	}
-
 	return payload, nil
 }
 
@@ -119,9 +206,9 @@ func (s *repo) fetchOperationalIntent(ctx context.Context, q dsssql.Queryable, q
 func (s *repo) fetchOperationByID(ctx context.Context, q dsssql.Queryable, id dssmodels.ID) (*scdmodels.OperationalIntent, error) {
 	query := fmt.Sprintf(`
 		SELECT %s FROM
-			scd_operations
+			%s
 		WHERE
-			id = $1`, operationFieldsWithoutPrefix)
+			id = $1`, s.operationalIntentFields(), s.operationalIntentSource())
 	uid, err := id.PgUUID()
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
@@ -129,40 +216,6 @@ func (s *repo) fetchOperationByID(ctx context.Context, q dsssql.Queryable, id ds
 	return s.fetchOperationalIntent(ctx, q, query, uid)
 }
 
-func (s *repo) populateOperationalIntentCells(ctx context.Context, q dsssql.Queryable, o *scdmodels.OperationalIntent) error {
-	const query = `
-	SELECT
-		unnest(cells) as cell_id
-	FROM
-		scd_operations
-	WHERE id = $1`
-
-	uid, err := o.ID.PgUUID()
-	if err != nil {
-		return stacktrace.Propagate(err, "Failed to convert id to PgUUID")
-	}
-	rows, err := q.Query(ctx, query, uid)
-	if err != nil {
-		return stacktrace.Propagate(err, "Error in query: %s", query)
-	}
-	defer rows.Close()
-
-	var cell int64
-	o.Cells = s2.CellUnion{}
-
-	for rows.Next() {
-		if err := rows.Scan(&cell); err != nil {
-			return stacktrace.Propagate(err, "Error scanning cell ID row")
-		}
-		o.Cells = append(o.Cells, s2.CellID(uint64(cell)))
-	}
-	if err := rows.Err(); err != nil {
-		return stacktrace.Propagate(err, "Error in rows query result")
-	}
-
-	return nil
-}
-
 // GetOperation implements repos.Operation.GetOperation.
 func (s *repo) GetOperationalIntent(ctx context.Context, id dssmodels.ID) (*scdmodels.OperationalIntent, error) {
 	return s.fetchOperationByID(ctx, s.q, id)
@@ -183,6 +236,9 @@ func (s *repo) DeleteOperationalIntent(ctx context.Context, id dssmodels.ID) err
 	if err != nil {
 		return stacktrace.Propagate(err, "Failed to convert id to PgUUID")
 	}
+	if err := s.archiveOperationalIntent(ctx, uid); err != nil {
+		return stacktrace.Propagate(err, "Error archiving Operation")
+	}
 	res, err := s.q.Exec(ctx, deleteOperationQuery, uid)
 	if err != nil {
 		return stacktrace.Propagate(err, "Error in query: %s", deleteOperationQuery)
@@ -197,13 +253,49 @@ func (s *repo) DeleteOperationalIntent(ctx context.Context, id dssmodels.ID) err
 
 // UpsertOperation implements repos.Operation.UpsertOperation.
 func (s *repo) UpsertOperationalIntent(ctx context.Context, operation *scdmodels.OperationalIntent) (*scdmodels.OperationalIntent, error) {
+	opid, err := operation.ID.PgUUID()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	if err := s.archiveOperationalIntent(ctx, opid); err != nil {
+		return nil, stacktrace.Propagate(err, "Error archiving Operation")
+	}
+	var (
+		priority   = operation.Priority
+		offNominal = operation.OffNominalVolume
+	)
+	operation, err = s.writeOperationalIntent(ctx, operation, nil)
+	if err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	operation.OVN, err = s.storeOVN(ctx, "scd_operations", operation.ID, operation.OVN)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error storing Operation OVN")
+	}
+	operation.Priority = priority
+	if err := s.upsertOperationalIntentPriority(ctx, operation); err != nil {
+		return nil, stacktrace.Propagate(err, "Error storing Operation priority")
+	}
+	operation.OffNominalVolume = offNominal
+	if err := s.upsertOffNominalVolume(ctx, operation); err != nil {
+		return nil, stacktrace.Propagate(err, "Error storing Operation off-nominal volume")
+	}
+
+	return operation, nil
+}
+
+// writeOperationalIntent writes the row of operation, without its OVN nor
+// the attributes stored outside of the row, and returns the written
+// operation. The row is written as updated at updatedAt, or at the time of
+// the transaction if nil.
+func (s *repo) writeOperationalIntent(ctx context.Context, operation *scdmodels.OperationalIntent, updatedAt *time.Time) (*scdmodels.OperationalIntent, error) {
 	var (
 		upsertOperationsQuery = fmt.Sprintf(`
 			UPSERT INTO
 				scd_operations
 				(%s)
 			VALUES
-				($1, $2, $3, $4, $5, $6, $7, $8, $9, transaction_timestamp(), $10, $11)
+				($1, $2, $3, $4, $5, $6, $7, $8, $9, COALESCE($12, transaction_timestamp()), $10, $11)
 			RETURNING
 				%s`, operationFieldsWithoutPrefix, operationFieldsWithPrefix)
 	)
@@ -229,7 +321,7 @@ func (s *repo) UpsertOperationalIntent(ctx context.Context, operation *scdmodels
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
 	}
-	operation, err = s.fetchOperationalIntent(ctx, s.q, upsertOperationsQuery,
+	operations, err := s.scanOperationalIntents(ctx, s.q, false, upsertOperationsQuery,
 		opid,
 		operation.Manager,
 		operation.Version,
@@ -241,21 +333,27 @@ func (s *repo) UpsertOperationalIntent(ctx context.Context, operation *scdmodels
 		subid,
 		operation.State,
 		pgCids,
+		updatedAt,
 	)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Error fetching Operation")
 	}
-
-	return operation, nil
+	if len(operations) != 1 {
+		return nil, stacktrace.NewError("Upsert returned %d Operations when only 1 was expected", len(operations))
+	}
+	return operations[0], nil
 }
 
-func (s *repo) searchOperationalIntents(ctx context.Context, q dsssql.Queryable, v4d *dssmodels.Volume4D) ([]*scdmodels.OperationalIntent, error) {
-	var (
-		operationsIntersectingVolumeQuery = fmt.Sprintf(`
+// operationsIntersectingVolumeQuery returns a query selecting fields of the
+// operations of source intersecting the volume described by the arguments
+// returned by operationsIntersectingVolumeArgs. source must expose the
+// operation fields under the scd_operations name.
+func operationsIntersectingVolumeQuery(fields string, source string) string {
+	return fmt.Sprintf(`
 			SELECT
 				%s
 			FROM
-				scd_operations
+				%s
 			WHERE
 				cells && $1
 			AND
@@ -266,9 +364,10 @@ func (s *repo) searchOperationalIntents(ctx context.Context, q dsssql.Queryable,
 				COALESCE(scd_operations.ends_at >= $4, true)
 			AND
 				COALESCE(scd_operations.starts_at <= $5, true)
-			LIMIT $6`, operationFieldsWithPrefix)
-	)
+			LIMIT $6`, fields, source)
+}
 
+func operationsIntersectingVolumeArgs(v4d *dssmodels.Volume4D) ([]interface{}, error) {
 	if v4d.SpatialVolume == nil || v4d.SpatialVolume.Footprint == nil {
 		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Missing geospatial footprint for query")
 	}
@@ -290,19 +389,58 @@ func (s *repo) searchOperationalIntents(ctx context.Context, q dsssql.Queryable,
 		return nil, stacktrace.Propagate(err, "Failed to convert array to jackc/pgtype")
 	}
 
-	result, err := s.fetchOperationalIntents(
-		ctx, q, operationsIntersectingVolumeQuery,
+	return []interface{}{
 		pgCids,
 		v4d.SpatialVolume.AltitudeLo,
 		v4d.SpatialVolume.AltitudeHi,
 		v4d.StartTime,
 		v4d.EndTime,
 		dssmodels.MaxResultLimit,
-	)
+	}, nil
+}
+
+func (s *repo) searchOperationalIntents(ctx context.Context, q dsssql.Queryable, v4d *dssmodels.Volume4D) ([]*scdmodels.OperationalIntent, error) {
+	args, err := operationsIntersectingVolumeArgs(v4d)
+	if err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+
+	return s.searchOperationalIntentsIn(ctx, q, s.operationalIntentSource(), true, args)
+}
+
+// searchOperationalIntentsIn returns the operations of source intersecting
+// the volume described by args, which start with the arguments returned by
+// operationsIntersectingVolumeArgs, read as by scanOperationalIntents with
+// attributes. The operations only intersecting the volume through their
+// off-nominal volume are included if attributes are read.
+func (s *repo) searchOperationalIntentsIn(ctx context.Context, q dsssql.Queryable, source string, attributes bool, args []interface{}) ([]*scdmodels.OperationalIntent, error) {
+	fields := operationFieldsWithPrefix
+	if attributes {
+		fields = s.operationalIntentFields()
+	}
+	result, err := s.scanOperationalIntents(ctx, q, attributes, operationsIntersectingVolumeQuery(fields, source), args...)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Error fetching Operations")
 	}
 
+	if attributes && s.storeOffNominalVolumes {
+		// Include the operations only intersecting the volume through their
+		// off-nominal volume.
+		offNominal, err := s.scanOperationalIntents(ctx, q, attributes, operationsIntersectingOffNominalVolumeQuery(fields, source), args...)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Error fetching Operations by off-nominal volume")
+		}
+		found := map[dssmodels.ID]bool{}
+		for _, op := range result {
+			found[op.ID] = true
+		}
+		for _, op := range offNominal {
+			if !found[op.ID] && len(result) < dssmodels.MaxResultLimit {
+				result = append(result, op)
+			}
+		}
+	}
+
 	return result, nil
 }
 
@@ -342,3 +480,87 @@ func (s *repo) GetDependentOperationalIntents(ctx context.Context, subscriptionI
 
 	return dependentOps, nil
 }
+
+// CountActiveOperationalIntentsByManager implements
+// repos.OperationalIntent.CountActiveOperationalIntentsByManager.
+func (s *repo) CountActiveOperationalIntentsByManager(ctx context.Context, manager dssmodels.Manager) (int, error) {
+	var countQuery = `
+      SELECT
+        COUNT(*)
+      FROM
+        scd_operations
+      WHERE
+        owner = $1
+      AND
+        COALESCE(ends_at >= $2, true)`
+
+	var count int
+	if err := s.q.QueryRow(ctx, countQuery, manager, s.clock.Now()).Scan(&count); err != nil {
+		return 0, stacktrace.Propagate(err, "Error in query: %s", countQuery)
+	}
+	return count, nil
+}
+
+// ListOperationalIntentsByManager implements
+// repos.OperationalIntent.ListOperationalIntentsByManager.
+func (s *repo) ListOperationalIntentsByManager(ctx context.Context, manager dssmodels.Manager) ([]*scdmodels.OperationalIntent, error) {
+	var query = fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			%s
+		WHERE
+			owner = $1
+		LIMIT $2`, s.operationalIntentFields(), s.operationalIntentSource())
+
+	result, err := s.fetchOperationalIntents(ctx, s.q, query, manager, dssmodels.MaxResultLimit)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error fetching Operations")
+	}
+	return result, nil
+}
+
+// ListOperationalIntents implements
+// repos.OperationalIntent.ListOperationalIntents.
+func (s *repo) ListOperationalIntents(ctx context.Context, after dssmodels.ID, limit int) ([]*scdmodels.OperationalIntent, error) {
+	var query = fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			%s
+		WHERE
+			($1::UUID IS NULL OR id > $1)
+		ORDER BY
+			id
+		LIMIT $2`, s.operationalIntentFields(), s.operationalIntentSource())
+
+	afterID, err := after.NullablePgUUID()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	result, err := s.fetchOperationalIntents(ctx, s.q, query, afterID, limit)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error fetching Operations")
+	}
+	return result, nil
+}
+
+// RestoreOperationalIntent implements
+// repos.OperationalIntent.RestoreOperationalIntent. The operation is written
+// without archiving a prior version of it, as updated at the time it was
+// backed up with, so that its OVN is kept if OVNs are not stored.
+func (s *repo) RestoreOperationalIntent(ctx context.Context, operation *scdmodels.OperationalIntent) error {
+	if _, err := s.writeOperationalIntent(ctx, operation, restoredUpdatedAt(operation.UpdatedAt)); err != nil {
+		return err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	if err := s.restoreOVN(ctx, "scd_operations", operation.ID, operation.OVN); err != nil {
+		return stacktrace.Propagate(err, "Error restoring Operation OVN")
+	}
+	if err := s.upsertOperationalIntentPriority(ctx, operation); err != nil {
+		return stacktrace.Propagate(err, "Error restoring Operation priority")
+	}
+	if err := s.upsertOffNominalVolume(ctx, operation); err != nil {
+		return stacktrace.Propagate(err, "Error restoring Operation off-nominal volume")
+	}
+	return nil
+}
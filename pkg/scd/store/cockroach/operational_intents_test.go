@@ -0,0 +1,256 @@
+package cockroach
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/geo/s2"
+	"github.com/google/uuid"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/dss/pkg/scd/repos"
+	"github.com/jackc/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+var benchmarkSearchSizes = []int{100, 1000, 5000}
+
+// seedOperationalIntents creates n OperationalIntents of a dedicated manager
+// in the same area, and returns the volume to search them with and a function
+// deleting them.
+func seedOperationalIntents(ctx context.Context, b *testing.B, store *Store, n int) (*dssmodels.Volume4D, func()) {
+	var (
+		manager  = dssmodels.Manager(fmt.Sprintf("benchmark-%s", uuid.New()))
+		cells    = s2.CellUnion{s2.CellIDFromLatLng(s2.LatLngFromDegrees(37.4, -122.1)).Parent(13)}
+		start    = time.Now().Add(time.Hour)
+		end      = start.Add(time.Hour)
+		altLower = float32(0)
+		altUpper = float32(100)
+	)
+	repo, err := store.Interact(ctx)
+	require.NoError(b, err)
+
+	sub, err := repo.UpsertSubscription(ctx, &scdmodels.Subscription{
+		ID:                          dssmodels.ID(uuid.New().String()),
+		Manager:                     manager,
+		StartTime:                   &start,
+		EndTime:                     &end,
+		USSBaseURL:                  "https://example.com/uss",
+		NotifyForOperationalIntents: true,
+		ImplicitSubscription:        true,
+		Cells:                       cells,
+	})
+	require.NoError(b, err)
+	for i := 0; i < n; i++ {
+		_, err := repo.UpsertOperationalIntent(ctx, &scdmodels.OperationalIntent{
+			ID:             dssmodels.ID(uuid.New().String()),
+			Manager:        manager,
+			Version:        1,
+			State:          scdmodels.OperationalIntentStateAccepted,
+			StartTime:      &start,
+			EndTime:        &end,
+			USSBaseURL:     "https://example.com/uss",
+			SubscriptionID: sub.ID,
+			AltitudeLower:  &altLower,
+			AltitudeUpper:  &altUpper,
+			Cells:          cells,
+		})
+		require.NoError(b, err)
+	}
+
+	vol4 := &dssmodels.Volume4D{
+		StartTime: &start,
+		EndTime:   &end,
+		SpatialVolume: &dssmodels.Volume3D{
+			AltitudeLo: &altLower,
+			AltitudeHi: &altUpper,
+			Footprint: dssmodels.GeometryFunc(func() (s2.CellUnion, error) {
+				return cells, nil
+			}),
+		},
+	}
+	return vol4, func() {
+		// Operations are deleted along with the Subscription they depend on.
+		require.NoError(b, repo.DeleteSubscription(ctx, sub.ID))
+	}
+}
+
+// Queries of the searches of OperationalIntents before their attributes were
+// read by the search query itself, which BenchmarkSearchOperationalIntentsBaseline
+// replays.
+var (
+	baselineVolumeQuery = fmt.Sprintf(`
+			SELECT
+				%s
+			FROM
+				scd_operations
+			WHERE
+				cells && $1
+			AND
+				COALESCE(scd_operations.altitude_upper >= $2, true)
+			AND
+				COALESCE(scd_operations.altitude_lower <= $3, true)
+			AND
+				COALESCE(scd_operations.ends_at >= $4, true)
+			AND
+				COALESCE(scd_operations.starts_at <= $5, true)
+			LIMIT $6`, operationFieldsWithPrefix)
+	baselineOffNominalVolumeQuery = fmt.Sprintf(`
+			SELECT
+				%s
+			FROM
+				scd_operations
+			JOIN
+				scd_operational_intent_off_nominal_volumes AS off_nominal
+			ON
+				off_nominal.operational_intent_id = scd_operations.id
+			WHERE
+				off_nominal.cells && $1
+			AND
+				COALESCE(off_nominal.altitude_upper >= $2, true)
+			AND
+				COALESCE(off_nominal.altitude_lower <= $3, true)
+			AND
+				COALESCE(off_nominal.ends_at >= $4, true)
+			AND
+				COALESCE(off_nominal.starts_at <= $5, true)
+			LIMIT $6`, operationFieldsWithPrefix)
+	baselineCellsQuery = `
+		SELECT
+			unnest(cells) as cell_id
+		FROM
+			scd_operations
+		WHERE id = $1`
+	baselineOVNsQuery = `
+		SELECT
+			id, ovn
+		FROM
+			scd_operations
+		WHERE
+			id = ANY($1)
+		AND
+			ovn IS NOT NULL`
+	baselinePrioritiesQuery = `
+		SELECT
+			operational_intent_id, priority
+		FROM
+			scd_operational_intent_priorities
+		WHERE
+			operational_intent_id = ANY($1)`
+	baselineOffNominalVolumesQuery = fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			scd_operational_intent_off_nominal_volumes
+		WHERE
+			operational_intent_id = ANY($1)`, offNominalVolumeFields)
+)
+
+// fetchOperationalIntentsBaseline replays the queries with which the
+// OperationalIntents selected by query were read before their attributes
+// were read by query itself: a query for the cells of each of them, then a
+// query for each of their OVNs, priorities and off-nominal volumes.
+func fetchOperationalIntentsBaseline(ctx context.Context, b *testing.B, r *repo, query string, args ...interface{}) []*scdmodels.OperationalIntent {
+	ops, err := r.scanOperationalIntents(ctx, r.q, false, query, args...)
+	require.NoError(b, err)
+	if len(ops) == 0 {
+		return ops
+	}
+
+	ids := make([]string, len(ops))
+	for i, op := range ops {
+		ids[i] = op.ID.String()
+		rows, err := r.q.Query(ctx, baselineCellsQuery, ids[i])
+		require.NoError(b, err)
+		op.Cells = s2.CellUnion{}
+		for rows.Next() {
+			var cell int64
+			require.NoError(b, rows.Scan(&cell))
+			op.Cells = append(op.Cells, s2.CellID(cell))
+		}
+		require.NoError(b, rows.Err())
+		rows.Close()
+	}
+	var pgIDs pgtype.UUIDArray
+	require.NoError(b, pgIDs.Set(ids))
+
+	for _, q := range []struct {
+		query   string
+		enabled bool
+	}{
+		{baselineOVNsQuery, r.storeOVNs},
+		{baselinePrioritiesQuery, r.storePriorities},
+		{baselineOffNominalVolumesQuery, r.storeOffNominalVolumes},
+	} {
+		if !q.enabled {
+			continue
+		}
+		rows, err := r.q.Query(ctx, q.query, pgIDs)
+		require.NoError(b, err)
+		for rows.Next() {
+			_, err := rows.Values()
+			require.NoError(b, err)
+		}
+		require.NoError(b, rows.Err())
+		rows.Close()
+	}
+	return ops
+}
+
+// BenchmarkSearchOperationalIntents measures searches reading the cells and
+// attributes of the found OperationalIntents with the search query itself.
+func BenchmarkSearchOperationalIntents(b *testing.B) {
+	ctx := context.Background()
+	store, tearDownStore := setUpStore(ctx, b)
+	defer tearDownStore()
+
+	for _, n := range benchmarkSearchSizes {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vol4, cleanUp := seedOperationalIntents(ctx, b, store, n)
+			defer cleanUp()
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				require.NoError(b, store.Transact(ctx, func(ctx context.Context, r repos.Repository) error {
+					ops, err := r.SearchOperationalIntents(ctx, vol4)
+					require.NoError(b, err)
+					require.GreaterOrEqual(b, len(ops), n)
+					return nil
+				}))
+			}
+		})
+	}
+}
+
+// BenchmarkSearchOperationalIntentsBaseline measures the same searches as
+// BenchmarkSearchOperationalIntents with the queries the repo formerly issued,
+// in the same transaction, as a baseline.
+func BenchmarkSearchOperationalIntentsBaseline(b *testing.B) {
+	ctx := context.Background()
+	store, tearDownStore := setUpStore(ctx, b)
+	defer tearDownStore()
+
+	for _, n := range benchmarkSearchSizes {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			vol4, cleanUp := seedOperationalIntents(ctx, b, store, n)
+			defer cleanUp()
+			args, err := operationsIntersectingVolumeArgs(vol4)
+			require.NoError(b, err)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				require.NoError(b, store.Transact(ctx, func(ctx context.Context, rr repos.Repository) error {
+					r := rr.(*repo)
+					ops := fetchOperationalIntentsBaseline(ctx, b, r, baselineVolumeQuery, args...)
+					if r.storeOffNominalVolumes {
+						ops = append(ops, fetchOperationalIntentsBaseline(ctx, b, r, baselineOffNominalVolumeQuery, args...)...)
+					}
+					require.GreaterOrEqual(b, len(ops), n)
+					return nil
+				}))
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package cockroach
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/stacktrace"
+	"github.com/jackc/pgtype"
+)
+
+// storeOVN generates a new OVN for the row of table identified by id, which
+// must have been written by the current transaction, and stores it in the
+// row. If OVNs are not stored by the current schema, it returns legacy, the
+// OVN derived from the update time of the row.
+func (s *repo) storeOVN(ctx context.Context, table string, id dssmodels.ID, legacy scdmodels.OVN) (scdmodels.OVN, error) {
+	if !s.storeOVNs {
+		return legacy, nil
+	}
+
+	var (
+		timestampQuery = `SELECT transaction_timestamp()`
+		updateQuery    = fmt.Sprintf(`
+			UPDATE
+				%s
+			SET
+				ovn = $2
+			WHERE
+				id = $1`, table)
+		committedAt time.Time
+	)
+
+	// Rows are written with the timestamp of their transaction as updated_at.
+	if err := s.q.QueryRow(ctx, timestampQuery).Scan(&committedAt); err != nil {
+		return "", stacktrace.Propagate(err, "Error in query: %s", timestampQuery)
+	}
+	ovn, err := scdmodels.NewOVN(s.ovnKey, id.String(), committedAt)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "Error generating OVN")
+	}
+
+	uid, err := id.PgUUID()
+	if err != nil {
+		return "", stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	if _, err := s.q.Exec(ctx, updateQuery, uid, ovn.String()); err != nil {
+		return "", stacktrace.Propagate(err, "Error in query: %s", updateQuery)
+	}
+	return ovn, nil
+}
+
+// restoreOVN stores ovn in the row of table identified by id, in place of the
+// OVN generated when the row was written, to restore an entity as is. If OVNs
+// are not stored by the current schema, the OVN of the restored entity is the
+// one derived from the update time it was restored with.
+func (s *repo) restoreOVN(ctx context.Context, table string, id dssmodels.ID, ovn scdmodels.OVN) error {
+	if !s.storeOVNs {
+		return nil
+	}
+
+	var updateQuery = fmt.Sprintf(`
+		UPDATE
+			%s
+		SET
+			ovn = $2
+		WHERE
+			id = $1`, table)
+
+	uid, err := id.PgUUID()
+	if err != nil {
+		return stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	if _, err := s.q.Exec(ctx, updateQuery, uid, ovn.String()); err != nil {
+		return stacktrace.Propagate(err, "Error in query: %s", updateQuery)
+	}
+	return nil
+}
+
+// ovnOrLegacy returns the OVN stored in the row of the entity identified by
+// id, or the OVN derived from the update time of the row if it was written
+// before OVNs were stored.
+func ovnOrLegacy(ovn pgtype.Text, updatedAt time.Time, id dssmodels.ID) scdmodels.OVN {
+	if ovn.Status == pgtype.Present {
+		return scdmodels.OVN(ovn.String)
+	}
+	return scdmodels.NewOVNFromTime(updatedAt, id.String())
+}
+
+// restoredUpdatedAt returns the update time to restore an entity backed up
+// with updatedAt, or nil to restore it as written at the time it is restored
+// if it was backed up without its update time.
+func restoredUpdatedAt(updatedAt time.Time) *time.Time {
+	if updatedAt.IsZero() {
+		return nil
+	}
+	return &updatedAt
+}
@@ -0,0 +1,50 @@
+package cockroach
+
+import (
+	"context"
+
+	dsserr "github.com/interuss/dss/pkg/errors"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/stacktrace"
+)
+
+// upsertOperationalIntentPriority stores the priority of operation. Only
+// non-zero priorities are stored, 0 being the priority of the operations
+// without a row.
+func (s *repo) upsertOperationalIntentPriority(ctx context.Context, operation *scdmodels.OperationalIntent) error {
+	if !s.storePriorities {
+		if operation.Priority != 0 {
+			return stacktrace.NewErrorWithCode(dsserr.BadRequest, "Priorities are not supported by the current schema version of the database")
+		}
+		return nil
+	}
+
+	var (
+		upsertQuery = `
+			UPSERT INTO
+				scd_operational_intent_priorities
+				(operational_intent_id, priority)
+			VALUES
+				($1, $2)`
+		deleteQuery = `
+			DELETE FROM
+				scd_operational_intent_priorities
+			WHERE
+				operational_intent_id = $1`
+	)
+
+	uid, err := operation.ID.PgUUID()
+	if err != nil {
+		return stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	if operation.Priority == 0 {
+		if _, err := s.q.Exec(ctx, deleteQuery, uid); err != nil {
+			return stacktrace.Propagate(err, "Error in query: %s", deleteQuery)
+		}
+		return nil
+	}
+	if _, err := s.q.Exec(ctx, upsertQuery, uid, operation.Priority); err != nil {
+		return stacktrace.Propagate(err, "Error in query: %s", upsertQuery)
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package cockroach
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/geo/s2"
+	"github.com/google/uuid"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/dss/pkg/scd/repos"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestore(t *testing.T) {
+	var (
+		ctx                  = context.Background()
+		store, tearDownStore = setUpStore(ctx, t)
+		manager              = dssmodels.Manager(fmt.Sprintf("restore-%s", uuid.New()))
+		cells                = s2.CellUnion{s2.CellIDFromLatLng(s2.LatLngFromDegrees(37.4, -122.1)).Parent(13)}
+		start                = time.Now().Add(time.Hour).Truncate(time.Microsecond)
+		end                  = start.Add(time.Hour)
+		altLower             = float32(0)
+		altUpper             = float32(100)
+		updatedAt            = time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	)
+	defer tearDownStore()
+	storeOVNs := store.newRepo(store.db.Pool).storeOVNs
+
+	sub := &scdmodels.Subscription{
+		ID:                          dssmodels.ID(uuid.New().String()),
+		Manager:                     manager,
+		Version:                     scdmodels.OVN("restored-subscription-ovn"),
+		NotificationIndex:           7,
+		StartTime:                   &start,
+		EndTime:                     &end,
+		USSBaseURL:                  "https://example.com/uss",
+		NotifyForOperationalIntents: true,
+		ImplicitSubscription:        true,
+		Cells:                       cells,
+	}
+	op := &scdmodels.OperationalIntent{
+		ID:             dssmodels.ID(uuid.New().String()),
+		Manager:        manager,
+		Version:        3,
+		State:          scdmodels.OperationalIntentStateAccepted,
+		OVN:            scdmodels.OVN("restored-operational-intent-ovn"),
+		StartTime:      &start,
+		EndTime:        &end,
+		USSBaseURL:     "https://example.com/uss",
+		SubscriptionID: sub.ID,
+		AltitudeLower:  &altLower,
+		AltitudeUpper:  &altUpper,
+		Cells:          cells,
+	}
+	constraint := &scdmodels.Constraint{
+		ID:            dssmodels.ID(uuid.New().String()),
+		Manager:       manager,
+		Version:       5,
+		OVN:           scdmodels.OVN("restored-constraint-ovn"),
+		StartTime:     &start,
+		EndTime:       &end,
+		USSBaseURL:    "https://example.com/uss",
+		AltitudeLower: &altLower,
+		AltitudeUpper: &altUpper,
+		Cells:         cells,
+	}
+	availability := &scdmodels.UssAvailabilityStatus{
+		Uss:          manager,
+		Availability: scdmodels.UssAvailabilityStateDown,
+		Version:      scdmodels.NewOVNFromTime(updatedAt, manager.String()),
+		UpdatedAt:    updatedAt,
+	}
+
+	require.NoError(t, store.Transact(ctx, func(ctx context.Context, r repos.Repository) error {
+		if err := r.RestoreSubscription(ctx, sub); err != nil {
+			return err
+		}
+		if err := r.RestoreOperationalIntent(ctx, op); err != nil {
+			return err
+		}
+		if err := r.RestoreConstraint(ctx, constraint); err != nil {
+			return err
+		}
+		return r.RestoreUssAvailability(ctx, availability)
+	}))
+	defer func() {
+		// Operations are deleted along with the Subscription they depend on.
+		require.NoError(t, store.Transact(ctx, func(ctx context.Context, r repos.Repository) error {
+			if err := r.DeleteConstraint(ctx, constraint.ID); err != nil {
+				return err
+			}
+			return r.DeleteSubscription(ctx, sub.ID)
+		}))
+	}()
+
+	repo, err := store.Interact(ctx)
+	require.NoError(t, err)
+
+	restoredSub, err := repo.GetSubscription(ctx, sub.ID)
+	require.NoError(t, err)
+	require.NotNil(t, restoredSub)
+	require.Equal(t, sub.ID, restoredSub.ID)
+	require.Equal(t, sub.NotificationIndex, restoredSub.NotificationIndex)
+	require.Equal(t, sub.Cells, restoredSub.Cells)
+	if storeOVNs {
+		require.Equal(t, sub.Version, restoredSub.Version)
+	}
+
+	restoredOp, err := repo.GetOperationalIntent(ctx, op.ID)
+	require.NoError(t, err)
+	require.NotNil(t, restoredOp)
+	require.Equal(t, op.ID, restoredOp.ID)
+	require.Equal(t, op.Version, restoredOp.Version)
+	require.Equal(t, op.State, restoredOp.State)
+	require.Equal(t, op.Cells, restoredOp.Cells)
+	if storeOVNs {
+		require.Equal(t, op.OVN, restoredOp.OVN)
+	}
+
+	restoredConstraint, err := repo.GetConstraint(ctx, constraint.ID)
+	require.NoError(t, err)
+	require.NotNil(t, restoredConstraint)
+	require.Equal(t, constraint.ID, restoredConstraint.ID)
+	require.Equal(t, constraint.Version, restoredConstraint.Version)
+	require.Equal(t, constraint.Cells, restoredConstraint.Cells)
+	if storeOVNs {
+		require.Equal(t, constraint.OVN, restoredConstraint.OVN)
+	}
+
+	restoredAvailability, err := repo.GetUssAvailability(ctx, manager)
+	require.NoError(t, err)
+	require.Equal(t, availability.Version, restoredAvailability.Version)
+	require.True(t, updatedAt.Equal(restoredAvailability.UpdatedAt))
+}
+
+func TestRestoreKeepsLegacyOVNs(t *testing.T) {
+	var (
+		ctx                  = context.Background()
+		store, tearDownStore = setUpStore(ctx, t)
+		manager              = dssmodels.Manager(fmt.Sprintf("restore-legacy-%s", uuid.New()))
+		cells                = s2.CellUnion{s2.CellIDFromLatLng(s2.LatLngFromDegrees(37.4, -122.1)).Parent(13)}
+		start                = time.Now().Add(time.Hour).Truncate(time.Microsecond)
+		end                  = start.Add(time.Hour)
+		altLower             = float32(0)
+		altUpper             = float32(100)
+		updatedAt            = time.Date(2022, 1, 1, 12, 0, 0, 123456000, time.UTC)
+	)
+	defer tearDownStore()
+	// Schemas earlier than 3.9.0 do not store OVNs, which are derived from the
+	// update time of the rows instead.
+	legacyVersion := v380
+	store.version = &legacyVersion
+	require.False(t, store.newRepo(store.db.Pool).storeOVNs)
+
+	subID := dssmodels.ID(uuid.New().String())
+	sub := &scdmodels.Subscription{
+		ID:                          subID,
+		Manager:                     manager,
+		Version:                     scdmodels.NewOVNFromTime(updatedAt, subID.String()),
+		StartTime:                   &start,
+		EndTime:                     &end,
+		USSBaseURL:                  "https://example.com/uss",
+		NotifyForOperationalIntents: true,
+		ImplicitSubscription:        true,
+		Cells:                       cells,
+		UpdatedAt:                   updatedAt,
+	}
+	opID := dssmodels.ID(uuid.New().String())
+	op := &scdmodels.OperationalIntent{
+		ID:             opID,
+		Manager:        manager,
+		Version:        3,
+		State:          scdmodels.OperationalIntentStateAccepted,
+		OVN:            scdmodels.NewOVNFromTime(updatedAt, opID.String()),
+		StartTime:      &start,
+		EndTime:        &end,
+		USSBaseURL:     "https://example.com/uss",
+		SubscriptionID: sub.ID,
+		AltitudeLower:  &altLower,
+		AltitudeUpper:  &altUpper,
+		Cells:          cells,
+		UpdatedAt:      updatedAt,
+	}
+	constraintID := dssmodels.ID(uuid.New().String())
+	constraint := &scdmodels.Constraint{
+		ID:            constraintID,
+		Manager:       manager,
+		Version:       5,
+		OVN:           scdmodels.NewOVNFromTime(updatedAt, constraintID.String()),
+		StartTime:     &start,
+		EndTime:       &end,
+		USSBaseURL:    "https://example.com/uss",
+		AltitudeLower: &altLower,
+		AltitudeUpper: &altUpper,
+		Cells:         cells,
+		UpdatedAt:     updatedAt,
+	}
+
+	require.NoError(t, store.Transact(ctx, func(ctx context.Context, r repos.Repository) error {
+		if err := r.RestoreSubscription(ctx, sub); err != nil {
+			return err
+		}
+		if err := r.RestoreOperationalIntent(ctx, op); err != nil {
+			return err
+		}
+		return r.RestoreConstraint(ctx, constraint)
+	}))
+	defer func() {
+		// Operations are deleted along with the Subscription they depend on.
+		require.NoError(t, store.Transact(ctx, func(ctx context.Context, r repos.Repository) error {
+			if err := r.DeleteConstraint(ctx, constraint.ID); err != nil {
+				return err
+			}
+			return r.DeleteSubscription(ctx, sub.ID)
+		}))
+	}()
+
+	repo, err := store.Interact(ctx)
+	require.NoError(t, err)
+
+	restoredSub, err := repo.GetSubscription(ctx, sub.ID)
+	require.NoError(t, err)
+	require.NotNil(t, restoredSub)
+	require.Equal(t, sub.Version, restoredSub.Version)
+	require.True(t, updatedAt.Equal(restoredSub.UpdatedAt))
+
+	restoredOp, err := repo.GetOperationalIntent(ctx, op.ID)
+	require.NoError(t, err)
+	require.NotNil(t, restoredOp)
+	require.Equal(t, op.OVN, restoredOp.OVN)
+	require.True(t, updatedAt.Equal(restoredOp.UpdatedAt))
+
+	restoredConstraint, err := repo.GetConstraint(ctx, constraint.ID)
+	require.NoError(t, err)
+	require.NotNil(t, restoredConstraint)
+	require.Equal(t, constraint.OVN, restoredConstraint.OVN)
+	require.True(t, updatedAt.Equal(restoredConstraint.UpdatedAt))
+}
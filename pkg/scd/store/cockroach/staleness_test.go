@@ -0,0 +1,42 @@
+package cockroach
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/interuss/dss/pkg/cockroach"
+	"github.com/interuss/dss/pkg/scd/repos"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestTransactReadOnlyStaleness(t *testing.T) {
+	const (
+		staleMethod = "/scdpb.UTMAPIUSSDSSAndUSSUSSService/GetSubscription"
+		freshMethod = "/scdpb.UTMAPIUSSDSSAndUSSUSSService/QuerySubscriptions"
+	)
+	var (
+		ctx                  = context.Background()
+		store, tearDownStore = setUpStore(ctx, t)
+		policy               = cockroach.StalenessPolicy{staleMethod: {Bound: time.Hour}}
+	)
+	defer tearDownStore()
+
+	// readTime returns the time at which the read-only transactions of method
+	// read, which is the timestamp of their transaction.
+	readTime := func(method string) time.Time {
+		var readAt time.Time
+		_, err := policy.Interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, store.TransactReadOnly(ctx, func(ctx context.Context, r repos.Repository) error {
+				return r.(*repo).q.QueryRow(ctx, "SELECT now()").Scan(&readAt)
+			})
+		})
+		require.NoError(t, err)
+		return readAt
+	}
+
+	// The configured method reads AS OF SYSTEM TIME an hour ago.
+	require.WithinDuration(t, time.Now().Add(-time.Hour), readTime(staleMethod), time.Minute)
+	require.WithinDuration(t, time.Now(), readTime(freshMethod), time.Minute)
+}
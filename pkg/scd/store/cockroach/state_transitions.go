@@ -0,0 +1,93 @@
+package cockroach
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	dsserr "github.com/interuss/dss/pkg/errors"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/stacktrace"
+)
+
+const (
+	stateTransitionFields = "operational_intent_id, owner, version, from_state, to_state, recorded_at"
+)
+
+// InsertStateTransition implements
+// repos.StateTransitions.InsertStateTransition.
+func (s *repo) InsertStateTransition(ctx context.Context, transition *scdmodels.StateTransition) error {
+	if !s.recordStateTransitions {
+		return nil
+	}
+
+	// Transitions are inserted rather than upserted: several transitions of
+	// an operational intent recorded in the same transaction share their
+	// recorded_at, and are told apart and ordered by their sequence_number.
+	var query = fmt.Sprintf(`
+		INSERT INTO
+			scd_operational_intent_state_transitions
+			(%s)
+		VALUES
+			($1, $2, $3, $4, $5, transaction_timestamp())`, stateTransitionFields)
+
+	uid, err := transition.OperationalIntentID.PgUUID()
+	if err != nil {
+		return stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	from := sql.NullString{String: transition.From.String(), Valid: transition.From != scdmodels.OperationalIntentStateUnknown}
+	if _, err := s.q.Exec(ctx, query, uid, transition.Manager, transition.Version, from, transition.To.String()); err != nil {
+		return stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	return nil
+}
+
+// ListStateTransitions implements repos.StateTransitions.ListStateTransitions.
+func (s *repo) ListStateTransitions(ctx context.Context, id dssmodels.ID) ([]*scdmodels.StateTransition, error) {
+	if !s.recordStateTransitions {
+		return nil, stacktrace.NewErrorWithCode(dsserr.NotImplemented, "State transitions are not supported by the current schema version of the database")
+	}
+
+	var query = fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			scd_operational_intent_state_transitions
+		WHERE
+			operational_intent_id = $1
+		ORDER BY recorded_at, sequence_number`, stateTransitionFields)
+
+	uid, err := id.PgUUID()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	rows, err := s.q.Query(ctx, query, uid)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	defer rows.Close()
+
+	var transitions []*scdmodels.StateTransition
+	for rows.Next() {
+		var (
+			t       = new(scdmodels.StateTransition)
+			opID    string
+			from    sql.NullString
+			to      string
+			version int32
+		)
+		if err := rows.Scan(&opID, &t.Manager, &version, &from, &to, &t.RecordedAt); err != nil {
+			return nil, stacktrace.Propagate(err, "Error scanning state transition row")
+		}
+		t.OperationalIntentID = dssmodels.ID(opID)
+		t.Version = scdmodels.VersionNumber(version)
+		t.From = scdmodels.OperationalIntentState(from.String)
+		t.To = scdmodels.OperationalIntentState(to)
+		transitions = append(transitions, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, stacktrace.Propagate(err, "Error in rows query result")
+	}
+	return transitions, nil
+}
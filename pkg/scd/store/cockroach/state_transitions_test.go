@@ -0,0 +1,48 @@
+package cockroach
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/dss/pkg/scd/repos"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateTransitionsOfSingleTransaction(t *testing.T) {
+	var (
+		ctx                  = context.Background()
+		store, tearDownStore = setUpStore(ctx, t)
+		id                   = dssmodels.ID(uuid.New().String())
+		transitions          = []*scdmodels.StateTransition{
+			{OperationalIntentID: id, Manager: "uss1", Version: 1, To: scdmodels.OperationalIntentStateAccepted},
+			{OperationalIntentID: id, Manager: "uss1", Version: 2, From: scdmodels.OperationalIntentStateAccepted, To: scdmodels.OperationalIntentStateActivated},
+			{OperationalIntentID: id, Manager: "uss1", Version: 2, From: scdmodels.OperationalIntentStateActivated, To: scdmodels.OperationalIntentStateEnded},
+		}
+	)
+	defer tearDownStore()
+
+	// The transitions share their recorded_at, yet are all kept in order.
+	require.NoError(t, store.Transact(ctx, func(ctx context.Context, r repos.Repository) error {
+		for _, transition := range transitions {
+			if err := r.InsertStateTransition(ctx, transition); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+
+	repo, err := store.Interact(ctx)
+	require.NoError(t, err)
+	got, err := repo.ListStateTransitions(ctx, id)
+	require.NoError(t, err)
+	require.Len(t, got, len(transitions))
+	for i, transition := range transitions {
+		require.Equal(t, transition.From, got[i].From)
+		require.Equal(t, transition.To, got[i].To)
+		require.Equal(t, transition.Version, got[i].Version)
+		require.Equal(t, got[0].RecordedAt, got[i].RecordedAt)
+	}
+}
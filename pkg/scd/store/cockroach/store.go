@@ -2,6 +2,10 @@ package cockroach
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/cockroachdb/cockroach-go/v2/crdb"
 	"github.com/cockroachdb/cockroach-go/v2/crdb/crdbpgx"
 	"github.com/coreos/go-semver/semver"
@@ -26,22 +30,130 @@ var (
 
 	// DatabaseName is the name of database storing strategic conflict detection data.
 	DatabaseName = "scd"
+
+	// DefaultTimeout is the timeout of read-only transactions, which are not
+	// retried when reading stale data. If a given deadline is already
+	// supplied on the context, the earlier deadline is used.
+	DefaultTimeout = 10 * time.Second
+
+	v320  = *semver.New("3.2.0")
+	v330  = *semver.New("3.3.0")
+	v340  = *semver.New("3.4.0")
+	v350  = *semver.New("3.5.0")
+	v360  = *semver.New("3.6.0")
+	v370  = *semver.New("3.7.0")
+	v380  = *semver.New("3.8.0")
+	v390  = *semver.New("3.9.0")
+	v3100 = *semver.New("3.10.0")
+	v3110 = *semver.New("3.11.0")
+	v3120 = *semver.New("3.12.0")
+)
+
+const (
+	// auditLogTable is the name of the table storing the strategic conflict
+	// detection audit log.
+	auditLogTable = "scd_audit_log"
+
+	// subscriptionEventsTable is the name of the table storing the events of
+	// strategic conflict detection subscriptions.
+	subscriptionEventsTable = "scd_subscription_events"
+
+	// leasesTable is the name of the table storing the leases of the
+	// strategic conflict detection scheduled jobs.
+	leasesTable = "scd_leases"
 )
 
 // repo is an implementation of repos.Repo using
 // a CockroachDB transaction.
 type repo struct {
+	*cockroach.AuditLog
+	*cockroach.SubscriptionEventLog
+
 	q      dsssql.Queryable
 	logger *zap.Logger
 	clock  clockwork.Clock
+
+	// retainHistory is true if prior versions of operational intents and
+	// constraints are to be kept in the history tables.
+	retainHistory bool
+
+	// historyAttributes is true if the history tables also retain the
+	// attributes of operational intents and constraints stored outside of
+	// their rows.
+	historyAttributes bool
+
+	// recordStateTransitions is true if the changes of the state of
+	// operational intents are to be recorded.
+	recordStateTransitions bool
+
+	// storePriorities is true if the priorities of operational intents are
+	// to be stored.
+	storePriorities bool
+
+	// storeOffNominalVolumes is true if the off-nominal volumes of
+	// operational intents are to be stored.
+	storeOffNominalVolumes bool
+
+	// storeConstraintCategories is true if the categories of constraints and
+	// the constraint categories of subscriptions are to be stored.
+	storeConstraintCategories bool
+
+	// storeOVNs is true if OVNs are to be generated with ovnKey and stored
+	// when entities are written, rather than derived from their update time.
+	storeOVNs bool
+
+	// ovnKey is the key of the HMAC of the stored OVNs. Random OVNs are
+	// generated if empty.
+	ovnKey []byte
+}
+
+// attribute is a field of the entities of a table which is stored in another
+// table, selected with expr under name.
+type attribute struct {
+	name string
+	expr string
+}
+
+// selectAttributes returns the select list of attributes, starting with a
+// separator from the fields preceding them.
+func selectAttributes(attributes []attribute) string {
+	var b strings.Builder
+	for _, a := range attributes {
+		fmt.Fprintf(&b, "\n\t\t\t\t%s AS %s,", a.expr, a.name)
+	}
+	return strings.TrimSuffix(b.String(), ",")
+}
+
+// attributeNames returns the names of attributes, starting with a separator
+// from the fields preceding them.
+func attributeNames(attributes []attribute) string {
+	var b strings.Builder
+	for _, a := range attributes {
+		fmt.Fprintf(&b, ",%s", a.name)
+	}
+	return b.String()
+}
+
+// attributeFields returns the names of attributes prefixed with table,
+// starting with a separator from the fields preceding them.
+func attributeFields(table string, attributes []attribute) string {
+	var b strings.Builder
+	for _, a := range attributes {
+		fmt.Fprintf(&b, ",%s.%s", table, a.name)
+	}
+	return b.String()
 }
 
 // Store is an implementation of an scd.Store using
 // a CockroachDB database.
 type Store struct {
-	db     *cockroach.DB
-	logger *zap.Logger
-	clock  clockwork.Clock
+	db      *cockroach.DB
+	logger  *zap.Logger
+	clock   clockwork.Clock
+	version *semver.Version
+	ovnKey  []byte
+	// multiRegion is set if the database is multi-region.
+	multiRegion bool
 }
 
 // NewStore returns a Store instance connected to a cockroach instance via db.
@@ -56,9 +168,30 @@ func NewStore(ctx context.Context, db *cockroach.DB, logger *zap.Logger) (*Store
 		return nil, stacktrace.Propagate(err, "Strategic conflict detection schema version check failed")
 	}
 
+	vs, err := store.GetVersion(ctx)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to get database schema version for strategic conflict detection")
+	}
+	store.version = vs
+
+	// Rows are only homed in regions once the table localities are set.
+	if vs.Compare(v3100) >= 0 {
+		regions, err := db.DatabaseRegions(ctx, DatabaseName)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "Failed to get the regions of strategic conflict detection db : %s", DatabaseName)
+		}
+		store.multiRegion = len(regions) > 0
+	}
+
 	return store, nil
 }
 
+// SetOVNKey sets the key with which the OVNs of the entities written through
+// the Store are generated. If no key is set, random OVNs are generated.
+func (s *Store) SetOVNKey(key []byte) {
+	s.ovnKey = key
+}
+
 // CheckCurrentMajorSchemaVersion returns nil if s supports the current major schema version.
 func (s *Store) CheckCurrentMajorSchemaVersion(ctx context.Context) error {
 	vs, err := s.GetVersion(ctx)
@@ -78,25 +211,52 @@ func (s *Store) CheckCurrentMajorSchemaVersion(ctx context.Context) error {
 
 // Interact implements store.Interactor interface.
 func (s *Store) Interact(_ context.Context) (repos.Repository, error) {
+	return s.newRepo(s.db.Pool), nil
+}
+
+func (s *Store) newRepo(q dsssql.Queryable) *repo {
 	return &repo{
-		q:      s.db.Pool,
-		logger: s.logger,
-		clock:  s.clock,
-	}, nil
+		AuditLog:                  cockroach.NewAuditLog(q, auditLogTable, s.version != nil && s.version.Compare(v320) >= 0),
+		SubscriptionEventLog:      cockroach.NewSubscriptionEventLog(q, subscriptionEventsTable, s.version != nil && s.version.Compare(v340) >= 0, s.multiRegion),
+		q:                         q,
+		logger:                    s.logger,
+		clock:                     s.clock,
+		retainHistory:             s.version != nil && s.version.Compare(v330) >= 0,
+		historyAttributes:         s.version != nil && s.version.Compare(v3120) >= 0,
+		recordStateTransitions:    s.version != nil && s.version.Compare(v350) >= 0,
+		storePriorities:           s.version != nil && s.version.Compare(v360) >= 0,
+		storeOffNominalVolumes:    s.version != nil && s.version.Compare(v370) >= 0,
+		storeConstraintCategories: s.version != nil && s.version.Compare(v380) >= 0,
+		storeOVNs:                 s.version != nil && s.version.Compare(v390) >= 0,
+		ovnKey:                    s.ovnKey,
+	}
 }
 
 // Transact implements store.Transactor interface.
 func (s *Store) Transact(ctx context.Context, f func(context.Context, repos.Repository) error) error {
 	ctx = crdb.WithMaxRetries(ctx, flags.ConnectParameters().MaxRetries)
 	return crdbpgx.ExecuteTx(ctx, s.db.Pool, pgx.TxOptions{}, func(tx pgx.Tx) error {
-		return f(ctx, &repo{
-			q:      tx,
-			logger: s.logger,
-			clock:  s.clock,
-		})
+		return f(ctx, s.newRepo(tx))
 	})
 }
 
+// TransactReadOnly implements store.ReadOnlyTransactor interface.
+func (s *Store) TransactReadOnly(ctx context.Context, f func(context.Context, repos.Repository) error) error {
+	ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+
+	ctx = crdb.WithMaxRetries(ctx, flags.ConnectParameters().MaxRetries)
+	return cockroach.ExecuteReadOnlyTx(ctx, s.db.Pool, func(tx pgx.Tx) error {
+		return f(ctx, s.newRepo(tx))
+	})
+}
+
+// Leases returns the table of the leases of the strategic conflict detection
+// scheduled jobs.
+func (s *Store) Leases() *cockroach.LeaseTable {
+	return cockroach.NewLeaseTable(s.db.Pool, leasesTable, s.version != nil && s.version.Compare(v3110) >= 0)
+}
+
 // Close closes the underlying DB connection.
 func (s *Store) Close() error {
 	s.db.Pool.Close()
@@ -0,0 +1,30 @@
+package cockroach
+
+import (
+	"context"
+	"testing"
+
+	"github.com/interuss/dss/pkg/cockroach"
+	"github.com/interuss/dss/pkg/cockroach/flags"
+	"github.com/interuss/dss/pkg/logging"
+	"github.com/stretchr/testify/require"
+)
+
+// setUpStore returns a Store connected to the strategic conflict detection
+// database designated by the cockroach flags, and a function closing it. tb
+// is skipped if no database is designated.
+func setUpStore(ctx context.Context, tb testing.TB) (*Store, func()) {
+	connectParameters := flags.ConnectParameters()
+	if connectParameters.Host == "" || connectParameters.Port == 0 {
+		tb.Skip()
+	}
+	connectParameters.DBName = DatabaseName
+
+	db, err := cockroach.Dial(ctx, connectParameters)
+	require.NoError(tb, err)
+	store, err := NewStore(ctx, db, logging.Logger)
+	require.NoError(tb, err)
+	return store, func() {
+		require.NoError(tb, store.Close())
+	}
+}
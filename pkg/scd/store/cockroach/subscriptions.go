@@ -50,46 +50,61 @@ func init() {
 	)
 }
 
-func (c *repo) fetchCellsForSubscription(ctx context.Context, q dsssql.Queryable, id dssmodels.ID) (s2.CellUnion, error) {
+// subscriptionAttributes returns the attributes of subscriptions selected
+// along with subscriptionFieldsWithIndices by subscriptionSource, and the
+// joins of scd_subscriptions with the tables storing them with the current
+// schema.
+func (c *repo) subscriptionAttributes() ([]attribute, string) {
 	var (
-		cellsQuery = `
-			SELECT
-				unnest(cells) as cell_id
-			FROM
-				scd_subscriptions
-			WHERE
-				id = $1
-		`
+		attributes []attribute
+		joins      string
 	)
+	if c.storeOVNs {
+		attributes = append(attributes, attribute{"ovn", "scd_subscriptions.ovn"})
+	}
+	if c.storeConstraintCategories {
+		attributes = append(attributes, attribute{"constraint_categories", "subscription_categories.categories"})
+		joins += `
+			LEFT JOIN
+				scd_subscription_constraint_categories AS subscription_categories
+			ON
+				subscription_categories.subscription_id = scd_subscriptions.id`
+	}
+	return attributes, joins
+}
 
-	uid, err := id.PgUUID()
-	if err != nil {
-		return nil, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+// subscriptionSource returns the source of the subscriptions read by c,
+// exposing under the scd_subscriptions name the fields of subscriptionFields,
+// so that subscriptions are read along with the attributes stored outside of
+// their rows with a single query.
+func (c *repo) subscriptionSource() string {
+	attributes, joins := c.subscriptionAttributes()
+	if len(attributes) == 0 {
+		return "scd_subscriptions"
 	}
-	rows, err := q.Query(ctx, cellsQuery, uid)
-	if err != nil {
-		return nil, stacktrace.Propagate(err, "Error in query: %s", cellsQuery)
-	}
-	defer rows.Close()
+	return fmt.Sprintf(`(
+			SELECT
+				%s,%s
+			FROM
+				scd_subscriptions%s
+		) AS scd_subscriptions`, subscriptionFieldsWithPrefix, selectAttributes(attributes), joins)
+}
 
-	var (
-		cu   s2.CellUnion
-		cidi int64
-	)
-	for rows.Next() {
-		err := rows.Scan(&cidi)
-		if err != nil {
-			return nil, stacktrace.Propagate(err, "Error scanning Subscription cell row")
-		}
-		cu = append(cu, s2.CellID(cidi))
-	}
-	if err := rows.Err(); err != nil {
-		return nil, stacktrace.Propagate(err, "Error in rows query result")
-	}
-	return cu, nil
+// subscriptionFields returns the fields of subscriptionSource read by
+// scanSubscriptions.
+func (c *repo) subscriptionFields() string {
+	attributes, _ := c.subscriptionAttributes()
+	return subscriptionFieldsWithPrefix + attributeFields("scd_subscriptions", attributes)
 }
 
 func (c *repo) fetchSubscriptions(ctx context.Context, q dsssql.Queryable, query string, args ...interface{}) ([]*scdmodels.Subscription, error) {
+	return c.scanSubscriptions(ctx, q, true, query, args...)
+}
+
+// scanSubscriptions returns the Subscriptions selected by query, which
+// selects subscriptionFieldsWithIndices followed, if attributes, by the
+// attributes of subscriptionFields.
+func (c *repo) scanSubscriptions(ctx context.Context, q dsssql.Queryable, attributes bool, query string, args ...interface{}) ([]*scdmodels.Subscription, error) {
 	rows, err := q.Query(ctx, query, args...)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Error in query: %s", query)
@@ -100,11 +115,12 @@ func (c *repo) fetchSubscriptions(ctx context.Context, q dsssql.Queryable, query
 	pgCids := pgtype.Int8Array{}
 	for rows.Next() {
 		var (
-			s         = new(scdmodels.Subscription)
-			updatedAt time.Time
-			version   int
+			s       = new(scdmodels.Subscription)
+			version int
+			ovn     = pgtype.Text{}
+			pgCats  = pgtype.TextArray{}
 		)
-		err = rows.Scan(
+		dest := []interface{}{
 			&s.ID,
 			&s.Manager,
 			&version,
@@ -116,20 +132,34 @@ func (c *repo) fetchSubscriptions(ctx context.Context, q dsssql.Queryable, query
 			&s.StartTime,
 			&s.EndTime,
 			&pgCids,
-			&updatedAt,
-		)
-		if err != nil {
-			return nil, stacktrace.Propagate(err, "Error scanning Subscription row")
+			&s.UpdatedAt,
 		}
-		s.Version = scdmodels.NewOVNFromTime(updatedAt, s.ID.String())
-		if err != nil {
-			return nil, stacktrace.Propagate(err, "Error generating Subscription version")
+		if attributes && c.storeOVNs {
+			dest = append(dest, &ovn)
 		}
+		if attributes && c.storeConstraintCategories {
+			dest = append(dest, &pgCats)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, stacktrace.Propagate(err, "Error scanning Subscription row")
+		}
+		s.Version = ovnOrLegacy(ovn, s.UpdatedAt, s.ID)
 		var cids []int64
 		if err := pgCids.AssignTo(&cids); err != nil {
 			return nil, stacktrace.Propagate(err, "Error Converting jackc/pgtype to array")
 		}
 		s.SetCells(cids)
+		// Subscriptions notified of the changes of constraints of any category
+		// have no constraint categories row.
+		if pgCats.Status == pgtype.Present {
+			var categories []string
+			if err := pgCats.AssignTo(&categories); err != nil {
+				return nil, stacktrace.Propagate(err, "Error Converting jackc/pgtype to array")
+			}
+			for _, category := range categories {
+				s.ConstraintCategories = append(s.ConstraintCategories, scdmodels.ConstraintCategory(category))
+			}
+		}
 		payload = append(payload, s)
 	}
 	if err = rows.Err(); err != nil {
@@ -159,9 +189,9 @@ func (c *repo) fetchSubscriptionByID(ctx context.Context, q dsssql.Queryable, id
 			SELECT
 				%s
 			FROM
-				scd_subscriptions
+				%s
 			WHERE
-				id = $1`, subscriptionFieldsWithPrefix)
+				id = $1`, c.subscriptionFields(), c.subscriptionSource())
 	)
 	uid, err := id.PgUUID()
 	if err != nil {
@@ -171,17 +201,12 @@ func (c *repo) fetchSubscriptionByID(ctx context.Context, q dsssql.Queryable, id
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Error fetching Subscription")
 	}
-	if result == nil {
-		return nil, nil
-	}
-	result.Cells, err = c.fetchCellsForSubscription(ctx, q, id)
-	if err != nil {
-		return nil, stacktrace.Propagate(err, "Error fetching cells for Subscription")
-	}
 	return result, nil
 }
 
-func (c *repo) pushSubscription(ctx context.Context, q dsssql.Queryable, s *scdmodels.Subscription) (*scdmodels.Subscription, error) {
+// pushSubscription writes s as updated at updatedAt, or at the time of the
+// transaction if nil, and returns the written subscription.
+func (c *repo) pushSubscription(ctx context.Context, q dsssql.Queryable, s *scdmodels.Subscription, updatedAt *time.Time) (*scdmodels.Subscription, error) {
 	var (
 		upsertQuery = fmt.Sprintf(`
 		WITH v AS (
@@ -196,7 +221,7 @@ func (c *repo) pushSubscription(ctx context.Context, q dsssql.Queryable, s *scdm
 		  scd_subscriptions
 		  (%s)
 		VALUES
-			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, transaction_timestamp())
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, COALESCE($12, transaction_timestamp()))
 		RETURNING
 			%s`, subscriptionFieldsWithoutPrefix, subscriptionFieldsWithPrefix)
 	)
@@ -218,7 +243,7 @@ func (c *repo) pushSubscription(ctx context.Context, q dsssql.Queryable, s *scdm
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
 	}
-	s, err = c.fetchSubscription(ctx, q, upsertQuery,
+	subscriptions, err := c.scanSubscriptions(ctx, q, false, upsertQuery,
 		id,
 		s.Manager,
 		0,
@@ -229,12 +254,18 @@ func (c *repo) pushSubscription(ctx context.Context, q dsssql.Queryable, s *scdm
 		s.ImplicitSubscription,
 		s.StartTime,
 		s.EndTime,
-		pgCids)
+		pgCids,
+		updatedAt)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "Error fetching Subscription from upsert query")
 	}
-	if s == nil {
-		return nil, stacktrace.NewError("Upsert query did not return a Subscription")
+	if len(subscriptions) != 1 {
+		return nil, stacktrace.NewError("Upsert returned %d Subscriptions when only 1 was expected", len(subscriptions))
+	}
+	s = subscriptions[0]
+	s.Version, err = c.storeOVN(ctx, "scd_subscriptions", s.ID, s.Version)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error storing Subscription version")
 	}
 
 	return s, nil
@@ -253,11 +284,21 @@ func (c *repo) GetSubscription(ctx context.Context, id dssmodels.ID) (*scdmodels
 
 // Implements repos.Subscription.UpsertSubscription
 func (c *repo) UpsertSubscription(ctx context.Context, s *scdmodels.Subscription) (*scdmodels.Subscription, error) {
-	newSubscription, err := c.pushSubscription(ctx, c.q, s)
+	return c.upsertSubscription(ctx, s, nil)
+}
+
+// upsertSubscription writes s and its constraint categories as updated at
+// updatedAt, or at the time of the transaction if nil.
+func (c *repo) upsertSubscription(ctx context.Context, s *scdmodels.Subscription, updatedAt *time.Time) (*scdmodels.Subscription, error) {
+	newSubscription, err := c.pushSubscription(ctx, c.q, s, updatedAt)
 	if err != nil {
 		return nil, err // No need to Propagate this error as this stack layer does not add useful information
 	}
 	newSubscription.Cells = s.Cells
+	if err := c.upsertSubscriptionConstraintCategories(ctx, s); err != nil {
+		return nil, stacktrace.Propagate(err, "Error storing constraint categories of Subscription")
+	}
+	newSubscription.ConstraintCategories = s.ConstraintCategories
 
 	return newSubscription, nil
 }
@@ -296,14 +337,14 @@ func (c *repo) SearchSubscriptions(ctx context.Context, v4d *dssmodels.Volume4D)
 			SELECT
 				%s
 			FROM
-				scd_subscriptions
+				%s
 				WHERE
 					cells && $1
 				AND
 					COALESCE(starts_at <= $3, true)
 				AND
 					COALESCE(ends_at >= $2, true)
-				LIMIT $4`, subscriptionFieldsWithPrefix)
+				LIMIT $4`, c.subscriptionFields(), c.subscriptionSource())
 	)
 
 	// TODO: Lazily calculate & cache spatial covering so that it is only ever
@@ -382,3 +423,122 @@ func (c *repo) IncrementNotificationIndices(ctx context.Context, subscriptionIds
 
 	return indices, nil
 }
+
+// Implements scd.repos.Subscription.MaxSubscriptionCountInCellsByManager
+func (c *repo) MaxSubscriptionCountInCellsByManager(ctx context.Context, cells s2.CellUnion, manager dssmodels.Manager, exclude dssmodels.ID) (int, error) {
+	var query = `
+		SELECT
+			IFNULL(MAX(subscriptions_per_cell_id), 0)
+		FROM (
+			SELECT
+				COUNT(*) AS subscriptions_per_cell_id
+			FROM (
+				SELECT unnest(cells) AS cell_id
+				FROM scd_subscriptions
+				WHERE owner = $1
+					AND NOT implicit
+					AND id != $2
+					AND COALESCE(ends_at >= $3, true)
+			)
+			WHERE
+				cell_id = ANY($4)
+			GROUP BY cell_id
+		)`
+
+	cids := make([]int64, len(cells))
+	for i, cell := range cells {
+		cids[i] = int64(cell)
+	}
+
+	var pgCids pgtype.Int8Array
+	if err := pgCids.Set(cids); err != nil {
+		return 0, stacktrace.Propagate(err, "Failed to convert array to jackc/pgtype")
+	}
+
+	uid, err := exclude.PgUUID()
+	if err != nil {
+		return 0, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+
+	var count int
+	if err := c.q.QueryRow(ctx, query, manager, uid, c.clock.Now(), pgCids).Scan(&count); err != nil {
+		return 0, stacktrace.Propagate(err, "Error scanning Subscription count row")
+	}
+	return count, nil
+}
+
+// Implements scd.repos.Subscription.CountImplicitSubscriptionsByManager
+func (c *repo) CountImplicitSubscriptionsByManager(ctx context.Context, manager dssmodels.Manager) (int, error) {
+	var query = `
+		SELECT
+			COUNT(*)
+		FROM
+			scd_subscriptions
+		WHERE
+			owner = $1
+		AND
+			implicit
+		AND
+			COALESCE(ends_at >= $2, true)`
+
+	var count int
+	if err := c.q.QueryRow(ctx, query, manager, c.clock.Now()).Scan(&count); err != nil {
+		return 0, stacktrace.Propagate(err, "Error in query: %s", query)
+	}
+	return count, nil
+}
+
+// Implements scd.repos.Subscription.ListSubscriptionsByManager
+func (c *repo) ListSubscriptionsByManager(ctx context.Context, manager dssmodels.Manager) ([]*scdmodels.Subscription, error) {
+	var query = fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			%s
+		WHERE
+			owner = $1
+		LIMIT $2`, c.subscriptionFields(), c.subscriptionSource())
+
+	subscriptions, err := c.fetchSubscriptions(ctx, c.q, query, manager, dssmodels.MaxResultLimit)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error fetching Subscriptions")
+	}
+	return subscriptions, nil
+}
+
+// Implements scd.repos.Subscription.ListSubscriptions
+func (c *repo) ListSubscriptions(ctx context.Context, after dssmodels.ID, limit int) ([]*scdmodels.Subscription, error) {
+	var query = fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			%s
+		WHERE
+			($1::UUID IS NULL OR id > $1)
+		ORDER BY
+			id
+		LIMIT $2`, c.subscriptionFields(), c.subscriptionSource())
+
+	afterID, err := after.NullablePgUUID()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Failed to convert id to PgUUID")
+	}
+	subscriptions, err := c.fetchSubscriptions(ctx, c.q, query, afterID, limit)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Error fetching Subscriptions")
+	}
+	return subscriptions, nil
+}
+
+// Implements scd.repos.Subscription.RestoreSubscription. The subscription is
+// written as updated at the time it was backed up with, so that its version
+// is kept if OVNs are not stored.
+func (c *repo) RestoreSubscription(ctx context.Context, s *scdmodels.Subscription) error {
+	if _, err := c.upsertSubscription(ctx, s, restoredUpdatedAt(s.UpdatedAt)); err != nil {
+		return err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	if err := c.restoreOVN(ctx, "scd_subscriptions", s.ID, s.Version); err != nil {
+		return stacktrace.Propagate(err, "Error restoring Subscription version")
+	}
+	return nil
+}
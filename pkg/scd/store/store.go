@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 
+	"github.com/coreos/go-semver/semver"
 	"github.com/interuss/dss/pkg/scd/repos"
 )
 
@@ -11,9 +12,13 @@ import (
 type Store interface {
 	Interactor
 	Transactor
+	ReadOnlyTransactor
 
 	// Close closes the store and releases all of its resources.
 	Close() error
+
+	// GetVersion returns the schema version of the store.
+	GetVersion(ctx context.Context) (*semver.Version, error)
 }
 
 // Interactor provides means to get hold of a repos.Repository instance *without* any
@@ -30,3 +35,12 @@ type Transactor interface {
 	// isolation/atomicity.
 	Transact(ctx context.Context, f func(context.Context, repos.Repository) error) error
 }
+
+// ReadOnlyTransactor provides means to get hold of a repos.Repository instance
+// in the context of a read-only transaction, which may read stale data if so
+// configured in the context.
+type ReadOnlyTransactor interface {
+	// TransactReadOnly executes f and provides a repos.Repository instance that
+	// guarantees isolation but may only be read from.
+	TransactReadOnly(ctx context.Context, f func(context.Context, repos.Repository) error) error
+}
@@ -0,0 +1,235 @@
+package scd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/golang/geo/s2"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	scdmodels "github.com/interuss/dss/pkg/scd/models"
+	"github.com/interuss/dss/pkg/scd/repos"
+)
+
+// memoryState holds the entities of a memoryStore.
+type memoryState struct {
+	ops         map[dssmodels.ID]*scdmodels.OperationalIntent
+	subs        map[dssmodels.ID]*scdmodels.Subscription
+	constraints map[dssmodels.ID]*scdmodels.Constraint
+	transitions []*scdmodels.StateTransition
+}
+
+func (s *memoryState) clone() *memoryState {
+	c := &memoryState{
+		ops:         map[dssmodels.ID]*scdmodels.OperationalIntent{},
+		subs:        map[dssmodels.ID]*scdmodels.Subscription{},
+		constraints: map[dssmodels.ID]*scdmodels.Constraint{},
+		transitions: append([]*scdmodels.StateTransition(nil), s.transitions...),
+	}
+	for id, op := range s.ops {
+		copied := *op
+		c.ops[id] = &copied
+	}
+	for id, sub := range s.subs {
+		copied := *sub
+		c.subs[id] = &copied
+	}
+	for id, constraint := range s.constraints {
+		copied := *constraint
+		c.constraints[id] = &copied
+	}
+	return c
+}
+
+// memoryStore is an in-memory store.Store of strategic conflict detection
+// entities, whose transactions are only committed if they succeed. Searches
+// ignore volumes, all the entities being considered to intersect.
+type memoryStore struct {
+	state        *memoryState
+	transactions int
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{state: (&memoryState{}).clone()}
+}
+
+func (s *memoryStore) Interact(ctx context.Context) (repos.Repository, error) {
+	return &memoryRepo{state: s.state}, nil
+}
+
+func (s *memoryStore) Transact(ctx context.Context, f func(context.Context, repos.Repository) error) error {
+	s.transactions++
+	state := s.state.clone()
+	if err := f(ctx, &memoryRepo{state: state}); err != nil {
+		return err
+	}
+	s.state = state
+	return nil
+}
+
+func (s *memoryStore) TransactReadOnly(ctx context.Context, f func(context.Context, repos.Repository) error) error {
+	return f(ctx, &memoryRepo{state: s.state.clone()})
+}
+
+func (s *memoryStore) Close() error {
+	return nil
+}
+
+func (s *memoryStore) GetVersion(ctx context.Context) (*semver.Version, error) {
+	return semver.New("3.13.0"), nil
+}
+
+// memoryRepo is the repos.Repository of a memoryStore. The methods it does
+// not implement panic.
+type memoryRepo struct {
+	repos.Repository
+	state *memoryState
+}
+
+func (r *memoryRepo) GetOperationalIntent(ctx context.Context, id dssmodels.ID) (*scdmodels.OperationalIntent, error) {
+	op, ok := r.state.ops[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *op
+	return &copied, nil
+}
+
+func (r *memoryRepo) UpsertOperationalIntent(ctx context.Context, op *scdmodels.OperationalIntent) (*scdmodels.OperationalIntent, error) {
+	copied := *op
+	copied.OVN = scdmodels.OVN(fmt.Sprintf("ovn-%s-%d", op.ID, op.Version))
+	r.state.ops[op.ID] = &copied
+	result := copied
+	return &result, nil
+}
+
+func (r *memoryRepo) DeleteOperationalIntent(ctx context.Context, id dssmodels.ID) error {
+	delete(r.state.ops, id)
+	return nil
+}
+
+func (r *memoryRepo) SearchOperationalIntents(ctx context.Context, v4d *dssmodels.Volume4D) ([]*scdmodels.OperationalIntent, error) {
+	var result []*scdmodels.OperationalIntent
+	for _, op := range r.state.ops {
+		copied := *op
+		result = append(result, &copied)
+	}
+	return result, nil
+}
+
+func (r *memoryRepo) GetDependentOperationalIntents(ctx context.Context, subscriptionID dssmodels.ID) ([]dssmodels.ID, error) {
+	var result []dssmodels.ID
+	for _, op := range r.state.ops {
+		if op.SubscriptionID == subscriptionID {
+			result = append(result, op.ID)
+		}
+	}
+	return result, nil
+}
+
+func (r *memoryRepo) CountActiveOperationalIntentsByManager(ctx context.Context, manager dssmodels.Manager) (int, error) {
+	count := 0
+	for _, op := range r.state.ops {
+		if op.Manager == manager {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryRepo) GetSubscription(ctx context.Context, id dssmodels.ID) (*scdmodels.Subscription, error) {
+	sub, ok := r.state.subs[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *sub
+	return &copied, nil
+}
+
+func (r *memoryRepo) UpsertSubscription(ctx context.Context, sub *scdmodels.Subscription) (*scdmodels.Subscription, error) {
+	copied := *sub
+	copied.Version = scdmodels.OVN(fmt.Sprintf("version-%s-%d", sub.ID, len(r.state.transitions)))
+	r.state.subs[sub.ID] = &copied
+	result := copied
+	return &result, nil
+}
+
+func (r *memoryRepo) DeleteSubscription(ctx context.Context, id dssmodels.ID) error {
+	delete(r.state.subs, id)
+	return nil
+}
+
+func (r *memoryRepo) SearchSubscriptions(ctx context.Context, v4d *dssmodels.Volume4D) ([]*scdmodels.Subscription, error) {
+	var result []*scdmodels.Subscription
+	for _, sub := range r.state.subs {
+		copied := *sub
+		result = append(result, &copied)
+	}
+	return result, nil
+}
+
+func (r *memoryRepo) IncrementNotificationIndices(ctx context.Context, ids []dssmodels.ID) ([]int, error) {
+	indices := make([]int, len(ids))
+	for i, id := range ids {
+		r.state.subs[id].NotificationIndex++
+		indices[i] = r.state.subs[id].NotificationIndex
+	}
+	return indices, nil
+}
+
+func (r *memoryRepo) MaxSubscriptionCountInCellsByManager(ctx context.Context, cells s2.CellUnion, manager dssmodels.Manager, exclude dssmodels.ID) (int, error) {
+	count := 0
+	for _, sub := range r.state.subs {
+		if sub.Manager == manager && !sub.ImplicitSubscription && sub.ID != exclude {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryRepo) CountImplicitSubscriptionsByManager(ctx context.Context, manager dssmodels.Manager) (int, error) {
+	count := 0
+	for _, sub := range r.state.subs {
+		if sub.Manager == manager && sub.ImplicitSubscription {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryRepo) SearchConstraints(ctx context.Context, v4d *dssmodels.Volume4D, categories []scdmodels.ConstraintCategory) ([]*scdmodels.Constraint, error) {
+	var result []*scdmodels.Constraint
+	for _, constraint := range r.state.constraints {
+		if !constraint.HasCategory(categories) {
+			continue
+		}
+		copied := *constraint
+		result = append(result, &copied)
+	}
+	return result, nil
+}
+
+func (r *memoryRepo) InsertAuditRecord(ctx context.Context, record *dssmodels.AuditRecord) error {
+	return nil
+}
+
+func (r *memoryRepo) InsertSubscriptionEvents(ctx context.Context, events []*dssmodels.SubscriptionEvent) error {
+	return nil
+}
+
+func (r *memoryRepo) InsertStateTransition(ctx context.Context, transition *scdmodels.StateTransition) error {
+	copied := *transition
+	r.state.transitions = append(r.state.transitions, &copied)
+	return nil
+}
+
+func (r *memoryRepo) ListStateTransitions(ctx context.Context, id dssmodels.ID) ([]*scdmodels.StateTransition, error) {
+	var result []*scdmodels.StateTransition
+	for _, transition := range r.state.transitions {
+		if transition.OperationalIntentID == id {
+			copied := *transition
+			result = append(result, &copied)
+		}
+	}
+	return result, nil
+}
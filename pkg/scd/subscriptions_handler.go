@@ -56,6 +56,11 @@ func (a *Server) PutSubscription(ctx context.Context, subscriptionid string, ver
 		return nil, stacktrace.PropagateWithCode(err, dsserr.BadRequest, "Unable to parse extents")
 	}
 
+	constraintCategories, err := scdmodels.ConstraintCategoriesFromStrings(params.GetConstraintCategories())
+	if err != nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+
 	// Construct requested Subscription model
 	cells, err := extents.CalculateSpatialCovering()
 	switch err {
@@ -79,12 +84,16 @@ func (a *Server) PutSubscription(ctx context.Context, subscriptionid string, ver
 		USSBaseURL:                  params.UssBaseUrl,
 		NotifyForOperationalIntents: params.NotifyForOperationalIntents,
 		NotifyForConstraints:        params.NotifyForConstraints,
+		ConstraintCategories:        constraintCategories,
 	}
 
 	// Validate requested Subscription
 	if !subreq.NotifyForOperationalIntents && !subreq.NotifyForConstraints {
 		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "No notification triggers requested for Subscription")
 	}
+	if len(subreq.ConstraintCategories) > 0 && !subreq.NotifyForConstraints {
+		return nil, stacktrace.NewErrorWithCode(dsserr.BadRequest, "Constraint categories requested for Subscription without notifications for constraints")
+	}
 
 	// TODO: Check scopes to verify requested information (op intents or constraints) may be requested
 
@@ -144,6 +153,19 @@ func (a *Server) PutSubscription(ctx context.Context, subscriptionid string, ver
 			}
 		}
 
+		// Enforce the maximum number of Subscriptions per area
+		if a.Limits.MaxSubscriptionsPerArea > 0 && len(subreq.Cells) > 0 {
+			count, err := r.MaxSubscriptionCountInCellsByManager(ctx, subreq.Cells, manager, subreq.ID)
+			if err != nil {
+				return stacktrace.Propagate(err, "Failed to fetch Subscription count, rejecting request")
+			}
+			if count >= a.Limits.MaxSubscriptionsPerArea {
+				return stacktrace.Propagate(
+					stacktrace.NewErrorWithCode(dsserr.Exhausted, "Too many existing Subscriptions in this area already"),
+					"%s had %d Subscriptions in the area", manager, count)
+			}
+		}
+
 		// Store Subscription model
 		sub, err := r.UpsertSubscription(ctx, subreq)
 		if err != nil {
@@ -152,6 +174,13 @@ func (a *Server) PutSubscription(ctx context.Context, subscriptionid string, ver
 		if sub == nil {
 			return stacktrace.NewError("UpsertSubscription returned no Subscription for ID: %s", id)
 		}
+		auditOp := dssmodels.AuditCreate
+		if old != nil {
+			auditOp = dssmodels.AuditUpdate
+		}
+		if err := r.InsertAuditRecord(ctx, subscriptionAuditRecord(auditOp, old, sub)); err != nil {
+			return stacktrace.Propagate(err, "Could not record Subscription change in audit log")
+		}
 
 		// Find relevant Operations
 		var relevantOperations []*scdmodels.OperationalIntent
@@ -195,7 +224,7 @@ func (a *Server) PutSubscription(ctx context.Context, subscriptionid string, ver
 
 		if sub.NotifyForConstraints {
 			// Query relevant Constraints
-			constraints, err := r.SearchConstraints(ctx, extents)
+			constraints, err := r.SearchConstraints(ctx, extents, sub.ConstraintCategories)
 			if err != nil {
 				return stacktrace.Propagate(err, "Could not search Constraints in repo")
 			}
@@ -277,7 +306,7 @@ func (a *Server) GetSubscription(ctx context.Context, req *scdpb.GetSubscription
 		return nil
 	}
 
-	err = a.Store.Transact(ctx, action)
+	err = a.Store.TransactReadOnly(ctx, action)
 	if err != nil {
 		return nil, err // No need to Propagate this error as this is not a useful stacktrace line
 	}
@@ -334,7 +363,7 @@ func (a *Server) QuerySubscriptions(ctx context.Context, req *scdpb.QuerySubscri
 		return nil
 	}
 
-	err = a.Store.Transact(ctx, action)
+	err = a.Store.TransactReadOnly(ctx, action)
 	if err != nil {
 		return nil, err // No need to Propagate this error as this is not a useful stacktrace line
 	}
@@ -387,6 +416,9 @@ func (a *Server) DeleteSubscription(ctx context.Context, req *scdpb.DeleteSubscr
 		if err != nil {
 			return stacktrace.Propagate(err, "Could not delete Subscription from repo")
 		}
+		if err := r.InsertAuditRecord(ctx, subscriptionAuditRecord(dssmodels.AuditDelete, old, nil)); err != nil {
+			return stacktrace.Propagate(err, "Could not record Subscription deletion in audit log")
+		}
 
 		// Convert deleted Subscription to proto
 		p, err := old.ToProto(dependentOps)
@@ -0,0 +1,100 @@
+package scd
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/interuss/dss/pkg/api/v1/scdpb"
+	dsserr "github.com/interuss/dss/pkg/errors"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	"github.com/interuss/stacktrace"
+	"github.com/stretchr/testify/require"
+)
+
+// testSubscriptionParams returns the parameters of a Subscription to
+// OperationalIntents starting in an hour for an hour.
+func testSubscriptionParams(t *testing.T) *scdpb.PutSubscriptionParameters {
+	var (
+		start    = time.Now().Add(time.Hour)
+		end      = start.Add(time.Hour)
+		altLower = float32(0)
+		altUpper = float32(100)
+	)
+	extent, err := (&dssmodels.Volume4D{
+		StartTime: &start,
+		EndTime:   &end,
+		SpatialVolume: &dssmodels.Volume3D{
+			AltitudeLo: &altLower,
+			AltitudeHi: &altUpper,
+			Footprint: &dssmodels.GeoCircle{
+				Center:      dssmodels.LatLngPoint{Lat: 37.4, Lng: -122.1},
+				RadiusMeter: 100,
+			},
+		},
+	}).ToSCDProto()
+	require.NoError(t, err)
+
+	return &scdpb.PutSubscriptionParameters{
+		Extents:                     extent,
+		UssBaseUrl:                  "https://uss1.example.com",
+		NotifyForOperationalIntents: true,
+	}
+}
+
+func TestPutSubscriptionLimits(t *testing.T) {
+	const id = "00000000-0000-4000-8000-100000000000"
+	for _, tc := range []struct {
+		name     string
+		limit    int
+		existing int
+		manager  dssmodels.Owner
+		code     stacktrace.ErrorCode
+	}{
+		{name: "below limit", limit: 3, existing: 2, manager: "uss1"},
+		{name: "at limit", limit: 2, existing: 2, manager: "uss1", code: dsserr.Exhausted},
+		{name: "over limit", limit: 1, existing: 2, manager: "uss1", code: dsserr.Exhausted},
+		{name: "Subscriptions of another manager", limit: 1, existing: 2, manager: "uss2"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				store = newMemoryStore()
+				a     = &Server{Store: store}
+			)
+
+			// The existing Subscriptions of uss1 are created without limit.
+			for i := 1; i <= tc.existing; i++ {
+				_, err := a.PutSubscription(strategicCoordinationContext("uss1"),
+					fmt.Sprintf("00000000-0000-4000-8000-%012d", i), "", testSubscriptionParams(t))
+				require.NoError(t, err)
+			}
+
+			a.Limits = Limits{MaxSubscriptionsPerArea: tc.limit}
+			_, err := a.PutSubscription(strategicCoordinationContext(tc.manager), id, "", testSubscriptionParams(t))
+			if tc.code == 0 {
+				require.NoError(t, err)
+				require.Contains(t, store.state.subs, dssmodels.ID(id))
+				return
+			}
+			require.Error(t, err)
+			require.Equal(t, tc.code, stacktrace.GetCode(err))
+			require.Len(t, store.state.subs, tc.existing)
+		})
+	}
+}
+
+func TestPutSubscriptionLimitExcludesUpdatedSubscription(t *testing.T) {
+	const id = "00000000-0000-4000-8000-000000000001"
+	var (
+		store = newMemoryStore()
+		a     = &Server{Store: store, Limits: Limits{MaxSubscriptionsPerArea: 1}}
+		ctx   = strategicCoordinationContext("uss1")
+	)
+
+	response, err := a.PutSubscription(ctx, id, "", testSubscriptionParams(t))
+	require.NoError(t, err)
+
+	// The Subscription being updated does not count towards the limit.
+	_, err = a.PutSubscription(ctx, id, response.Subscription.Version, testSubscriptionParams(t))
+	require.NoError(t, err)
+}
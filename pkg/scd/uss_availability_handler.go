@@ -3,6 +3,7 @@ package scd
 import (
 	"context"
 	"github.com/interuss/dss/pkg/api/v1/scdpb"
+	"github.com/interuss/dss/pkg/auth"
 	dsserr "github.com/interuss/dss/pkg/errors"
 	dssmodels "github.com/interuss/dss/pkg/models"
 	scdmodels "github.com/interuss/dss/pkg/scd/models"
@@ -48,7 +49,7 @@ func (a *Server) GetUssAvailability(ctx context.Context, request *scdpb.GetUssAv
 		return nil
 	}
 
-	err := a.Store.Transact(ctx, action)
+	err := a.Store.TransactReadOnly(ctx, action)
 	if err != nil {
 		// In case of older DB versions where availability table doesn't exist
 		if strings.Contains(err.Error(), "does not exist") {
@@ -87,6 +88,10 @@ func (a *Server) PutUssAvailability(ctx context.Context, ussID string, version s
 		if ussa == nil {
 			return stacktrace.NewError("UssAvailability returned no Uss for ID: %s", ussID)
 		}
+		manager, _ := auth.ManagerFromContext(ctx)
+		if err := r.InsertAuditRecord(ctx, ussAvailabilityAuditRecord(manager, ussa)); err != nil {
+			return stacktrace.Propagate(err, "Could not record UssAvailability change in audit log")
+		}
 		result = &scdpb.UssAvailabilityStatusResponse{
 			Status: &scdpb.UssAvailabilityStatus{
 				Availability: ussa.Availability.String(),
@@ -0,0 +1,260 @@
+package watch
+
+import (
+	"context"
+	"time"
+
+	"github.com/interuss/dss/pkg/api/v1/watchpb"
+	"github.com/interuss/dss/pkg/auth"
+	"github.com/interuss/dss/pkg/cockroach"
+	dsserr "github.com/interuss/dss/pkg/errors"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	ridrepos "github.com/interuss/dss/pkg/rid/repos"
+	ridv1 "github.com/interuss/dss/pkg/rid/server/v1"
+	ridv2 "github.com/interuss/dss/pkg/rid/server/v2"
+	ridstore "github.com/interuss/dss/pkg/rid/store"
+	scdrepos "github.com/interuss/dss/pkg/scd/repos"
+	scdstore "github.com/interuss/dss/pkg/scd/store"
+	"github.com/interuss/stacktrace"
+	"google.golang.org/grpc/metadata"
+	tspb "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// pageSize is the maximum number of events read from the store at once.
+	pageSize = 100
+)
+
+var (
+	// Scopes are the scopes granting access to the subscriptions which may be
+	// watched: the remote ID v1 and v2 display provider scopes and the
+	// strategic coordination scopes.
+	Scopes = []auth.Scope{
+		ridv1.Scopes.ISA.Read,
+		ridv2.Scopes.DisplayProvider,
+		"utm.strategic_coordination",
+		"utm.constraint_processing",
+	}
+)
+
+// Server implements watchpb.DSSWatchService.
+type Server struct {
+	RIDStore ridstore.Store
+	// SCDStore is nil if strategic conflict detection is disabled.
+	SCDStore scdstore.Store
+	// Timeout bounds each query to the stores.
+	Timeout time.Duration
+	// PollInterval is the interval at which the stores are polled for new
+	// events.
+	PollInterval time.Duration
+}
+
+// AuthScopes returns a map of endpoint to required Oauth scope.
+func (s *Server) AuthScopes() map[auth.Operation]auth.KeyClaimedScopesValidator {
+	return map[auth.Operation]auth.KeyClaimedScopesValidator{
+		"/watchpb.DSSWatchService/WatchSubscription": auth.RequireAnyScope(Scopes...),
+	}
+}
+
+// subscription is the state of a watched subscription in its store.
+type subscription struct {
+	owner             dssmodels.Owner
+	notificationIndex int
+}
+
+// eventSource reads a watched subscription and its events from its store.
+type eventSource interface {
+	// getSubscription returns the subscription identified by "id", or nil if
+	// it does not exist.
+	getSubscription(ctx context.Context, id dssmodels.ID) (*subscription, error)
+
+	// listEvents returns the events of the subscription identified by "id"
+	// following afterIndex, read in a read-only transaction with the
+	// staleness attached to ctx.
+	listEvents(ctx context.Context, id dssmodels.ID, afterIndex int) ([]*dssmodels.SubscriptionEvent, error)
+}
+
+type ridSource struct {
+	store ridstore.Store
+}
+
+func (r ridSource) getSubscription(ctx context.Context, id dssmodels.ID) (*subscription, error) {
+	repo, err := r.store.Interact(ctx)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Unable to interact with remote ID store")
+	}
+	sub, err := repo.GetSubscription(ctx, id)
+	if err != nil || sub == nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	return &subscription{owner: sub.Owner, notificationIndex: sub.NotificationIndex}, nil
+}
+
+func (r ridSource) listEvents(ctx context.Context, id dssmodels.ID, afterIndex int) ([]*dssmodels.SubscriptionEvent, error) {
+	var events []*dssmodels.SubscriptionEvent
+	err := r.store.TransactReadOnly(ctx, func(repo ridrepos.Repository) (err error) {
+		events, err = repo.ListSubscriptionEvents(ctx, id, afterIndex, pageSize)
+		return err
+	})
+	return events, err
+}
+
+type scdSource struct {
+	store scdstore.Store
+}
+
+func (r scdSource) getSubscription(ctx context.Context, id dssmodels.ID) (*subscription, error) {
+	repo, err := r.store.Interact(ctx)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Unable to interact with strategic conflict detection store")
+	}
+	sub, err := repo.GetSubscription(ctx, id)
+	if err != nil || sub == nil {
+		return nil, err // No need to Propagate this error as this stack layer does not add useful information
+	}
+	return &subscription{owner: dssmodels.Owner(sub.Manager), notificationIndex: sub.NotificationIndex}, nil
+}
+
+func (r scdSource) listEvents(ctx context.Context, id dssmodels.ID, afterIndex int) ([]*dssmodels.SubscriptionEvent, error) {
+	var events []*dssmodels.SubscriptionEvent
+	err := r.store.TransactReadOnly(ctx, func(ctx context.Context, repo scdrepos.Repository) (err error) {
+		events, err = repo.ListSubscriptionEvents(ctx, id, afterIndex, pageSize)
+		return err
+	})
+	return events, err
+}
+
+// sources returns the stores in which subscriptions are looked up, in order.
+func (s *Server) sources() []eventSource {
+	sources := []eventSource{ridSource{store: s.RIDStore}}
+	if s.SCDStore != nil {
+		sources = append(sources, scdSource{store: s.SCDStore})
+	}
+	return sources
+}
+
+// lookup returns the subscription identified by "id" and the store it was
+// found in, or a nil subscription if it exists in none of the stores.
+func (s *Server) lookup(ctx context.Context, id dssmodels.ID) (*subscription, eventSource, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.Timeout)
+	defer cancel()
+
+	for _, source := range s.sources() {
+		sub, err := source.getSubscription(ctx, id)
+		if err != nil {
+			return nil, nil, stacktrace.Propagate(err, "Unable to get Subscription")
+		}
+		if sub != nil {
+			return sub, source, nil
+		}
+	}
+	return nil, nil, nil
+}
+
+func eventToProto(e *dssmodels.SubscriptionEvent) *watchpb.SubscriptionEvent {
+	return &watchpb.SubscriptionEvent{
+		SubscriptionId:    e.SubscriptionID.String(),
+		NotificationIndex: int32(e.NotificationIndex),
+		EntityType:        string(e.EntityType),
+		EntityId:          e.EntityID,
+		Version:           e.Version,
+		Deleted:           e.Deleted,
+		RecordedAt:        tspb.New(e.RecordedAt),
+	}
+}
+
+// WatchSubscription streams the events of a subscription owned by the caller
+// until the subscription is deleted or the caller cancels the stream.
+func (s *Server) WatchSubscription(req *watchpb.WatchSubscriptionRequest, stream watchpb.DSSWatchService_WatchSubscriptionServer) error {
+	ctx := stream.Context()
+	owner, ok := auth.OwnerFromContext(ctx)
+	if !ok {
+		return stacktrace.NewErrorWithCode(dsserr.PermissionDenied, "Missing owner from context")
+	}
+	id, err := dssmodels.IDFromString(req.GetSubscriptionId())
+	if err != nil {
+		return stacktrace.NewErrorWithCode(dsserr.BadRequest, "Invalid ID format: `%s`", req.GetSubscriptionId())
+	}
+
+	sub, source, err := s.lookup(ctx, id)
+	switch {
+	case err != nil:
+		return err // No need to Propagate this error as this stack layer does not add useful information
+	case sub == nil:
+		return stacktrace.NewErrorWithCode(dsserr.NotFound, "Subscription %s not found", id)
+	case sub.owner != owner:
+		return stacktrace.NewErrorWithCode(dsserr.PermissionDenied,
+			"Subscription owned by %s, but %s attempted to watch", sub.owner, owner)
+	}
+
+	index := sub.notificationIndex
+	if after := req.GetAfterNotificationIndex(); after != nil {
+		if after.GetValue() < 0 || int(after.GetValue()) > index {
+			return stacktrace.NewErrorWithCode(dsserr.BadRequest,
+				"after_notification_index %d is not between 0 and the current notification index %d", after.GetValue(), index)
+		}
+		index = int(after.GetValue())
+	}
+
+	// Send the headers right away so that clients can tell a stream which was
+	// accepted from one which is waiting for a response.
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return stacktrace.Propagate(err, "Unable to send headers")
+	}
+
+	// The subscription is read before each listing of its events, so that
+	// once it is found deleted, a last listing reads all the events recorded
+	// before its deletion. That listing reads the latest data, as a stale one
+	// could miss the last events.
+	ticker := time.NewTicker(s.PollInterval)
+	defer ticker.Stop()
+	for {
+		listCtx := ctx
+		if sub == nil {
+			listCtx = cockroach.WithStaleness(ctx, cockroach.Staleness{})
+		}
+		for {
+			events, err := s.listEvents(listCtx, source, id, index)
+			if err != nil {
+				return stacktrace.Propagate(err, "Unable to list Subscription events")
+			}
+			for _, e := range events {
+				if err := stream.Send(eventToProto(e)); err != nil {
+					return stacktrace.Propagate(err, "Unable to send Subscription event")
+				}
+				index = e.NotificationIndex
+			}
+			if len(events) < pageSize {
+				break
+			}
+			// More events may be pending.
+		}
+		if sub == nil {
+			// The subscription was deleted: no further events may occur.
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		sub, err = s.getSubscription(ctx, source, id)
+		if err != nil {
+			return stacktrace.Propagate(err, "Unable to get Subscription")
+		}
+	}
+}
+
+func (s *Server) listEvents(ctx context.Context, source eventSource, id dssmodels.ID, afterIndex int) ([]*dssmodels.SubscriptionEvent, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.Timeout)
+	defer cancel()
+	return source.listEvents(ctx, id, afterIndex)
+}
+
+func (s *Server) getSubscription(ctx context.Context, source eventSource, id dssmodels.ID) (*subscription, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.Timeout)
+	defer cancel()
+	return source.getSubscription(ctx, id)
+}
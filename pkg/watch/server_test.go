@@ -0,0 +1,238 @@
+package watch
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/interuss/dss/pkg/api/v1/watchpb"
+	"github.com/interuss/dss/pkg/auth"
+	"github.com/interuss/dss/pkg/cockroach"
+	dsserr "github.com/interuss/dss/pkg/errors"
+	dssmodels "github.com/interuss/dss/pkg/models"
+	ridmodels "github.com/interuss/dss/pkg/rid/models"
+	"github.com/interuss/dss/pkg/rid/repos"
+	"github.com/interuss/stacktrace"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const subID = dssmodels.ID("4348c8e5-0b1c-43cf-9114-2e67a4532765")
+
+// mockRIDStore holds at most one subscription and its events.
+type mockRIDStore struct {
+	repos.Repository
+
+	mu     sync.Mutex
+	sub    *ridmodels.Subscription
+	events []*dssmodels.SubscriptionEvent
+	// onList is called after each listing of events.
+	onList func()
+	// staleness records the Staleness of each listing of events.
+	staleness []cockroach.Staleness
+}
+
+func (s *mockRIDStore) Interact(ctx context.Context) (repos.Repository, error) {
+	return s, nil
+}
+
+func (s *mockRIDStore) Transact(ctx context.Context, f func(repo repos.Repository) error) error {
+	return f(s)
+}
+
+func (s *mockRIDStore) TransactReadOnly(ctx context.Context, f func(repo repos.Repository) error) error {
+	return f(s)
+}
+
+func (s *mockRIDStore) Close() error {
+	return nil
+}
+
+func (s *mockRIDStore) GetVersion(ctx context.Context) (*semver.Version, error) {
+	return semver.New("4.2.0"), nil
+}
+
+func (s *mockRIDStore) GetSubscription(ctx context.Context, id dssmodels.ID) (*ridmodels.Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sub == nil || s.sub.ID != id {
+		return nil, nil
+	}
+	copy := *s.sub
+	return &copy, nil
+}
+
+func (s *mockRIDStore) ListSubscriptionEvents(ctx context.Context, id dssmodels.ID, afterIndex int, limit int) ([]*dssmodels.SubscriptionEvent, error) {
+	s.mu.Lock()
+	s.staleness = append(s.staleness, cockroach.StalenessFromContext(ctx))
+	var events []*dssmodels.SubscriptionEvent
+	for _, e := range s.events {
+		if e.SubscriptionID == id && e.NotificationIndex > afterIndex && len(events) < limit {
+			events = append(events, e)
+		}
+	}
+	s.mu.Unlock()
+	if s.onList != nil {
+		s.onList()
+	}
+	return events, nil
+}
+
+// notify records a change incrementing the notification index of the
+// subscription.
+func (s *mockRIDStore) notify(entityID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sub.NotificationIndex++
+	s.events = append(s.events, &dssmodels.SubscriptionEvent{
+		SubscriptionID:    s.sub.ID,
+		NotificationIndex: s.sub.NotificationIndex,
+		EntityType:        dssmodels.AuditEntityISA,
+		EntityID:          entityID,
+		Version:           "v",
+	})
+}
+
+func (s *mockRIDStore) deleteSubscription() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sub = nil
+}
+
+type mockStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	onSend func(e *watchpb.SubscriptionEvent)
+	sent   []*watchpb.SubscriptionEvent
+}
+
+func (s *mockStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *mockStream) SendHeader(metadata.MD) error {
+	return nil
+}
+
+func (s *mockStream) Send(e *watchpb.SubscriptionEvent) error {
+	s.sent = append(s.sent, e)
+	if s.onSend != nil {
+		s.onSend(e)
+	}
+	return nil
+}
+
+func setUp() (*Server, *mockRIDStore) {
+	store := &mockRIDStore{
+		sub: &ridmodels.Subscription{ID: subID, Owner: "owner"},
+	}
+	return &Server{
+		RIDStore:     store,
+		Timeout:      time.Second,
+		PollInterval: time.Millisecond,
+	}, store
+}
+
+func TestWatchSubscriptionErrors(t *testing.T) {
+	s, _ := setUp()
+
+	for _, tc := range []struct {
+		name  string
+		owner dssmodels.Owner
+		req   *watchpb.WatchSubscriptionRequest
+		code  stacktrace.ErrorCode
+	}{
+		{"bad id", "owner", &watchpb.WatchSubscriptionRequest{SubscriptionId: "bad"}, dsserr.BadRequest},
+		{"not found", "owner", &watchpb.WatchSubscriptionRequest{SubscriptionId: "d5a58e1f-3ef7-4b47-8e4d-bb1ba86ee5fa"}, dsserr.NotFound},
+		{"other owner", "other", &watchpb.WatchSubscriptionRequest{SubscriptionId: subID.String()}, dsserr.PermissionDenied},
+		{"future index", "owner", &watchpb.WatchSubscriptionRequest{
+			SubscriptionId:         subID.String(),
+			AfterNotificationIndex: wrapperspb.Int32(1),
+		}, dsserr.BadRequest},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			stream := &mockStream{ctx: auth.ContextWithOwner(context.Background(), tc.owner)}
+			err := s.WatchSubscription(tc.req, stream)
+			require.Error(t, err)
+			require.Equal(t, tc.code, stacktrace.GetCode(err))
+		})
+	}
+}
+
+func TestWatchSubscriptionResumes(t *testing.T) {
+	s, store := setUp()
+	for _, id := range []string{"a", "b", "c"} {
+		store.notify(id)
+	}
+
+	stream := &mockStream{
+		ctx: auth.ContextWithOwner(context.Background(), "owner"),
+		onSend: func(e *watchpb.SubscriptionEvent) {
+			if e.NotificationIndex == 3 {
+				store.deleteSubscription()
+			}
+		},
+	}
+	require.NoError(t, s.WatchSubscription(&watchpb.WatchSubscriptionRequest{
+		SubscriptionId:         subID.String(),
+		AfterNotificationIndex: wrapperspb.Int32(1),
+	}, stream))
+
+	require.Len(t, stream.sent, 2)
+	require.Equal(t, int32(2), stream.sent[0].NotificationIndex)
+	require.Equal(t, "b", stream.sent[0].EntityId)
+	require.Equal(t, int32(3), stream.sent[1].NotificationIndex)
+	require.Equal(t, "c", stream.sent[1].EntityId)
+}
+
+func TestWatchSubscriptionStreamsNewEvents(t *testing.T) {
+	s, store := setUp()
+	store.notify("old")
+
+	ctx, cancel := context.WithCancel(auth.ContextWithOwner(context.Background(), "owner"))
+	defer cancel()
+	stream := &mockStream{
+		ctx: ctx,
+		onSend: func(e *watchpb.SubscriptionEvent) {
+			cancel()
+		},
+	}
+	var once sync.Once
+	store.onList = func() {
+		// Only notified once the stream has started.
+		once.Do(func() { store.notify("new") })
+	}
+	require.NoError(t, s.WatchSubscription(&watchpb.WatchSubscriptionRequest{SubscriptionId: subID.String()}, stream))
+
+	require.Len(t, stream.sent, 1)
+	require.Equal(t, int32(2), stream.sent[0].NotificationIndex)
+	require.Equal(t, "new", stream.sent[0].EntityId)
+}
+
+func TestWatchSubscriptionStreamsEventsBeforeDeletion(t *testing.T) {
+	s, store := setUp()
+
+	// The last event is recorded right after a listing, just before the
+	// subscription is deleted.
+	var once sync.Once
+	store.onList = func() {
+		once.Do(func() {
+			store.notify("last")
+			store.deleteSubscription()
+		})
+	}
+	ctx := cockroach.WithStaleness(auth.ContextWithOwner(context.Background(), "owner"), cockroach.Staleness{Bound: time.Second})
+	stream := &mockStream{ctx: ctx}
+	require.NoError(t, s.WatchSubscription(&watchpb.WatchSubscriptionRequest{SubscriptionId: subID.String()}, stream))
+
+	require.Len(t, stream.sent, 1)
+	require.Equal(t, int32(1), stream.sent[0].NotificationIndex)
+	require.Equal(t, "last", stream.sent[0].EntityId)
+
+	// Only the listing following the deletion reads the latest data.
+	require.Equal(t, []cockroach.Staleness{{Bound: time.Second}, {}}, store.staleness)
+}